@@ -0,0 +1,432 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BucketData is the raw data of a window bucket.
+//
+// It is a flat map of numeric fields, so it can be stored as-is by the state providers (e.g. as a Redis hash) and
+// by the historical writers. Each field is merged across buckets with a single operation (see BucketOp):
+//   - "sum" and "count" are added up.
+//   - "m2" is the sum of squared differences from the mean, used by the variance. It's merged along with the count
+//     and the mean of its values ("m2_count" and "m2_mean"), see BucketOpMoments.
+//   - "min" and "max" keep the minimal and maximal values.
+//   - "first" and "last" keep the value with the earliest (or latest) "first_ts" (or "last_ts"), in unix microseconds.
+//   - "hll:<register>" are the registers of a HyperLogLog sketch, used by AggrFnCountDistinct. They keep the max.
+//   - "dd:<index>", "dd-:<index>" and "dd0" are the bins of a DDSketch, used by the percentiles. They are added up.
+type BucketData map[string]float64
+
+const (
+	bucketFieldSum         = "sum"
+	bucketFieldCount       = "count"
+	bucketFieldMin         = "min"
+	bucketFieldMax         = "max"
+	bucketFieldM2          = "m2"
+	bucketFieldFirst       = "first"
+	bucketFieldLast        = "last"
+	bucketFieldTsSuffix    = "_ts"
+	bucketFieldCountSuffix = "_count"
+	bucketFieldMeanSuffix  = "_mean"
+	bucketFieldHLL         = "hll:"
+	bucketFieldDDPositive  = "dd:"
+	bucketFieldDDNegative  = "dd-:"
+	bucketFieldDDZero      = "dd0"
+)
+
+const (
+	// HLLPrecision is the number of bits that are used to select the register of the HyperLogLog sketch.
+	// The standard error of the distinct count is 1.04/sqrt(2^HLLPrecision), ~3.25%.
+	HLLPrecision = 10
+	// HLLRegisters is the number of registers of the HyperLogLog sketch.
+	HLLRegisters = 1 << HLLPrecision
+
+	// DDSketchRelativeAccuracy is the relative accuracy of the percentiles.
+	DDSketchRelativeAccuracy = 0.01
+	// DDSketchGamma is the base of the logarithmic bins of the DDSketch.
+	DDSketchGamma = (1 + DDSketchRelativeAccuracy) / (1 - DDSketchRelativeAccuracy)
+
+	// ddMinIndexable is the smallest absolute value that is not counted as zero
+	ddMinIndexable = 1e-9
+)
+
+var ddLogGamma = math.Log(DDSketchGamma)
+
+// BucketOp is the operation that is used to update and merge a field of a BucketData.
+type BucketOp int
+
+const (
+	BucketOpIncr BucketOp = iota
+	BucketOpMin
+	BucketOpMax
+	// BucketOpFirst sets the field (and its timestamp field) if the timestamp is earlier than the stored one.
+	BucketOpFirst
+	// BucketOpLast sets the field (and its timestamp field) if the timestamp is later than the stored one.
+	BucketOpLast
+	// BucketOpMoments merges the sum of squared differences from the mean (the field), along with the count and the
+	// mean of the values (their fields), using the parallel algorithm of Chan et al.
+	// Unlike the sum of squares, it doesn't lose the precision of the variance when the mean is large relative to the
+	// spread of the values.
+	BucketOpMoments
+)
+
+// BucketUpdate is a single field update of a BucketData.
+type BucketUpdate struct {
+	Op    BucketOp
+	Field string
+	Value float64
+	// Timestamp is the time of the value in unix microseconds. It is used by BucketOpFirst and BucketOpLast.
+	Timestamp float64
+	// Count and Mean are the count and the mean of the values. They are used by BucketOpMoments.
+	Count float64
+	Mean  float64
+}
+
+// TimestampField returns the field that keeps the timestamp of BucketOpFirst and BucketOpLast updates.
+func (u BucketUpdate) TimestampField() string {
+	return u.Field + bucketFieldTsSuffix
+}
+
+// CountField returns the field that keeps the count of BucketOpMoments updates.
+func (u BucketUpdate) CountField() string {
+	return u.Field + bucketFieldCountSuffix
+}
+
+// MeanField returns the field that keeps the mean of BucketOpMoments updates.
+func (u BucketUpdate) MeanField() string {
+	return u.Field + bucketFieldMeanSuffix
+}
+
+// BucketUpdates returns the updates that are required to add the value to a bucket of the given aggregations.
+// Values of non-numeric primitives can be added only if all the aggregations support them (see AggrFn.Numeric).
+func BucketUpdates(fns []AggrFn, value any, ts time.Time) ([]BucketUpdate, error) {
+	var val float64
+	numeric := true
+	switch v := value.(type) {
+	case int:
+		val = float64(v)
+	case float64:
+		val = v
+	case string, bool, time.Time:
+		numeric = false
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+
+	fields := make(map[string]BucketUpdate)
+	add := func(u BucketUpdate) {
+		fields[u.Field] = u
+	}
+	for _, fn := range fns {
+		if fn.Numeric() && !numeric {
+			return nil, fmt.Errorf("aggregation %s doesn't support value of type %T", fn, value)
+		}
+		switch fn {
+		case AggrFnSum:
+			add(BucketUpdate{Op: BucketOpIncr, Field: bucketFieldSum, Value: val})
+		case AggrFnCount:
+			add(BucketUpdate{Op: BucketOpIncr, Field: bucketFieldCount, Value: 1})
+		case AggrFnMin:
+			add(BucketUpdate{Op: BucketOpMin, Field: bucketFieldMin, Value: val})
+		case AggrFnMax:
+			add(BucketUpdate{Op: BucketOpMax, Field: bucketFieldMax, Value: val})
+		case AggrFnAvg:
+			add(BucketUpdate{Op: BucketOpIncr, Field: bucketFieldSum, Value: val})
+			add(BucketUpdate{Op: BucketOpIncr, Field: bucketFieldCount, Value: 1})
+		case AggrFnStddev, AggrFnVariance:
+			add(BucketUpdate{Op: BucketOpMoments, Field: bucketFieldM2, Value: 0, Count: 1, Mean: val})
+		case AggrFnFirst:
+			add(BucketUpdate{Op: BucketOpFirst, Field: bucketFieldFirst, Value: val, Timestamp: float64(ts.UnixMicro())})
+		case AggrFnLast:
+			add(BucketUpdate{Op: BucketOpLast, Field: bucketFieldLast, Value: val, Timestamp: float64(ts.UnixMicro())})
+		case AggrFnCountDistinct:
+			register, rank := hllRegister(value)
+			add(BucketUpdate{Op: BucketOpMax, Field: bucketFieldHLL + strconv.Itoa(register), Value: float64(rank)})
+		case AggrFnP50, AggrFnP95, AggrFnP99:
+			add(BucketUpdate{Op: BucketOpIncr, Field: ddBin(val), Value: 1})
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedAggrError, fn)
+		}
+	}
+
+	updates := make([]BucketUpdate, 0, len(fields))
+	for _, u := range fields {
+		updates = append(updates, u)
+	}
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].Field < updates[j].Field
+	})
+	return updates, nil
+}
+
+// Apply applies the updates to the bucket.
+func (bd BucketData) Apply(updates []BucketUpdate) {
+	for _, u := range updates {
+		cur, ok := bd[u.Field]
+		switch u.Op {
+		case BucketOpIncr:
+			bd[u.Field] = cur + u.Value
+		case BucketOpMin:
+			if !ok || u.Value < cur {
+				bd[u.Field] = u.Value
+			}
+		case BucketOpMax:
+			if !ok || u.Value > cur {
+				bd[u.Field] = u.Value
+			}
+		case BucketOpFirst, BucketOpLast:
+			curTs, ok := bd[u.TimestampField()]
+			if !ok || (u.Op == BucketOpFirst && u.Timestamp < curTs) || (u.Op == BucketOpLast && u.Timestamp >= curTs) {
+				bd[u.Field] = u.Value
+				bd[u.TimestampField()] = u.Timestamp
+			}
+		case BucketOpMoments:
+			count := bd[u.CountField()]
+			total := count + u.Count
+			if total == 0 {
+				continue
+			}
+			delta := u.Mean - bd[u.MeanField()]
+			bd[u.Field] = cur + u.Value + delta*delta*count*u.Count/total
+			bd[u.MeanField()] += delta * u.Count / total
+			bd[u.CountField()] = total
+		}
+	}
+}
+
+// Merge merges another bucket into this bucket.
+func (bd BucketData) Merge(other BucketData) {
+	bd.Apply(other.Updates())
+}
+
+// Updates returns the updates that merge the bucket into another bucket.
+func (bd BucketData) Updates() []BucketUpdate {
+	var updates []BucketUpdate
+	for field, v := range bd {
+		switch {
+		case field == bucketFieldFirst+bucketFieldTsSuffix || field == bucketFieldLast+bucketFieldTsSuffix,
+			field == bucketFieldM2+bucketFieldCountSuffix || field == bucketFieldM2+bucketFieldMeanSuffix:
+			// merged along with their value
+			continue
+		case field == bucketFieldFirst:
+			updates = append(updates, BucketUpdate{Op: BucketOpFirst, Field: field, Value: v, Timestamp: bd[field+bucketFieldTsSuffix]})
+		case field == bucketFieldLast:
+			updates = append(updates, BucketUpdate{Op: BucketOpLast, Field: field, Value: v, Timestamp: bd[field+bucketFieldTsSuffix]})
+		case field == bucketFieldM2:
+			updates = append(updates, BucketUpdate{Op: BucketOpMoments, Field: field, Value: v,
+				Count: bd[field+bucketFieldCountSuffix], Mean: bd[field+bucketFieldMeanSuffix]})
+		case field == bucketFieldMin:
+			updates = append(updates, BucketUpdate{Op: BucketOpMin, Field: field, Value: v})
+		case field == bucketFieldMax, strings.HasPrefix(field, bucketFieldHLL):
+			updates = append(updates, BucketUpdate{Op: BucketOpMax, Field: field, Value: v})
+		default:
+			updates = append(updates, BucketUpdate{Op: BucketOpIncr, Field: field, Value: v})
+		}
+	}
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].Field < updates[j].Field
+	})
+	return updates
+}
+
+// Copy returns a copy of the bucket.
+func (bd BucketData) Copy() BucketData {
+	ret := make(BucketData, len(bd))
+	for k, v := range bd {
+		ret[k] = v
+	}
+	return ret
+}
+
+// WindowResult calculates the aggregations of a window out of the data of its buckets.
+// It returns nil if there's no data in the window.
+func WindowResult(fns []AggrFn, buckets ...BucketData) WindowResultMap {
+	data := make(BucketData)
+	for _, b := range buckets {
+		data.Merge(b)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	ret := make(WindowResultMap)
+	set := func(fn AggrFn, field string) {
+		if v, ok := data[field]; ok {
+			ret[fn] = v
+		}
+	}
+	count := data[bucketFieldCount]
+	for _, fn := range fns {
+		switch fn {
+		case AggrFnSum:
+			ret[fn] = data[bucketFieldSum]
+		case AggrFnCount:
+			ret[fn] = count
+		case AggrFnMin:
+			set(fn, bucketFieldMin)
+		case AggrFnMax:
+			set(fn, bucketFieldMax)
+		case AggrFnFirst:
+			set(fn, bucketFieldFirst)
+		case AggrFnLast:
+			set(fn, bucketFieldLast)
+		case AggrFnAvg:
+			if count > 0 {
+				ret[fn] = data[bucketFieldSum] / count
+			}
+		case AggrFnVariance, AggrFnStddev:
+			// the sample variance is undefined for less than two values
+			n := data[bucketFieldM2+bucketFieldCountSuffix]
+			if n < 2 {
+				continue
+			}
+			variance := data[bucketFieldM2] / (n - 1)
+			if fn == AggrFnStddev {
+				ret[fn] = math.Sqrt(variance)
+			} else {
+				ret[fn] = variance
+			}
+		case AggrFnCountDistinct:
+			ret[fn] = hllEstimate(data)
+		case AggrFnP50:
+			if v, ok := ddQuantile(data, 0.5); ok {
+				ret[fn] = v
+			}
+		case AggrFnP95:
+			if v, ok := ddQuantile(data, 0.95); ok {
+				ret[fn] = v
+			}
+		case AggrFnP99:
+			if v, ok := ddQuantile(data, 0.99); ok {
+				ret[fn] = v
+			}
+		}
+	}
+	return ret
+}
+
+// hllRegister returns the register of the value in the HyperLogLog sketch, and the rank to store in it.
+func hllRegister(value any) (int, int) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(ScalarString(value)))
+	x := h.Sum64()
+	// FNV doesn't spread short inputs well enough on the high bits, so it's finalized with a mixer (splitmix64)
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	register := int(x >> (64 - HLLPrecision))
+	rank := bits.LeadingZeros64(x<<HLLPrecision|1<<(HLLPrecision-1)) + 1
+	return register, rank
+}
+
+// hllEstimate estimates the number of distinct values out of the HyperLogLog registers of the bucket.
+func hllEstimate(data BucketData) float64 {
+	const m = float64(HLLRegisters)
+	sum := 0.0
+	zeros := m
+	for field, rank := range data {
+		if !strings.HasPrefix(field, bucketFieldHLL) {
+			continue
+		}
+		sum += math.Pow(2, -rank)
+		zeros--
+	}
+	// registers that were never set are zero
+	sum += zeros
+
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		// linear counting is more accurate for small cardinalities
+		estimate = m * math.Log(m/zeros)
+	}
+	return math.Round(estimate)
+}
+
+// ddBin returns the DDSketch bin of the value.
+func ddBin(v float64) string {
+	switch {
+	case math.Abs(v) < ddMinIndexable:
+		return bucketFieldDDZero
+	case v > 0:
+		return bucketFieldDDPositive + strconv.Itoa(int(math.Ceil(math.Log(v)/ddLogGamma)))
+	default:
+		return bucketFieldDDNegative + strconv.Itoa(int(math.Ceil(math.Log(-v)/ddLogGamma)))
+	}
+}
+
+// ddQuantile returns the approximate quantile out of the DDSketch bins of the bucket.
+func ddQuantile(data BucketData, q float64) (float64, bool) {
+	type bin struct {
+		value float64
+		count float64
+	}
+	var bins []bin
+	total := 0.0
+	for field, count := range data {
+		var value float64
+		switch {
+		case field == bucketFieldDDZero:
+			value = 0
+		case strings.HasPrefix(field, bucketFieldDDPositive), strings.HasPrefix(field, bucketFieldDDNegative):
+			sign := 1.0
+			idx := strings.TrimPrefix(field, bucketFieldDDPositive)
+			if strings.HasPrefix(field, bucketFieldDDNegative) {
+				sign = -1
+				idx = strings.TrimPrefix(field, bucketFieldDDNegative)
+			}
+			i, err := strconv.Atoi(idx)
+			if err != nil {
+				continue
+			}
+			// the bin i holds the values within (gamma^(i-1), gamma^i]
+			value = sign * 2 * math.Pow(DDSketchGamma, float64(i)) / (DDSketchGamma + 1)
+		default:
+			continue
+		}
+		bins = append(bins, bin{value: value, count: count})
+		total += count
+	}
+	if total == 0 {
+		return 0, false
+	}
+
+	sort.Slice(bins, func(i, j int) bool {
+		return bins[i].value < bins[j].value
+	})
+	rank := q * (total - 1)
+	cum := 0.0
+	for _, b := range bins {
+		cum += b.count
+		if cum > rank {
+			return b.value, true
+		}
+	}
+	return bins[len(bins)-1].value, true
+}
@@ -0,0 +1,437 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+// bucketOf adds the values to a new bucket, a second apart
+func bucketOf(t *testing.T, fns []AggrFn, start time.Time, values ...any) BucketData {
+	t.Helper()
+	bd := make(BucketData)
+	for i, v := range values {
+		updates, err := BucketUpdates(fns, v, start.Add(time.Duration(i)*time.Second))
+		if err != nil {
+			t.Fatalf("failed to add %v: %v", v, err)
+		}
+		bd.Apply(updates)
+	}
+	return bd
+}
+
+func floats(from, to int) []any {
+	var ret []any
+	for i := from; i <= to; i++ {
+		ret = append(ret, float64(i))
+	}
+	return ret
+}
+
+// sampleVariance is the two-pass sample variance of the values
+func sampleVariance(values ...float64) float64 {
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	m2 := 0.0
+	for _, v := range values {
+		m2 += (v - mean) * (v - mean)
+	}
+	return m2 / float64(len(values)-1)
+}
+
+func almostEqual(a, b, relTolerance float64) bool {
+	if a == b {
+		return true
+	}
+	return math.Abs(a-b) <= relTolerance*math.Max(math.Abs(a), math.Abs(b))
+}
+
+func TestBucketUpdates(t *testing.T) {
+	ts := time.UnixMicro(1_700_000_000_000_000)
+	tests := []struct {
+		name    string
+		fns     []AggrFn
+		value   any
+		want    []BucketUpdate
+		wantErr error
+	}{
+		{
+			name:  "sum and count",
+			fns:   []AggrFn{AggrFnSum, AggrFnCount},
+			value: 3,
+			want: []BucketUpdate{
+				{Op: BucketOpIncr, Field: "count", Value: 1},
+				{Op: BucketOpIncr, Field: "sum", Value: 3},
+			},
+		},
+		{
+			name:  "shared fields are updated once",
+			fns:   []AggrFn{AggrFnSum, AggrFnAvg, AggrFnCount},
+			value: 2.5,
+			want: []BucketUpdate{
+				{Op: BucketOpIncr, Field: "count", Value: 1},
+				{Op: BucketOpIncr, Field: "sum", Value: 2.5},
+			},
+		},
+		{
+			name:  "min and max",
+			fns:   []AggrFn{AggrFnMin, AggrFnMax},
+			value: -1,
+			want: []BucketUpdate{
+				{Op: BucketOpMax, Field: "max", Value: -1},
+				{Op: BucketOpMin, Field: "min", Value: -1},
+			},
+		},
+		{
+			name:  "variance and stddev",
+			fns:   []AggrFn{AggrFnVariance, AggrFnStddev},
+			value: 10,
+			want: []BucketUpdate{
+				{Op: BucketOpMoments, Field: "m2", Value: 0, Count: 1, Mean: 10},
+			},
+		},
+		{
+			name:  "first and last",
+			fns:   []AggrFn{AggrFnFirst, AggrFnLast},
+			value: 7,
+			want: []BucketUpdate{
+				{Op: BucketOpFirst, Field: "first", Value: 7, Timestamp: float64(ts.UnixMicro())},
+				{Op: BucketOpLast, Field: "last", Value: 7, Timestamp: float64(ts.UnixMicro())},
+			},
+		},
+		{
+			name:  "percentiles share the bins",
+			fns:   []AggrFn{AggrFnP50, AggrFnP99},
+			value: 0,
+			want: []BucketUpdate{
+				{Op: BucketOpIncr, Field: "dd0", Value: 1},
+			},
+		},
+		{
+			name:  "count distinct of a string",
+			fns:   []AggrFn{AggrFnCountDistinct, AggrFnCount},
+			value: "a",
+			want: func() []BucketUpdate {
+				register, rank := hllRegister("a")
+				return []BucketUpdate{
+					{Op: BucketOpIncr, Field: "count", Value: 1},
+					{Op: BucketOpMax, Field: fmt.Sprintf("hll:%d", register), Value: float64(rank)},
+				}
+			}(),
+		},
+		{
+			name:    "numeric aggregation of a string",
+			fns:     []AggrFn{AggrFnCount, AggrFnSum},
+			value:   "a",
+			wantErr: errors.New("aggregation sum doesn't support value of type string"),
+		},
+		{
+			name:    "unsupported type",
+			fns:     []AggrFn{AggrFnCount},
+			value:   []int{1},
+			wantErr: errors.New("unsupported value type []int"),
+		},
+		{
+			name:    "unknown aggregation",
+			fns:     []AggrFn{AggrFnUnknown},
+			value:   1,
+			wantErr: ErrUnsupportedAggrError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BucketUpdates(tt.fns, tt.value, ts)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatalf("expected error %q, got %+v", tt.wantErr, got)
+				}
+				if !errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error() {
+					t.Errorf("expected error %q, got %q", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWindowResult(t *testing.T) {
+	start := time.UnixMicro(1_700_000_000_000_000)
+	all := []AggrFn{AggrFnSum, AggrFnCount, AggrFnMin, AggrFnMax, AggrFnAvg, AggrFnFirst, AggrFnLast,
+		AggrFnVariance, AggrFnStddev}
+
+	tests := []struct {
+		name    string
+		fns     []AggrFn
+		buckets []BucketData
+		want    WindowResultMap
+	}{
+		{
+			name: "no data",
+			fns:  all,
+			want: nil,
+		},
+		{
+			name:    "single bucket",
+			fns:     all,
+			buckets: []BucketData{bucketOf(t, all, start, 2, 4, 4, 4, 5, 5, 7, 9)},
+			want: WindowResultMap{
+				AggrFnSum: 40, AggrFnCount: 8, AggrFnMin: 2, AggrFnMax: 9, AggrFnAvg: 5, AggrFnFirst: 2, AggrFnLast: 9,
+				AggrFnVariance: 32.0 / 7, AggrFnStddev: math.Sqrt(32.0 / 7),
+			},
+		},
+		{
+			name: "buckets are merged regardless of their order",
+			fns:  all,
+			buckets: []BucketData{
+				bucketOf(t, all, start.Add(time.Minute), 5, 7, 9),
+				bucketOf(t, all, start, 2, 4, 4),
+				bucketOf(t, all, start.Add(30*time.Second), 4, 5),
+			},
+			want: WindowResultMap{
+				AggrFnSum: 40, AggrFnCount: 8, AggrFnMin: 2, AggrFnMax: 9, AggrFnAvg: 5, AggrFnFirst: 2, AggrFnLast: 9,
+				AggrFnVariance: 32.0 / 7, AggrFnStddev: math.Sqrt(32.0 / 7),
+			},
+		},
+		{
+			name:    "variance is undefined for a single value",
+			fns:     []AggrFn{AggrFnVariance, AggrFnStddev, AggrFnCount},
+			buckets: []BucketData{bucketOf(t, all, start, 3)},
+			want:    WindowResultMap{AggrFnCount: 1},
+		},
+		{
+			name: "variance of values with a large mean",
+			fns:  []AggrFn{AggrFnVariance},
+			buckets: []BucketData{
+				bucketOf(t, all, start, 1e9+4, 1e9+7),
+				bucketOf(t, all, start.Add(time.Minute), 1e9+13, 1e9+16),
+			},
+			want: WindowResultMap{AggrFnVariance: 30},
+		},
+		{
+			name: "count of non-numeric values",
+			fns:  []AggrFn{AggrFnCount, AggrFnFirst},
+			buckets: []BucketData{
+				bucketOf(t, []AggrFn{AggrFnCount}, start, "a", "b"),
+				bucketOf(t, []AggrFn{AggrFnCount}, start, "c"),
+			},
+			want: WindowResultMap{AggrFnCount: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WindowResult(tt.fns, tt.buckets...)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("expected nil, got %v", got)
+				}
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+			for fn, want := range tt.want {
+				v, ok := got[fn]
+				if !ok || !almostEqual(v, want, 1e-12) {
+					t.Errorf("expected %s to be %v, got %v", fn, want, got[fn])
+				}
+			}
+		})
+	}
+}
+
+func TestBucketData_Merge(t *testing.T) {
+	start := time.UnixMicro(1_700_000_000_000_000)
+	fns := []AggrFn{AggrFnSum, AggrFnMin, AggrFnMax, AggrFnFirst, AggrFnLast, AggrFnVariance, AggrFnCountDistinct,
+		AggrFnP50}
+	values := []any{3.5, -2, 1e6, 0, 42, 42, 7.25, -1e-3, 19}
+
+	whole := bucketOf(t, fns, start, values...)
+	for split := 1; split < len(values); split++ {
+		t.Run(fmt.Sprintf("split at %d", split), func(t *testing.T) {
+			merged := bucketOf(t, fns, start, values[:split]...)
+			merged.Merge(bucketOf(t, fns, start.Add(time.Duration(split)*time.Second), values[split:]...))
+
+			if len(merged) != len(whole) {
+				t.Fatalf("expected the fields %v, got %v", whole, merged)
+			}
+			for field, want := range whole {
+				if got, ok := merged[field]; !ok || !almostEqual(got, want, 1e-9) {
+					t.Errorf("expected %s to be %v, got %v", field, want, merged[field])
+				}
+			}
+		})
+	}
+
+	t.Run("copy", func(t *testing.T) {
+		cp := whole.Copy()
+		if !reflect.DeepEqual(cp, whole) {
+			t.Fatalf("expected %v, got %v", whole, cp)
+		}
+		cp.Merge(whole)
+		if reflect.DeepEqual(cp, whole) {
+			t.Errorf("expected the copy to be independent of the bucket")
+		}
+	})
+}
+
+func TestWindowResult_Variance(t *testing.T) {
+	start := time.UnixMicro(1_700_000_000_000_000)
+	fns := []AggrFn{AggrFnVariance}
+	for _, shift := range []float64{0, 1e6, 1e9} {
+		t.Run(fmt.Sprintf("shift %g", shift), func(t *testing.T) {
+			var raw []float64
+			var buckets []BucketData
+			for b := 0; b < 10; b++ {
+				var values []any
+				for i := 0; i < 7; i++ {
+					v := shift + float64((b*7+i)%13) + 0.25*float64(i)
+					raw = append(raw, v)
+					values = append(values, v)
+				}
+				buckets = append(buckets, bucketOf(t, fns, start.Add(time.Duration(b)*time.Minute), values...))
+			}
+
+			want := sampleVariance(raw...)
+			got := WindowResult(fns, buckets...)[AggrFnVariance]
+			if !almostEqual(got, want, 1e-6) {
+				t.Errorf("expected the variance to be %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestWindowResult_CountDistinct(t *testing.T) {
+	start := time.UnixMicro(1_700_000_000_000_000)
+	fns := []AggrFn{AggrFnCountDistinct}
+	// the standard error is 1.04/sqrt(HLLRegisters)
+	tolerance := 3 * 1.04 / math.Sqrt(HLLRegisters)
+
+	tests := []struct {
+		name    string
+		buckets [][]any
+		want    float64
+	}{
+		{
+			name:    "small cardinality",
+			buckets: [][]any{{"a", "b", "c", "a", "b"}},
+			want:    3,
+		},
+		{
+			name:    "integers",
+			buckets: [][]any{floats(1, 100)},
+			want:    100,
+		},
+		{
+			name:    "large cardinality",
+			buckets: [][]any{floats(1, 20_000)},
+			want:    20_000,
+		},
+		{
+			name:    "overlapping buckets",
+			buckets: [][]any{floats(1, 6_000), floats(4_001, 10_000), floats(1, 10_000)},
+			want:    10_000,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buckets []BucketData
+			for i, values := range tt.buckets {
+				buckets = append(buckets, bucketOf(t, fns, start.Add(time.Duration(i)*time.Minute), values...))
+			}
+			got := WindowResult(fns, buckets...)[AggrFnCountDistinct]
+			if !almostEqual(got, tt.want, tolerance) {
+				t.Errorf("expected ~%v distinct values, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWindowResult_Percentiles(t *testing.T) {
+	start := time.UnixMicro(1_700_000_000_000_000)
+	fns := []AggrFn{AggrFnP50, AggrFnP95, AggrFnP99}
+	quantiles := map[AggrFn]float64{AggrFnP50: 0.5, AggrFnP95: 0.95, AggrFnP99: 0.99}
+
+	tests := []struct {
+		name    string
+		buckets [][]float64
+	}{
+		{
+			name:    "single bucket",
+			buckets: [][]float64{{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}},
+		},
+		{
+			name: "merged buckets",
+			buckets: func() [][]float64 {
+				var low, high []float64
+				for i := 1; i <= 1000; i++ {
+					if i%3 == 0 {
+						low = append(low, float64(i))
+					} else {
+						high = append(high, float64(i)*1.5)
+					}
+				}
+				return [][]float64{low, high}
+			}(),
+		},
+		{
+			name:    "negative values and zeros",
+			buckets: [][]float64{{-100, -50, -10, 0, 0}, {-1, 0, 0.5, 20, 300}},
+		},
+		{
+			name:    "a single value",
+			buckets: [][]float64{{42}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sorted []float64
+			var buckets []BucketData
+			for i, values := range tt.buckets {
+				var add []any
+				for _, v := range values {
+					sorted = append(sorted, v)
+					add = append(add, v)
+				}
+				buckets = append(buckets, bucketOf(t, fns, start.Add(time.Duration(i)*time.Minute), add...))
+			}
+			sort.Float64s(sorted)
+
+			got := WindowResult(fns, buckets...)
+			for fn, q := range quantiles {
+				want := sorted[int(math.Floor(q*float64(len(sorted)-1)))]
+				if !almostEqual(got[fn], want, DDSketchRelativeAccuracy) {
+					t.Errorf("expected %s to be ~%v, got %v", fn, want, got[fn])
+				}
+			}
+		})
+	}
+}
@@ -20,16 +20,44 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 )
 
-var FQNRegExp = regexp.MustCompile(`(?si)^((?P<namespace>[a-z0-9]+(?:_[a-z0-9]+)*)\.)?(?P<name>[a-z0-9]+(?:_[a-z0-9]+)*)(\+(?P<aggrFn>([a-z]+_*[a-z]+)))?(@-(?P<version>([0-9]+)))?(\[(?P<encoding>([a-z]+_*[a-z]+))])?$`)
+var FQNRegExp = regexp.MustCompile(`(?si)^((?P<namespace>[a-z0-9]+(?:_[a-z0-9]+)*)\.)?(?P<name>[a-z0-9]+(?:_[a-z0-9]+)*)(:(?P<field>[a-z0-9]+(?:_[a-z0-9]+)*))?(\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\[(?P<encoding>([a-z]+_*[a-z]+))])?$`)
 
+// Selector is a parsed Feature Selector, i.e. `[namespace.]name[:field][+aggrFn[[lookback]]][@-version][[encoding]]`.
+type Selector struct {
+	Namespace string
+	Name      string
+	// Field (e.g. `ns.profile:country`) addresses a single field of a struct feature.
+	Field  string
+	AggrFn AggrFn
+	// Lookback (e.g. `ns.clicks+sum[1h]`) limits the window of the aggregation to the requested range.
+	Lookback time.Duration
+	Version  uint
+	Encoding string
+}
+
+// ParseSelector parses a Feature Selector into its namespace, name, aggregation function, version and encoding.
+//
+// Deprecated: use ParseSelectorStruct, which returns the field and the lookback of the selector as well.
 func ParseSelector(fqn string) (namespace, name string, aggrFn AggrFn, version uint, encoding string, err error) {
-	if !FQNRegExp.MatchString(fqn) {
-		return "", "", AggrFnUnknown, 0, "", fmt.Errorf("invalid FQN: %s", fqn)
+	sel, err := ParseSelectorStruct(fqn)
+	if err != nil {
+		return "", "", AggrFnUnknown, 0, "", err
+	}
+	return sel.Namespace, sel.Name, sel.AggrFn, sel.Version, sel.Encoding, nil
+}
+
+// ParseSelectorStruct parses a Feature Selector, i.e.
+// `[namespace.]name[:field][+aggrFn[[lookback]]][@-version][[encoding]]`.
+func ParseSelectorStruct(selector string) (Selector, error) {
+	if !FQNRegExp.MatchString(selector) {
+		return Selector{}, fmt.Errorf("invalid FQN: %s", selector)
 	}
 
-	match := FQNRegExp.FindStringSubmatch(fqn)
+	match := FQNRegExp.FindStringSubmatch(selector)
 	parsedFQN := make(map[string]string)
 	for i, name := range FQNRegExp.SubexpNames() {
 		if i != 0 && name != "" {
@@ -39,55 +67,92 @@ func ParseSelector(fqn string) (namespace, name string, aggrFn AggrFn, version u
 
 	var ver = 0
 	if parsedFQN["version"] != "" {
+		var err error
 		ver, err = strconv.Atoi(parsedFQN["version"])
 		if err != nil {
-			return "", "", AggrFnUnknown, 0, "", fmt.Errorf("invalid version: %s", parsedFQN["version"])
+			return Selector{}, fmt.Errorf("invalid version: %s", parsedFQN["version"])
 		}
 		if ver < 0 {
 			ver *= -1
 		}
 	}
 
-	namespace = parsedFQN["namespace"]
-	name = parsedFQN["name"]
-	aggrFn = StringToAggrFn(parsedFQN["aggrFn"])
-	version = uint(ver)
-	encoding = parsedFQN["encoding"]
-	return
+	var lookback time.Duration
+	if parsedFQN["lookback"] != "" {
+		var err error
+		lookback, err = time.ParseDuration(parsedFQN["lookback"])
+		if err != nil || lookback <= 0 {
+			return Selector{}, fmt.Errorf("invalid lookback: %s", parsedFQN["lookback"])
+		}
+	}
+
+	return Selector{
+		Namespace: parsedFQN["namespace"],
+		Name:      parsedFQN["name"],
+		Field:     parsedFQN["field"],
+		AggrFn:    StringToAggrFn(parsedFQN["aggrFn"]),
+		Lookback:  lookback,
+		Version:   uint(ver),
+		Encoding:  parsedFQN["encoding"],
+	}, nil
+}
+
+// String returns the selector in its canonical form
+func (s Selector) String() string {
+	ret := s.Name
+	if s.Namespace != "" {
+		ret = fmt.Sprintf("%s.%s", s.Namespace, ret)
+	}
+	if s.Field != "" {
+		ret = fmt.Sprintf("%s:%s", ret, s.Field)
+	}
+	if s.AggrFn != AggrFnUnknown {
+		ret = fmt.Sprintf("%s+%s", ret, s.AggrFn)
+	}
+	if s.Lookback != 0 {
+		ret = fmt.Sprintf("%s[%s]", ret, formatLookback(s.Lookback))
+	}
+	if s.Version != 0 {
+		ret = fmt.Sprintf("%s@-%d", ret, s.Version)
+	}
+	if s.Encoding != "" {
+		ret = fmt.Sprintf("%s[%s]", ret, s.Encoding)
+	}
+	return ret
 }
 
 // NormalizeFQN returns an FQN with the namespace
 func NormalizeFQN(fqn, defaultNamespace string) (string, error) {
-	namespace, name, _, _, _, err := ParseSelector(fqn)
+	sel, err := ParseSelectorStruct(fqn)
 	if err != nil {
 		return "", err
 	}
-	if namespace == "" {
-		namespace = defaultNamespace
+	if sel.Namespace == "" {
+		sel.Namespace = defaultNamespace
 	}
-	return fmt.Sprintf("%s.%s", namespace, name), nil
+	return fmt.Sprintf("%s.%s", sel.Namespace, sel.Name), nil
 }
 
 // NormalizeSelector returns a selector with the default namespace if not specified
 func NormalizeSelector(selector, defaultNamespace string) (string, error) {
-	ns, name, aggrFn, version, enc, err := ParseSelector(selector)
+	sel, err := ParseSelectorStruct(selector)
 	if err != nil {
 		return "", err
 	}
-
-	if ns == "" {
-		ns = defaultNamespace
+	if sel.Namespace == "" {
+		sel.Namespace = defaultNamespace
 	}
+	return sel.String(), nil
+}
 
-	other := ""
-	if aggrFn != AggrFnUnknown {
-		other = fmt.Sprintf("%s+%s", other, aggrFn)
-	}
-	if version != 0 {
-		other = fmt.Sprintf("%s@-%d", other, version)
+// formatLookback formats the lookback duration without its zero units, i.e. `1h` instead of `1h0m0s`
+func formatLookback(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
 	}
-	if enc != "" {
-		other = fmt.Sprintf("%s[%s]", other, enc)
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
 	}
-	return fmt.Sprintf("%s.%s%s", ns, name, other), nil
+	return s
 }
@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2022 RaptorML authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSelectorStruct(t *testing.T) {
+	tests := []struct {
+		selector string
+		want     Selector
+		// canonical is the expected String() of the selector, if it's different from the selector itself
+		canonical string
+		wantErr   bool
+	}{
+		{selector: "clicks", want: Selector{Name: "clicks"}},
+		{selector: "default.clicks", want: Selector{Namespace: "default", Name: "clicks"}},
+		{selector: "default.user_profile", want: Selector{Namespace: "default", Name: "user_profile"}},
+		{selector: "default.profile:country", want: Selector{Namespace: "default", Name: "profile", Field: "country"}},
+		{selector: "default.profile:home_country", want: Selector{Namespace: "default", Name: "profile", Field: "home_country"}},
+		{selector: "default.clicks+sum", want: Selector{Namespace: "default", Name: "clicks", AggrFn: AggrFnSum}},
+		{selector: "default.clicks+count_distinct", want: Selector{Namespace: "default", Name: "clicks", AggrFn: AggrFnCountDistinct}},
+		{selector: "default.clicks+p99", want: Selector{Namespace: "default", Name: "clicks", AggrFn: AggrFnP99}},
+		{selector: "default.clicks+sum[1h]", want: Selector{Namespace: "default", Name: "clicks", AggrFn: AggrFnSum, Lookback: time.Hour}},
+		{selector: "default.clicks+sum[90m]", want: Selector{Namespace: "default", Name: "clicks", AggrFn: AggrFnSum, Lookback: 90 * time.Minute}, canonical: "default.clicks+sum[1h30m]"},
+		{selector: "default.clicks+sum[1.5h]", want: Selector{Namespace: "default", Name: "clicks", AggrFn: AggrFnSum, Lookback: 90 * time.Minute}, canonical: "default.clicks+sum[1h30m]"},
+		{selector: "default.clicks+sum[30s]", want: Selector{Namespace: "default", Name: "clicks", AggrFn: AggrFnSum, Lookback: 30 * time.Second}},
+		{selector: "default.score@-2", want: Selector{Namespace: "default", Name: "score", Version: 2}},
+		{selector: "default.score[onehot]", want: Selector{Namespace: "default", Name: "score", Encoding: "onehot"}},
+		{selector: "default.score[min_max]", want: Selector{Namespace: "default", Name: "score", Encoding: "min_max"}},
+		{selector: "default.clicks+sum[onehot]", want: Selector{Namespace: "default", Name: "clicks", AggrFn: AggrFnSum, Encoding: "onehot"}},
+		{selector: "default.clicks+avg[2h][zscore]", want: Selector{Namespace: "default", Name: "clicks", AggrFn: AggrFnAvg, Lookback: 2 * time.Hour, Encoding: "zscore"}},
+		{selector: "default.score@-1[zscore]", want: Selector{Namespace: "default", Name: "score", Version: 1, Encoding: "zscore"}},
+		{selector: "default.profile:age[zscore]", want: Selector{Namespace: "default", Name: "profile", Field: "age", Encoding: "zscore"}},
+		{selector: "Default.Clicks+SUM", want: Selector{Namespace: "Default", Name: "Clicks", AggrFn: AggrFnSum}, canonical: "Default.Clicks+sum"},
+		{selector: "", wantErr: true},
+		{selector: "default.", wantErr: true},
+		{selector: "default.clicks-", wantErr: true},
+		{selector: "default.clicks+sum[0s]", wantErr: true},
+		{selector: "default.clicks+sum[1x]", wantErr: true},
+		{selector: "default.clicks[1h]", wantErr: true},
+		{selector: "default.clicks@2", wantErr: true},
+		{selector: "default.profile:", wantErr: true},
+		{selector: "default.clicks;drop", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := ParseSelectorStruct(tt.selector)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSelectorStruct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseSelectorStruct() = %+v, want %+v", got, tt.want)
+			}
+
+			canonical := tt.canonical
+			if canonical == "" {
+				canonical = tt.selector
+			}
+			if s := got.String(); s != canonical {
+				t.Errorf("String() = %s, want %s", s, canonical)
+			}
+			// the canonical form is parsed back to the same selector
+			again, err := ParseSelectorStruct(got.String())
+			if err != nil {
+				t.Fatalf("failed to parse the canonical selector %s: %v", got.String(), err)
+			}
+			if again != got {
+				t.Errorf("round-trip = %+v, want %+v", again, got)
+			}
+
+			ns, name, aggrFn, version, encoding, err := ParseSelector(tt.selector)
+			if err != nil {
+				t.Fatalf("ParseSelector() error = %v", err)
+			}
+			if ns != got.Namespace || name != got.Name || aggrFn != got.AggrFn || version != got.Version || encoding != got.Encoding {
+				t.Errorf("ParseSelector() = %s, %s, %s, %d, %s, want %+v", ns, name, aggrFn, version, encoding, got)
+			}
+		})
+	}
+}
+
+func TestNormalizeSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		wantFQN  string
+		want     string
+	}{
+		{selector: "clicks", wantFQN: "ns.clicks", want: "ns.clicks"},
+		{selector: "other.clicks", wantFQN: "other.clicks", want: "other.clicks"},
+		{selector: "clicks+sum[60m]@-0", wantFQN: "ns.clicks", want: "ns.clicks+sum[1h]"},
+		{selector: "profile:country[onehot]", wantFQN: "ns.profile", want: "ns.profile:country[onehot]"},
+		{selector: "score@-3", wantFQN: "ns.score", want: "ns.score@-3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			fqn, err := NormalizeFQN(tt.selector, "ns")
+			if err != nil {
+				t.Fatalf("NormalizeFQN() error = %v", err)
+			}
+			if fqn != tt.wantFQN {
+				t.Errorf("NormalizeFQN() = %s, want %s", fqn, tt.wantFQN)
+			}
+			got, err := NormalizeSelector(tt.selector, "ns")
+			if err != nil {
+				t.Fatalf("NormalizeSelector() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeSelector() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// ConstraintMode defines how a written value that violates the constraints is handled
+type ConstraintMode string
+
+const (
+	// ConstraintModeReject rejects the write
+	ConstraintModeReject ConstraintMode = "reject"
+	// ConstraintModeClamp clamps the value to the min/max and the list to the max items. Other violations are rejected.
+	ConstraintModeClamp ConstraintMode = "clamp"
+	// ConstraintModeLog accepts the value as is
+	ConstraintModeLog ConstraintMode = "log"
+)
+
+// Constraint names
+const (
+	ConstraintMin           = "min"
+	ConstraintMax           = "max"
+	ConstraintAllowedValues = "allowedValues"
+	ConstraintPattern       = "pattern"
+	ConstraintMaxItems      = "maxItems"
+	ConstraintNotNull       = "notNull"
+	// ConstraintNaN is violated by NaN values of features with a min or max constraint. It can't be clamped.
+	ConstraintNaN = "nan"
+)
+
+// Constraints are the constraints of the values that are written to a feature.
+// For list features, the constraints of the values apply to each of the list items. Appended items are checked with
+// CheckItem, so MaxItems isn't enforced on appends.
+type Constraints struct {
+	Mode          ConstraintMode `json:"mode"`
+	Min           *float64       `json:"min,omitempty"`
+	Max           *float64       `json:"max,omitempty"`
+	AllowedValues []any          `json:"allowed_values,omitempty"`
+	Pattern       string         `json:"pattern,omitempty"`
+	MaxItems      int            `json:"max_items,omitempty"`
+	NotNull       bool           `json:"not_null,omitempty"`
+
+	primitive PrimitiveType
+	pattern   *regexp.Regexp
+}
+
+// Violation is a violation of a constraint by a value
+type Violation struct {
+	// Constraint is the name of the violated constraint, i.e. `min`
+	Constraint string
+	// Message describes the violation
+	Message string
+}
+
+// Clampable returns true if the violation can be fixed by clamping the value
+func (v Violation) Clampable() bool {
+	return v.Constraint == ConstraintMin || v.Constraint == ConstraintMax || v.Constraint == ConstraintMaxItems
+}
+
+// ConstraintsFromManifest parses and validates the constraints of a feature against its primitive.
+func ConstraintsFromManifest(in *manifests.FeatureConstraints, primitive PrimitiveType) (*Constraints, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	c := &Constraints{
+		Mode:      ConstraintMode(in.Mode),
+		NotNull:   in.NotNull,
+		primitive: primitive,
+	}
+	switch c.Mode {
+	case "":
+		c.Mode = ConstraintModeReject
+	case ConstraintModeReject, ConstraintModeClamp, ConstraintModeLog:
+	default:
+		return nil, fmt.Errorf("unsupported constraint mode: %s", in.Mode)
+	}
+
+	item := primitive.Singular()
+	list := !primitive.Scalar() && primitive != PrimitiveTypeEmbedding && primitive != PrimitiveTypeStruct
+	numeric := item == PrimitiveTypeInteger || item == PrimitiveTypeFloat
+	if primitive == PrimitiveTypeEmbedding || primitive == PrimitiveTypeStruct {
+		numeric = false
+		item = PrimitiveTypeUnknown
+	}
+
+	var err error
+	if in.Min != nil || in.Max != nil {
+		if !numeric {
+			return nil, fmt.Errorf("min/max constraints are only supported for numeric features")
+		}
+		if c.Min, err = constraintNumber(in.Min); err != nil {
+			return nil, fmt.Errorf("invalid min constraint: %w", err)
+		}
+		if c.Max, err = constraintNumber(in.Max); err != nil {
+			return nil, fmt.Errorf("invalid max constraint: %w", err)
+		}
+		if c.Min != nil && c.Max != nil && *c.Min > *c.Max {
+			return nil, fmt.Errorf("min constraint (%v) is greater than max constraint (%v)", *c.Min, *c.Max)
+		}
+	}
+	if len(in.AllowedValues) > 0 {
+		if item == PrimitiveTypeUnknown || item == PrimitiveTypeTimestamp {
+			return nil, fmt.Errorf("allowed values constraint is not supported for %s features", primitive)
+		}
+		for _, v := range in.AllowedValues {
+			av, err := CastPrimitive(v, item)
+			if err != nil {
+				return nil, fmt.Errorf("allowed value `%s` doesn't match the feature's primitive: %w", v, err)
+			}
+			c.AllowedValues = append(c.AllowedValues, av)
+		}
+	}
+	if in.Pattern != "" {
+		if item != PrimitiveTypeString {
+			return nil, fmt.Errorf("pattern constraint is only supported for string features")
+		}
+		if c.pattern, err = regexp.Compile(in.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid pattern constraint: %w", err)
+		}
+		c.Pattern = in.Pattern
+	}
+	if in.MaxItems != nil {
+		if !list {
+			return nil, fmt.Errorf("max items constraint is only supported for list features")
+		}
+		if *in.MaxItems < 1 {
+			return nil, fmt.Errorf("max items constraint must be positive")
+		}
+		c.MaxItems = *in.MaxItems
+	}
+	return c, nil
+}
+
+func constraintNumber(n *json.Number) (*float64, error) {
+	if n == nil {
+		return nil, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// Check checks the value against the constraints.
+// It returns the violations, and the value clamped to the constraints. Values that don't match the primitive are
+// not checked, as they are rejected when written to the state.
+func (c *Constraints) Check(val any) (any, []Violation) {
+	if val == nil {
+		if c.NotNull {
+			return val, []Violation{{Constraint: ConstraintNotNull, Message: "value is null"}}
+		}
+		return val, nil
+	}
+	if c.primitive == PrimitiveTypeEmbedding || c.primitive == PrimitiveTypeStruct {
+		return val, nil
+	}
+
+	cv, err := CastPrimitive(val, c.primitive)
+	if err != nil || cv == nil {
+		return val, nil
+	}
+	if c.primitive.Scalar() {
+		return c.checkItem(cv, "value")
+	}
+
+	var violations []Violation
+	items := reflect.ValueOf(cv)
+	n := items.Len()
+	if c.MaxItems > 0 && n > c.MaxItems {
+		violations = append(violations, Violation{
+			Constraint: ConstraintMaxItems,
+			Message:    fmt.Sprintf("list has %d items, while the maximum is %d", n, c.MaxItems),
+		})
+		n = c.MaxItems
+	}
+	clamped := reflect.MakeSlice(items.Type(), n, n)
+	for i := 0; i < n; i++ {
+		item, vs := c.checkItem(items.Index(i).Interface(), fmt.Sprintf("item %d", i))
+		clamped.Index(i).Set(reflect.ValueOf(item))
+		violations = append(violations, vs...)
+	}
+	return clamped.Interface(), violations
+}
+
+// CheckItem checks a single item that is appended to a list feature against the constraints of the list's items.
+// It returns the violations, and the item clamped to the constraints.
+func (c *Constraints) CheckItem(val any) (any, []Violation) {
+	if val == nil {
+		if c.NotNull {
+			return val, []Violation{{Constraint: ConstraintNotNull, Message: "appended item is null"}}
+		}
+		return val, nil
+	}
+	if c.primitive.Scalar() || c.primitive == PrimitiveTypeEmbedding || c.primitive == PrimitiveTypeStruct {
+		return val, nil
+	}
+
+	cv, err := CastPrimitive(val, c.primitive.Singular())
+	if err != nil || cv == nil {
+		return val, nil
+	}
+	return c.checkItem(cv, "appended item")
+}
+
+// CheckAppended checks the value that is appended to a list feature, which is either a single item or a list of items,
+// against the constraints of the list's items. MaxItems isn't checked, since it limits the list in the state rather
+// than the appended items.
+// It returns the violations, and the value clamped to the constraints.
+func (c *Constraints) CheckAppended(val any) (any, []Violation) {
+	if rv := reflect.ValueOf(val); rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return c.CheckItem(val)
+	}
+	if c.primitive.Scalar() || c.primitive == PrimitiveTypeEmbedding || c.primitive == PrimitiveTypeStruct {
+		return val, nil
+	}
+
+	cv, err := CastPrimitive(val, c.primitive)
+	if err != nil || cv == nil {
+		return val, nil
+	}
+	var violations []Violation
+	items := reflect.ValueOf(cv)
+	clamped := reflect.MakeSlice(items.Type(), items.Len(), items.Len())
+	for i := 0; i < items.Len(); i++ {
+		item, vs := c.checkItem(items.Index(i).Interface(), fmt.Sprintf("appended item %d", i))
+		clamped.Index(i).Set(reflect.ValueOf(item))
+		violations = append(violations, vs...)
+	}
+	return clamped.Interface(), violations
+}
+
+func (c *Constraints) checkItem(val any, name string) (any, []Violation) {
+	var violations []Violation
+	if s, ok := val.(string); ok {
+		if c.NotNull && s == "" {
+			violations = append(violations, Violation{Constraint: ConstraintNotNull, Message: name + " is empty"})
+		}
+		if c.pattern != nil && !c.pattern.MatchString(s) {
+			violations = append(violations, Violation{
+				Constraint: ConstraintPattern,
+				Message:    fmt.Sprintf("%s `%s` doesn't match the pattern `%s`", name, s, c.Pattern),
+			})
+		}
+	}
+	if len(c.AllowedValues) > 0 && !c.allowed(val) {
+		violations = append(violations, Violation{
+			Constraint: ConstraintAllowedValues,
+			Message:    fmt.Sprintf("%s `%v` is not one of the allowed values", name, val),
+		})
+	}
+
+	var f float64
+	switch v := val.(type) {
+	case int:
+		f = float64(v)
+	case float64:
+		f = v
+	default:
+		return val, violations
+	}
+	if math.IsNaN(f) && (c.Min != nil || c.Max != nil) {
+		violations = append(violations, Violation{
+			Constraint: ConstraintNaN,
+			Message:    fmt.Sprintf("%s is NaN, while the value must be within the min/max bounds", name),
+		})
+		return val, violations
+	}
+	if c.Min != nil && f < *c.Min {
+		violations = append(violations, Violation{
+			Constraint: ConstraintMin,
+			Message:    fmt.Sprintf("%s %v is less than the minimum %v", name, val, *c.Min),
+		})
+		val = clampNumber(val, *c.Min, math.Ceil)
+	}
+	if c.Max != nil && f > *c.Max {
+		violations = append(violations, Violation{
+			Constraint: ConstraintMax,
+			Message:    fmt.Sprintf("%s %v is greater than the maximum %v", name, val, *c.Max),
+		})
+		val = clampNumber(val, *c.Max, math.Floor)
+	}
+	return val, violations
+}
+
+func (c *Constraints) allowed(val any) bool {
+	for _, av := range c.AllowedValues {
+		if av == val {
+			return true
+		}
+	}
+	return false
+}
+
+// clampNumber converts the bound to the type of the value. Integer bounds are rounded inwards.
+func clampNumber(val any, bound float64, round func(float64) float64) any {
+	if _, ok := val.(int); ok {
+		return int(round(bound))
+	}
+	return bound
+}
+
+// ViolationsError returns an error that describes the violations
+func ViolationsError(violations []Violation) error {
+	msgs := make([]string, len(violations))
+	for i, v := range violations {
+		msgs[i] = v.Message
+	}
+	return fmt.Errorf("%w: %s", ErrConstraintViolation, strings.Join(msgs, "; "))
+}
@@ -0,0 +1,437 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func number(s string) *json.Number {
+	n := json.Number(s)
+	return &n
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestConstraintsFromManifest(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        *manifests.FeatureConstraints
+		primitive PrimitiveType
+		wantErr   bool
+		check     func(t *testing.T, c *Constraints)
+	}{
+		{
+			name:      "nil",
+			primitive: PrimitiveTypeInteger,
+			check: func(t *testing.T, c *Constraints) {
+				if c != nil {
+					t.Errorf("expected nil constraints, got %+v", c)
+				}
+			},
+		},
+		{
+			name:      "default mode",
+			in:        &manifests.FeatureConstraints{Min: number("0")},
+			primitive: PrimitiveTypeInteger,
+			check: func(t *testing.T, c *Constraints) {
+				if c.Mode != ConstraintModeReject {
+					t.Errorf("expected mode %s, got %s", ConstraintModeReject, c.Mode)
+				}
+				if c.Min == nil || *c.Min != 0 {
+					t.Errorf("expected min 0, got %v", c.Min)
+				}
+			},
+		},
+		{
+			name:      "unsupported mode",
+			in:        &manifests.FeatureConstraints{Mode: "ignore"},
+			primitive: PrimitiveTypeInteger,
+			wantErr:   true,
+		},
+		{
+			name:      "min/max of a string",
+			in:        &manifests.FeatureConstraints{Max: number("1")},
+			primitive: PrimitiveTypeString,
+			wantErr:   true,
+		},
+		{
+			name:      "min greater than max",
+			in:        &manifests.FeatureConstraints{Min: number("2"), Max: number("1.5")},
+			primitive: PrimitiveTypeFloat,
+			wantErr:   true,
+		},
+		{
+			name:      "min/max of a list applies to the items",
+			in:        &manifests.FeatureConstraints{Min: number("1"), Max: number("2")},
+			primitive: PrimitiveTypeFloatList,
+		},
+		{
+			name:      "allowed values are casted",
+			in:        &manifests.FeatureConstraints{AllowedValues: []string{"1", "2"}},
+			primitive: PrimitiveTypeInteger,
+			check: func(t *testing.T, c *Constraints) {
+				if !reflect.DeepEqual(c.AllowedValues, []any{1, 2}) {
+					t.Errorf("expected allowed values [1 2], got %#v", c.AllowedValues)
+				}
+			},
+		},
+		{
+			name:      "allowed values that don't match the primitive",
+			in:        &manifests.FeatureConstraints{AllowedValues: []string{"a"}},
+			primitive: PrimitiveTypeInteger,
+			wantErr:   true,
+		},
+		{
+			name:      "allowed values of a timestamp",
+			in:        &manifests.FeatureConstraints{AllowedValues: []string{"2022-01-01T00:00:00Z"}},
+			primitive: PrimitiveTypeTimestamp,
+			wantErr:   true,
+		},
+		{
+			name:      "pattern of an integer",
+			in:        &manifests.FeatureConstraints{Pattern: "^a"},
+			primitive: PrimitiveTypeInteger,
+			wantErr:   true,
+		},
+		{
+			name:      "invalid pattern",
+			in:        &manifests.FeatureConstraints{Pattern: "("},
+			primitive: PrimitiveTypeString,
+			wantErr:   true,
+		},
+		{
+			name:      "max items of a scalar",
+			in:        &manifests.FeatureConstraints{MaxItems: intPtr(2)},
+			primitive: PrimitiveTypeString,
+			wantErr:   true,
+		},
+		{
+			name:      "max items of an embedding",
+			in:        &manifests.FeatureConstraints{MaxItems: intPtr(2)},
+			primitive: PrimitiveTypeEmbedding,
+			wantErr:   true,
+		},
+		{
+			name:      "non-positive max items",
+			in:        &manifests.FeatureConstraints{MaxItems: intPtr(0)},
+			primitive: PrimitiveTypeStringList,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ConstraintsFromManifest(tt.in, tt.primitive)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConstraintsFromManifest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, c)
+			}
+		})
+	}
+}
+
+func TestConstraintsCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        manifests.FeatureConstraints
+		primitive PrimitiveType
+		val       any
+		want      any
+		// violations are the names of the violated constraints
+		violations []string
+	}{
+		{
+			name:      "integer within bounds",
+			in:        manifests.FeatureConstraints{Min: number("0"), Max: number("10")},
+			primitive: PrimitiveTypeInteger,
+			val:       5,
+			want:      5,
+		},
+		{
+			name:       "integer is clamped inwards",
+			in:         manifests.FeatureConstraints{Min: number("0.5")},
+			primitive:  PrimitiveTypeInteger,
+			val:        -3,
+			want:       1,
+			violations: []string{ConstraintMin},
+		},
+		{
+			name:       "integer above max",
+			in:         manifests.FeatureConstraints{Max: number("9.5")},
+			primitive:  PrimitiveTypeInteger,
+			val:        12,
+			want:       9,
+			violations: []string{ConstraintMax},
+		},
+		{
+			name:       "float is clamped",
+			in:         manifests.FeatureConstraints{Min: number("-1.5"), Max: number("1.5")},
+			primitive:  PrimitiveTypeFloat,
+			val:        2.25,
+			want:       1.5,
+			violations: []string{ConstraintMax},
+		},
+		{
+			name:       "NaN with bounds",
+			in:         manifests.FeatureConstraints{Min: number("0"), Max: number("1")},
+			primitive:  PrimitiveTypeFloat,
+			val:        math.NaN(),
+			want:       math.NaN(),
+			violations: []string{ConstraintNaN},
+		},
+		{
+			name:      "NaN without bounds",
+			in:        manifests.FeatureConstraints{NotNull: true},
+			primitive: PrimitiveTypeFloat,
+			val:       math.NaN(),
+			want:      math.NaN(),
+		},
+		{
+			name:       "null",
+			in:         manifests.FeatureConstraints{NotNull: true},
+			primitive:  PrimitiveTypeString,
+			val:        nil,
+			want:       nil,
+			violations: []string{ConstraintNotNull},
+		},
+		{
+			name:       "empty string",
+			in:         manifests.FeatureConstraints{NotNull: true},
+			primitive:  PrimitiveTypeString,
+			val:        "",
+			want:       "",
+			violations: []string{ConstraintNotNull},
+		},
+		{
+			name:      "pattern",
+			in:        manifests.FeatureConstraints{Pattern: "^[a-z]+$"},
+			primitive: PrimitiveTypeString,
+			val:       "abc",
+			want:      "abc",
+		},
+		{
+			name:       "pattern mismatch",
+			in:         manifests.FeatureConstraints{Pattern: "^[a-z]+$"},
+			primitive:  PrimitiveTypeString,
+			val:        "ab1",
+			want:       "ab1",
+			violations: []string{ConstraintPattern},
+		},
+		{
+			name:       "allowed values",
+			in:         manifests.FeatureConstraints{AllowedValues: []string{"red", "green"}},
+			primitive:  PrimitiveTypeString,
+			val:        "blue",
+			want:       "blue",
+			violations: []string{ConstraintAllowedValues},
+		},
+		{
+			name:      "allowed values of a casted value",
+			in:        manifests.FeatureConstraints{AllowedValues: []string{"1", "2"}},
+			primitive: PrimitiveTypeInteger,
+			val:       json.Number("2"),
+			want:      2,
+		},
+		{
+			name:       "list with max items",
+			in:         manifests.FeatureConstraints{MaxItems: intPtr(2)},
+			primitive:  PrimitiveTypeIntegerList,
+			val:        []int{1, 2, 3},
+			want:       []int{1, 2},
+			violations: []string{ConstraintMaxItems},
+		},
+		{
+			name:       "list items are clamped",
+			in:         manifests.FeatureConstraints{Max: number("2"), MaxItems: intPtr(3)},
+			primitive:  PrimitiveTypeFloatList,
+			val:        []any{1.0, 3.0},
+			want:       []float64{1, 2},
+			violations: []string{ConstraintMax},
+		},
+		{
+			name:       "list items with pattern and allowed values",
+			in:         manifests.FeatureConstraints{Pattern: "^a", AllowedValues: []string{"ab", "b"}},
+			primitive:  PrimitiveTypeStringList,
+			val:        []string{"ab", "b", "ac"},
+			want:       []string{"ab", "b", "ac"},
+			violations: []string{ConstraintPattern, ConstraintAllowedValues},
+		},
+		{
+			name:      "value that doesn't match the primitive isn't checked",
+			in:        manifests.FeatureConstraints{Min: number("0")},
+			primitive: PrimitiveTypeInteger,
+			val:       "abc",
+			want:      "abc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ConstraintsFromManifest(&tt.in, tt.primitive)
+			if err != nil {
+				t.Fatalf("ConstraintsFromManifest() error = %v", err)
+			}
+			got, violations := c.Check(tt.val)
+			assertCheck(t, got, violations, tt.want, tt.violations)
+		})
+	}
+}
+
+func TestConstraintsCheckItem(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         manifests.FeatureConstraints
+		primitive  PrimitiveType
+		val        any
+		want       any
+		violations []string
+	}{
+		{
+			name:       "appended item is clamped",
+			in:         manifests.FeatureConstraints{Min: number("0"), MaxItems: intPtr(1)},
+			primitive:  PrimitiveTypeIntegerList,
+			val:        -1,
+			want:       0,
+			violations: []string{ConstraintMin},
+		},
+		{
+			name:       "appended item with a pattern",
+			in:         manifests.FeatureConstraints{Pattern: "^a"},
+			primitive:  PrimitiveTypeStringList,
+			val:        "b",
+			want:       "b",
+			violations: []string{ConstraintPattern},
+		},
+		{
+			name:       "appended null item",
+			in:         manifests.FeatureConstraints{NotNull: true},
+			primitive:  PrimitiveTypeStringList,
+			val:        nil,
+			want:       nil,
+			violations: []string{ConstraintNotNull},
+		},
+		{
+			name:       "appended NaN item",
+			in:         manifests.FeatureConstraints{Max: number("1")},
+			primitive:  PrimitiveTypeFloatList,
+			val:        math.NaN(),
+			want:       math.NaN(),
+			violations: []string{ConstraintNaN},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ConstraintsFromManifest(&tt.in, tt.primitive)
+			if err != nil {
+				t.Fatalf("ConstraintsFromManifest() error = %v", err)
+			}
+			got, violations := c.CheckItem(tt.val)
+			assertCheck(t, got, violations, tt.want, tt.violations)
+		})
+	}
+}
+
+func TestConstraintsCheckAppended(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         manifests.FeatureConstraints
+		primitive  PrimitiveType
+		val        any
+		want       any
+		violations []string
+	}{
+		{
+			name:       "single item",
+			in:         manifests.FeatureConstraints{Pattern: "^a"},
+			primitive:  PrimitiveTypeStringList,
+			val:        "b",
+			want:       "b",
+			violations: []string{ConstraintPattern},
+		},
+		{
+			name:       "items",
+			in:         manifests.FeatureConstraints{AllowedValues: []string{"a", "b"}},
+			primitive:  PrimitiveTypeStringList,
+			val:        []string{"a", "c"},
+			want:       []string{"a", "c"},
+			violations: []string{ConstraintAllowedValues},
+		},
+		{
+			name:       "items are clamped",
+			in:         manifests.FeatureConstraints{Max: number("10")},
+			primitive:  PrimitiveTypeIntegerList,
+			val:        []any{1, 20},
+			want:       []int{1, 10},
+			violations: []string{ConstraintMax},
+		},
+		{
+			// the max items limits the list in the state, rather than the appended items
+			name:      "max items",
+			in:        manifests.FeatureConstraints{MaxItems: intPtr(1)},
+			primitive: PrimitiveTypeIntegerList,
+			val:       []int{1, 2},
+			want:      []int{1, 2},
+		},
+		{
+			name:      "valid items",
+			in:        manifests.FeatureConstraints{Min: number("0")},
+			primitive: PrimitiveTypeFloatList,
+			val:       []float64{1, 2},
+			want:      []float64{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ConstraintsFromManifest(&tt.in, tt.primitive)
+			if err != nil {
+				t.Fatalf("ConstraintsFromManifest() error = %v", err)
+			}
+			got, violations := c.CheckAppended(tt.val)
+			assertCheck(t, got, violations, tt.want, tt.violations)
+		})
+	}
+}
+
+func assertCheck(t *testing.T, got any, violations []Violation, want any, wantViolations []string) {
+	t.Helper()
+	if f, ok := want.(float64); ok && math.IsNaN(f) {
+		if g, ok := got.(float64); !ok || !math.IsNaN(g) {
+			t.Errorf("expected NaN, got %#v", got)
+		}
+	} else if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected value %#v, got %#v", want, got)
+	}
+
+	var names []string
+	for _, v := range violations {
+		names = append(names, v.Constraint)
+	}
+	if !reflect.DeepEqual(names, wantViolations) {
+		t.Errorf("expected violations %v, got %v", wantViolations, names)
+	}
+	if len(violations) > 0 && !errors.Is(ViolationsError(violations), ErrConstraintViolation) {
+		t.Errorf("expected the violations error to wrap ErrConstraintViolation")
+	}
+}
@@ -32,3 +32,6 @@ var ErrFeatureAlreadyExists = fmt.Errorf("feature already exists")
 
 // ErrInvalidPipelineContext is returned when the context is invalid for pipelining.
 var ErrInvalidPipelineContext = fmt.Errorf("invalid pipeline context")
+
+// ErrConstraintViolation is returned when a written value violates the constraints of the feature.
+var ErrConstraintViolation = fmt.Errorf("constraint violation")
@@ -17,6 +17,7 @@ limitations under the License.
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
 	"strings"
@@ -40,7 +41,32 @@ type FeatureDescriptor struct {
 	RuntimeEnv   string        `json:"runtimeEnv"`
 	DataSource   string        `json:"data_source"`
 	Dependencies []string      `json:"dependencies"`
+	// EmbeddingDim is the dimension of an embedding feature
+	EmbeddingDim int `json:"embedding_dim,omitempty"`
+	// Fields is the schema of a struct feature
+	Fields []StructField `json:"fields,omitempty"`
+	// Encodings is the configuration of the feature's encodings by their name
+	Encodings map[string]json.RawMessage `json:"encodings,omitempty"`
+	// Default is the value that is served when the feature has no value, conformed to the feature's primitive
+	Default any `json:"default,omitempty"`
+	// OnError is the policy of handling reads that failed to calculate the value
+	OnError ErrorPolicy `json:"on_error,omitempty"`
+	// Constraints are the constraints of the values that are written to the feature
+	Constraints *Constraints `json:"constraints,omitempty"`
 }
+
+// ErrorPolicy defines how a read of a feature is handled when the value failed to be calculated
+type ErrorPolicy string
+
+const (
+	// ErrorPolicyFail fails the read
+	ErrorPolicyFail ErrorPolicy = "fail"
+	// ErrorPolicyDefault serves the default value of the feature
+	ErrorPolicyDefault ErrorPolicy = "default"
+	// ErrorPolicyLastKnown serves the last known value of the feature, regardless of its staleness
+	ErrorPolicyLastKnown ErrorPolicy = "lastKnown"
+)
+
 type KeepPrevious struct {
 	Versions uint
 	Over     time.Duration
@@ -57,11 +83,146 @@ func (fd FeatureDescriptor) ValidWindow() bool {
 	if len(fd.Aggr) == 0 {
 		return false
 	}
-	if !(fd.Primitive == PrimitiveTypeInteger || fd.Primitive == PrimitiveTypeFloat) {
+	if !fd.Primitive.Scalar() {
 		return false
 	}
+	if fd.Primitive == PrimitiveTypeInteger || fd.Primitive == PrimitiveTypeFloat {
+		return true
+	}
+	// non-numeric features can only be counted
+	for _, fn := range fd.Aggr {
+		if fn.Numeric() {
+			return false
+		}
+	}
 	return true
 }
+
+// WithLookback returns a copy of the windowed feature, that its window is limited to the given lookback.
+// Reading the returned descriptor from the state aggregates only the buckets within the lookback, so the lookback
+// must be a multiple of the aggregation granularity (the feature's freshness).
+func (fd FeatureDescriptor) WithLookback(lookback time.Duration) (FeatureDescriptor, error) {
+	if !fd.ValidWindow() {
+		return fd, fmt.Errorf("lookback is only supported for windowed features")
+	}
+	if lookback <= 0 || lookback > fd.Staleness {
+		return fd, fmt.Errorf("lookback %s must be positive and within the feature's window (%s)", lookback, fd.Staleness)
+	}
+	if lookback%fd.Freshness != 0 {
+		return fd, fmt.Errorf("lookback %s must be a multiple of the aggregation granularity (%s)", lookback, fd.Freshness)
+	}
+	fd.Staleness = lookback
+	return fd, nil
+}
+
+// EmbeddingValue converts the value to an Embedding, and validates it matches the feature's dimension.
+func (fd FeatureDescriptor) EmbeddingValue(val any) (Embedding, error) {
+	if fd.Primitive != PrimitiveTypeEmbedding {
+		return nil, fmt.Errorf("feature %s is not an embedding", fd.FQN)
+	}
+	emb, err := ToEmbedding(val)
+	if err != nil {
+		return nil, err
+	}
+	if len(emb) != fd.EmbeddingDim {
+		return nil, fmt.Errorf("embedding dimension mismatch: expected %d, got %d", fd.EmbeddingDim, len(emb))
+	}
+	return emb, nil
+}
+
+// Field returns the schema of a field of a struct feature
+func (fd FeatureDescriptor) Field(name string) (StructField, bool) {
+	for _, f := range fd.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return StructField{}, false
+}
+
+// StructValue converts the value to a Struct, and casts its fields to the feature's schema.
+// Fields that are not part of the schema are rejected, while missing fields are allowed.
+func (fd FeatureDescriptor) StructValue(val any) (Struct, error) {
+	if fd.Primitive != PrimitiveTypeStruct {
+		return nil, fmt.Errorf("feature %s is not a struct", fd.FQN)
+	}
+	var m map[string]any
+	switch v := val.(type) {
+	case Struct:
+		m = v
+	case map[string]any:
+		m = v
+	default:
+		return nil, fmt.Errorf("value of type %T is not a struct", val)
+	}
+
+	ret := make(Struct, len(m))
+	for name, v := range m {
+		f, ok := fd.Field(name)
+		if !ok {
+			return nil, fmt.Errorf("field `%s` is not defined for feature %s", name, fd.FQN)
+		}
+		if v == nil {
+			continue
+		}
+		c, err := CastPrimitive(v, f.Primitive)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for field `%s`: %w", name, err)
+		}
+		if c != nil {
+			ret[name] = c
+		}
+	}
+	return ret, nil
+}
+
+// conformDefault converts the default value to the feature's primitive.
+// The default value of windowed features is the result of their aggregations.
+func (fd FeatureDescriptor) conformDefault(raw json.RawMessage) (any, error) {
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	v, err := NormalizeAny(v)
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	switch {
+	case fd.ValidWindow():
+		return CastPrimitive(v, PrimitiveTypeFloat)
+	case fd.Primitive == PrimitiveTypeEmbedding:
+		return fd.EmbeddingValue(v)
+	case fd.Primitive == PrimitiveTypeStruct:
+		return fd.StructValue(v)
+	default:
+		return CastPrimitive(v, fd.Primitive)
+	}
+}
+
+func structFieldsFromManifest(in []manifests.StructField) ([]StructField, error) {
+	if len(in) == 0 {
+		return nil, fmt.Errorf("%w: struct features must define their fields", ErrUnsupportedPrimitiveError)
+	}
+	fields := make([]StructField, len(in))
+	seen := make(map[string]struct{}, len(in))
+	for i, f := range in {
+		if _, ok := seen[f.Name]; ok {
+			return nil, fmt.Errorf("struct field `%s` is defined more than once", f.Name)
+		}
+		seen[f.Name] = struct{}{}
+
+		pt := StringToPrimitiveType(string(f.Primitive))
+		if pt == PrimitiveTypeUnknown || pt == PrimitiveTypeStruct || pt == PrimitiveTypeEmbedding {
+			return nil, fmt.Errorf("%w for struct field `%s`: %s", ErrUnsupportedPrimitiveError, f.Name, f.Primitive)
+		}
+		fields[i] = StructField{Name: f.Name, Primitive: pt}
+	}
+	return fields, nil
+}
 func aggrsToStrings(a []manifests.AggrFn) []string {
 	var res []string
 	for _, v := range a {
@@ -76,12 +237,30 @@ func FeatureDescriptorFromManifest(in *manifests.Feature) (*FeatureDescriptor, e
 	if primitive == PrimitiveTypeUnknown {
 		return nil, fmt.Errorf("%w: %s", ErrUnsupportedPrimitiveError, in.Spec.Primitive)
 	}
+	embeddingDim := 0
+	if primitive == PrimitiveTypeEmbedding {
+		var err error
+		embeddingDim, err = EmbeddingDimFromString(string(in.Spec.Primitive))
+		if err != nil {
+			return nil, err
+		}
+	}
+	var fields []StructField
+	if primitive == PrimitiveTypeStruct {
+		var err error
+		fields, err = structFieldsFromManifest(in.Spec.Fields)
+		if err != nil {
+			return nil, err
+		}
+	}
 	aggr, err := StringsToAggrFns(aggrsToStrings(in.Spec.Builder.Aggr))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse aggregation functions: %w", err)
 	}
-	if len(aggr) > 0 && primitive != PrimitiveTypeInteger && primitive != PrimitiveTypeFloat {
-		return nil, fmt.Errorf("%w with Aggregation: %s", ErrUnsupportedPrimitiveError, in.Spec.Primitive)
+	for _, fn := range aggr {
+		if !primitive.Scalar() || (fn.Numeric() && primitive != PrimitiveTypeInteger && primitive != PrimitiveTypeFloat) {
+			return nil, fmt.Errorf("%w with Aggregation %s: %s", ErrUnsupportedPrimitiveError, fn, in.Spec.Primitive)
+		}
 	}
 	if in.Spec.Builder.AggrGranularity.Milliseconds() > 0 && len(aggr) > 0 {
 		in.Spec.Freshness = in.Spec.Builder.AggrGranularity
@@ -103,6 +282,8 @@ func FeatureDescriptorFromManifest(in *manifests.Feature) (*FeatureDescriptor, e
 		RuntimeEnv:   in.Spec.Builder.Runtime,
 		Builder:      strings.ToLower(in.Spec.Builder.Kind),
 		Dependencies: deps,
+		EmbeddingDim: embeddingDim,
+		Fields:       fields,
 	}
 	if in.Spec.KeepPrevious != nil {
 		fd.KeepPrevious = &KeepPrevious{
@@ -113,6 +294,11 @@ func FeatureDescriptorFromManifest(in *manifests.Feature) (*FeatureDescriptor, e
 	if in.Spec.DataSource != nil {
 		fd.DataSource = in.Spec.DataSource.FQN()
 	}
+	if len(in.Spec.Encodings) > 0 {
+		if err := json.Unmarshal(in.Spec.Encodings, &fd.Encodings); err != nil {
+			return nil, fmt.Errorf("failed to parse encodings: %w", err)
+		}
+	}
 	if fd.Builder == "" {
 		fd.Builder = SourcelessBuilder
 	}
@@ -120,5 +306,31 @@ func FeatureDescriptorFromManifest(in *manifests.Feature) (*FeatureDescriptor, e
 	if len(fd.Aggr) > 0 && !fd.ValidWindow() {
 		return nil, fmt.Errorf("invalid feature specification for windowed feature")
 	}
+
+	if len(in.Spec.Default) > 0 {
+		fd.Default, err = fd.conformDefault(in.Spec.Default)
+		if err != nil {
+			return nil, fmt.Errorf("invalid default value: %w", err)
+		}
+	}
+	fd.Constraints, err = ConstraintsFromManifest(in.Spec.Constraints, primitive)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraints: %w", err)
+	}
+	switch fd.OnError = ErrorPolicy(in.Spec.OnError); fd.OnError {
+	case "":
+		fd.OnError = ErrorPolicyFail
+	case ErrorPolicyFail:
+	case ErrorPolicyLastKnown:
+		if fd.ValidWindow() {
+			return nil, fmt.Errorf("the `lastKnown` error policy is not supported for windowed features")
+		}
+	case ErrorPolicyDefault:
+		if fd.Default == nil {
+			return nil, fmt.Errorf("the `default` error policy requires a default value")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported error policy: %s", in.Spec.OnError)
+	}
 	return fd, nil
 }
@@ -19,10 +19,11 @@ package api
 import (
 	"context"
 	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"time"
 )
 
 type Notification interface {
-	CollectNotification | WriteNotification
+	CollectNotification | WriteNotification | WatchNotification
 }
 type CollectNotification struct {
 	FQN         string `json:"fqn"`
@@ -37,6 +38,13 @@ type WriteNotification struct {
 	Value        *Value `json:"value,omitempty"`
 }
 
+// WatchNotification is notifying that a feature value was committed to the state.
+// It is used to fan out the updates to the watchers across all the instances.
+type WatchNotification struct {
+	FQN         string `json:"fqn"`
+	EncodedKeys string `json:"encoded_keys"`
+}
+
 // Notifier is the interface to be implemented by plugins that want to provide a Queue implementation
 // The Queue is used to sync notifications between instances
 type Notifier[T Notification] interface {
@@ -44,6 +52,13 @@ type Notifier[T Notification] interface {
 	Subscribe(context.Context) (<-chan T, error)
 }
 
+// AckNotifier is a Notifier that delivers the notifications at least once.
+// The subscriber must acknowledge each notification once it was handled successfully, otherwise it is redelivered.
+type AckNotifier[T Notification] interface {
+	Notifier[T]
+	Ack(context.Context, T) error
+}
+
 type HistoricalWriter interface {
 	Commit(context.Context, WriteNotification) error
 	Flush(ctx context.Context, fqn string) error
@@ -51,3 +66,26 @@ type HistoricalWriter interface {
 	Close(ctx context.Context) error
 	BindFeature(fd *FeatureDescriptor, model *manifests.ModelSpec, getter FeatureDescriptorGetter) error
 }
+
+// HistoricalRecord is a record that was written by the HistoricalWriter.
+// For windowed features, it represents a single bucket, and the Value is a BucketData.
+type HistoricalRecord struct {
+	FQN          string
+	EncodedKeys  string
+	Timestamp    time.Time
+	Value        any
+	Bucket       string
+	ActiveBucket bool
+}
+
+// HistoricalStatistics calculates statistics over the recent historical values of a feature, across all of its keys.
+type HistoricalStatistics interface {
+	Statistic(ctx context.Context, fd FeatureDescriptor, fn AggrFn) (float64, error)
+}
+
+// HistoricalReader reads the records that were written by a HistoricalWriter.
+type HistoricalReader interface {
+	// Read returns the records of the feature with a timestamp within the given range (inclusive), ordered by timestamp.
+	Read(ctx context.Context, fqn string, since, until time.Time) ([]HistoricalRecord, error)
+	Close(ctx context.Context) error
+}
@@ -71,6 +71,11 @@ type Engine interface {
 	// If the feature is not available, it returns nil.
 	// If the feature is windowed, the returned Value is a map from window function to Value.
 	Get(ctx context.Context, selector string, keys Keys) (Value, FeatureDescriptor, error)
+	// BatchGet returns the values for multiple selectors and keys at once.
+	// The results are in the same order as the requested items, and each result carries its own error, so a failure
+	// of a single item doesn't fail the whole batch.
+	// The returned error indicates a failure of the batch as a whole.
+	BatchGet(ctx context.Context, items []BatchGetItem) ([]BatchGetResult, error)
 	// Set sets the raw value for the given FQN and keys
 	// If the feature's primitive is a List, it replaces the entire list.
 	// If the feature is windowed, it is aliased to WindowAdd instead of Set.
@@ -88,7 +93,65 @@ type Engine interface {
 	//	- Append for Lists
 	//  - WindowAdd for Windows
 	Update(ctx context.Context, FQN string, keys Keys, val any, ts time.Time) error
+	// Ingest applies a stream of write operations until the records channel is closed.
+	// The records are applied concurrently, so the order of the writes is not guaranteed.
+	// It returns a summary of the ingestion, including the per-record failures.
+	Ingest(ctx context.Context, records <-chan IngestRecord) (IngestSummary, error)
+	// Watch streams the values of the given selectors whenever they are committed to the state.
+	// If keys is not empty, only the updates of the entities that match all the given keys are streamed.
+	// The returned channel is closed when the context is done.
+	Watch(ctx context.Context, selectors []string, keys Keys) (<-chan WatchEvent, error)
 }
+
+// BatchGetItem is a single selector and keys pair of a batch read.
+type BatchGetItem struct {
+	Selector string
+	Keys     Keys
+}
+
+// BatchGetResult is the result of a single BatchGetItem.
+type BatchGetResult struct {
+	Value             Value
+	FeatureDescriptor FeatureDescriptor
+	Err               error
+}
+
+// MaxIngestFailures is the maximum number of per-record failures that are reported in an IngestSummary.
+const MaxIngestFailures = 1000
+
+// IngestRecord is a single write operation of an ingestion stream.
+type IngestRecord struct {
+	Method    StateMethod
+	Selector  string
+	Keys      Keys
+	Value     any
+	Timestamp time.Time
+}
+
+// IngestFailure is a failure of a single IngestRecord.
+type IngestFailure struct {
+	// Index is the position of the record in the stream
+	Index uint64
+	Err   error
+}
+
+// IngestSummary is the summary of an ingestion stream.
+type IngestSummary struct {
+	Received  uint64
+	Succeeded uint64
+	Failed    uint64
+	// Failures holds up to MaxIngestFailures of the per-record failures
+	Failures []IngestFailure
+}
+
+// WatchEvent is an update of a watched feature value.
+type WatchEvent struct {
+	Selector          string
+	Keys              Keys
+	Value             Value
+	FeatureDescriptor FeatureDescriptor
+}
+
 type FeatureDescriptorGetter func(ctx context.Context, FQN string) (FeatureDescriptor, error)
 
 // Logger is a simple interface that returns a Logr.Logger
@@ -144,6 +207,10 @@ type ExtendedManager interface {
 	Engine
 	RuntimeManager
 	DataSourceGetter
+	HistoricalStatistics
+
+	// HasStatistics returns true if the historical statistics are configured
+	HasStatistics() bool
 }
 
 // ManagerEngine is the business-logic implementation of the Core
@@ -30,6 +30,8 @@ type Pipeliner interface {
 	AddPostGetMiddleware(priority int, fn Middleware)
 	AddPreSetMiddleware(priority int, fn Middleware)
 	AddPostSetMiddleware(priority int, fn Middleware)
+	// AddUnbindHook adds a function that is called once the feature is unbound, to release the plugin's resources.
+	AddUnbindHook(fn func())
 }
 
 // ContextKey is a key to store data in	context.
@@ -19,10 +19,11 @@ package api
 import manifests "github.com/raptor-ml/raptor/api/v1alpha1"
 
 type ModelDescriptor struct {
-	Features        []string               `json:"features"`
-	KeyFeature      string                 `json:"keyFeature,omitempty"`
-	Keys            []string               `json:"keys"`
-	ModelFramework  string                 `json:"modelFramework"`
-	ModelServer     string                 `json:"modelServer"`
-	InferenceConfig manifests.ParsedConfig `json:"inferenceConfig"`
+	Features        []string                       `json:"features"`
+	FeaturePolicies []manifests.ModelFeaturePolicy `json:"featurePolicies,omitempty"`
+	KeyFeature      string                         `json:"keyFeature,omitempty"`
+	Keys            []string                       `json:"keys"`
+	ModelFramework  string                         `json:"modelFramework"`
+	ModelServer     string                         `json:"modelServer"`
+	InferenceConfig manifests.ParsedConfig         `json:"inferenceConfig"`
 }
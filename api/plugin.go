@@ -18,6 +18,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
@@ -27,8 +28,8 @@ import (
 
 type Plugins interface {
 	BindConfig | FeatureApply | DataSourceReconcile | StateFactory |
-		CollectNotifierFactory | WriteNotifierFactory |
-		HistoricalWriterFactory
+		CollectNotifierFactory | WriteNotifierFactory | WatchNotifierFactory |
+		HistoricalWriterFactory | HistoricalReaderFactory | EncoderFactory
 }
 
 // BindConfig adds config flags for the plugin.
@@ -73,5 +74,14 @@ type StateFactory func(viper *viper.Viper) (State, error)
 type NotifierFactory[T Notification] func(viper *viper.Viper) (Notifier[T], error)
 type CollectNotifierFactory NotifierFactory[CollectNotification]
 type WriteNotifierFactory NotifierFactory[WriteNotification]
+type WatchNotifierFactory NotifierFactory[WatchNotification]
+
+// Encoder encodes a feature value to a model-ready representation, i.e. a vector or a scaled number.
+type Encoder func(val any) (any, error)
+
+// EncoderFactory is the interface to be implemented by plugins that implements encodings.
+// The spec is the configuration of the encoding in the Feature's spec, and may be nil if it wasn't configured.
+type EncoderFactory func(fd FeatureDescriptor, spec json.RawMessage) (Encoder, error)
 
 type HistoricalWriterFactory func(viper *viper.Viper) (HistoricalWriter, error)
+type HistoricalReaderFactory func(viper *viper.Viper) (HistoricalReader, error)
@@ -17,8 +17,10 @@ limitations under the License.
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -39,9 +41,45 @@ const (
 	PrimitiveTypeFloatList
 	PrimitiveTypeBooleanList
 	PrimitiveTypeTimestampList
+
+	// PrimitiveTypeEmbedding is a dense vector of floats with a fixed dimension (see FeatureDescriptor.EmbeddingDim)
+	PrimitiveTypeEmbedding
+
+	// PrimitiveTypeStruct is a set of named fields, each with its own primitive (see FeatureDescriptor.Fields)
+	PrimitiveTypeStruct
 )
 
+// Embedding is the value of an embedding feature
+type Embedding []float32
+
+// Struct is the value of a struct feature. The values of the fields are normalized primitives.
+type Struct map[string]any
+
+// StructField describes a single field of a struct feature
+type StructField struct {
+	Name      string        `json:"name"`
+	Primitive PrimitiveType `json:"primitive"`
+}
+
+var embeddingRegExp = regexp.MustCompile(`^embedding\((?P<dim>[0-9]+)\)$`)
+
+// EmbeddingDimFromString returns the dimension of an embedding primitive, i.e. `embedding(<dim>)`
+func EmbeddingDimFromString(s string) (int, error) {
+	match := embeddingRegExp.FindStringSubmatch(strings.ToLower(strings.ReplaceAll(s, " ", "")))
+	if match == nil {
+		return 0, fmt.Errorf("%w: expected `embedding(<dim>)`, got %s", ErrUnsupportedPrimitiveError, s)
+	}
+	dim, err := strconv.Atoi(match[1])
+	if err != nil || dim <= 0 {
+		return 0, fmt.Errorf("%w: invalid embedding dimension %s", ErrUnsupportedPrimitiveError, match[1])
+	}
+	return dim, nil
+}
+
 func StringToPrimitiveType(s string) PrimitiveType {
+	if _, err := EmbeddingDimFromString(s); err == nil {
+		return PrimitiveTypeEmbedding
+	}
 	switch strings.ToLower(s) {
 	case "string", "text":
 		return PrimitiveTypeString
@@ -63,6 +101,10 @@ func StringToPrimitiveType(s string) PrimitiveType {
 		return PrimitiveTypeBooleanList
 	case "[]time", "[]datetime", "[]timestamp", "[]time.time":
 		return PrimitiveTypeTimestampList
+	case "embedding":
+		return PrimitiveTypeEmbedding
+	case "struct", "map", "dict", "object", "api.struct", "map[string]interface {}":
+		return PrimitiveTypeStruct
 	default:
 		return PrimitiveTypeUnknown
 	}
@@ -70,7 +112,7 @@ func StringToPrimitiveType(s string) PrimitiveType {
 
 func (pt PrimitiveType) Scalar() bool {
 	switch pt {
-	case PrimitiveTypeStringList, PrimitiveTypeIntegerList, PrimitiveTypeFloatList, PrimitiveTypeBooleanList, PrimitiveTypeTimestampList:
+	case PrimitiveTypeStringList, PrimitiveTypeIntegerList, PrimitiveTypeFloatList, PrimitiveTypeBooleanList, PrimitiveTypeTimestampList, PrimitiveTypeEmbedding, PrimitiveTypeStruct:
 		return false
 	default:
 		return true
@@ -130,11 +172,21 @@ func (pt PrimitiveType) String() string {
 		return "[]bool"
 	case PrimitiveTypeTimestampList:
 		return "[]timestamp"
+	case PrimitiveTypeEmbedding:
+		return "embedding"
+	case PrimitiveTypeStruct:
+		return "struct"
 	default:
 		return "(unknown)"
 	}
 }
 func (pt PrimitiveType) Interface() any {
+	switch pt {
+	case PrimitiveTypeEmbedding:
+		return Embedding{}
+	case PrimitiveTypeStruct:
+		return Struct{}
+	}
 	if !pt.Scalar() {
 		return reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(pt.Singular().Interface())), 0, 0).Interface()
 	}
@@ -197,6 +249,12 @@ func ScalarFromString(val string, scalar PrimitiveType) (any, error) {
 
 // TypeDetect detects the PrimitiveType of the value.
 func TypeDetect(t any) PrimitiveType {
+	switch t.(type) {
+	case Embedding:
+		return PrimitiveTypeEmbedding
+	case Struct, map[string]any:
+		return PrimitiveTypeStruct
+	}
 	reflectType := reflect.TypeOf(t)
 	if reflectType == reflect.TypeOf([]any{}) {
 		for _, v := range t.([]any) {
@@ -221,6 +279,147 @@ func NormalizeAny(t any) (any, error) {
 			ret.Index(i).Set(reflect.ValueOf(v2))
 		}
 		t = ret.Interface()
+	case map[string]any:
+		return normalizeStruct(v)
+	case Struct:
+		return normalizeStruct(v)
 	}
 	return t, nil
 }
+
+func normalizeStruct(m map[string]any) (Struct, error) {
+	ret := make(Struct, len(m))
+	for k, v := range m {
+		n, err := NormalizeAny(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize field `%s`: %w", k, err)
+		}
+		ret[k] = n
+	}
+	return ret, nil
+}
+
+// ToEmbedding converts a list of numbers to an Embedding.
+func ToEmbedding(val any) (Embedding, error) {
+	switch v := val.(type) {
+	case Embedding:
+		return v, nil
+	case []float32:
+		return v, nil
+	case []float64:
+		ret := make(Embedding, len(v))
+		for i, f := range v {
+			ret[i] = float32(f)
+		}
+		return ret, nil
+	case []int:
+		ret := make(Embedding, len(v))
+		for i, n := range v {
+			ret[i] = float32(n)
+		}
+		return ret, nil
+	case []any:
+		ret := make(Embedding, len(v))
+		for i, item := range v {
+			switch f := item.(type) {
+			case float64:
+				ret[i] = float32(f)
+			case float32:
+				ret[i] = f
+			case int:
+				ret[i] = float32(f)
+			default:
+				return nil, fmt.Errorf("embedding values must be numbers, got %T", item)
+			}
+		}
+		return ret, nil
+	default:
+		return nil, fmt.Errorf("value of type %T is not an embedding", val)
+	}
+}
+
+// CastPrimitive converts the value to the given primitive.
+// It's used for values that don't keep their exact type, e.g. values that were decoded from JSON.
+// Structs are converted with FeatureDescriptor.StructValue, as they require the schema of the feature.
+func CastPrimitive(v any, pt PrimitiveType) (any, error) {
+	if pt == PrimitiveTypeEmbedding {
+		return ToEmbedding(v)
+	}
+	if !pt.Scalar() {
+		var l []any
+		switch v := v.(type) {
+		case []any:
+			l = v
+		case []string, []int, []float64, []time.Time:
+			return v, nil
+		default:
+			return nil, fmt.Errorf("expected a list, got %T", v)
+		}
+		ret := make([]any, len(l))
+		for i, item := range l {
+			c, err := CastPrimitive(item, pt.Singular())
+			if err != nil {
+				return nil, err
+			}
+			ret[i] = c
+		}
+		return NormalizeAny(ret)
+	}
+
+	switch pt {
+	case PrimitiveTypeInteger:
+		switch v := v.(type) {
+		case int:
+			return v, nil
+		case float64:
+			return int(v), nil
+		case json.Number:
+			if n, err := v.Int64(); err == nil {
+				return int(n), nil
+			}
+			f, err := v.Float64()
+			return int(f), err
+		case string:
+			return strconv.Atoi(v)
+		}
+	case PrimitiveTypeFloat:
+		switch v := v.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case json.Number:
+			return v.Float64()
+		case string:
+			return strconv.ParseFloat(v, 64)
+		}
+	case PrimitiveTypeString:
+		switch v := v.(type) {
+		case string:
+			return v, nil
+		case json.Number:
+			return v.String(), nil
+		case int, float64, bool:
+			return ScalarString(v), nil
+		}
+	case PrimitiveTypeBoolean:
+		switch v := v.(type) {
+		case bool:
+			return v, nil
+		case string:
+			return strconv.ParseBool(v)
+		}
+	case PrimitiveTypeTimestamp:
+		switch v := v.(type) {
+		case time.Time:
+			return v, nil
+		case string:
+			for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999 -0700", "2006-01-02 15:04:05.999999999"} {
+				if ts, err := time.Parse(layout, v); err == nil {
+					return ts, nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("cannot convert %T to %s", v, pt)
+}
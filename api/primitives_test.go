@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEmbeddingDimFromString(t *testing.T) {
+	tests := []struct {
+		primitive string
+		want      int
+		wantErr   bool
+	}{
+		{primitive: "embedding(3)", want: 3},
+		{primitive: "embedding(1536)", want: 1536},
+		{primitive: "Embedding( 128 )", want: 128},
+		{primitive: "EMBEDDING(4)", want: 4},
+		{primitive: "embedding(0)", wantErr: true},
+		{primitive: "embedding(-1)", wantErr: true},
+		{primitive: "embedding(1.5)", wantErr: true},
+		{primitive: "embedding(abc)", wantErr: true},
+		{primitive: "embedding()", wantErr: true},
+		{primitive: "embedding", wantErr: true},
+		{primitive: "embedding(99999999999999999999)", wantErr: true},
+		{primitive: "[]float", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.primitive, func(t *testing.T) {
+			got, err := EmbeddingDimFromString(tt.primitive)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EmbeddingDimFromString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnsupportedPrimitiveError) {
+					t.Errorf("expected an unsupported primitive error, got %v", err)
+				}
+				return
+			}
+			if got != tt.want {
+				t.Errorf("EmbeddingDimFromString() = %d, want %d", got, tt.want)
+			}
+			if pt := StringToPrimitiveType(tt.primitive); pt != PrimitiveTypeEmbedding {
+				t.Errorf("StringToPrimitiveType() = %s, want %s", pt, PrimitiveTypeEmbedding)
+			}
+		})
+	}
+}
+
+func TestToEmbedding(t *testing.T) {
+	tests := []struct {
+		name    string
+		val     any
+		want    Embedding
+		wantErr bool
+	}{
+		{name: "embedding", val: Embedding{1, 2.5}, want: Embedding{1, 2.5}},
+		{name: "float32", val: []float32{1, 2.5}, want: Embedding{1, 2.5}},
+		{name: "float64", val: []float64{1, 2.5}, want: Embedding{1, 2.5}},
+		{name: "int", val: []int{1, -2}, want: Embedding{1, -2}},
+		{name: "any", val: []any{1, float32(2.5), 3.25}, want: Embedding{1, 2.5, 3.25}},
+		{name: "empty", val: []float64{}, want: Embedding{}},
+		{name: "any with a string", val: []any{1.0, "2"}, wantErr: true},
+		{name: "strings", val: []string{"1"}, wantErr: true},
+		{name: "scalar", val: 1.0, wantErr: true},
+		{name: "nil", val: nil, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ToEmbedding(tt.val)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ToEmbedding() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ToEmbedding() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
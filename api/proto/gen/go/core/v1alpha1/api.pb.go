@@ -24,6 +24,62 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// WriteMethod is the method used to write a feature value.
+type WriteMethod int32
+
+const (
+	WriteMethod_WRITE_METHOD_UNSPECIFIED WriteMethod = 0
+	WriteMethod_WRITE_METHOD_SET         WriteMethod = 1
+	WriteMethod_WRITE_METHOD_APPEND      WriteMethod = 2
+	WriteMethod_WRITE_METHOD_INCR        WriteMethod = 3
+	WriteMethod_WRITE_METHOD_UPDATE      WriteMethod = 4
+)
+
+// Enum value maps for WriteMethod.
+var (
+	WriteMethod_name = map[int32]string{
+		0: "WRITE_METHOD_UNSPECIFIED",
+		1: "WRITE_METHOD_SET",
+		2: "WRITE_METHOD_APPEND",
+		3: "WRITE_METHOD_INCR",
+		4: "WRITE_METHOD_UPDATE",
+	}
+	WriteMethod_value = map[string]int32{
+		"WRITE_METHOD_UNSPECIFIED": 0,
+		"WRITE_METHOD_SET":         1,
+		"WRITE_METHOD_APPEND":      2,
+		"WRITE_METHOD_INCR":        3,
+		"WRITE_METHOD_UPDATE":      4,
+	}
+)
+
+func (x WriteMethod) Enum() *WriteMethod {
+	p := new(WriteMethod)
+	*p = x
+	return p
+}
+
+func (x WriteMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WriteMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_v1alpha1_api_proto_enumTypes[0].Descriptor()
+}
+
+func (WriteMethod) Type() protoreflect.EnumType {
+	return &file_core_v1alpha1_api_proto_enumTypes[0]
+}
+
+func (x WriteMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WriteMethod.Descriptor instead.
+func (WriteMethod) EnumDescriptor() ([]byte, []int) {
+	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{0}
+}
+
 // GetRequest is the request to get a feature value.
 type GetRequest struct {
 	state         protoimpl.MessageState
@@ -103,6 +159,8 @@ type GetResponse struct {
 	Value *FeatureValue `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	// Feature descriptor
 	FeatureDescriptor *FeatureDescriptor `protobuf:"bytes,3,opt,name=feature_descriptor,json=featureDescriptor,proto3" json:"feature_descriptor,omitempty"`
+	// Fallback that served the value, if the value wasn't calculated
+	Fallback Fallback `protobuf:"varint,4,opt,name=fallback,proto3,enum=core.v1alpha1.Fallback" json:"fallback,omitempty"`
 }
 
 func (x *GetResponse) Reset() {
@@ -158,20 +216,27 @@ func (x *GetResponse) GetFeatureDescriptor() *FeatureDescriptor {
 	return nil
 }
 
-// FeatureDescriptorRequest is the request to get a feature descriptor.
-type FeatureDescriptorRequest struct {
+func (x *GetResponse) GetFallback() Fallback {
+	if x != nil {
+		return x.Fallback
+	}
+	return Fallback_FALLBACK_UNSPECIFIED
+}
+
+// BatchGetRequest is the request to get multiple feature values at once.
+type BatchGetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// UUID of the request
 	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	// Selector of the feature
-	Selector string `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Items to get
+	Items []*BatchGetItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
 }
 
-func (x *FeatureDescriptorRequest) Reset() {
-	*x = FeatureDescriptorRequest{}
+func (x *BatchGetRequest) Reset() {
+	*x = BatchGetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_core_v1alpha1_api_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -179,13 +244,13 @@ func (x *FeatureDescriptorRequest) Reset() {
 	}
 }
 
-func (x *FeatureDescriptorRequest) String() string {
+func (x *BatchGetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FeatureDescriptorRequest) ProtoMessage() {}
+func (*BatchGetRequest) ProtoMessage() {}
 
-func (x *FeatureDescriptorRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchGetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_core_v1alpha1_api_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -197,39 +262,39 @@ func (x *FeatureDescriptorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FeatureDescriptorRequest.ProtoReflect.Descriptor instead.
-func (*FeatureDescriptorRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetRequest) Descriptor() ([]byte, []int) {
 	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *FeatureDescriptorRequest) GetUuid() string {
+func (x *BatchGetRequest) GetUuid() string {
 	if x != nil {
 		return x.Uuid
 	}
 	return ""
 }
 
-func (x *FeatureDescriptorRequest) GetSelector() string {
+func (x *BatchGetRequest) GetItems() []*BatchGetItem {
 	if x != nil {
-		return x.Selector
+		return x.Items
 	}
-	return ""
+	return nil
 }
 
-// FeatureDescriptorResponse is the response to get a feature descriptor.
-type FeatureDescriptorResponse struct {
+// BatchGetItem is a single feature value to get as part of a BatchGetRequest.
+type BatchGetItem struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// UUID corresponding to the request
-	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	// Feature descriptor
-	FeatureDescriptor *FeatureDescriptor `protobuf:"bytes,2,opt,name=feature_descriptor,json=featureDescriptor,proto3" json:"feature_descriptor,omitempty"`
+	// Selector of the feature
+	Selector string `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Keys of the feature
+	Keys map[string]string `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *FeatureDescriptorResponse) Reset() {
-	*x = FeatureDescriptorResponse{}
+func (x *BatchGetItem) Reset() {
+	*x = BatchGetItem{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_core_v1alpha1_api_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -237,13 +302,13 @@ func (x *FeatureDescriptorResponse) Reset() {
 	}
 }
 
-func (x *FeatureDescriptorResponse) String() string {
+func (x *BatchGetItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FeatureDescriptorResponse) ProtoMessage() {}
+func (*BatchGetItem) ProtoMessage() {}
 
-func (x *FeatureDescriptorResponse) ProtoReflect() protoreflect.Message {
+func (x *BatchGetItem) ProtoReflect() protoreflect.Message {
 	mi := &file_core_v1alpha1_api_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -255,45 +320,39 @@ func (x *FeatureDescriptorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FeatureDescriptorResponse.ProtoReflect.Descriptor instead.
-func (*FeatureDescriptorResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetItem.ProtoReflect.Descriptor instead.
+func (*BatchGetItem) Descriptor() ([]byte, []int) {
 	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *FeatureDescriptorResponse) GetUuid() string {
+func (x *BatchGetItem) GetSelector() string {
 	if x != nil {
-		return x.Uuid
+		return x.Selector
 	}
 	return ""
 }
 
-func (x *FeatureDescriptorResponse) GetFeatureDescriptor() *FeatureDescriptor {
+func (x *BatchGetItem) GetKeys() map[string]string {
 	if x != nil {
-		return x.FeatureDescriptor
+		return x.Keys
 	}
 	return nil
 }
 
-// SetRequest is the request to set a feature value.
-type SetRequest struct {
+// BatchGetResponse is the response to get multiple feature values at once.
+type BatchGetResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// UUID of the request
+	// UUID corresponding to the request
 	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	// Selector of the feature
-	Selector string `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
-	// Keys of the feature
-	Keys map[string]string `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Value to set
-	Value *Value `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
-	// Timestamp of the update
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Results of the request, in the same order as the requested items
+	Results []*BatchGetResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *SetRequest) Reset() {
-	*x = SetRequest{}
+func (x *BatchGetResponse) Reset() {
+	*x = BatchGetResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_core_v1alpha1_api_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -301,13 +360,13 @@ func (x *SetRequest) Reset() {
 	}
 }
 
-func (x *SetRequest) String() string {
+func (x *BatchGetResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetRequest) ProtoMessage() {}
+func (*BatchGetResponse) ProtoMessage() {}
 
-func (x *SetRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchGetResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_core_v1alpha1_api_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -319,60 +378,45 @@ func (x *SetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetRequest.ProtoReflect.Descriptor instead.
-func (*SetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetResponse) Descriptor() ([]byte, []int) {
 	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *SetRequest) GetUuid() string {
+func (x *BatchGetResponse) GetUuid() string {
 	if x != nil {
 		return x.Uuid
 	}
 	return ""
 }
 
-func (x *SetRequest) GetSelector() string {
-	if x != nil {
-		return x.Selector
-	}
-	return ""
-}
-
-func (x *SetRequest) GetKeys() map[string]string {
-	if x != nil {
-		return x.Keys
-	}
-	return nil
-}
-
-func (x *SetRequest) GetValue() *Value {
-	if x != nil {
-		return x.Value
-	}
-	return nil
-}
-
-func (x *SetRequest) GetTimestamp() *timestamppb.Timestamp {
+func (x *BatchGetResponse) GetResults() []*BatchGetResult {
 	if x != nil {
-		return x.Timestamp
+		return x.Results
 	}
 	return nil
 }
 
-// SetResponse is the response to set a feature value.
-type SetResponse struct {
+// BatchGetResult is the result of a single BatchGetItem.
+type BatchGetResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// UUID corresponding to the request
-	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	// Timestamp of the update
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Feature value
+	Value *FeatureValue `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// Feature descriptor
+	FeatureDescriptor *FeatureDescriptor `protobuf:"bytes,2,opt,name=feature_descriptor,json=featureDescriptor,proto3" json:"feature_descriptor,omitempty"`
+	// Error message. Empty if the item succeeded.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// gRPC status code of the error. Zero if the item succeeded.
+	Code uint32 `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
+	// Fallback that served the value, if the value wasn't calculated
+	Fallback Fallback `protobuf:"varint,5,opt,name=fallback,proto3,enum=core.v1alpha1.Fallback" json:"fallback,omitempty"`
 }
 
-func (x *SetResponse) Reset() {
-	*x = SetResponse{}
+func (x *BatchGetResult) Reset() {
+	*x = BatchGetResult{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_core_v1alpha1_api_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -380,13 +424,13 @@ func (x *SetResponse) Reset() {
 	}
 }
 
-func (x *SetResponse) String() string {
+func (x *BatchGetResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetResponse) ProtoMessage() {}
+func (*BatchGetResult) ProtoMessage() {}
 
-func (x *SetResponse) ProtoReflect() protoreflect.Message {
+func (x *BatchGetResult) ProtoReflect() protoreflect.Message {
 	mi := &file_core_v1alpha1_api_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -398,45 +442,62 @@ func (x *SetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetResponse.ProtoReflect.Descriptor instead.
-func (*SetResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetResult.ProtoReflect.Descriptor instead.
+func (*BatchGetResult) Descriptor() ([]byte, []int) {
 	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *SetResponse) GetUuid() string {
+func (x *BatchGetResult) GetValue() *FeatureValue {
 	if x != nil {
-		return x.Uuid
+		return x.Value
 	}
-	return ""
+	return nil
 }
 
-func (x *SetResponse) GetTimestamp() *timestamppb.Timestamp {
+func (x *BatchGetResult) GetFeatureDescriptor() *FeatureDescriptor {
 	if x != nil {
-		return x.Timestamp
+		return x.FeatureDescriptor
 	}
 	return nil
 }
 
-// AppendRequest is the request to append a value to a feature value.
-type AppendRequest struct {
+func (x *BatchGetResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchGetResult) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchGetResult) GetFallback() Fallback {
+	if x != nil {
+		return x.Fallback
+	}
+	return Fallback_FALLBACK_UNSPECIFIED
+}
+
+// WatchRequest is the request to watch feature values for updates.
+type WatchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// UUID of the request
 	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	// Selector of the feature
-	Fqn string `protobuf:"bytes,2,opt,name=fqn,proto3" json:"fqn,omitempty"`
-	// Keys of the feature
+	// Selectors of the features to watch
+	Selectors []string `protobuf:"bytes,2,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// Keys to filter the updates by. If empty, updates of all the entities are sent.
 	Keys map[string]string `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Value to append
-	Value *Scalar `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
-	// Timestamp of the update
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
-func (x *AppendRequest) Reset() {
-	*x = AppendRequest{}
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_core_v1alpha1_api_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -444,13 +505,13 @@ func (x *AppendRequest) Reset() {
 	}
 }
 
-func (x *AppendRequest) String() string {
+func (x *WatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AppendRequest) ProtoMessage() {}
+func (*WatchRequest) ProtoMessage() {}
 
-func (x *AppendRequest) ProtoReflect() protoreflect.Message {
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_core_v1alpha1_api_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -462,60 +523,48 @@ func (x *AppendRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AppendRequest.ProtoReflect.Descriptor instead.
-func (*AppendRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
 	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *AppendRequest) GetUuid() string {
+func (x *WatchRequest) GetUuid() string {
 	if x != nil {
 		return x.Uuid
 	}
 	return ""
 }
 
-func (x *AppendRequest) GetFqn() string {
-	if x != nil {
-		return x.Fqn
-	}
-	return ""
-}
-
-func (x *AppendRequest) GetKeys() map[string]string {
-	if x != nil {
-		return x.Keys
-	}
-	return nil
-}
-
-func (x *AppendRequest) GetValue() *Scalar {
+func (x *WatchRequest) GetSelectors() []string {
 	if x != nil {
-		return x.Value
+		return x.Selectors
 	}
 	return nil
 }
 
-func (x *AppendRequest) GetTimestamp() *timestamppb.Timestamp {
+func (x *WatchRequest) GetKeys() map[string]string {
 	if x != nil {
-		return x.Timestamp
+		return x.Keys
 	}
 	return nil
 }
 
-// AppendResponse is the response to append a value to a feature value.
-type AppendResponse struct {
+// WatchResponse is an update of a watched feature value.
+type WatchResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// UUID corresponding to the request
 	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	// Timestamp of the update
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Selector of the updated feature, as requested
+	Selector string `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Feature value
+	Value *FeatureValue `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *AppendResponse) Reset() {
-	*x = AppendResponse{}
+func (x *WatchResponse) Reset() {
+	*x = WatchResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_core_v1alpha1_api_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -523,13 +572,13 @@ func (x *AppendResponse) Reset() {
 	}
 }
 
-func (x *AppendResponse) String() string {
+func (x *WatchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AppendResponse) ProtoMessage() {}
+func (*WatchResponse) ProtoMessage() {}
 
-func (x *AppendResponse) ProtoReflect() protoreflect.Message {
+func (x *WatchResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_core_v1alpha1_api_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -541,27 +590,34 @@ func (x *AppendResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AppendResponse.ProtoReflect.Descriptor instead.
-func (*AppendResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchResponse.ProtoReflect.Descriptor instead.
+func (*WatchResponse) Descriptor() ([]byte, []int) {
 	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *AppendResponse) GetUuid() string {
+func (x *WatchResponse) GetUuid() string {
 	if x != nil {
 		return x.Uuid
 	}
 	return ""
 }
 
-func (x *AppendResponse) GetTimestamp() *timestamppb.Timestamp {
+func (x *WatchResponse) GetSelector() string {
 	if x != nil {
-		return x.Timestamp
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *WatchResponse) GetValue() *FeatureValue {
+	if x != nil {
+		return x.Value
 	}
 	return nil
 }
 
-// IncrRequest is the request to atomic-increment a feature value.
-type IncrRequest struct {
+// FeatureDescriptorRequest is the request to get a feature descriptor.
+type FeatureDescriptorRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -569,17 +625,11 @@ type IncrRequest struct {
 	// UUID of the request
 	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
 	// Selector of the feature
-	Fqn string `protobuf:"bytes,2,opt,name=fqn,proto3" json:"fqn,omitempty"`
-	// Keys of the feature
-	Keys map[string]string `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Value to increment by
-	Value *Scalar `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
-	// Timestamp of the update
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Selector string `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
 }
 
-func (x *IncrRequest) Reset() {
-	*x = IncrRequest{}
+func (x *FeatureDescriptorRequest) Reset() {
+	*x = FeatureDescriptorRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_core_v1alpha1_api_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -587,13 +637,13 @@ func (x *IncrRequest) Reset() {
 	}
 }
 
-func (x *IncrRequest) String() string {
+func (x *FeatureDescriptorRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrRequest) ProtoMessage() {}
+func (*FeatureDescriptorRequest) ProtoMessage() {}
 
-func (x *IncrRequest) ProtoReflect() protoreflect.Message {
+func (x *FeatureDescriptorRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_core_v1alpha1_api_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -605,48 +655,599 @@ func (x *IncrRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrRequest.ProtoReflect.Descriptor instead.
-func (*IncrRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FeatureDescriptorRequest.ProtoReflect.Descriptor instead.
+func (*FeatureDescriptorRequest) Descriptor() ([]byte, []int) {
 	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *IncrRequest) GetUuid() string {
+func (x *FeatureDescriptorRequest) GetUuid() string {
 	if x != nil {
 		return x.Uuid
 	}
 	return ""
 }
 
-func (x *IncrRequest) GetFqn() string {
+func (x *FeatureDescriptorRequest) GetSelector() string {
 	if x != nil {
-		return x.Fqn
+		return x.Selector
 	}
 	return ""
 }
 
-func (x *IncrRequest) GetKeys() map[string]string {
-	if x != nil {
-		return x.Keys
-	}
-	return nil
-}
+// FeatureDescriptorResponse is the response to get a feature descriptor.
+type FeatureDescriptorResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *IncrRequest) GetValue() *Scalar {
-	if x != nil {
-		return x.Value
-	}
-	return nil
+	// UUID corresponding to the request
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// Feature descriptor
+	FeatureDescriptor *FeatureDescriptor `protobuf:"bytes,2,opt,name=feature_descriptor,json=featureDescriptor,proto3" json:"feature_descriptor,omitempty"`
 }
 
-func (x *IncrRequest) GetTimestamp() *timestamppb.Timestamp {
-	if x != nil {
-		return x.Timestamp
+func (x *FeatureDescriptorResponse) Reset() {
+	*x = FeatureDescriptorResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_v1alpha1_api_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FeatureDescriptorResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureDescriptorResponse) ProtoMessage() {}
+
+func (x *FeatureDescriptorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_api_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureDescriptorResponse.ProtoReflect.Descriptor instead.
+func (*FeatureDescriptorResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FeatureDescriptorResponse) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *FeatureDescriptorResponse) GetFeatureDescriptor() *FeatureDescriptor {
+	if x != nil {
+		return x.FeatureDescriptor
+	}
+	return nil
+}
+
+// SetRequest is the request to set a feature value.
+type SetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// UUID of the request
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// Selector of the feature
+	Selector string `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Keys of the feature
+	Keys map[string]string `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Value to set
+	Value *Value `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// Timestamp of the update
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *SetRequest) Reset() {
+	*x = SetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_v1alpha1_api_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRequest) ProtoMessage() {}
+
+func (x *SetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_api_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRequest.ProtoReflect.Descriptor instead.
+func (*SetRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *SetRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *SetRequest) GetKeys() map[string]string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *SetRequest) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SetRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// SetResponse is the response to set a feature value.
+type SetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// UUID corresponding to the request
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// Timestamp of the update
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *SetResponse) Reset() {
+	*x = SetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_v1alpha1_api_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetResponse) ProtoMessage() {}
+
+func (x *SetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_api_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetResponse.ProtoReflect.Descriptor instead.
+func (*SetResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetResponse) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *SetResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// AppendRequest is the request to append a value to a feature value.
+type AppendRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// UUID of the request
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// Selector of the feature
+	Fqn string `protobuf:"bytes,2,opt,name=fqn,proto3" json:"fqn,omitempty"`
+	// Keys of the feature
+	Keys map[string]string `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Value to append
+	Value *Scalar `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// Timestamp of the update
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *AppendRequest) Reset() {
+	*x = AppendRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_v1alpha1_api_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendRequest) ProtoMessage() {}
+
+func (x *AppendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_api_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendRequest.ProtoReflect.Descriptor instead.
+func (*AppendRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AppendRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *AppendRequest) GetFqn() string {
+	if x != nil {
+		return x.Fqn
+	}
+	return ""
+}
+
+func (x *AppendRequest) GetKeys() map[string]string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *AppendRequest) GetValue() *Scalar {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *AppendRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// AppendResponse is the response to append a value to a feature value.
+type AppendResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// UUID corresponding to the request
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// Timestamp of the update
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *AppendResponse) Reset() {
+	*x = AppendResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_v1alpha1_api_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AppendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendResponse) ProtoMessage() {}
+
+func (x *AppendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_api_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendResponse.ProtoReflect.Descriptor instead.
+func (*AppendResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AppendResponse) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *AppendResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// IncrRequest is the request to atomic-increment a feature value.
+type IncrRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// UUID of the request
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// Selector of the feature
+	Fqn string `protobuf:"bytes,2,opt,name=fqn,proto3" json:"fqn,omitempty"`
+	// Keys of the feature
+	Keys map[string]string `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Value to increment by
+	Value *Scalar `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// Timestamp of the update
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *IncrRequest) Reset() {
+	*x = IncrRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_v1alpha1_api_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IncrRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrRequest) ProtoMessage() {}
+
+func (x *IncrRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_api_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrRequest.ProtoReflect.Descriptor instead.
+func (*IncrRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *IncrRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *IncrRequest) GetFqn() string {
+	if x != nil {
+		return x.Fqn
+	}
+	return ""
+}
+
+func (x *IncrRequest) GetKeys() map[string]string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *IncrRequest) GetValue() *Scalar {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *IncrRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// IncrResponse is the response to atomic-increment a feature value.
+type IncrResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// UUID corresponding to the request
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// Timestamp of the update
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *IncrResponse) Reset() {
+	*x = IncrResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_v1alpha1_api_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IncrResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrResponse) ProtoMessage() {}
+
+func (x *IncrResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_api_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrResponse.ProtoReflect.Descriptor instead.
+func (*IncrResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *IncrResponse) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *IncrResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// UpdateRequest is the request to update a feature value.
+type UpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// UUID of the request
+	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// Selector of the feature
+	Selector string `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Keys of the feature
+	Keys map[string]string `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Value to update
+	Value *Value `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// Timestamp of the update
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_v1alpha1_api_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRequest) ProtoMessage() {}
+
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_api_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *UpdateRequest) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *UpdateRequest) GetKeys() map[string]string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
 	}
 	return nil
 }
 
-// IncrResponse is the response to atomic-increment a feature value.
-type IncrResponse struct {
+// UpdateResponse is the response to update a feature value.
+type UpdateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -657,23 +1258,23 @@ type IncrResponse struct {
 	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
-func (x *IncrResponse) Reset() {
-	*x = IncrResponse{}
+func (x *UpdateResponse) Reset() {
+	*x = UpdateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_core_v1alpha1_api_proto_msgTypes[9]
+		mi := &file_core_v1alpha1_api_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IncrResponse) String() string {
+func (x *UpdateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IncrResponse) ProtoMessage() {}
+func (*UpdateResponse) ProtoMessage() {}
 
-func (x *IncrResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1alpha1_api_proto_msgTypes[9]
+func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_api_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -684,60 +1285,61 @@ func (x *IncrResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IncrResponse.ProtoReflect.Descriptor instead.
-func (*IncrResponse) Descriptor() ([]byte, []int) {
-	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use UpdateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *IncrResponse) GetUuid() string {
+func (x *UpdateResponse) GetUuid() string {
 	if x != nil {
 		return x.Uuid
 	}
 	return ""
 }
 
-func (x *IncrResponse) GetTimestamp() *timestamppb.Timestamp {
+func (x *UpdateResponse) GetTimestamp() *timestamppb.Timestamp {
 	if x != nil {
 		return x.Timestamp
 	}
 	return nil
 }
 
-// UpdateRequest is the request to update a feature value.
-type UpdateRequest struct {
+// IngestRequest is a single write operation within an ingestion stream.
+// Records are not validated upfront, so an invalid record fails individually instead of failing the whole stream.
+type IngestRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// UUID of the request
-	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// Method of the write operation
+	Method WriteMethod `protobuf:"varint,1,opt,name=method,proto3,enum=core.v1alpha1.WriteMethod" json:"method,omitempty"`
 	// Selector of the feature
 	Selector string `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
 	// Keys of the feature
 	Keys map[string]string `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Value to update
+	// Value to write
 	Value *Value `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
 	// Timestamp of the update
 	Timestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
-func (x *UpdateRequest) Reset() {
-	*x = UpdateRequest{}
+func (x *IngestRequest) Reset() {
+	*x = IngestRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_core_v1alpha1_api_proto_msgTypes[10]
+		mi := &file_core_v1alpha1_api_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateRequest) String() string {
+func (x *IngestRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateRequest) ProtoMessage() {}
+func (*IngestRequest) ProtoMessage() {}
 
-func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1alpha1_api_proto_msgTypes[10]
+func (x *IngestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_api_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -748,75 +1350,75 @@ func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
-func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use IngestRequest.ProtoReflect.Descriptor instead.
+func (*IngestRequest) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *UpdateRequest) GetUuid() string {
+func (x *IngestRequest) GetMethod() WriteMethod {
 	if x != nil {
-		return x.Uuid
+		return x.Method
 	}
-	return ""
+	return WriteMethod_WRITE_METHOD_UNSPECIFIED
 }
 
-func (x *UpdateRequest) GetSelector() string {
+func (x *IngestRequest) GetSelector() string {
 	if x != nil {
 		return x.Selector
 	}
 	return ""
 }
 
-func (x *UpdateRequest) GetKeys() map[string]string {
+func (x *IngestRequest) GetKeys() map[string]string {
 	if x != nil {
 		return x.Keys
 	}
 	return nil
 }
 
-func (x *UpdateRequest) GetValue() *Value {
+func (x *IngestRequest) GetValue() *Value {
 	if x != nil {
 		return x.Value
 	}
 	return nil
 }
 
-func (x *UpdateRequest) GetTimestamp() *timestamppb.Timestamp {
+func (x *IngestRequest) GetTimestamp() *timestamppb.Timestamp {
 	if x != nil {
 		return x.Timestamp
 	}
 	return nil
 }
 
-// UpdateResponse is the response to update a feature value.
-type UpdateResponse struct {
+// IngestFailure is a failure of a single record within an ingestion stream.
+type IngestFailure struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// UUID corresponding to the request
-	Uuid string `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	// Timestamp of the update
-	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Index of the record in the stream
+	Index uint64 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// Error message
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *UpdateResponse) Reset() {
-	*x = UpdateResponse{}
+func (x *IngestFailure) Reset() {
+	*x = IngestFailure{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_core_v1alpha1_api_proto_msgTypes[11]
+		mi := &file_core_v1alpha1_api_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateResponse) String() string {
+func (x *IngestFailure) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateResponse) ProtoMessage() {}
+func (*IngestFailure) ProtoMessage() {}
 
-func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1alpha1_api_proto_msgTypes[11]
+func (x *IngestFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_api_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -827,21 +1429,97 @@ func (x *UpdateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateResponse.ProtoReflect.Descriptor instead.
-func (*UpdateResponse) Descriptor() ([]byte, []int) {
-	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use IngestFailure.ProtoReflect.Descriptor instead.
+func (*IngestFailure) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *UpdateResponse) GetUuid() string {
+func (x *IngestFailure) GetIndex() uint64 {
 	if x != nil {
-		return x.Uuid
+		return x.Index
+	}
+	return 0
+}
+
+func (x *IngestFailure) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-func (x *UpdateResponse) GetTimestamp() *timestamppb.Timestamp {
+// IngestResponse is the summary of an ingestion stream.
+type IngestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of records received
+	Received uint64 `protobuf:"varint,1,opt,name=received,proto3" json:"received,omitempty"`
+	// Number of records written successfully
+	Succeeded uint64 `protobuf:"varint,2,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	// Number of records failed
+	Failed uint64 `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	// Failures of the records. Limited to the first 1000 failures.
+	Failures []*IngestFailure `protobuf:"bytes,4,rep,name=failures,proto3" json:"failures,omitempty"`
+}
+
+func (x *IngestResponse) Reset() {
+	*x = IngestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_v1alpha1_api_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestResponse) ProtoMessage() {}
+
+func (x *IngestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_api_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestResponse.ProtoReflect.Descriptor instead.
+func (*IngestResponse) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_api_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *IngestResponse) GetReceived() uint64 {
 	if x != nil {
-		return x.Timestamp
+		return x.Received
+	}
+	return 0
+}
+
+func (x *IngestResponse) GetSucceeded() uint64 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *IngestResponse) GetFailed() uint64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *IngestResponse) GetFailures() []*IngestFailure {
+	if x != nil {
+		return x.Failures
 	}
 	return nil
 }
@@ -861,71 +1539,265 @@ var file_core_v1alpha1_api_proto_rawDesc = []byte{
 	0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x63, 0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76,
 	0x32, 0x2f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x8e, 0x03, 0x0a, 0x0a,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe8, 0x03, 0x0a, 0x0a,
 	0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75,
 	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0,
-	0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0xef, 0x01, 0x0a, 0x08, 0x73, 0x65, 0x6c,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0xd2, 0x01, 0xfa, 0x42,
-	0xce, 0x01, 0x72, 0xcb, 0x01, 0x32, 0xc8, 0x01, 0x28, 0x3f, 0x73, 0x69, 0x29, 0x5e, 0x28, 0x28,
+	0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0xc9, 0x02, 0x0a, 0x08, 0x73, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0xac, 0x02, 0xfa, 0x42,
+	0xa8, 0x02, 0x72, 0xa5, 0x02, 0x32, 0xa2, 0x02, 0x28, 0x3f, 0x73, 0x69, 0x29, 0x5e, 0x28, 0x28,
 	0x3f, 0x50, 0x3c, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x3e, 0x28, 0x5b, 0x61,
 	0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5f, 0x5d, 0x2a, 0x5b,
 	0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x29, 0x7b, 0x31, 0x2c, 0x32, 0x35, 0x36, 0x7d, 0x29,
 	0x5c, 0x2e, 0x29, 0x3f, 0x28, 0x3f, 0x50, 0x3c, 0x6e, 0x61, 0x6d, 0x65, 0x3e, 0x28, 0x5b, 0x61,
 	0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5f, 0x5d, 0x2a, 0x5b,
 	0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x29, 0x7b, 0x31, 0x2c, 0x32, 0x35, 0x36, 0x7d, 0x29,
-	0x28, 0x5c, 0x2b, 0x28, 0x3f, 0x50, 0x3c, 0x61, 0x67, 0x67, 0x72, 0x46, 0x6e, 0x3e, 0x28, 0x5b,
-	0x61, 0x2d, 0x7a, 0x5d, 0x2b, 0x5f, 0x2a, 0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2b, 0x29, 0x29, 0x29,
-	0x3f, 0x28, 0x40, 0x2d, 0x28, 0x3f, 0x50, 0x3c, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x3e,
-	0x28, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x2b, 0x29, 0x29, 0x29, 0x3f, 0x28, 0x5c, 0x5b, 0x28, 0x3f,
-	0x50, 0x3c, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x3e, 0x28, 0x5b, 0x61, 0x2d, 0x7a,
-	0x5d, 0x2b, 0x5f, 0x2a, 0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2b, 0x29, 0x29, 0x5d, 0x29, 0x3f, 0x24,
-	0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x37, 0x0a, 0x04, 0x6b, 0x65,
-	0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b,
+	0x28, 0x3a, 0x28, 0x3f, 0x50, 0x3c, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x3e, 0x28, 0x5b, 0x61, 0x30,
+	0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5f, 0x5d, 0x2a, 0x5b, 0x61,
+	0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x29, 0x7b, 0x31, 0x2c, 0x32, 0x35, 0x36, 0x7d, 0x29, 0x29,
+	0x3f, 0x28, 0x5c, 0x2b, 0x28, 0x3f, 0x50, 0x3c, 0x61, 0x67, 0x67, 0x72, 0x46, 0x6e, 0x3e, 0x28,
+	0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2b, 0x5f, 0x2a, 0x5b, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x5d,
+	0x2b, 0x29, 0x29, 0x28, 0x5c, 0x5b, 0x28, 0x3f, 0x50, 0x3c, 0x6c, 0x6f, 0x6f, 0x6b, 0x62, 0x61,
+	0x63, 0x6b, 0x3e, 0x28, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x2d, 0x7a, 0x30, 0x2d,
+	0x39, 0x2e, 0x5d, 0x2a, 0x29, 0x29, 0x5d, 0x29, 0x3f, 0x29, 0x3f, 0x28, 0x40, 0x2d, 0x28, 0x3f,
+	0x50, 0x3c, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x3e, 0x28, 0x5b, 0x30, 0x2d, 0x39, 0x5d,
+	0x2b, 0x29, 0x29, 0x29, 0x3f, 0x28, 0x5c, 0x5b, 0x28, 0x3f, 0x50, 0x3c, 0x65, 0x6e, 0x63, 0x6f,
+	0x64, 0x69, 0x6e, 0x67, 0x3e, 0x28, 0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2b, 0x5f, 0x2a, 0x5b, 0x61,
+	0x2d, 0x7a, 0x5d, 0x2b, 0x29, 0x29, 0x5d, 0x29, 0x3f, 0x24, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x12, 0x37, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4b, 0x65,
+	0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x1a, 0x37, 0x0a,
+	0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xe4, 0x01, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04,
+	0x75, 0x75, 0x69, 0x64, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x4f, 0x0a, 0x12, 0x66, 0x65, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x11, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x08, 0x66, 0x61, 0x6c, 0x6c,
+	0x62, 0x61, 0x63, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x46, 0x61, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x52, 0x08, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x22, 0x6c, 0x0a,
+	0x0f, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08,
+	0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x3b,
+	0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x92,
+	0x01, 0x02, 0x08, 0x01, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0xce, 0x03, 0x0a, 0x0c,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0xc9, 0x02, 0x0a,
+	0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0xac, 0x02, 0xfa, 0x42, 0xa8, 0x02, 0x72, 0xa5, 0x02, 0x32, 0xa2, 0x02, 0x28, 0x3f, 0x73, 0x69,
+	0x29, 0x5e, 0x28, 0x28, 0x3f, 0x50, 0x3c, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x3e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39,
+	0x5f, 0x5d, 0x2a, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x29, 0x7b, 0x31, 0x2c, 0x32,
+	0x35, 0x36, 0x7d, 0x29, 0x5c, 0x2e, 0x29, 0x3f, 0x28, 0x3f, 0x50, 0x3c, 0x6e, 0x61, 0x6d, 0x65,
+	0x3e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39,
+	0x5f, 0x5d, 0x2a, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x29, 0x7b, 0x31, 0x2c, 0x32,
+	0x35, 0x36, 0x7d, 0x29, 0x28, 0x3a, 0x28, 0x3f, 0x50, 0x3c, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x3e,
+	0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5f,
+	0x5d, 0x2a, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x29, 0x7b, 0x31, 0x2c, 0x32, 0x35,
+	0x36, 0x7d, 0x29, 0x29, 0x3f, 0x28, 0x5c, 0x2b, 0x28, 0x3f, 0x50, 0x3c, 0x61, 0x67, 0x67, 0x72,
+	0x46, 0x6e, 0x3e, 0x28, 0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2b, 0x5f, 0x2a, 0x5b, 0x61, 0x2d, 0x7a,
+	0x30, 0x2d, 0x39, 0x5d, 0x2b, 0x29, 0x29, 0x28, 0x5c, 0x5b, 0x28, 0x3f, 0x50, 0x3c, 0x6c, 0x6f,
+	0x6f, 0x6b, 0x62, 0x61, 0x63, 0x6b, 0x3e, 0x28, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x2b, 0x5b, 0x61,
+	0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x2e, 0x5d, 0x2a, 0x29, 0x29, 0x5d, 0x29, 0x3f, 0x29, 0x3f, 0x28,
+	0x40, 0x2d, 0x28, 0x3f, 0x50, 0x3c, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x3e, 0x28, 0x5b,
+	0x30, 0x2d, 0x39, 0x5d, 0x2b, 0x29, 0x29, 0x29, 0x3f, 0x28, 0x5c, 0x5b, 0x28, 0x3f, 0x50, 0x3c,
+	0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x3e, 0x28, 0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2b,
+	0x5f, 0x2a, 0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2b, 0x29, 0x29, 0x5d, 0x29, 0x3f, 0x24, 0x52, 0x08,
+	0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x39, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x49,
+	0x74, 0x65, 0x6d, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b,
 	0x65, 0x79, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
 	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
 	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xaf, 0x01, 0x0a,
-	0x0b, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04,
-	0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72,
-	0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x69, 0x0a, 0x10,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08,
+	0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x37,
+	0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xf3, 0x01, 0x0a, 0x0e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65,
 	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72,
 	0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x4f, 0x0a,
 	0x12, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x72, 0x65,
 	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72,
 	0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x11, 0x66, 0x65, 0x61,
-	0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x22, 0xaa,
-	0x02, 0x0a, 0x18, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75,
-	0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03,
-	0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0xef, 0x01, 0x0a, 0x08, 0x73, 0x65,
-	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0xd2, 0x01, 0xfa,
-	0x42, 0xce, 0x01, 0x72, 0xcb, 0x01, 0x32, 0xc8, 0x01, 0x28, 0x3f, 0x73, 0x69, 0x29, 0x5e, 0x28,
-	0x28, 0x3f, 0x50, 0x3c, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x3e, 0x28, 0x5b,
-	0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5f, 0x5d, 0x2a,
-	0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x29, 0x7b, 0x31, 0x2c, 0x32, 0x35, 0x36, 0x7d,
-	0x29, 0x5c, 0x2e, 0x29, 0x3f, 0x28, 0x3f, 0x50, 0x3c, 0x6e, 0x61, 0x6d, 0x65, 0x3e, 0x28, 0x5b,
-	0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5f, 0x5d, 0x2a,
-	0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x29, 0x7b, 0x31, 0x2c, 0x32, 0x35, 0x36, 0x7d,
-	0x29, 0x28, 0x5c, 0x2b, 0x28, 0x3f, 0x50, 0x3c, 0x61, 0x67, 0x67, 0x72, 0x46, 0x6e, 0x3e, 0x28,
-	0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2b, 0x5f, 0x2a, 0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2b, 0x29, 0x29,
-	0x29, 0x3f, 0x28, 0x40, 0x2d, 0x28, 0x3f, 0x50, 0x3c, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x3e, 0x28, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x2b, 0x29, 0x29, 0x29, 0x3f, 0x28, 0x5c, 0x5b, 0x28,
-	0x3f, 0x50, 0x3c, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x3e, 0x28, 0x5b, 0x61, 0x2d,
-	0x7a, 0x5d, 0x2b, 0x5f, 0x2a, 0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2b, 0x29, 0x29, 0x5d, 0x29, 0x3f,
-	0x24, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x8a, 0x01, 0x0a, 0x19,
-	0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f,
-	0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01,
-	0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x4f, 0x0a, 0x12, 0x66, 0x65, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x11, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x22, 0xcc, 0x02, 0x0a, 0x0a, 0x53, 0x65, 0x74,
+	0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x66, 0x61, 0x6c, 0x6c,
+	0x62, 0x61, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x46, 0x61, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x52, 0x08, 0x66, 0x61, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x22, 0xf7, 0x03,
+	0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42,
+	0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0xd4, 0x02, 0x0a,
+	0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x42, 0xb5, 0x02, 0xfa, 0x42, 0xb1, 0x02, 0x92, 0x01, 0xad, 0x02, 0x08, 0x01, 0x22, 0xa8, 0x02,
+	0x72, 0xa5, 0x02, 0x32, 0xa2, 0x02, 0x28, 0x3f, 0x73, 0x69, 0x29, 0x5e, 0x28, 0x28, 0x3f, 0x50,
+	0x3c, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x3e, 0x28, 0x5b, 0x61, 0x30, 0x2d,
+	0x7a, 0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5f, 0x5d, 0x2a, 0x5b, 0x61, 0x30,
+	0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x29, 0x7b, 0x31, 0x2c, 0x32, 0x35, 0x36, 0x7d, 0x29, 0x5c, 0x2e,
+	0x29, 0x3f, 0x28, 0x3f, 0x50, 0x3c, 0x6e, 0x61, 0x6d, 0x65, 0x3e, 0x28, 0x5b, 0x61, 0x30, 0x2d,
+	0x7a, 0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5f, 0x5d, 0x2a, 0x5b, 0x61, 0x30,
+	0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x29, 0x7b, 0x31, 0x2c, 0x32, 0x35, 0x36, 0x7d, 0x29, 0x28, 0x3a,
+	0x28, 0x3f, 0x50, 0x3c, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x3e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a,
+	0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5f, 0x5d, 0x2a, 0x5b, 0x61, 0x30, 0x2d,
+	0x7a, 0x39, 0x5d, 0x2b, 0x29, 0x7b, 0x31, 0x2c, 0x32, 0x35, 0x36, 0x7d, 0x29, 0x29, 0x3f, 0x28,
+	0x5c, 0x2b, 0x28, 0x3f, 0x50, 0x3c, 0x61, 0x67, 0x67, 0x72, 0x46, 0x6e, 0x3e, 0x28, 0x5b, 0x61,
+	0x2d, 0x7a, 0x5d, 0x2b, 0x5f, 0x2a, 0x5b, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x5d, 0x2b, 0x29,
+	0x29, 0x28, 0x5c, 0x5b, 0x28, 0x3f, 0x50, 0x3c, 0x6c, 0x6f, 0x6f, 0x6b, 0x62, 0x61, 0x63, 0x6b,
+	0x3e, 0x28, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x2e,
+	0x5d, 0x2a, 0x29, 0x29, 0x5d, 0x29, 0x3f, 0x29, 0x3f, 0x28, 0x40, 0x2d, 0x28, 0x3f, 0x50, 0x3c,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x3e, 0x28, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x2b, 0x29,
+	0x29, 0x29, 0x3f, 0x28, 0x5c, 0x5b, 0x28, 0x3f, 0x50, 0x3c, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69,
+	0x6e, 0x67, 0x3e, 0x28, 0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2b, 0x5f, 0x2a, 0x5b, 0x61, 0x2d, 0x7a,
+	0x5d, 0x2b, 0x29, 0x29, 0x5d, 0x29, 0x3f, 0x24, 0x52, 0x09, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x73, 0x12, 0x39, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4b,
+	0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x1a, 0x37,
+	0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x7c, 0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01,
+	0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x84, 0x03, 0x0a, 0x18, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64,
+	0x12, 0xc9, 0x02, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0xac, 0x02, 0xfa, 0x42, 0xa8, 0x02, 0x72, 0xa5, 0x02, 0x32, 0xa2, 0x02,
+	0x28, 0x3f, 0x73, 0x69, 0x29, 0x5e, 0x28, 0x28, 0x3f, 0x50, 0x3c, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x3e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x5b, 0x61,
+	0x30, 0x2d, 0x7a, 0x39, 0x5f, 0x5d, 0x2a, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x29,
+	0x7b, 0x31, 0x2c, 0x32, 0x35, 0x36, 0x7d, 0x29, 0x5c, 0x2e, 0x29, 0x3f, 0x28, 0x3f, 0x50, 0x3c,
+	0x6e, 0x61, 0x6d, 0x65, 0x3e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x5b, 0x61,
+	0x30, 0x2d, 0x7a, 0x39, 0x5f, 0x5d, 0x2a, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x29,
+	0x7b, 0x31, 0x2c, 0x32, 0x35, 0x36, 0x7d, 0x29, 0x28, 0x3a, 0x28, 0x3f, 0x50, 0x3c, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x3e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x5b, 0x61, 0x30,
+	0x2d, 0x7a, 0x39, 0x5f, 0x5d, 0x2a, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x2b, 0x29, 0x7b,
+	0x31, 0x2c, 0x32, 0x35, 0x36, 0x7d, 0x29, 0x29, 0x3f, 0x28, 0x5c, 0x2b, 0x28, 0x3f, 0x50, 0x3c,
+	0x61, 0x67, 0x67, 0x72, 0x46, 0x6e, 0x3e, 0x28, 0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2b, 0x5f, 0x2a,
+	0x5b, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x5d, 0x2b, 0x29, 0x29, 0x28, 0x5c, 0x5b, 0x28, 0x3f,
+	0x50, 0x3c, 0x6c, 0x6f, 0x6f, 0x6b, 0x62, 0x61, 0x63, 0x6b, 0x3e, 0x28, 0x5b, 0x30, 0x2d, 0x39,
+	0x5d, 0x2b, 0x5b, 0x61, 0x2d, 0x7a, 0x30, 0x2d, 0x39, 0x2e, 0x5d, 0x2a, 0x29, 0x29, 0x5d, 0x29,
+	0x3f, 0x29, 0x3f, 0x28, 0x40, 0x2d, 0x28, 0x3f, 0x50, 0x3c, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x3e, 0x28, 0x5b, 0x30, 0x2d, 0x39, 0x5d, 0x2b, 0x29, 0x29, 0x29, 0x3f, 0x28, 0x5c, 0x5b,
+	0x28, 0x3f, 0x50, 0x3c, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x3e, 0x28, 0x5b, 0x61,
+	0x2d, 0x7a, 0x5d, 0x2b, 0x5f, 0x2a, 0x5b, 0x61, 0x2d, 0x7a, 0x5d, 0x2b, 0x29, 0x29, 0x5d, 0x29,
+	0x3f, 0x24, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x22, 0x8a, 0x01, 0x0a,
+	0x19, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0,
+	0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x4f, 0x0a, 0x12, 0x66, 0x65, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x11, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x22, 0xcc, 0x02, 0x0a, 0x0a, 0x53, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01,
+	0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x48, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2c, 0xfa, 0x42, 0x29, 0x72, 0x27, 0x32,
+	0x25, 0x28, 0x69, 0x3f, 0x29, 0x5e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5c, 0x2d, 0x5c,
+	0x2e, 0x5d, 0x2a, 0x29, 0x28, 0x5c, 0x5b, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x29,
+	0x2a, 0x5c, 0x5d, 0x29, 0x3f, 0x24, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x12, 0x37, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53,
+	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x1a,
+	0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x65, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52,
+	0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22,
+	0xc9, 0x02, 0x0a, 0x0d, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12,
+	0x3e, 0x0a, 0x03, 0x66, 0x71, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2c, 0xfa, 0x42,
+	0x29, 0x72, 0x27, 0x32, 0x25, 0x28, 0x69, 0x3f, 0x29, 0x5e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a,
+	0x39, 0x5c, 0x2d, 0x5c, 0x2e, 0x5d, 0x2a, 0x29, 0x28, 0x5c, 0x5b, 0x28, 0x5b, 0x61, 0x30, 0x2d,
+	0x7a, 0x39, 0x5d, 0x29, 0x2a, 0x5c, 0x5d, 0x29, 0x3f, 0x24, 0x52, 0x03, 0x66, 0x71, 0x6e, 0x12,
+	0x3a, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x70,
+	0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x2b, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61,
+	0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x1a, 0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x68, 0x0a, 0x0e, 0x41,
+	0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a,
+	0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05,
+	0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xc5, 0x02, 0x0a, 0x0b, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75,
+	0x75, 0x69, 0x64, 0x12, 0x3e, 0x0a, 0x03, 0x66, 0x71, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x42, 0x2c, 0xfa, 0x42, 0x29, 0x72, 0x27, 0x32, 0x25, 0x28, 0x69, 0x3f, 0x29, 0x5e, 0x28, 0x5b,
+	0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5c, 0x2d, 0x5c, 0x2e, 0x5d, 0x2a, 0x29, 0x28, 0x5c, 0x5b, 0x28,
+	0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x29, 0x2a, 0x5c, 0x5d, 0x29, 0x3f, 0x24, 0x52, 0x03,
+	0x66, 0x71, 0x6e, 0x12, 0x38, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4b, 0x65,
+	0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x2b, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x63, 0x61,
+	0x6c, 0x61, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x1a, 0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x66, 0x0a,
+	0x0c, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a,
+	0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05,
+	0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xd2, 0x02, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52,
 	0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x48, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
@@ -933,39 +1805,12 @@ var file_core_v1alpha1_api_proto_rawDesc = []byte{
 	0x28, 0x69, 0x3f, 0x29, 0x5e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5c, 0x2d, 0x5c, 0x2e,
 	0x5d, 0x2a, 0x29, 0x28, 0x5c, 0x5b, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x29, 0x2a,
 	0x5c, 0x5d, 0x29, 0x3f, 0x24, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12,
-	0x37, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
-	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x1a, 0x37,
-	0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x65, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04,
-	0x75, 0x75, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xc9,
-	0x02, 0x0a, 0x0d, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08,
-	0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x3e,
-	0x0a, 0x03, 0x66, 0x71, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2c, 0xfa, 0x42, 0x29,
-	0x72, 0x27, 0x32, 0x25, 0x28, 0x69, 0x3f, 0x29, 0x5e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39,
-	0x5c, 0x2d, 0x5c, 0x2e, 0x5d, 0x2a, 0x29, 0x28, 0x5c, 0x5b, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a,
-	0x39, 0x5d, 0x29, 0x2a, 0x5c, 0x5d, 0x29, 0x3f, 0x24, 0x52, 0x03, 0x66, 0x71, 0x6e, 0x12, 0x3a,
-	0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63,
-	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x70, 0x70,
-	0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x2b, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x63, 0x61, 0x6c, 0x61, 0x72,
+	0x3a, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
 	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
 	0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
@@ -973,130 +1818,134 @@ var file_core_v1alpha1_api_proto_rawDesc = []byte{
 	0x70, 0x1a, 0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
 	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
 	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x68, 0x0a, 0x0e, 0x41, 0x70,
-	0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04,
-	0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72,
-	0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69,
-	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x22, 0xc5, 0x02, 0x0a, 0x0b, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75,
-	0x69, 0x64, 0x12, 0x3e, 0x0a, 0x03, 0x66, 0x71, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42,
-	0x2c, 0xfa, 0x42, 0x29, 0x72, 0x27, 0x32, 0x25, 0x28, 0x69, 0x3f, 0x29, 0x5e, 0x28, 0x5b, 0x61,
-	0x30, 0x2d, 0x7a, 0x39, 0x5c, 0x2d, 0x5c, 0x2e, 0x5d, 0x2a, 0x29, 0x28, 0x5c, 0x5b, 0x28, 0x5b,
-	0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x29, 0x2a, 0x5c, 0x5d, 0x29, 0x3f, 0x24, 0x52, 0x03, 0x66,
-	0x71, 0x6e, 0x12, 0x38, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x24, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4b, 0x65, 0x79,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x2b, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f,
-	0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x63, 0x61, 0x6c,
-	0x61, 0x72, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x1a, 0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x66, 0x0a, 0x0c,
-	0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x68, 0x0a, 0x0e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04,
 	0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72,
 	0x03, 0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69,
 	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
 	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x22, 0xd2, 0x02, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03, 0xb0, 0x01, 0x01, 0x52, 0x04,
-	0x75, 0x75, 0x69, 0x64, 0x12, 0x48, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2c, 0xfa, 0x42, 0x29, 0x72, 0x27, 0x32, 0x25, 0x28,
-	0x69, 0x3f, 0x29, 0x5e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5c, 0x2d, 0x5c, 0x2e, 0x5d,
-	0x2a, 0x29, 0x28, 0x5c, 0x5b, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x29, 0x2a, 0x5c,
-	0x5d, 0x29, 0x3f, 0x24, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x3a,
-	0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63,
-	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x1a, 0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
-	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x68, 0x0a, 0x0e, 0x55, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x04, 0x75,
-	0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x72, 0x03,
-	0xb0, 0x01, 0x01, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
-	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x32, 0xcb, 0x04, 0x0a, 0x0d, 0x45, 0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x83, 0x01, 0x0a, 0x11, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x27, 0x2e, 0x63, 0x6f,
+	0x74, 0x61, 0x6d, 0x70, 0x22, 0xba, 0x02, 0x0a, 0x0d, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x3a, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65,
+	0x79, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x38,
+	0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x1a, 0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x3b, 0x0a, 0x0d, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x9c,
+	0x01, 0x0a, 0x0e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x66,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x12, 0x38, 0x0a, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x46, 0x61, 0x69, 0x6c,
+	0x75, 0x72, 0x65, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x2a, 0x8a, 0x01,
+	0x0a, 0x0b, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1c, 0x0a,
+	0x18, 0x57, 0x52, 0x49, 0x54, 0x45, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x57,
+	0x52, 0x49, 0x54, 0x45, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x53, 0x45, 0x54, 0x10,
+	0x01, 0x12, 0x17, 0x0a, 0x13, 0x57, 0x52, 0x49, 0x54, 0x45, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f,
+	0x44, 0x5f, 0x41, 0x50, 0x50, 0x45, 0x4e, 0x44, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x57, 0x52,
+	0x49, 0x54, 0x45, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x49, 0x4e, 0x43, 0x52, 0x10,
+	0x03, 0x12, 0x17, 0x0a, 0x13, 0x57, 0x52, 0x49, 0x54, 0x45, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f,
+	0x44, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x04, 0x32, 0xd8, 0x06, 0x0a, 0x0d, 0x45,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x83, 0x01, 0x0a,
+	0x11, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x6f, 0x72, 0x12, 0x27, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x63, 0x6f,
 	0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x46, 0x65, 0x61, 0x74,
-	0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c,
-	0x70, 0x68, 0x61, 0x31, 0x2e, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x42, 0x13, 0x0a, 0x04, 0x48, 0x45, 0x41, 0x44, 0x12, 0x0b,
-	0x2f, 0x7b, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x7d, 0x12, 0x51, 0x0a, 0x03, 0x47,
-	0x65, 0x74, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
-	0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e,
-	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02,
-	0x0d, 0x12, 0x0b, 0x2f, 0x7b, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x7d, 0x12, 0x51,
-	0x0a, 0x03, 0x53, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
-	0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x0d, 0x1a, 0x0b, 0x2f, 0x7b, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x7d, 0x12, 0x5c, 0x0a, 0x06, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x12, 0x1c, 0x2e, 0x63, 0x6f,
-	0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x65,
-	0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6f, 0x72, 0x65,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f,
-	0x22, 0x0d, 0x2f, 0x7b, 0x66, 0x71, 0x6e, 0x7d, 0x2f, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x12,
-	0x54, 0x0a, 0x04, 0x49, 0x6e, 0x63, 0x72, 0x12, 0x1a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76,
-	0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
-	0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x22, 0x0b, 0x2f, 0x7b, 0x66, 0x71, 0x6e, 0x7d,
-	0x2f, 0x69, 0x6e, 0x63, 0x72, 0x12, 0x5a, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12,
+	0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x42, 0x13, 0x0a,
+	0x04, 0x48, 0x45, 0x41, 0x44, 0x12, 0x0b, 0x2f, 0x7b, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x7d, 0x12, 0x51, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x12, 0x0b, 0x2f, 0x7b, 0x73, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x7d, 0x12, 0x62, 0x0a, 0x08, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65,
+	0x74, 0x12, 0x1e, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1f, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x3a, 0x01, 0x2a, 0x22, 0x0a, 0x2f,
+	0x62, 0x61, 0x74, 0x63, 0x68, 0x2f, 0x67, 0x65, 0x74, 0x12, 0x5e, 0x0a, 0x05, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
 	0x1c, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
-	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3,
-	0xe4, 0x93, 0x02, 0x0d, 0x22, 0x0b, 0x2f, 0x7b, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x7d, 0x42, 0xf5, 0x02, 0x92, 0x41, 0xb6, 0x01, 0x12, 0x5b, 0x0a, 0x08, 0x43, 0x6f, 0x72, 0x65,
-	0x20, 0x41, 0x50, 0x49, 0x12, 0x4f, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x73, 0x20, 0x61,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x20, 0x6c, 0x6f, 0x77, 0x2d, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x20,
-	0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x20, 0x6f, 0x76, 0x65, 0x72, 0x20,
-	0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x20, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x20, 0x61,
-	0x6e, 0x64, 0x20, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x20, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x1a, 0x27, 0x72, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x2d, 0x63, 0x6f,
-	0x72, 0x65, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x72, 0x61, 0x70, 0x74, 0x6f,
-	0x72, 0x2d, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x3a, 0x36, 0x30, 0x30, 0x30, 0x31, 0x2a, 0x01,
-	0x01, 0x72, 0x2b, 0x0a, 0x16, 0x4f, 0x66, 0x66, 0x69, 0x63, 0x69, 0x61, 0x6c, 0x20, 0x64, 0x6f,
-	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x11, 0x68, 0x74, 0x74,
-	0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x72, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x6d, 0x6c, 0x0a, 0x11,
-	0x63, 0x6f, 0x6d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61,
-	0x31, 0x42, 0x08, 0x41, 0x70, 0x69, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x47, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x61, 0x70, 0x74, 0x6f, 0x72,
-	0x2d, 0x6d, 0x6c, 0x2f, 0x72, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x72, 0x65,
-	0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x3b, 0x63, 0x6f, 0x72, 0x65, 0x76, 0x31,
-	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x58, 0x58, 0xaa, 0x02, 0x0d, 0x43,
-	0x6f, 0x72, 0x65, 0x2e, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xca, 0x02, 0x0d, 0x43,
-	0x6f, 0x72, 0x65, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xe2, 0x02, 0x19, 0x43,
-	0x6f, 0x72, 0x65, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x5c, 0x47, 0x50, 0x42,
-	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0e, 0x43, 0x6f, 0x72, 0x65, 0x3a,
-	0x3a, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x12, 0x3a, 0x01, 0x2a, 0x22, 0x0d, 0x2f, 0x77, 0x61, 0x74, 0x63, 0x68,
+	0x2f, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x30, 0x01, 0x12, 0x51, 0x0a, 0x03, 0x53, 0x65, 0x74,
+	0x12, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x1a,
+	0x0b, 0x2f, 0x7b, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x7d, 0x12, 0x5c, 0x0a, 0x06,
+	0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x12, 0x1c, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x15, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0f, 0x22, 0x0d, 0x2f, 0x7b, 0x66,
+	0x71, 0x6e, 0x7d, 0x2f, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x12, 0x54, 0x0a, 0x04, 0x49, 0x6e,
+	0x63, 0x72, 0x12, 0x1a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x49, 0x6e, 0x63, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49,
+	0x6e, 0x63, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x0d, 0x22, 0x0b, 0x2f, 0x7b, 0x66, 0x71, 0x6e, 0x7d, 0x2f, 0x69, 0x6e, 0x63, 0x72,
+	0x12, 0x5a, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x22,
+	0x0b, 0x2f, 0x7b, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x7d, 0x12, 0x47, 0x0a, 0x06,
+	0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x28, 0x01, 0x42, 0xf5, 0x02, 0x92, 0x41, 0xb6, 0x01, 0x12, 0x5b, 0x0a, 0x08,
+	0x43, 0x6f, 0x72, 0x65, 0x20, 0x41, 0x50, 0x49, 0x12, 0x4f, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
+	0x65, 0x73, 0x20, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x20, 0x6c, 0x6f, 0x77, 0x2d, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x20, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x20, 0x6f,
+	0x76, 0x65, 0x72, 0x20, 0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x20, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x20, 0x61, 0x6e, 0x64, 0x20, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x20, 0x70, 0x72, 0x65,
+	0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x1a, 0x27, 0x72, 0x61, 0x70, 0x74, 0x6f,
+	0x72, 0x2d, 0x63, 0x6f, 0x72, 0x65, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x72,
+	0x61, 0x70, 0x74, 0x6f, 0x72, 0x2d, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x3a, 0x36, 0x30, 0x30,
+	0x30, 0x31, 0x2a, 0x01, 0x01, 0x72, 0x2b, 0x0a, 0x16, 0x4f, 0x66, 0x66, 0x69, 0x63, 0x69, 0x61,
+	0x6c, 0x20, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x11, 0x68, 0x74, 0x74, 0x70, 0x73, 0x3a, 0x2f, 0x2f, 0x72, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x2e,
+	0x6d, 0x6c, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x42, 0x08, 0x41, 0x70, 0x69, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50,
+	0x01, 0x5a, 0x47, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x61,
+	0x70, 0x74, 0x6f, 0x72, 0x2d, 0x6d, 0x6c, 0x2f, 0x72, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f,
+	0x63, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x3b, 0x63, 0x6f,
+	0x72, 0x65, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x58, 0x58,
+	0xaa, 0x02, 0x0d, 0x43, 0x6f, 0x72, 0x65, 0x2e, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0xca, 0x02, 0x0d, 0x43, 0x6f, 0x72, 0x65, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0xe2, 0x02, 0x19, 0x43, 0x6f, 0x72, 0x65, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0e, 0x43,
+	0x6f, 0x72, 0x65, 0x3a, 0x3a, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1111,69 +1960,104 @@ func file_core_v1alpha1_api_proto_rawDescGZIP() []byte {
 	return file_core_v1alpha1_api_proto_rawDescData
 }
 
-var file_core_v1alpha1_api_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_core_v1alpha1_api_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_core_v1alpha1_api_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_core_v1alpha1_api_proto_goTypes = []interface{}{
-	(*GetRequest)(nil),                // 0: core.v1alpha1.GetRequest
-	(*GetResponse)(nil),               // 1: core.v1alpha1.GetResponse
-	(*FeatureDescriptorRequest)(nil),  // 2: core.v1alpha1.FeatureDescriptorRequest
-	(*FeatureDescriptorResponse)(nil), // 3: core.v1alpha1.FeatureDescriptorResponse
-	(*SetRequest)(nil),                // 4: core.v1alpha1.SetRequest
-	(*SetResponse)(nil),               // 5: core.v1alpha1.SetResponse
-	(*AppendRequest)(nil),             // 6: core.v1alpha1.AppendRequest
-	(*AppendResponse)(nil),            // 7: core.v1alpha1.AppendResponse
-	(*IncrRequest)(nil),               // 8: core.v1alpha1.IncrRequest
-	(*IncrResponse)(nil),              // 9: core.v1alpha1.IncrResponse
-	(*UpdateRequest)(nil),             // 10: core.v1alpha1.UpdateRequest
-	(*UpdateResponse)(nil),            // 11: core.v1alpha1.UpdateResponse
-	nil,                               // 12: core.v1alpha1.GetRequest.KeysEntry
-	nil,                               // 13: core.v1alpha1.SetRequest.KeysEntry
-	nil,                               // 14: core.v1alpha1.AppendRequest.KeysEntry
-	nil,                               // 15: core.v1alpha1.IncrRequest.KeysEntry
-	nil,                               // 16: core.v1alpha1.UpdateRequest.KeysEntry
-	(*FeatureValue)(nil),              // 17: core.v1alpha1.FeatureValue
-	(*FeatureDescriptor)(nil),         // 18: core.v1alpha1.FeatureDescriptor
-	(*Value)(nil),                     // 19: core.v1alpha1.Value
-	(*timestamppb.Timestamp)(nil),     // 20: google.protobuf.Timestamp
-	(*Scalar)(nil),                    // 21: core.v1alpha1.Scalar
+	(WriteMethod)(0),                  // 0: core.v1alpha1.WriteMethod
+	(*GetRequest)(nil),                // 1: core.v1alpha1.GetRequest
+	(*GetResponse)(nil),               // 2: core.v1alpha1.GetResponse
+	(*BatchGetRequest)(nil),           // 3: core.v1alpha1.BatchGetRequest
+	(*BatchGetItem)(nil),              // 4: core.v1alpha1.BatchGetItem
+	(*BatchGetResponse)(nil),          // 5: core.v1alpha1.BatchGetResponse
+	(*BatchGetResult)(nil),            // 6: core.v1alpha1.BatchGetResult
+	(*WatchRequest)(nil),              // 7: core.v1alpha1.WatchRequest
+	(*WatchResponse)(nil),             // 8: core.v1alpha1.WatchResponse
+	(*FeatureDescriptorRequest)(nil),  // 9: core.v1alpha1.FeatureDescriptorRequest
+	(*FeatureDescriptorResponse)(nil), // 10: core.v1alpha1.FeatureDescriptorResponse
+	(*SetRequest)(nil),                // 11: core.v1alpha1.SetRequest
+	(*SetResponse)(nil),               // 12: core.v1alpha1.SetResponse
+	(*AppendRequest)(nil),             // 13: core.v1alpha1.AppendRequest
+	(*AppendResponse)(nil),            // 14: core.v1alpha1.AppendResponse
+	(*IncrRequest)(nil),               // 15: core.v1alpha1.IncrRequest
+	(*IncrResponse)(nil),              // 16: core.v1alpha1.IncrResponse
+	(*UpdateRequest)(nil),             // 17: core.v1alpha1.UpdateRequest
+	(*UpdateResponse)(nil),            // 18: core.v1alpha1.UpdateResponse
+	(*IngestRequest)(nil),             // 19: core.v1alpha1.IngestRequest
+	(*IngestFailure)(nil),             // 20: core.v1alpha1.IngestFailure
+	(*IngestResponse)(nil),            // 21: core.v1alpha1.IngestResponse
+	nil,                               // 22: core.v1alpha1.GetRequest.KeysEntry
+	nil,                               // 23: core.v1alpha1.BatchGetItem.KeysEntry
+	nil,                               // 24: core.v1alpha1.WatchRequest.KeysEntry
+	nil,                               // 25: core.v1alpha1.SetRequest.KeysEntry
+	nil,                               // 26: core.v1alpha1.AppendRequest.KeysEntry
+	nil,                               // 27: core.v1alpha1.IncrRequest.KeysEntry
+	nil,                               // 28: core.v1alpha1.UpdateRequest.KeysEntry
+	nil,                               // 29: core.v1alpha1.IngestRequest.KeysEntry
+	(*FeatureValue)(nil),              // 30: core.v1alpha1.FeatureValue
+	(*FeatureDescriptor)(nil),         // 31: core.v1alpha1.FeatureDescriptor
+	(Fallback)(0),                     // 32: core.v1alpha1.Fallback
+	(*Value)(nil),                     // 33: core.v1alpha1.Value
+	(*timestamppb.Timestamp)(nil),     // 34: google.protobuf.Timestamp
+	(*Scalar)(nil),                    // 35: core.v1alpha1.Scalar
 }
 var file_core_v1alpha1_api_proto_depIdxs = []int32{
-	12, // 0: core.v1alpha1.GetRequest.keys:type_name -> core.v1alpha1.GetRequest.KeysEntry
-	17, // 1: core.v1alpha1.GetResponse.value:type_name -> core.v1alpha1.FeatureValue
-	18, // 2: core.v1alpha1.GetResponse.feature_descriptor:type_name -> core.v1alpha1.FeatureDescriptor
-	18, // 3: core.v1alpha1.FeatureDescriptorResponse.feature_descriptor:type_name -> core.v1alpha1.FeatureDescriptor
-	13, // 4: core.v1alpha1.SetRequest.keys:type_name -> core.v1alpha1.SetRequest.KeysEntry
-	19, // 5: core.v1alpha1.SetRequest.value:type_name -> core.v1alpha1.Value
-	20, // 6: core.v1alpha1.SetRequest.timestamp:type_name -> google.protobuf.Timestamp
-	20, // 7: core.v1alpha1.SetResponse.timestamp:type_name -> google.protobuf.Timestamp
-	14, // 8: core.v1alpha1.AppendRequest.keys:type_name -> core.v1alpha1.AppendRequest.KeysEntry
-	21, // 9: core.v1alpha1.AppendRequest.value:type_name -> core.v1alpha1.Scalar
-	20, // 10: core.v1alpha1.AppendRequest.timestamp:type_name -> google.protobuf.Timestamp
-	20, // 11: core.v1alpha1.AppendResponse.timestamp:type_name -> google.protobuf.Timestamp
-	15, // 12: core.v1alpha1.IncrRequest.keys:type_name -> core.v1alpha1.IncrRequest.KeysEntry
-	21, // 13: core.v1alpha1.IncrRequest.value:type_name -> core.v1alpha1.Scalar
-	20, // 14: core.v1alpha1.IncrRequest.timestamp:type_name -> google.protobuf.Timestamp
-	20, // 15: core.v1alpha1.IncrResponse.timestamp:type_name -> google.protobuf.Timestamp
-	16, // 16: core.v1alpha1.UpdateRequest.keys:type_name -> core.v1alpha1.UpdateRequest.KeysEntry
-	19, // 17: core.v1alpha1.UpdateRequest.value:type_name -> core.v1alpha1.Value
-	20, // 18: core.v1alpha1.UpdateRequest.timestamp:type_name -> google.protobuf.Timestamp
-	20, // 19: core.v1alpha1.UpdateResponse.timestamp:type_name -> google.protobuf.Timestamp
-	2,  // 20: core.v1alpha1.EngineService.FeatureDescriptor:input_type -> core.v1alpha1.FeatureDescriptorRequest
-	0,  // 21: core.v1alpha1.EngineService.Get:input_type -> core.v1alpha1.GetRequest
-	4,  // 22: core.v1alpha1.EngineService.Set:input_type -> core.v1alpha1.SetRequest
-	6,  // 23: core.v1alpha1.EngineService.Append:input_type -> core.v1alpha1.AppendRequest
-	8,  // 24: core.v1alpha1.EngineService.Incr:input_type -> core.v1alpha1.IncrRequest
-	10, // 25: core.v1alpha1.EngineService.Update:input_type -> core.v1alpha1.UpdateRequest
-	3,  // 26: core.v1alpha1.EngineService.FeatureDescriptor:output_type -> core.v1alpha1.FeatureDescriptorResponse
-	1,  // 27: core.v1alpha1.EngineService.Get:output_type -> core.v1alpha1.GetResponse
-	5,  // 28: core.v1alpha1.EngineService.Set:output_type -> core.v1alpha1.SetResponse
-	7,  // 29: core.v1alpha1.EngineService.Append:output_type -> core.v1alpha1.AppendResponse
-	9,  // 30: core.v1alpha1.EngineService.Incr:output_type -> core.v1alpha1.IncrResponse
-	11, // 31: core.v1alpha1.EngineService.Update:output_type -> core.v1alpha1.UpdateResponse
-	26, // [26:32] is the sub-list for method output_type
-	20, // [20:26] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	22, // 0: core.v1alpha1.GetRequest.keys:type_name -> core.v1alpha1.GetRequest.KeysEntry
+	30, // 1: core.v1alpha1.GetResponse.value:type_name -> core.v1alpha1.FeatureValue
+	31, // 2: core.v1alpha1.GetResponse.feature_descriptor:type_name -> core.v1alpha1.FeatureDescriptor
+	32, // 3: core.v1alpha1.GetResponse.fallback:type_name -> core.v1alpha1.Fallback
+	4,  // 4: core.v1alpha1.BatchGetRequest.items:type_name -> core.v1alpha1.BatchGetItem
+	23, // 5: core.v1alpha1.BatchGetItem.keys:type_name -> core.v1alpha1.BatchGetItem.KeysEntry
+	6,  // 6: core.v1alpha1.BatchGetResponse.results:type_name -> core.v1alpha1.BatchGetResult
+	30, // 7: core.v1alpha1.BatchGetResult.value:type_name -> core.v1alpha1.FeatureValue
+	31, // 8: core.v1alpha1.BatchGetResult.feature_descriptor:type_name -> core.v1alpha1.FeatureDescriptor
+	32, // 9: core.v1alpha1.BatchGetResult.fallback:type_name -> core.v1alpha1.Fallback
+	24, // 10: core.v1alpha1.WatchRequest.keys:type_name -> core.v1alpha1.WatchRequest.KeysEntry
+	30, // 11: core.v1alpha1.WatchResponse.value:type_name -> core.v1alpha1.FeatureValue
+	31, // 12: core.v1alpha1.FeatureDescriptorResponse.feature_descriptor:type_name -> core.v1alpha1.FeatureDescriptor
+	25, // 13: core.v1alpha1.SetRequest.keys:type_name -> core.v1alpha1.SetRequest.KeysEntry
+	33, // 14: core.v1alpha1.SetRequest.value:type_name -> core.v1alpha1.Value
+	34, // 15: core.v1alpha1.SetRequest.timestamp:type_name -> google.protobuf.Timestamp
+	34, // 16: core.v1alpha1.SetResponse.timestamp:type_name -> google.protobuf.Timestamp
+	26, // 17: core.v1alpha1.AppendRequest.keys:type_name -> core.v1alpha1.AppendRequest.KeysEntry
+	35, // 18: core.v1alpha1.AppendRequest.value:type_name -> core.v1alpha1.Scalar
+	34, // 19: core.v1alpha1.AppendRequest.timestamp:type_name -> google.protobuf.Timestamp
+	34, // 20: core.v1alpha1.AppendResponse.timestamp:type_name -> google.protobuf.Timestamp
+	27, // 21: core.v1alpha1.IncrRequest.keys:type_name -> core.v1alpha1.IncrRequest.KeysEntry
+	35, // 22: core.v1alpha1.IncrRequest.value:type_name -> core.v1alpha1.Scalar
+	34, // 23: core.v1alpha1.IncrRequest.timestamp:type_name -> google.protobuf.Timestamp
+	34, // 24: core.v1alpha1.IncrResponse.timestamp:type_name -> google.protobuf.Timestamp
+	28, // 25: core.v1alpha1.UpdateRequest.keys:type_name -> core.v1alpha1.UpdateRequest.KeysEntry
+	33, // 26: core.v1alpha1.UpdateRequest.value:type_name -> core.v1alpha1.Value
+	34, // 27: core.v1alpha1.UpdateRequest.timestamp:type_name -> google.protobuf.Timestamp
+	34, // 28: core.v1alpha1.UpdateResponse.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 29: core.v1alpha1.IngestRequest.method:type_name -> core.v1alpha1.WriteMethod
+	29, // 30: core.v1alpha1.IngestRequest.keys:type_name -> core.v1alpha1.IngestRequest.KeysEntry
+	33, // 31: core.v1alpha1.IngestRequest.value:type_name -> core.v1alpha1.Value
+	34, // 32: core.v1alpha1.IngestRequest.timestamp:type_name -> google.protobuf.Timestamp
+	20, // 33: core.v1alpha1.IngestResponse.failures:type_name -> core.v1alpha1.IngestFailure
+	9,  // 34: core.v1alpha1.EngineService.FeatureDescriptor:input_type -> core.v1alpha1.FeatureDescriptorRequest
+	1,  // 35: core.v1alpha1.EngineService.Get:input_type -> core.v1alpha1.GetRequest
+	3,  // 36: core.v1alpha1.EngineService.BatchGet:input_type -> core.v1alpha1.BatchGetRequest
+	7,  // 37: core.v1alpha1.EngineService.Watch:input_type -> core.v1alpha1.WatchRequest
+	11, // 38: core.v1alpha1.EngineService.Set:input_type -> core.v1alpha1.SetRequest
+	13, // 39: core.v1alpha1.EngineService.Append:input_type -> core.v1alpha1.AppendRequest
+	15, // 40: core.v1alpha1.EngineService.Incr:input_type -> core.v1alpha1.IncrRequest
+	17, // 41: core.v1alpha1.EngineService.Update:input_type -> core.v1alpha1.UpdateRequest
+	19, // 42: core.v1alpha1.EngineService.Ingest:input_type -> core.v1alpha1.IngestRequest
+	10, // 43: core.v1alpha1.EngineService.FeatureDescriptor:output_type -> core.v1alpha1.FeatureDescriptorResponse
+	2,  // 44: core.v1alpha1.EngineService.Get:output_type -> core.v1alpha1.GetResponse
+	5,  // 45: core.v1alpha1.EngineService.BatchGet:output_type -> core.v1alpha1.BatchGetResponse
+	8,  // 46: core.v1alpha1.EngineService.Watch:output_type -> core.v1alpha1.WatchResponse
+	12, // 47: core.v1alpha1.EngineService.Set:output_type -> core.v1alpha1.SetResponse
+	14, // 48: core.v1alpha1.EngineService.Append:output_type -> core.v1alpha1.AppendResponse
+	16, // 49: core.v1alpha1.EngineService.Incr:output_type -> core.v1alpha1.IncrResponse
+	18, // 50: core.v1alpha1.EngineService.Update:output_type -> core.v1alpha1.UpdateResponse
+	21, // 51: core.v1alpha1.EngineService.Ingest:output_type -> core.v1alpha1.IngestResponse
+	43, // [43:52] is the sub-list for method output_type
+	34, // [34:43] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
 }
 
 func init() { file_core_v1alpha1_api_proto_init() }
@@ -1208,7 +2092,7 @@ func file_core_v1alpha1_api_proto_init() {
 			}
 		}
 		file_core_v1alpha1_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FeatureDescriptorRequest); i {
+			switch v := v.(*BatchGetRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1220,7 +2104,7 @@ func file_core_v1alpha1_api_proto_init() {
 			}
 		}
 		file_core_v1alpha1_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FeatureDescriptorResponse); i {
+			switch v := v.(*BatchGetItem); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1232,7 +2116,7 @@ func file_core_v1alpha1_api_proto_init() {
 			}
 		}
 		file_core_v1alpha1_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetRequest); i {
+			switch v := v.(*BatchGetResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1244,7 +2128,7 @@ func file_core_v1alpha1_api_proto_init() {
 			}
 		}
 		file_core_v1alpha1_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetResponse); i {
+			switch v := v.(*BatchGetResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1256,7 +2140,7 @@ func file_core_v1alpha1_api_proto_init() {
 			}
 		}
 		file_core_v1alpha1_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AppendRequest); i {
+			switch v := v.(*WatchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1268,7 +2152,7 @@ func file_core_v1alpha1_api_proto_init() {
 			}
 		}
 		file_core_v1alpha1_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AppendResponse); i {
+			switch v := v.(*WatchResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1280,7 +2164,7 @@ func file_core_v1alpha1_api_proto_init() {
 			}
 		}
 		file_core_v1alpha1_api_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IncrRequest); i {
+			switch v := v.(*FeatureDescriptorRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1292,7 +2176,7 @@ func file_core_v1alpha1_api_proto_init() {
 			}
 		}
 		file_core_v1alpha1_api_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IncrResponse); i {
+			switch v := v.(*FeatureDescriptorResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1304,7 +2188,7 @@ func file_core_v1alpha1_api_proto_init() {
 			}
 		}
 		file_core_v1alpha1_api_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateRequest); i {
+			switch v := v.(*SetRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1316,6 +2200,78 @@ func file_core_v1alpha1_api_proto_init() {
 			}
 		}
 		file_core_v1alpha1_api_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_v1alpha1_api_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppendRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_v1alpha1_api_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AppendResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_v1alpha1_api_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IncrRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_v1alpha1_api_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IncrResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_v1alpha1_api_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_v1alpha1_api_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UpdateResponse); i {
 			case 0:
 				return &v.state
@@ -1327,19 +2283,56 @@ func file_core_v1alpha1_api_proto_init() {
 				return nil
 			}
 		}
+		file_core_v1alpha1_api_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_v1alpha1_api_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestFailure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_v1alpha1_api_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_core_v1alpha1_api_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   17,
+			NumEnums:      1,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_core_v1alpha1_api_proto_goTypes,
 		DependencyIndexes: file_core_v1alpha1_api_proto_depIdxs,
+		EnumInfos:         file_core_v1alpha1_api_proto_enumTypes,
 		MessageInfos:      file_core_v1alpha1_api_proto_msgTypes,
 	}.Build()
 	File_core_v1alpha1_api_proto = out.File
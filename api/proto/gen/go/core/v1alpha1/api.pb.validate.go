@@ -75,7 +75,7 @@ func (m *GetRequest) validate(all bool) error {
 	if !_GetRequest_Selector_Pattern.MatchString(m.GetSelector()) {
 		err := GetRequestValidationError{
 			field:  "Selector",
-			reason: "value does not match regex pattern \"(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(\\\\+(?P<aggrFn>([a-z]+_*[a-z]+)))?(@-(?P<version>([0-9]+)))?(\\\\[(?P<encoding>([a-z]+_*[a-z]+))])?$\"",
+			reason: "value does not match regex pattern \"(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\\\[(?P<encoding>([a-z]+_*[a-z]+))])?$\"",
 		}
 		if !all {
 			return err
@@ -170,7 +170,7 @@ var _ interface {
 	ErrorName() string
 } = GetRequestValidationError{}
 
-var _GetRequest_Selector_Pattern = regexp.MustCompile("(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(\\+(?P<aggrFn>([a-z]+_*[a-z]+)))?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$")
+var _GetRequest_Selector_Pattern = regexp.MustCompile("(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$")
 
 // Validate checks the field values on GetResponse with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
@@ -264,6 +264,8 @@ func (m *GetResponse) validate(all bool) error {
 		}
 	}
 
+	// no validation rules for Fallback
+
 	if len(errors) > 0 {
 		return GetResponseMultiError(errors)
 	}
@@ -349,22 +351,22 @@ var _ interface {
 	ErrorName() string
 } = GetResponseValidationError{}
 
-// Validate checks the field values on FeatureDescriptorRequest with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *FeatureDescriptorRequest) Validate() error {
+// Validate checks the field values on BatchGetRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on FeatureDescriptorRequest with the
-// rules defined in the proto definition for this message. If any rules are
+// ValidateAll checks the field values on BatchGetRequest with the rules
+// defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// FeatureDescriptorRequestMultiError, or nil if none found.
-func (m *FeatureDescriptorRequest) ValidateAll() error {
+// BatchGetRequestMultiError, or nil if none found.
+func (m *BatchGetRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *FeatureDescriptorRequest) validate(all bool) error {
+func (m *BatchGetRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -372,7 +374,7 @@ func (m *FeatureDescriptorRequest) validate(all bool) error {
 	var errors []error
 
 	if err := m._validateUuid(m.GetUuid()); err != nil {
-		err = FeatureDescriptorRequestValidationError{
+		err = BatchGetRequestValidationError{
 			field:  "Uuid",
 			reason: "value must be a valid UUID",
 			cause:  err,
@@ -383,10 +385,10 @@ func (m *FeatureDescriptorRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if !_FeatureDescriptorRequest_Selector_Pattern.MatchString(m.GetSelector()) {
-		err := FeatureDescriptorRequestValidationError{
-			field:  "Selector",
-			reason: "value does not match regex pattern \"(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(\\\\+(?P<aggrFn>([a-z]+_*[a-z]+)))?(@-(?P<version>([0-9]+)))?(\\\\[(?P<encoding>([a-z]+_*[a-z]+))])?$\"",
+	if len(m.GetItems()) < 1 {
+		err := BatchGetRequestValidationError{
+			field:  "Items",
+			reason: "value must contain at least 1 item(s)",
 		}
 		if !all {
 			return err
@@ -394,14 +396,48 @@ func (m *FeatureDescriptorRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	for idx, item := range m.GetItems() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BatchGetRequestValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BatchGetRequestValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchGetRequestValidationError{
+					field:  fmt.Sprintf("Items[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
-		return FeatureDescriptorRequestMultiError(errors)
+		return BatchGetRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-func (m *FeatureDescriptorRequest) _validateUuid(uuid string) error {
+func (m *BatchGetRequest) _validateUuid(uuid string) error {
 	if matched := _api_uuidPattern.MatchString(uuid); !matched {
 		return errors.New("invalid uuid format")
 	}
@@ -409,13 +445,13 @@ func (m *FeatureDescriptorRequest) _validateUuid(uuid string) error {
 	return nil
 }
 
-// FeatureDescriptorRequestMultiError is an error wrapping multiple validation
-// errors returned by FeatureDescriptorRequest.ValidateAll() if the designated
-// constraints aren't met.
-type FeatureDescriptorRequestMultiError []error
+// BatchGetRequestMultiError is an error wrapping multiple validation errors
+// returned by BatchGetRequest.ValidateAll() if the designated constraints
+// aren't met.
+type BatchGetRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m FeatureDescriptorRequestMultiError) Error() string {
+func (m BatchGetRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -424,11 +460,11 @@ func (m FeatureDescriptorRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m FeatureDescriptorRequestMultiError) AllErrors() []error { return m }
+func (m BatchGetRequestMultiError) AllErrors() []error { return m }
 
-// FeatureDescriptorRequestValidationError is the validation error returned by
-// FeatureDescriptorRequest.Validate if the designated constraints aren't met.
-type FeatureDescriptorRequestValidationError struct {
+// BatchGetRequestValidationError is the validation error returned by
+// BatchGetRequest.Validate if the designated constraints aren't met.
+type BatchGetRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -436,24 +472,22 @@ type FeatureDescriptorRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e FeatureDescriptorRequestValidationError) Field() string { return e.field }
+func (e BatchGetRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e FeatureDescriptorRequestValidationError) Reason() string { return e.reason }
+func (e BatchGetRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e FeatureDescriptorRequestValidationError) Cause() error { return e.cause }
+func (e BatchGetRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e FeatureDescriptorRequestValidationError) Key() bool { return e.key }
+func (e BatchGetRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e FeatureDescriptorRequestValidationError) ErrorName() string {
-	return "FeatureDescriptorRequestValidationError"
-}
+func (e BatchGetRequestValidationError) ErrorName() string { return "BatchGetRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e FeatureDescriptorRequestValidationError) Error() string {
+func (e BatchGetRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -465,14 +499,14 @@ func (e FeatureDescriptorRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sFeatureDescriptorRequest.%s: %s%s",
+		"invalid %sBatchGetRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = FeatureDescriptorRequestValidationError{}
+var _ error = BatchGetRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -480,37 +514,34 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = FeatureDescriptorRequestValidationError{}
+} = BatchGetRequestValidationError{}
 
-var _FeatureDescriptorRequest_Selector_Pattern = regexp.MustCompile("(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(\\+(?P<aggrFn>([a-z]+_*[a-z]+)))?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$")
-
-// Validate checks the field values on FeatureDescriptorResponse with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the first error encountered is returned, or nil if there are no violations.
-func (m *FeatureDescriptorResponse) Validate() error {
+// Validate checks the field values on BatchGetItem with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BatchGetItem) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on FeatureDescriptorResponse with the
-// rules defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// FeatureDescriptorResponseMultiError, or nil if none found.
-func (m *FeatureDescriptorResponse) ValidateAll() error {
+// ValidateAll checks the field values on BatchGetItem with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BatchGetItemMultiError, or
+// nil if none found.
+func (m *BatchGetItem) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *FeatureDescriptorResponse) validate(all bool) error {
+func (m *BatchGetItem) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if err := m._validateUuid(m.GetUuid()); err != nil {
-		err = FeatureDescriptorResponseValidationError{
-			field:  "Uuid",
-			reason: "value must be a valid UUID",
-			cause:  err,
+	if !_BatchGetItem_Selector_Pattern.MatchString(m.GetSelector()) {
+		err := BatchGetItemValidationError{
+			field:  "Selector",
+			reason: "value does not match regex pattern \"(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\\\[(?P<encoding>([a-z]+_*[a-z]+))])?$\"",
 		}
 		if !all {
 			return err
@@ -518,57 +549,21 @@ func (m *FeatureDescriptorResponse) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if all {
-		switch v := interface{}(m.GetFeatureDescriptor()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, FeatureDescriptorResponseValidationError{
-					field:  "FeatureDescriptor",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, FeatureDescriptorResponseValidationError{
-					field:  "FeatureDescriptor",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetFeatureDescriptor()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return FeatureDescriptorResponseValidationError{
-				field:  "FeatureDescriptor",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
-	}
+	// no validation rules for Keys
 
 	if len(errors) > 0 {
-		return FeatureDescriptorResponseMultiError(errors)
-	}
-
-	return nil
-}
-
-func (m *FeatureDescriptorResponse) _validateUuid(uuid string) error {
-	if matched := _api_uuidPattern.MatchString(uuid); !matched {
-		return errors.New("invalid uuid format")
+		return BatchGetItemMultiError(errors)
 	}
 
 	return nil
 }
 
-// FeatureDescriptorResponseMultiError is an error wrapping multiple validation
-// errors returned by FeatureDescriptorResponse.ValidateAll() if the
-// designated constraints aren't met.
-type FeatureDescriptorResponseMultiError []error
+// BatchGetItemMultiError is an error wrapping multiple validation errors
+// returned by BatchGetItem.ValidateAll() if the designated constraints aren't met.
+type BatchGetItemMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m FeatureDescriptorResponseMultiError) Error() string {
+func (m BatchGetItemMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -577,11 +572,11 @@ func (m FeatureDescriptorResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m FeatureDescriptorResponseMultiError) AllErrors() []error { return m }
+func (m BatchGetItemMultiError) AllErrors() []error { return m }
 
-// FeatureDescriptorResponseValidationError is the validation error returned by
-// FeatureDescriptorResponse.Validate if the designated constraints aren't met.
-type FeatureDescriptorResponseValidationError struct {
+// BatchGetItemValidationError is the validation error returned by
+// BatchGetItem.Validate if the designated constraints aren't met.
+type BatchGetItemValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -589,24 +584,22 @@ type FeatureDescriptorResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e FeatureDescriptorResponseValidationError) Field() string { return e.field }
+func (e BatchGetItemValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e FeatureDescriptorResponseValidationError) Reason() string { return e.reason }
+func (e BatchGetItemValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e FeatureDescriptorResponseValidationError) Cause() error { return e.cause }
+func (e BatchGetItemValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e FeatureDescriptorResponseValidationError) Key() bool { return e.key }
+func (e BatchGetItemValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e FeatureDescriptorResponseValidationError) ErrorName() string {
-	return "FeatureDescriptorResponseValidationError"
-}
+func (e BatchGetItemValidationError) ErrorName() string { return "BatchGetItemValidationError" }
 
 // Error satisfies the builtin error interface
-func (e FeatureDescriptorResponseValidationError) Error() string {
+func (e BatchGetItemValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -618,14 +611,14 @@ func (e FeatureDescriptorResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sFeatureDescriptorResponse.%s: %s%s",
+		"invalid %sBatchGetItem.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = FeatureDescriptorResponseValidationError{}
+var _ error = BatchGetItemValidationError{}
 
 var _ interface {
 	Field() string
@@ -633,24 +626,26 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = FeatureDescriptorResponseValidationError{}
+} = BatchGetItemValidationError{}
 
-// Validate checks the field values on SetRequest with the rules defined in the
-// proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *SetRequest) Validate() error {
+var _BatchGetItem_Selector_Pattern = regexp.MustCompile("(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$")
+
+// Validate checks the field values on BatchGetResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *BatchGetResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on SetRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in SetRequestMultiError, or
-// nil if none found.
-func (m *SetRequest) ValidateAll() error {
+// ValidateAll checks the field values on BatchGetResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchGetResponseMultiError, or nil if none found.
+func (m *BatchGetResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *SetRequest) validate(all bool) error {
+func (m *BatchGetResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -658,7 +653,7 @@ func (m *SetRequest) validate(all bool) error {
 	var errors []error
 
 	if err := m._validateUuid(m.GetUuid()); err != nil {
-		err = SetRequestValidationError{
+		err = BatchGetResponseValidationError{
 			field:  "Uuid",
 			reason: "value must be a valid UUID",
 			cause:  err,
@@ -669,85 +664,48 @@ func (m *SetRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if !_SetRequest_Selector_Pattern.MatchString(m.GetSelector()) {
-		err := SetRequestValidationError{
-			field:  "Selector",
-			reason: "value does not match regex pattern \"(i?)^([a0-z9\\\\-\\\\.]*)(\\\\[([a0-z9])*\\\\])?$\"",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	// no validation rules for Keys
-
-	if all {
-		switch v := interface{}(m.GetValue()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, SetRequestValidationError{
-					field:  "Value",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+	for idx, item := range m.GetResults() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BatchGetResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BatchGetResponseValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
 			}
-		case interface{ Validate() error }:
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				errors = append(errors, SetRequestValidationError{
-					field:  "Value",
+				return BatchGetResponseValidationError{
+					field:  fmt.Sprintf("Results[%v]", idx),
 					reason: "embedded message failed validation",
 					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetValue()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return SetRequestValidationError{
-				field:  "Value",
-				reason: "embedded message failed validation",
-				cause:  err,
+				}
 			}
 		}
-	}
 
-	if all {
-		switch v := interface{}(m.GetTimestamp()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, SetRequestValidationError{
-					field:  "Timestamp",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		case interface{ Validate() error }:
-			if err := v.Validate(); err != nil {
-				errors = append(errors, SetRequestValidationError{
-					field:  "Timestamp",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return SetRequestValidationError{
-				field:  "Timestamp",
-				reason: "embedded message failed validation",
-				cause:  err,
-			}
-		}
 	}
 
 	if len(errors) > 0 {
-		return SetRequestMultiError(errors)
+		return BatchGetResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-func (m *SetRequest) _validateUuid(uuid string) error {
+func (m *BatchGetResponse) _validateUuid(uuid string) error {
 	if matched := _api_uuidPattern.MatchString(uuid); !matched {
 		return errors.New("invalid uuid format")
 	}
@@ -755,12 +713,13 @@ func (m *SetRequest) _validateUuid(uuid string) error {
 	return nil
 }
 
-// SetRequestMultiError is an error wrapping multiple validation errors
-// returned by SetRequest.ValidateAll() if the designated constraints aren't met.
-type SetRequestMultiError []error
+// BatchGetResponseMultiError is an error wrapping multiple validation errors
+// returned by BatchGetResponse.ValidateAll() if the designated constraints
+// aren't met.
+type BatchGetResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m SetRequestMultiError) Error() string {
+func (m BatchGetResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -769,11 +728,11 @@ func (m SetRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m SetRequestMultiError) AllErrors() []error { return m }
+func (m BatchGetResponseMultiError) AllErrors() []error { return m }
 
-// SetRequestValidationError is the validation error returned by
-// SetRequest.Validate if the designated constraints aren't met.
-type SetRequestValidationError struct {
+// BatchGetResponseValidationError is the validation error returned by
+// BatchGetResponse.Validate if the designated constraints aren't met.
+type BatchGetResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -781,22 +740,22 @@ type SetRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e SetRequestValidationError) Field() string { return e.field }
+func (e BatchGetResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e SetRequestValidationError) Reason() string { return e.reason }
+func (e BatchGetResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e SetRequestValidationError) Cause() error { return e.cause }
+func (e BatchGetResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e SetRequestValidationError) Key() bool { return e.key }
+func (e BatchGetResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e SetRequestValidationError) ErrorName() string { return "SetRequestValidationError" }
+func (e BatchGetResponseValidationError) ErrorName() string { return "BatchGetResponseValidationError" }
 
 // Error satisfies the builtin error interface
-func (e SetRequestValidationError) Error() string {
+func (e BatchGetResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -808,14 +767,14 @@ func (e SetRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sSetRequest.%s: %s%s",
+		"invalid %sBatchGetResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = SetRequestValidationError{}
+var _ error = BatchGetResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -823,58 +782,1340 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = SetRequestValidationError{}
+} = BatchGetResponseValidationError{}
 
-var _SetRequest_Selector_Pattern = regexp.MustCompile("(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$")
-
-// Validate checks the field values on SetResponse with the rules defined in
+// Validate checks the field values on BatchGetResult with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *SetResponse) Validate() error {
+func (m *BatchGetResult) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on SetResponse with the rules defined in
-// the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in SetResponseMultiError, or
-// nil if none found.
-func (m *SetResponse) ValidateAll() error {
+// ValidateAll checks the field values on BatchGetResult with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BatchGetResultMultiError,
+// or nil if none found.
+func (m *BatchGetResult) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *SetResponse) validate(all bool) error {
+func (m *BatchGetResult) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if err := m._validateUuid(m.GetUuid()); err != nil {
-		err = SetResponseValidationError{
-			field:  "Uuid",
-			reason: "value must be a valid UUID",
-			cause:  err,
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
 	if all {
-		switch v := interface{}(m.GetTimestamp()).(type) {
+		switch v := interface{}(m.GetValue()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, SetResponseValidationError{
-					field:  "Timestamp",
+				errors = append(errors, BatchGetResultValidationError{
+					field:  "Value",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, SetResponseValidationError{
-					field:  "Timestamp",
+				errors = append(errors, BatchGetResultValidationError{
+					field:  "Value",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetValue()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BatchGetResultValidationError{
+				field:  "Value",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetFeatureDescriptor()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BatchGetResultValidationError{
+					field:  "FeatureDescriptor",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BatchGetResultValidationError{
+					field:  "FeatureDescriptor",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFeatureDescriptor()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BatchGetResultValidationError{
+				field:  "FeatureDescriptor",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Error
+
+	// no validation rules for Code
+
+	// no validation rules for Fallback
+
+	if len(errors) > 0 {
+		return BatchGetResultMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchGetResultMultiError is an error wrapping multiple validation errors
+// returned by BatchGetResult.ValidateAll() if the designated constraints
+// aren't met.
+type BatchGetResultMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchGetResultMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchGetResultMultiError) AllErrors() []error { return m }
+
+// BatchGetResultValidationError is the validation error returned by
+// BatchGetResult.Validate if the designated constraints aren't met.
+type BatchGetResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchGetResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchGetResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchGetResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchGetResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchGetResultValidationError) ErrorName() string { return "BatchGetResultValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BatchGetResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchGetResult.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchGetResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchGetResultValidationError{}
+
+// Validate checks the field values on WatchRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *WatchRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WatchRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in WatchRequestMultiError, or
+// nil if none found.
+func (m *WatchRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WatchRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUuid()); err != nil {
+		err = WatchRequestValidationError{
+			field:  "Uuid",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetSelectors()) < 1 {
+		err := WatchRequestValidationError{
+			field:  "Selectors",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetSelectors() {
+		_, _ = idx, item
+
+		if !_WatchRequest_Selectors_Pattern.MatchString(item) {
+			err := WatchRequestValidationError{
+				field:  fmt.Sprintf("Selectors[%v]", idx),
+				reason: "value does not match regex pattern \"(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\\\[(?P<encoding>([a-z]+_*[a-z]+))])?$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for Keys
+
+	if len(errors) > 0 {
+		return WatchRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *WatchRequest) _validateUuid(uuid string) error {
+	if matched := _api_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// WatchRequestMultiError is an error wrapping multiple validation errors
+// returned by WatchRequest.ValidateAll() if the designated constraints aren't met.
+type WatchRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WatchRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WatchRequestMultiError) AllErrors() []error { return m }
+
+// WatchRequestValidationError is the validation error returned by
+// WatchRequest.Validate if the designated constraints aren't met.
+type WatchRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WatchRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WatchRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WatchRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WatchRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WatchRequestValidationError) ErrorName() string { return "WatchRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e WatchRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWatchRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WatchRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WatchRequestValidationError{}
+
+var _WatchRequest_Selectors_Pattern = regexp.MustCompile("(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$")
+
+// Validate checks the field values on WatchResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *WatchResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WatchResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in WatchResponseMultiError, or
+// nil if none found.
+func (m *WatchResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WatchResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUuid()); err != nil {
+		err = WatchResponseValidationError{
+			field:  "Uuid",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Selector
+
+	if all {
+		switch v := interface{}(m.GetValue()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, WatchResponseValidationError{
+					field:  "Value",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, WatchResponseValidationError{
+					field:  "Value",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetValue()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return WatchResponseValidationError{
+				field:  "Value",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return WatchResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *WatchResponse) _validateUuid(uuid string) error {
+	if matched := _api_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// WatchResponseMultiError is an error wrapping multiple validation errors
+// returned by WatchResponse.ValidateAll() if the designated constraints
+// aren't met.
+type WatchResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WatchResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WatchResponseMultiError) AllErrors() []error { return m }
+
+// WatchResponseValidationError is the validation error returned by
+// WatchResponse.Validate if the designated constraints aren't met.
+type WatchResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WatchResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WatchResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WatchResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WatchResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WatchResponseValidationError) ErrorName() string { return "WatchResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e WatchResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWatchResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WatchResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WatchResponseValidationError{}
+
+// Validate checks the field values on FeatureDescriptorRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *FeatureDescriptorRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FeatureDescriptorRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// FeatureDescriptorRequestMultiError, or nil if none found.
+func (m *FeatureDescriptorRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FeatureDescriptorRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUuid()); err != nil {
+		err = FeatureDescriptorRequestValidationError{
+			field:  "Uuid",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_FeatureDescriptorRequest_Selector_Pattern.MatchString(m.GetSelector()) {
+		err := FeatureDescriptorRequestValidationError{
+			field:  "Selector",
+			reason: "value does not match regex pattern \"(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\\\[(?P<encoding>([a-z]+_*[a-z]+))])?$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return FeatureDescriptorRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *FeatureDescriptorRequest) _validateUuid(uuid string) error {
+	if matched := _api_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// FeatureDescriptorRequestMultiError is an error wrapping multiple validation
+// errors returned by FeatureDescriptorRequest.ValidateAll() if the designated
+// constraints aren't met.
+type FeatureDescriptorRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FeatureDescriptorRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FeatureDescriptorRequestMultiError) AllErrors() []error { return m }
+
+// FeatureDescriptorRequestValidationError is the validation error returned by
+// FeatureDescriptorRequest.Validate if the designated constraints aren't met.
+type FeatureDescriptorRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FeatureDescriptorRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FeatureDescriptorRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FeatureDescriptorRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FeatureDescriptorRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FeatureDescriptorRequestValidationError) ErrorName() string {
+	return "FeatureDescriptorRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e FeatureDescriptorRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFeatureDescriptorRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FeatureDescriptorRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FeatureDescriptorRequestValidationError{}
+
+var _FeatureDescriptorRequest_Selector_Pattern = regexp.MustCompile("(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$")
+
+// Validate checks the field values on FeatureDescriptorResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *FeatureDescriptorResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FeatureDescriptorResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// FeatureDescriptorResponseMultiError, or nil if none found.
+func (m *FeatureDescriptorResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FeatureDescriptorResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUuid()); err != nil {
+		err = FeatureDescriptorResponseValidationError{
+			field:  "Uuid",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetFeatureDescriptor()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, FeatureDescriptorResponseValidationError{
+					field:  "FeatureDescriptor",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, FeatureDescriptorResponseValidationError{
+					field:  "FeatureDescriptor",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFeatureDescriptor()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return FeatureDescriptorResponseValidationError{
+				field:  "FeatureDescriptor",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return FeatureDescriptorResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *FeatureDescriptorResponse) _validateUuid(uuid string) error {
+	if matched := _api_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// FeatureDescriptorResponseMultiError is an error wrapping multiple validation
+// errors returned by FeatureDescriptorResponse.ValidateAll() if the
+// designated constraints aren't met.
+type FeatureDescriptorResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FeatureDescriptorResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FeatureDescriptorResponseMultiError) AllErrors() []error { return m }
+
+// FeatureDescriptorResponseValidationError is the validation error returned by
+// FeatureDescriptorResponse.Validate if the designated constraints aren't met.
+type FeatureDescriptorResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FeatureDescriptorResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FeatureDescriptorResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FeatureDescriptorResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FeatureDescriptorResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FeatureDescriptorResponseValidationError) ErrorName() string {
+	return "FeatureDescriptorResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e FeatureDescriptorResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFeatureDescriptorResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FeatureDescriptorResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FeatureDescriptorResponseValidationError{}
+
+// Validate checks the field values on SetRequest with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SetRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SetRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SetRequestMultiError, or
+// nil if none found.
+func (m *SetRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SetRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUuid()); err != nil {
+		err = SetRequestValidationError{
+			field:  "Uuid",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_SetRequest_Selector_Pattern.MatchString(m.GetSelector()) {
+		err := SetRequestValidationError{
+			field:  "Selector",
+			reason: "value does not match regex pattern \"(i?)^([a0-z9\\\\-\\\\.]*)(\\\\[([a0-z9])*\\\\])?$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Keys
+
+	if all {
+		switch v := interface{}(m.GetValue()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SetRequestValidationError{
+					field:  "Value",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SetRequestValidationError{
+					field:  "Value",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetValue()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SetRequestValidationError{
+				field:  "Value",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SetRequestValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SetRequestValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SetRequestValidationError{
+				field:  "Timestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SetRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *SetRequest) _validateUuid(uuid string) error {
+	if matched := _api_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// SetRequestMultiError is an error wrapping multiple validation errors
+// returned by SetRequest.ValidateAll() if the designated constraints aren't met.
+type SetRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SetRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SetRequestMultiError) AllErrors() []error { return m }
+
+// SetRequestValidationError is the validation error returned by
+// SetRequest.Validate if the designated constraints aren't met.
+type SetRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetRequestValidationError) ErrorName() string { return "SetRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SetRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetRequestValidationError{}
+
+var _SetRequest_Selector_Pattern = regexp.MustCompile("(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$")
+
+// Validate checks the field values on SetResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SetResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SetResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SetResponseMultiError, or
+// nil if none found.
+func (m *SetResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SetResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUuid()); err != nil {
+		err = SetResponseValidationError{
+			field:  "Uuid",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SetResponseValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SetResponseValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SetResponseValidationError{
+				field:  "Timestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SetResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *SetResponse) _validateUuid(uuid string) error {
+	if matched := _api_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// SetResponseMultiError is an error wrapping multiple validation errors
+// returned by SetResponse.ValidateAll() if the designated constraints aren't met.
+type SetResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SetResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SetResponseMultiError) AllErrors() []error { return m }
+
+// SetResponseValidationError is the validation error returned by
+// SetResponse.Validate if the designated constraints aren't met.
+type SetResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SetResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SetResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SetResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SetResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SetResponseValidationError) ErrorName() string { return "SetResponseValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SetResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSetResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SetResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SetResponseValidationError{}
+
+// Validate checks the field values on AppendRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AppendRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AppendRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AppendRequestMultiError, or
+// nil if none found.
+func (m *AppendRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AppendRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUuid()); err != nil {
+		err = AppendRequestValidationError{
+			field:  "Uuid",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_AppendRequest_Fqn_Pattern.MatchString(m.GetFqn()) {
+		err := AppendRequestValidationError{
+			field:  "Fqn",
+			reason: "value does not match regex pattern \"(i?)^([a0-z9\\\\-\\\\.]*)(\\\\[([a0-z9])*\\\\])?$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Keys
+
+	if all {
+		switch v := interface{}(m.GetValue()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AppendRequestValidationError{
+					field:  "Value",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AppendRequestValidationError{
+					field:  "Value",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetValue()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AppendRequestValidationError{
+				field:  "Value",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AppendRequestValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AppendRequestValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AppendRequestValidationError{
+				field:  "Timestamp",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return AppendRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *AppendRequest) _validateUuid(uuid string) error {
+	if matched := _api_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// AppendRequestMultiError is an error wrapping multiple validation errors
+// returned by AppendRequest.ValidateAll() if the designated constraints
+// aren't met.
+type AppendRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AppendRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AppendRequestMultiError) AllErrors() []error { return m }
+
+// AppendRequestValidationError is the validation error returned by
+// AppendRequest.Validate if the designated constraints aren't met.
+type AppendRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AppendRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AppendRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AppendRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AppendRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AppendRequestValidationError) ErrorName() string { return "AppendRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AppendRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAppendRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AppendRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AppendRequestValidationError{}
+
+var _AppendRequest_Fqn_Pattern = regexp.MustCompile("(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$")
+
+// Validate checks the field values on AppendResponse with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AppendResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AppendResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AppendResponseMultiError,
+// or nil if none found.
+func (m *AppendResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AppendResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if err := m._validateUuid(m.GetUuid()); err != nil {
+		err = AppendResponseValidationError{
+			field:  "Uuid",
+			reason: "value must be a valid UUID",
+			cause:  err,
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetTimestamp()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AppendResponseValidationError{
+					field:  "Timestamp",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AppendResponseValidationError{
+					field:  "Timestamp",
 					reason: "embedded message failed validation",
 					cause:  err,
 				})
@@ -882,7 +2123,7 @@ func (m *SetResponse) validate(all bool) error {
 		}
 	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return SetResponseValidationError{
+			return AppendResponseValidationError{
 				field:  "Timestamp",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -891,13 +2132,13 @@ func (m *SetResponse) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return SetResponseMultiError(errors)
+		return AppendResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-func (m *SetResponse) _validateUuid(uuid string) error {
+func (m *AppendResponse) _validateUuid(uuid string) error {
 	if matched := _api_uuidPattern.MatchString(uuid); !matched {
 		return errors.New("invalid uuid format")
 	}
@@ -905,12 +2146,13 @@ func (m *SetResponse) _validateUuid(uuid string) error {
 	return nil
 }
 
-// SetResponseMultiError is an error wrapping multiple validation errors
-// returned by SetResponse.ValidateAll() if the designated constraints aren't met.
-type SetResponseMultiError []error
+// AppendResponseMultiError is an error wrapping multiple validation errors
+// returned by AppendResponse.ValidateAll() if the designated constraints
+// aren't met.
+type AppendResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m SetResponseMultiError) Error() string {
+func (m AppendResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -919,11 +2161,11 @@ func (m SetResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m SetResponseMultiError) AllErrors() []error { return m }
+func (m AppendResponseMultiError) AllErrors() []error { return m }
 
-// SetResponseValidationError is the validation error returned by
-// SetResponse.Validate if the designated constraints aren't met.
-type SetResponseValidationError struct {
+// AppendResponseValidationError is the validation error returned by
+// AppendResponse.Validate if the designated constraints aren't met.
+type AppendResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -931,22 +2173,22 @@ type SetResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e SetResponseValidationError) Field() string { return e.field }
+func (e AppendResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e SetResponseValidationError) Reason() string { return e.reason }
+func (e AppendResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e SetResponseValidationError) Cause() error { return e.cause }
+func (e AppendResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e SetResponseValidationError) Key() bool { return e.key }
+func (e AppendResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e SetResponseValidationError) ErrorName() string { return "SetResponseValidationError" }
+func (e AppendResponseValidationError) ErrorName() string { return "AppendResponseValidationError" }
 
 // Error satisfies the builtin error interface
-func (e SetResponseValidationError) Error() string {
+func (e AppendResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -958,14 +2200,14 @@ func (e SetResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sSetResponse.%s: %s%s",
+		"invalid %sAppendResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = SetResponseValidationError{}
+var _ error = AppendResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -973,24 +2215,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = SetResponseValidationError{}
+} = AppendResponseValidationError{}
 
-// Validate checks the field values on AppendRequest with the rules defined in
+// Validate checks the field values on IncrRequest with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *AppendRequest) Validate() error {
+func (m *IncrRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on AppendRequest with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in AppendRequestMultiError, or
+// ValidateAll checks the field values on IncrRequest with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in IncrRequestMultiError, or
 // nil if none found.
-func (m *AppendRequest) ValidateAll() error {
+func (m *IncrRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *AppendRequest) validate(all bool) error {
+func (m *IncrRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -998,7 +2240,7 @@ func (m *AppendRequest) validate(all bool) error {
 	var errors []error
 
 	if err := m._validateUuid(m.GetUuid()); err != nil {
-		err = AppendRequestValidationError{
+		err = IncrRequestValidationError{
 			field:  "Uuid",
 			reason: "value must be a valid UUID",
 			cause:  err,
@@ -1009,8 +2251,8 @@ func (m *AppendRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if !_AppendRequest_Fqn_Pattern.MatchString(m.GetFqn()) {
-		err := AppendRequestValidationError{
+	if !_IncrRequest_Fqn_Pattern.MatchString(m.GetFqn()) {
+		err := IncrRequestValidationError{
 			field:  "Fqn",
 			reason: "value does not match regex pattern \"(i?)^([a0-z9\\\\-\\\\.]*)(\\\\[([a0-z9])*\\\\])?$\"",
 		}
@@ -1026,7 +2268,7 @@ func (m *AppendRequest) validate(all bool) error {
 		switch v := interface{}(m.GetValue()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, AppendRequestValidationError{
+				errors = append(errors, IncrRequestValidationError{
 					field:  "Value",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1034,7 +2276,7 @@ func (m *AppendRequest) validate(all bool) error {
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, AppendRequestValidationError{
+				errors = append(errors, IncrRequestValidationError{
 					field:  "Value",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1043,7 +2285,7 @@ func (m *AppendRequest) validate(all bool) error {
 		}
 	} else if v, ok := interface{}(m.GetValue()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return AppendRequestValidationError{
+			return IncrRequestValidationError{
 				field:  "Value",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -1055,7 +2297,7 @@ func (m *AppendRequest) validate(all bool) error {
 		switch v := interface{}(m.GetTimestamp()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, AppendRequestValidationError{
+				errors = append(errors, IncrRequestValidationError{
 					field:  "Timestamp",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1063,7 +2305,7 @@ func (m *AppendRequest) validate(all bool) error {
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, AppendRequestValidationError{
+				errors = append(errors, IncrRequestValidationError{
 					field:  "Timestamp",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1072,7 +2314,7 @@ func (m *AppendRequest) validate(all bool) error {
 		}
 	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return AppendRequestValidationError{
+			return IncrRequestValidationError{
 				field:  "Timestamp",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -1081,13 +2323,13 @@ func (m *AppendRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return AppendRequestMultiError(errors)
+		return IncrRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-func (m *AppendRequest) _validateUuid(uuid string) error {
+func (m *IncrRequest) _validateUuid(uuid string) error {
 	if matched := _api_uuidPattern.MatchString(uuid); !matched {
 		return errors.New("invalid uuid format")
 	}
@@ -1095,13 +2337,12 @@ func (m *AppendRequest) _validateUuid(uuid string) error {
 	return nil
 }
 
-// AppendRequestMultiError is an error wrapping multiple validation errors
-// returned by AppendRequest.ValidateAll() if the designated constraints
-// aren't met.
-type AppendRequestMultiError []error
+// IncrRequestMultiError is an error wrapping multiple validation errors
+// returned by IncrRequest.ValidateAll() if the designated constraints aren't met.
+type IncrRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m AppendRequestMultiError) Error() string {
+func (m IncrRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1110,11 +2351,11 @@ func (m AppendRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m AppendRequestMultiError) AllErrors() []error { return m }
+func (m IncrRequestMultiError) AllErrors() []error { return m }
 
-// AppendRequestValidationError is the validation error returned by
-// AppendRequest.Validate if the designated constraints aren't met.
-type AppendRequestValidationError struct {
+// IncrRequestValidationError is the validation error returned by
+// IncrRequest.Validate if the designated constraints aren't met.
+type IncrRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1122,22 +2363,22 @@ type AppendRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e AppendRequestValidationError) Field() string { return e.field }
+func (e IncrRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e AppendRequestValidationError) Reason() string { return e.reason }
+func (e IncrRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e AppendRequestValidationError) Cause() error { return e.cause }
+func (e IncrRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e AppendRequestValidationError) Key() bool { return e.key }
+func (e IncrRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e AppendRequestValidationError) ErrorName() string { return "AppendRequestValidationError" }
+func (e IncrRequestValidationError) ErrorName() string { return "IncrRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e AppendRequestValidationError) Error() string {
+func (e IncrRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1149,14 +2390,14 @@ func (e AppendRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sAppendRequest.%s: %s%s",
+		"invalid %sIncrRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = AppendRequestValidationError{}
+var _ error = IncrRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1164,26 +2405,26 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = AppendRequestValidationError{}
+} = IncrRequestValidationError{}
 
-var _AppendRequest_Fqn_Pattern = regexp.MustCompile("(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$")
+var _IncrRequest_Fqn_Pattern = regexp.MustCompile("(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$")
 
-// Validate checks the field values on AppendResponse with the rules defined in
+// Validate checks the field values on IncrResponse with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *AppendResponse) Validate() error {
+func (m *IncrResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on AppendResponse with the rules defined
+// ValidateAll checks the field values on IncrResponse with the rules defined
 // in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in AppendResponseMultiError,
-// or nil if none found.
-func (m *AppendResponse) ValidateAll() error {
+// result is a list of violation errors wrapped in IncrResponseMultiError, or
+// nil if none found.
+func (m *IncrResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *AppendResponse) validate(all bool) error {
+func (m *IncrResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -1191,7 +2432,7 @@ func (m *AppendResponse) validate(all bool) error {
 	var errors []error
 
 	if err := m._validateUuid(m.GetUuid()); err != nil {
-		err = AppendResponseValidationError{
+		err = IncrResponseValidationError{
 			field:  "Uuid",
 			reason: "value must be a valid UUID",
 			cause:  err,
@@ -1206,7 +2447,7 @@ func (m *AppendResponse) validate(all bool) error {
 		switch v := interface{}(m.GetTimestamp()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, AppendResponseValidationError{
+				errors = append(errors, IncrResponseValidationError{
 					field:  "Timestamp",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1214,7 +2455,7 @@ func (m *AppendResponse) validate(all bool) error {
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, AppendResponseValidationError{
+				errors = append(errors, IncrResponseValidationError{
 					field:  "Timestamp",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1223,7 +2464,7 @@ func (m *AppendResponse) validate(all bool) error {
 		}
 	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return AppendResponseValidationError{
+			return IncrResponseValidationError{
 				field:  "Timestamp",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -1232,13 +2473,13 @@ func (m *AppendResponse) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return AppendResponseMultiError(errors)
+		return IncrResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-func (m *AppendResponse) _validateUuid(uuid string) error {
+func (m *IncrResponse) _validateUuid(uuid string) error {
 	if matched := _api_uuidPattern.MatchString(uuid); !matched {
 		return errors.New("invalid uuid format")
 	}
@@ -1246,13 +2487,12 @@ func (m *AppendResponse) _validateUuid(uuid string) error {
 	return nil
 }
 
-// AppendResponseMultiError is an error wrapping multiple validation errors
-// returned by AppendResponse.ValidateAll() if the designated constraints
-// aren't met.
-type AppendResponseMultiError []error
+// IncrResponseMultiError is an error wrapping multiple validation errors
+// returned by IncrResponse.ValidateAll() if the designated constraints aren't met.
+type IncrResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m AppendResponseMultiError) Error() string {
+func (m IncrResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1261,11 +2501,11 @@ func (m AppendResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m AppendResponseMultiError) AllErrors() []error { return m }
+func (m IncrResponseMultiError) AllErrors() []error { return m }
 
-// AppendResponseValidationError is the validation error returned by
-// AppendResponse.Validate if the designated constraints aren't met.
-type AppendResponseValidationError struct {
+// IncrResponseValidationError is the validation error returned by
+// IncrResponse.Validate if the designated constraints aren't met.
+type IncrResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1273,22 +2513,22 @@ type AppendResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e AppendResponseValidationError) Field() string { return e.field }
+func (e IncrResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e AppendResponseValidationError) Reason() string { return e.reason }
+func (e IncrResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e AppendResponseValidationError) Cause() error { return e.cause }
+func (e IncrResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e AppendResponseValidationError) Key() bool { return e.key }
+func (e IncrResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e AppendResponseValidationError) ErrorName() string { return "AppendResponseValidationError" }
+func (e IncrResponseValidationError) ErrorName() string { return "IncrResponseValidationError" }
 
 // Error satisfies the builtin error interface
-func (e AppendResponseValidationError) Error() string {
+func (e IncrResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1300,14 +2540,14 @@ func (e AppendResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sAppendResponse.%s: %s%s",
+		"invalid %sIncrResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = AppendResponseValidationError{}
+var _ error = IncrResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1315,24 +2555,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = AppendResponseValidationError{}
+} = IncrResponseValidationError{}
 
-// Validate checks the field values on IncrRequest with the rules defined in
+// Validate checks the field values on UpdateRequest with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *IncrRequest) Validate() error {
+func (m *UpdateRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on IncrRequest with the rules defined in
-// the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in IncrRequestMultiError, or
+// ValidateAll checks the field values on UpdateRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UpdateRequestMultiError, or
 // nil if none found.
-func (m *IncrRequest) ValidateAll() error {
+func (m *UpdateRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *IncrRequest) validate(all bool) error {
+func (m *UpdateRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -1340,7 +2580,7 @@ func (m *IncrRequest) validate(all bool) error {
 	var errors []error
 
 	if err := m._validateUuid(m.GetUuid()); err != nil {
-		err = IncrRequestValidationError{
+		err = UpdateRequestValidationError{
 			field:  "Uuid",
 			reason: "value must be a valid UUID",
 			cause:  err,
@@ -1351,9 +2591,9 @@ func (m *IncrRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if !_IncrRequest_Fqn_Pattern.MatchString(m.GetFqn()) {
-		err := IncrRequestValidationError{
-			field:  "Fqn",
+	if !_UpdateRequest_Selector_Pattern.MatchString(m.GetSelector()) {
+		err := UpdateRequestValidationError{
+			field:  "Selector",
 			reason: "value does not match regex pattern \"(i?)^([a0-z9\\\\-\\\\.]*)(\\\\[([a0-z9])*\\\\])?$\"",
 		}
 		if !all {
@@ -1368,7 +2608,7 @@ func (m *IncrRequest) validate(all bool) error {
 		switch v := interface{}(m.GetValue()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, IncrRequestValidationError{
+				errors = append(errors, UpdateRequestValidationError{
 					field:  "Value",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1376,7 +2616,7 @@ func (m *IncrRequest) validate(all bool) error {
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, IncrRequestValidationError{
+				errors = append(errors, UpdateRequestValidationError{
 					field:  "Value",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1385,7 +2625,7 @@ func (m *IncrRequest) validate(all bool) error {
 		}
 	} else if v, ok := interface{}(m.GetValue()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return IncrRequestValidationError{
+			return UpdateRequestValidationError{
 				field:  "Value",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -1397,7 +2637,7 @@ func (m *IncrRequest) validate(all bool) error {
 		switch v := interface{}(m.GetTimestamp()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, IncrRequestValidationError{
+				errors = append(errors, UpdateRequestValidationError{
 					field:  "Timestamp",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1405,7 +2645,7 @@ func (m *IncrRequest) validate(all bool) error {
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, IncrRequestValidationError{
+				errors = append(errors, UpdateRequestValidationError{
 					field:  "Timestamp",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1414,7 +2654,7 @@ func (m *IncrRequest) validate(all bool) error {
 		}
 	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return IncrRequestValidationError{
+			return UpdateRequestValidationError{
 				field:  "Timestamp",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -1423,13 +2663,13 @@ func (m *IncrRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return IncrRequestMultiError(errors)
+		return UpdateRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-func (m *IncrRequest) _validateUuid(uuid string) error {
+func (m *UpdateRequest) _validateUuid(uuid string) error {
 	if matched := _api_uuidPattern.MatchString(uuid); !matched {
 		return errors.New("invalid uuid format")
 	}
@@ -1437,12 +2677,13 @@ func (m *IncrRequest) _validateUuid(uuid string) error {
 	return nil
 }
 
-// IncrRequestMultiError is an error wrapping multiple validation errors
-// returned by IncrRequest.ValidateAll() if the designated constraints aren't met.
-type IncrRequestMultiError []error
+// UpdateRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m IncrRequestMultiError) Error() string {
+func (m UpdateRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1451,11 +2692,11 @@ func (m IncrRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m IncrRequestMultiError) AllErrors() []error { return m }
+func (m UpdateRequestMultiError) AllErrors() []error { return m }
 
-// IncrRequestValidationError is the validation error returned by
-// IncrRequest.Validate if the designated constraints aren't met.
-type IncrRequestValidationError struct {
+// UpdateRequestValidationError is the validation error returned by
+// UpdateRequest.Validate if the designated constraints aren't met.
+type UpdateRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1463,22 +2704,22 @@ type IncrRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e IncrRequestValidationError) Field() string { return e.field }
+func (e UpdateRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e IncrRequestValidationError) Reason() string { return e.reason }
+func (e UpdateRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e IncrRequestValidationError) Cause() error { return e.cause }
+func (e UpdateRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e IncrRequestValidationError) Key() bool { return e.key }
+func (e UpdateRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e IncrRequestValidationError) ErrorName() string { return "IncrRequestValidationError" }
+func (e UpdateRequestValidationError) ErrorName() string { return "UpdateRequestValidationError" }
 
 // Error satisfies the builtin error interface
-func (e IncrRequestValidationError) Error() string {
+func (e UpdateRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1490,14 +2731,14 @@ func (e IncrRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sIncrRequest.%s: %s%s",
+		"invalid %sUpdateRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = IncrRequestValidationError{}
+var _ error = UpdateRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1505,26 +2746,26 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = IncrRequestValidationError{}
+} = UpdateRequestValidationError{}
 
-var _IncrRequest_Fqn_Pattern = regexp.MustCompile("(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$")
+var _UpdateRequest_Selector_Pattern = regexp.MustCompile("(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$")
 
-// Validate checks the field values on IncrResponse with the rules defined in
+// Validate checks the field values on UpdateResponse with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *IncrResponse) Validate() error {
+func (m *UpdateResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on IncrResponse with the rules defined
+// ValidateAll checks the field values on UpdateResponse with the rules defined
 // in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in IncrResponseMultiError, or
-// nil if none found.
-func (m *IncrResponse) ValidateAll() error {
+// result is a list of violation errors wrapped in UpdateResponseMultiError,
+// or nil if none found.
+func (m *UpdateResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *IncrResponse) validate(all bool) error {
+func (m *UpdateResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -1532,7 +2773,7 @@ func (m *IncrResponse) validate(all bool) error {
 	var errors []error
 
 	if err := m._validateUuid(m.GetUuid()); err != nil {
-		err = IncrResponseValidationError{
+		err = UpdateResponseValidationError{
 			field:  "Uuid",
 			reason: "value must be a valid UUID",
 			cause:  err,
@@ -1547,7 +2788,7 @@ func (m *IncrResponse) validate(all bool) error {
 		switch v := interface{}(m.GetTimestamp()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, IncrResponseValidationError{
+				errors = append(errors, UpdateResponseValidationError{
 					field:  "Timestamp",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1555,7 +2796,7 @@ func (m *IncrResponse) validate(all bool) error {
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, IncrResponseValidationError{
+				errors = append(errors, UpdateResponseValidationError{
 					field:  "Timestamp",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1564,7 +2805,7 @@ func (m *IncrResponse) validate(all bool) error {
 		}
 	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return IncrResponseValidationError{
+			return UpdateResponseValidationError{
 				field:  "Timestamp",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -1573,13 +2814,13 @@ func (m *IncrResponse) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return IncrResponseMultiError(errors)
+		return UpdateResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-func (m *IncrResponse) _validateUuid(uuid string) error {
+func (m *UpdateResponse) _validateUuid(uuid string) error {
 	if matched := _api_uuidPattern.MatchString(uuid); !matched {
 		return errors.New("invalid uuid format")
 	}
@@ -1587,12 +2828,13 @@ func (m *IncrResponse) _validateUuid(uuid string) error {
 	return nil
 }
 
-// IncrResponseMultiError is an error wrapping multiple validation errors
-// returned by IncrResponse.ValidateAll() if the designated constraints aren't met.
-type IncrResponseMultiError []error
+// UpdateResponseMultiError is an error wrapping multiple validation errors
+// returned by UpdateResponse.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m IncrResponseMultiError) Error() string {
+func (m UpdateResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1601,11 +2843,11 @@ func (m IncrResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m IncrResponseMultiError) AllErrors() []error { return m }
+func (m UpdateResponseMultiError) AllErrors() []error { return m }
 
-// IncrResponseValidationError is the validation error returned by
-// IncrResponse.Validate if the designated constraints aren't met.
-type IncrResponseValidationError struct {
+// UpdateResponseValidationError is the validation error returned by
+// UpdateResponse.Validate if the designated constraints aren't met.
+type UpdateResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1613,22 +2855,22 @@ type IncrResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e IncrResponseValidationError) Field() string { return e.field }
+func (e UpdateResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e IncrResponseValidationError) Reason() string { return e.reason }
+func (e UpdateResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e IncrResponseValidationError) Cause() error { return e.cause }
+func (e UpdateResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e IncrResponseValidationError) Key() bool { return e.key }
+func (e UpdateResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e IncrResponseValidationError) ErrorName() string { return "IncrResponseValidationError" }
+func (e UpdateResponseValidationError) ErrorName() string { return "UpdateResponseValidationError" }
 
 // Error satisfies the builtin error interface
-func (e IncrResponseValidationError) Error() string {
+func (e UpdateResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1640,14 +2882,14 @@ func (e IncrResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sIncrResponse.%s: %s%s",
+		"invalid %sUpdateResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = IncrResponseValidationError{}
+var _ error = UpdateResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1655,52 +2897,33 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = IncrResponseValidationError{}
+} = UpdateResponseValidationError{}
 
-// Validate checks the field values on UpdateRequest with the rules defined in
+// Validate checks the field values on IngestRequest with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *UpdateRequest) Validate() error {
+func (m *IngestRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateRequest with the rules defined
+// ValidateAll checks the field values on IngestRequest with the rules defined
 // in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in UpdateRequestMultiError, or
+// result is a list of violation errors wrapped in IngestRequestMultiError, or
 // nil if none found.
-func (m *UpdateRequest) ValidateAll() error {
+func (m *IngestRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateRequest) validate(all bool) error {
+func (m *IngestRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if err := m._validateUuid(m.GetUuid()); err != nil {
-		err = UpdateRequestValidationError{
-			field:  "Uuid",
-			reason: "value must be a valid UUID",
-			cause:  err,
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
+	// no validation rules for Method
 
-	if !_UpdateRequest_Selector_Pattern.MatchString(m.GetSelector()) {
-		err := UpdateRequestValidationError{
-			field:  "Selector",
-			reason: "value does not match regex pattern \"(i?)^([a0-z9\\\\-\\\\.]*)(\\\\[([a0-z9])*\\\\])?$\"",
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
+	// no validation rules for Selector
 
 	// no validation rules for Keys
 
@@ -1708,7 +2931,7 @@ func (m *UpdateRequest) validate(all bool) error {
 		switch v := interface{}(m.GetValue()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UpdateRequestValidationError{
+				errors = append(errors, IngestRequestValidationError{
 					field:  "Value",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1716,7 +2939,7 @@ func (m *UpdateRequest) validate(all bool) error {
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, UpdateRequestValidationError{
+				errors = append(errors, IngestRequestValidationError{
 					field:  "Value",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1725,7 +2948,7 @@ func (m *UpdateRequest) validate(all bool) error {
 		}
 	} else if v, ok := interface{}(m.GetValue()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return UpdateRequestValidationError{
+			return IngestRequestValidationError{
 				field:  "Value",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -1737,7 +2960,7 @@ func (m *UpdateRequest) validate(all bool) error {
 		switch v := interface{}(m.GetTimestamp()).(type) {
 		case interface{ ValidateAll() error }:
 			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UpdateRequestValidationError{
+				errors = append(errors, IngestRequestValidationError{
 					field:  "Timestamp",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1745,7 +2968,7 @@ func (m *UpdateRequest) validate(all bool) error {
 			}
 		case interface{ Validate() error }:
 			if err := v.Validate(); err != nil {
-				errors = append(errors, UpdateRequestValidationError{
+				errors = append(errors, IngestRequestValidationError{
 					field:  "Timestamp",
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -1754,7 +2977,7 @@ func (m *UpdateRequest) validate(all bool) error {
 		}
 	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
 		if err := v.Validate(); err != nil {
-			return UpdateRequestValidationError{
+			return IngestRequestValidationError{
 				field:  "Timestamp",
 				reason: "embedded message failed validation",
 				cause:  err,
@@ -1763,27 +2986,123 @@ func (m *UpdateRequest) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return UpdateRequestMultiError(errors)
+		return IngestRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-func (m *UpdateRequest) _validateUuid(uuid string) error {
-	if matched := _api_uuidPattern.MatchString(uuid); !matched {
-		return errors.New("invalid uuid format")
+// IngestRequestMultiError is an error wrapping multiple validation errors
+// returned by IngestRequest.ValidateAll() if the designated constraints
+// aren't met.
+type IngestRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m IngestRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m IngestRequestMultiError) AllErrors() []error { return m }
+
+// IngestRequestValidationError is the validation error returned by
+// IngestRequest.Validate if the designated constraints aren't met.
+type IngestRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e IngestRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e IngestRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e IngestRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e IngestRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e IngestRequestValidationError) ErrorName() string { return "IngestRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e IngestRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sIngestRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = IngestRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = IngestRequestValidationError{}
+
+// Validate checks the field values on IngestFailure with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *IngestFailure) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on IngestFailure with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in IngestFailureMultiError, or
+// nil if none found.
+func (m *IngestFailure) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *IngestFailure) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Index
+
+	// no validation rules for Error
+
+	if len(errors) > 0 {
+		return IngestFailureMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateRequestMultiError is an error wrapping multiple validation errors
-// returned by UpdateRequest.ValidateAll() if the designated constraints
+// IngestFailureMultiError is an error wrapping multiple validation errors
+// returned by IngestFailure.ValidateAll() if the designated constraints
 // aren't met.
-type UpdateRequestMultiError []error
+type IngestFailureMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateRequestMultiError) Error() string {
+func (m IngestFailureMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1792,11 +3111,11 @@ func (m UpdateRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateRequestMultiError) AllErrors() []error { return m }
+func (m IngestFailureMultiError) AllErrors() []error { return m }
 
-// UpdateRequestValidationError is the validation error returned by
-// UpdateRequest.Validate if the designated constraints aren't met.
-type UpdateRequestValidationError struct {
+// IngestFailureValidationError is the validation error returned by
+// IngestFailure.Validate if the designated constraints aren't met.
+type IngestFailureValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1804,22 +3123,22 @@ type UpdateRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateRequestValidationError) Field() string { return e.field }
+func (e IngestFailureValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateRequestValidationError) Reason() string { return e.reason }
+func (e IngestFailureValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateRequestValidationError) Cause() error { return e.cause }
+func (e IngestFailureValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateRequestValidationError) Key() bool { return e.key }
+func (e IngestFailureValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateRequestValidationError) ErrorName() string { return "UpdateRequestValidationError" }
+func (e IngestFailureValidationError) ErrorName() string { return "IngestFailureValidationError" }
 
 // Error satisfies the builtin error interface
-func (e UpdateRequestValidationError) Error() string {
+func (e IngestFailureValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1831,14 +3150,14 @@ func (e UpdateRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateRequest.%s: %s%s",
+		"invalid %sIngestFailure.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateRequestValidationError{}
+var _ error = IngestFailureValidationError{}
 
 var _ interface {
 	Field() string
@@ -1846,95 +3165,84 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateRequestValidationError{}
+} = IngestFailureValidationError{}
 
-var _UpdateRequest_Selector_Pattern = regexp.MustCompile("(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$")
-
-// Validate checks the field values on UpdateResponse with the rules defined in
+// Validate checks the field values on IngestResponse with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
-func (m *UpdateResponse) Validate() error {
+func (m *IngestResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateResponse with the rules defined
+// ValidateAll checks the field values on IngestResponse with the rules defined
 // in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in UpdateResponseMultiError,
+// result is a list of violation errors wrapped in IngestResponseMultiError,
 // or nil if none found.
-func (m *UpdateResponse) ValidateAll() error {
+func (m *IngestResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateResponse) validate(all bool) error {
+func (m *IngestResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if err := m._validateUuid(m.GetUuid()); err != nil {
-		err = UpdateResponseValidationError{
-			field:  "Uuid",
-			reason: "value must be a valid UUID",
-			cause:  err,
-		}
-		if !all {
-			return err
-		}
-		errors = append(errors, err)
-	}
-
-	if all {
-		switch v := interface{}(m.GetTimestamp()).(type) {
-		case interface{ ValidateAll() error }:
-			if err := v.ValidateAll(); err != nil {
-				errors = append(errors, UpdateResponseValidationError{
-					field:  "Timestamp",
-					reason: "embedded message failed validation",
-					cause:  err,
-				})
+	// no validation rules for Received
+
+	// no validation rules for Succeeded
+
+	// no validation rules for Failed
+
+	for idx, item := range m.GetFailures() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, IngestResponseValidationError{
+						field:  fmt.Sprintf("Failures[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, IngestResponseValidationError{
+						field:  fmt.Sprintf("Failures[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
 			}
-		case interface{ Validate() error }:
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				errors = append(errors, UpdateResponseValidationError{
-					field:  "Timestamp",
+				return IngestResponseValidationError{
+					field:  fmt.Sprintf("Failures[%v]", idx),
 					reason: "embedded message failed validation",
 					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetTimestamp()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return UpdateResponseValidationError{
-				field:  "Timestamp",
-				reason: "embedded message failed validation",
-				cause:  err,
+				}
 			}
 		}
-	}
 
-	if len(errors) > 0 {
-		return UpdateResponseMultiError(errors)
 	}
 
-	return nil
-}
-
-func (m *UpdateResponse) _validateUuid(uuid string) error {
-	if matched := _api_uuidPattern.MatchString(uuid); !matched {
-		return errors.New("invalid uuid format")
+	if len(errors) > 0 {
+		return IngestResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateResponseMultiError is an error wrapping multiple validation errors
-// returned by UpdateResponse.ValidateAll() if the designated constraints
+// IngestResponseMultiError is an error wrapping multiple validation errors
+// returned by IngestResponse.ValidateAll() if the designated constraints
 // aren't met.
-type UpdateResponseMultiError []error
+type IngestResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateResponseMultiError) Error() string {
+func (m IngestResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1943,11 +3251,11 @@ func (m UpdateResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateResponseMultiError) AllErrors() []error { return m }
+func (m IngestResponseMultiError) AllErrors() []error { return m }
 
-// UpdateResponseValidationError is the validation error returned by
-// UpdateResponse.Validate if the designated constraints aren't met.
-type UpdateResponseValidationError struct {
+// IngestResponseValidationError is the validation error returned by
+// IngestResponse.Validate if the designated constraints aren't met.
+type IngestResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1955,22 +3263,22 @@ type UpdateResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateResponseValidationError) Field() string { return e.field }
+func (e IngestResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateResponseValidationError) Reason() string { return e.reason }
+func (e IngestResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateResponseValidationError) Cause() error { return e.cause }
+func (e IngestResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateResponseValidationError) Key() bool { return e.key }
+func (e IngestResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateResponseValidationError) ErrorName() string { return "UpdateResponseValidationError" }
+func (e IngestResponseValidationError) ErrorName() string { return "IngestResponseValidationError" }
 
 // Error satisfies the builtin error interface
-func (e UpdateResponseValidationError) Error() string {
+func (e IngestResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1982,14 +3290,14 @@ func (e UpdateResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateResponse.%s: %s%s",
+		"invalid %sIngestResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateResponseValidationError{}
+var _ error = IngestResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1997,4 +3305,4 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateResponseValidationError{}
+} = IngestResponseValidationError{}
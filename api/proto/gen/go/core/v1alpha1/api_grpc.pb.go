@@ -21,10 +21,13 @@ const _ = grpc.SupportPackageIsVersion7
 const (
 	EngineService_FeatureDescriptor_FullMethodName = "/core.v1alpha1.EngineService/FeatureDescriptor"
 	EngineService_Get_FullMethodName               = "/core.v1alpha1.EngineService/Get"
+	EngineService_BatchGet_FullMethodName          = "/core.v1alpha1.EngineService/BatchGet"
+	EngineService_Watch_FullMethodName             = "/core.v1alpha1.EngineService/Watch"
 	EngineService_Set_FullMethodName               = "/core.v1alpha1.EngineService/Set"
 	EngineService_Append_FullMethodName            = "/core.v1alpha1.EngineService/Append"
 	EngineService_Incr_FullMethodName              = "/core.v1alpha1.EngineService/Incr"
 	EngineService_Update_FullMethodName            = "/core.v1alpha1.EngineService/Update"
+	EngineService_Ingest_FullMethodName            = "/core.v1alpha1.EngineService/Ingest"
 )
 
 // EngineServiceClient is the client API for EngineService service.
@@ -35,6 +38,11 @@ type EngineServiceClient interface {
 	FeatureDescriptor(ctx context.Context, in *FeatureDescriptorRequest, opts ...grpc.CallOption) (*FeatureDescriptorResponse, error)
 	// Get returns the feature value or model prediction for the given selector.
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	// BatchGet returns the feature values or model predictions for multiple selectors and keys at once.
+	// Each result carries its own error, so a single failing item doesn't fail the whole batch.
+	BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error)
+	// Watch streams the feature values for the given selectors whenever they are updated.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (EngineService_WatchClient, error)
 	// Set sets the feature value for the given selector.
 	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
 	// Append appends the given value to the feature value for the given selector.
@@ -43,6 +51,8 @@ type EngineServiceClient interface {
 	Incr(ctx context.Context, in *IncrRequest, opts ...grpc.CallOption) (*IncrResponse, error)
 	// Update updates the feature value for the given selector.
 	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
+	// Ingest applies a stream of write operations, and returns a summary with the per-record failures.
+	Ingest(ctx context.Context, opts ...grpc.CallOption) (EngineService_IngestClient, error)
 }
 
 type engineServiceClient struct {
@@ -71,6 +81,47 @@ func (c *engineServiceClient) Get(ctx context.Context, in *GetRequest, opts ...g
 	return out, nil
 }
 
+func (c *engineServiceClient) BatchGet(ctx context.Context, in *BatchGetRequest, opts ...grpc.CallOption) (*BatchGetResponse, error) {
+	out := new(BatchGetResponse)
+	err := c.cc.Invoke(ctx, EngineService_BatchGet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *engineServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (EngineService_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EngineService_ServiceDesc.Streams[0], EngineService_Watch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &engineServiceWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EngineService_WatchClient interface {
+	Recv() (*WatchResponse, error)
+	grpc.ClientStream
+}
+
+type engineServiceWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *engineServiceWatchClient) Recv() (*WatchResponse, error) {
+	m := new(WatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *engineServiceClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error) {
 	out := new(SetResponse)
 	err := c.cc.Invoke(ctx, EngineService_Set_FullMethodName, in, out, opts...)
@@ -107,6 +158,40 @@ func (c *engineServiceClient) Update(ctx context.Context, in *UpdateRequest, opt
 	return out, nil
 }
 
+func (c *engineServiceClient) Ingest(ctx context.Context, opts ...grpc.CallOption) (EngineService_IngestClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EngineService_ServiceDesc.Streams[1], EngineService_Ingest_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &engineServiceIngestClient{stream}
+	return x, nil
+}
+
+type EngineService_IngestClient interface {
+	Send(*IngestRequest) error
+	CloseAndRecv() (*IngestResponse, error)
+	grpc.ClientStream
+}
+
+type engineServiceIngestClient struct {
+	grpc.ClientStream
+}
+
+func (x *engineServiceIngestClient) Send(m *IngestRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *engineServiceIngestClient) CloseAndRecv() (*IngestResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(IngestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // EngineServiceServer is the server API for EngineService service.
 // All implementations should embed UnimplementedEngineServiceServer
 // for forward compatibility
@@ -115,6 +200,11 @@ type EngineServiceServer interface {
 	FeatureDescriptor(context.Context, *FeatureDescriptorRequest) (*FeatureDescriptorResponse, error)
 	// Get returns the feature value or model prediction for the given selector.
 	Get(context.Context, *GetRequest) (*GetResponse, error)
+	// BatchGet returns the feature values or model predictions for multiple selectors and keys at once.
+	// Each result carries its own error, so a single failing item doesn't fail the whole batch.
+	BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error)
+	// Watch streams the feature values for the given selectors whenever they are updated.
+	Watch(*WatchRequest, EngineService_WatchServer) error
 	// Set sets the feature value for the given selector.
 	Set(context.Context, *SetRequest) (*SetResponse, error)
 	// Append appends the given value to the feature value for the given selector.
@@ -123,6 +213,8 @@ type EngineServiceServer interface {
 	Incr(context.Context, *IncrRequest) (*IncrResponse, error)
 	// Update updates the feature value for the given selector.
 	Update(context.Context, *UpdateRequest) (*UpdateResponse, error)
+	// Ingest applies a stream of write operations, and returns a summary with the per-record failures.
+	Ingest(EngineService_IngestServer) error
 }
 
 // UnimplementedEngineServiceServer should be embedded to have forward compatible implementations.
@@ -135,6 +227,12 @@ func (UnimplementedEngineServiceServer) FeatureDescriptor(context.Context, *Feat
 func (UnimplementedEngineServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedEngineServiceServer) BatchGet(context.Context, *BatchGetRequest) (*BatchGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGet not implemented")
+}
+func (UnimplementedEngineServiceServer) Watch(*WatchRequest, EngineService_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedEngineServiceServer) Set(context.Context, *SetRequest) (*SetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
 }
@@ -147,6 +245,9 @@ func (UnimplementedEngineServiceServer) Incr(context.Context, *IncrRequest) (*In
 func (UnimplementedEngineServiceServer) Update(context.Context, *UpdateRequest) (*UpdateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
+func (UnimplementedEngineServiceServer) Ingest(EngineService_IngestServer) error {
+	return status.Errorf(codes.Unimplemented, "method Ingest not implemented")
+}
 
 // UnsafeEngineServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to EngineServiceServer will
@@ -195,6 +296,45 @@ func _EngineService_Get_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EngineService_BatchGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EngineServiceServer).BatchGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EngineService_BatchGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EngineServiceServer).BatchGet(ctx, req.(*BatchGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EngineService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EngineServiceServer).Watch(m, &engineServiceWatchServer{stream})
+}
+
+type EngineService_WatchServer interface {
+	Send(*WatchResponse) error
+	grpc.ServerStream
+}
+
+type engineServiceWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *engineServiceWatchServer) Send(m *WatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _EngineService_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetRequest)
 	if err := dec(in); err != nil {
@@ -267,6 +407,32 @@ func _EngineService_Update_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EngineService_Ingest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(EngineServiceServer).Ingest(&engineServiceIngestServer{stream})
+}
+
+type EngineService_IngestServer interface {
+	SendAndClose(*IngestResponse) error
+	Recv() (*IngestRequest, error)
+	grpc.ServerStream
+}
+
+type engineServiceIngestServer struct {
+	grpc.ServerStream
+}
+
+func (x *engineServiceIngestServer) SendAndClose(m *IngestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *engineServiceIngestServer) Recv() (*IngestRequest, error) {
+	m := new(IngestRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // EngineService_ServiceDesc is the grpc.ServiceDesc for EngineService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -282,6 +448,10 @@ var EngineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _EngineService_Get_Handler,
 		},
+		{
+			MethodName: "BatchGet",
+			Handler:    _EngineService_BatchGet_Handler,
+		},
 		{
 			MethodName: "Set",
 			Handler:    _EngineService_Set_Handler,
@@ -299,6 +469,17 @@ var EngineService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _EngineService_Update_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _EngineService_Watch_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Ingest",
+			Handler:       _EngineService_Ingest_Handler,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "core/v1alpha1/api.proto",
 }
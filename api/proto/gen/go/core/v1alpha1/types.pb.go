@@ -38,6 +38,9 @@ const (
 	Primitive_PRIMITIVE_FLOAT_LIST     Primitive = 12
 	Primitive_PRIMITIVE_BOOL_LIST      Primitive = 13
 	Primitive_PRIMITIVE_TIMESTAMP_LIST Primitive = 14
+	// 15-19 Reserved for future use.
+	Primitive_PRIMITIVE_EMBEDDING Primitive = 20
+	Primitive_PRIMITIVE_STRUCT    Primitive = 21
 )
 
 // Enum value maps for Primitive.
@@ -54,6 +57,8 @@ var (
 		12: "PRIMITIVE_FLOAT_LIST",
 		13: "PRIMITIVE_BOOL_LIST",
 		14: "PRIMITIVE_TIMESTAMP_LIST",
+		20: "PRIMITIVE_EMBEDDING",
+		21: "PRIMITIVE_STRUCT",
 	}
 	Primitive_value = map[string]int32{
 		"PRIMITIVE_UNSPECIFIED":    0,
@@ -67,6 +72,8 @@ var (
 		"PRIMITIVE_FLOAT_LIST":     12,
 		"PRIMITIVE_BOOL_LIST":      13,
 		"PRIMITIVE_TIMESTAMP_LIST": 14,
+		"PRIMITIVE_EMBEDDING":      20,
+		"PRIMITIVE_STRUCT":         21,
 	}
 )
 
@@ -100,31 +107,55 @@ func (Primitive) EnumDescriptor() ([]byte, []int) {
 type AggrFn int32
 
 const (
-	AggrFn_AGGR_FN_UNSPECIFIED AggrFn = 0
-	AggrFn_AGGR_FN_SUM         AggrFn = 1
-	AggrFn_AGGR_FN_AVG         AggrFn = 2
-	AggrFn_AGGR_FN_MAX         AggrFn = 3
-	AggrFn_AGGR_FN_MIN         AggrFn = 4
-	AggrFn_AGGR_FN_COUNT       AggrFn = 5
+	AggrFn_AGGR_FN_UNSPECIFIED    AggrFn = 0
+	AggrFn_AGGR_FN_SUM            AggrFn = 1
+	AggrFn_AGGR_FN_AVG            AggrFn = 2
+	AggrFn_AGGR_FN_MAX            AggrFn = 3
+	AggrFn_AGGR_FN_MIN            AggrFn = 4
+	AggrFn_AGGR_FN_COUNT          AggrFn = 5
+	AggrFn_AGGR_FN_COUNT_DISTINCT AggrFn = 6
+	AggrFn_AGGR_FN_STDDEV         AggrFn = 7
+	AggrFn_AGGR_FN_VARIANCE       AggrFn = 8
+	AggrFn_AGGR_FN_FIRST          AggrFn = 9
+	AggrFn_AGGR_FN_LAST           AggrFn = 10
+	AggrFn_AGGR_FN_P50            AggrFn = 11
+	AggrFn_AGGR_FN_P95            AggrFn = 12
+	AggrFn_AGGR_FN_P99            AggrFn = 13
 )
 
 // Enum value maps for AggrFn.
 var (
 	AggrFn_name = map[int32]string{
-		0: "AGGR_FN_UNSPECIFIED",
-		1: "AGGR_FN_SUM",
-		2: "AGGR_FN_AVG",
-		3: "AGGR_FN_MAX",
-		4: "AGGR_FN_MIN",
-		5: "AGGR_FN_COUNT",
+		0:  "AGGR_FN_UNSPECIFIED",
+		1:  "AGGR_FN_SUM",
+		2:  "AGGR_FN_AVG",
+		3:  "AGGR_FN_MAX",
+		4:  "AGGR_FN_MIN",
+		5:  "AGGR_FN_COUNT",
+		6:  "AGGR_FN_COUNT_DISTINCT",
+		7:  "AGGR_FN_STDDEV",
+		8:  "AGGR_FN_VARIANCE",
+		9:  "AGGR_FN_FIRST",
+		10: "AGGR_FN_LAST",
+		11: "AGGR_FN_P50",
+		12: "AGGR_FN_P95",
+		13: "AGGR_FN_P99",
 	}
 	AggrFn_value = map[string]int32{
-		"AGGR_FN_UNSPECIFIED": 0,
-		"AGGR_FN_SUM":         1,
-		"AGGR_FN_AVG":         2,
-		"AGGR_FN_MAX":         3,
-		"AGGR_FN_MIN":         4,
-		"AGGR_FN_COUNT":       5,
+		"AGGR_FN_UNSPECIFIED":    0,
+		"AGGR_FN_SUM":            1,
+		"AGGR_FN_AVG":            2,
+		"AGGR_FN_MAX":            3,
+		"AGGR_FN_MIN":            4,
+		"AGGR_FN_COUNT":          5,
+		"AGGR_FN_COUNT_DISTINCT": 6,
+		"AGGR_FN_STDDEV":         7,
+		"AGGR_FN_VARIANCE":       8,
+		"AGGR_FN_FIRST":          9,
+		"AGGR_FN_LAST":           10,
+		"AGGR_FN_P50":            11,
+		"AGGR_FN_P95":            12,
+		"AGGR_FN_P99":            13,
 	}
 )
 
@@ -155,6 +186,112 @@ func (AggrFn) EnumDescriptor() ([]byte, []int) {
 	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{1}
 }
 
+// ErrorPolicy defines how a read of a feature is handled when the feature value failed to be calculated.
+type ErrorPolicy int32
+
+const (
+	ErrorPolicy_ERROR_POLICY_UNSPECIFIED ErrorPolicy = 0
+	ErrorPolicy_ERROR_POLICY_FAIL        ErrorPolicy = 1
+	ErrorPolicy_ERROR_POLICY_DEFAULT     ErrorPolicy = 2
+	ErrorPolicy_ERROR_POLICY_LAST_KNOWN  ErrorPolicy = 3
+)
+
+// Enum value maps for ErrorPolicy.
+var (
+	ErrorPolicy_name = map[int32]string{
+		0: "ERROR_POLICY_UNSPECIFIED",
+		1: "ERROR_POLICY_FAIL",
+		2: "ERROR_POLICY_DEFAULT",
+		3: "ERROR_POLICY_LAST_KNOWN",
+	}
+	ErrorPolicy_value = map[string]int32{
+		"ERROR_POLICY_UNSPECIFIED": 0,
+		"ERROR_POLICY_FAIL":        1,
+		"ERROR_POLICY_DEFAULT":     2,
+		"ERROR_POLICY_LAST_KNOWN":  3,
+	}
+)
+
+func (x ErrorPolicy) Enum() *ErrorPolicy {
+	p := new(ErrorPolicy)
+	*p = x
+	return p
+}
+
+func (x ErrorPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_v1alpha1_types_proto_enumTypes[2].Descriptor()
+}
+
+func (ErrorPolicy) Type() protoreflect.EnumType {
+	return &file_core_v1alpha1_types_proto_enumTypes[2]
+}
+
+func (x ErrorPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorPolicy.Descriptor instead.
+func (ErrorPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{2}
+}
+
+// Fallback is the kind of fallback that served a feature value instead of calculating it.
+type Fallback int32
+
+const (
+	// The value was calculated.
+	Fallback_FALLBACK_UNSPECIFIED Fallback = 0
+	// The value is the default value of the feature.
+	Fallback_FALLBACK_DEFAULT Fallback = 1
+	// The value is the last known value of the feature, that may be stale.
+	Fallback_FALLBACK_LAST_KNOWN Fallback = 2
+)
+
+// Enum value maps for Fallback.
+var (
+	Fallback_name = map[int32]string{
+		0: "FALLBACK_UNSPECIFIED",
+		1: "FALLBACK_DEFAULT",
+		2: "FALLBACK_LAST_KNOWN",
+	}
+	Fallback_value = map[string]int32{
+		"FALLBACK_UNSPECIFIED": 0,
+		"FALLBACK_DEFAULT":     1,
+		"FALLBACK_LAST_KNOWN":  2,
+	}
+)
+
+func (x Fallback) Enum() *Fallback {
+	p := new(Fallback)
+	*p = x
+	return p
+}
+
+func (x Fallback) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Fallback) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_v1alpha1_types_proto_enumTypes[3].Descriptor()
+}
+
+func (Fallback) Type() protoreflect.EnumType {
+	return &file_core_v1alpha1_types_proto_enumTypes[3]
+}
+
+func (x Fallback) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Fallback.Descriptor instead.
+func (Fallback) EnumDescriptor() ([]byte, []int) {
+	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{3}
+}
+
 type Scalar struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -325,6 +462,102 @@ func (x *List) GetValues() []*Scalar {
 	return nil
 }
 
+// Embedding is a dense vector of floats
+type Embedding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Values []float32 `protobuf:"fixed32,1,rep,packed,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *Embedding) Reset() {
+	*x = Embedding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_v1alpha1_types_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Embedding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Embedding) ProtoMessage() {}
+
+func (x *Embedding) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_types_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Embedding.ProtoReflect.Descriptor instead.
+func (*Embedding) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Embedding) GetValues() []float32 {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// Struct is a set of named fields, each holding a scalar or a list
+type Struct struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fields map[string]*Value `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Struct) Reset() {
+	*x = Struct{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_v1alpha1_types_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Struct) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Struct) ProtoMessage() {}
+
+func (x *Struct) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_types_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Struct.ProtoReflect.Descriptor instead.
+func (*Struct) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Struct) GetFields() map[string]*Value {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
 type Value struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -334,13 +567,15 @@ type Value struct {
 	//
 	//	*Value_ScalarValue
 	//	*Value_ListValue
+	//	*Value_EmbeddingValue
+	//	*Value_StructValue
 	Value isValue_Value `protobuf_oneof:"value"`
 }
 
 func (x *Value) Reset() {
 	*x = Value{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_core_v1alpha1_types_proto_msgTypes[2]
+		mi := &file_core_v1alpha1_types_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -353,7 +588,7 @@ func (x *Value) String() string {
 func (*Value) ProtoMessage() {}
 
 func (x *Value) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1alpha1_types_proto_msgTypes[2]
+	mi := &file_core_v1alpha1_types_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -366,7 +601,7 @@ func (x *Value) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Value.ProtoReflect.Descriptor instead.
 func (*Value) Descriptor() ([]byte, []int) {
-	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{2}
+	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{4}
 }
 
 func (m *Value) GetValue() isValue_Value {
@@ -390,6 +625,20 @@ func (x *Value) GetListValue() *List {
 	return nil
 }
 
+func (x *Value) GetEmbeddingValue() *Embedding {
+	if x, ok := x.GetValue().(*Value_EmbeddingValue); ok {
+		return x.EmbeddingValue
+	}
+	return nil
+}
+
+func (x *Value) GetStructValue() *Struct {
+	if x, ok := x.GetValue().(*Value_StructValue); ok {
+		return x.StructValue
+	}
+	return nil
+}
+
 type isValue_Value interface {
 	isValue_Value()
 }
@@ -402,10 +651,22 @@ type Value_ListValue struct {
 	ListValue *List `protobuf:"bytes,2,opt,name=list_value,json=listValue,proto3,oneof"`
 }
 
+type Value_EmbeddingValue struct {
+	EmbeddingValue *Embedding `protobuf:"bytes,3,opt,name=embedding_value,json=embeddingValue,proto3,oneof"`
+}
+
+type Value_StructValue struct {
+	StructValue *Struct `protobuf:"bytes,4,opt,name=struct_value,json=structValue,proto3,oneof"`
+}
+
 func (*Value_ScalarValue) isValue_Value() {}
 
 func (*Value_ListValue) isValue_Value() {}
 
+func (*Value_EmbeddingValue) isValue_Value() {}
+
+func (*Value_StructValue) isValue_Value() {}
+
 type ObjectReference struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -418,7 +679,7 @@ type ObjectReference struct {
 func (x *ObjectReference) Reset() {
 	*x = ObjectReference{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_core_v1alpha1_types_proto_msgTypes[3]
+		mi := &file_core_v1alpha1_types_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -431,7 +692,7 @@ func (x *ObjectReference) String() string {
 func (*ObjectReference) ProtoMessage() {}
 
 func (x *ObjectReference) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1alpha1_types_proto_msgTypes[3]
+	mi := &file_core_v1alpha1_types_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -444,7 +705,7 @@ func (x *ObjectReference) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ObjectReference.ProtoReflect.Descriptor instead.
 func (*ObjectReference) Descriptor() ([]byte, []int) {
-	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{3}
+	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ObjectReference) GetName() string {
@@ -473,7 +734,7 @@ type KeepPrevious struct {
 func (x *KeepPrevious) Reset() {
 	*x = KeepPrevious{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_core_v1alpha1_types_proto_msgTypes[4]
+		mi := &file_core_v1alpha1_types_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -486,7 +747,7 @@ func (x *KeepPrevious) String() string {
 func (*KeepPrevious) ProtoMessage() {}
 
 func (x *KeepPrevious) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1alpha1_types_proto_msgTypes[4]
+	mi := &file_core_v1alpha1_types_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -499,7 +760,7 @@ func (x *KeepPrevious) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KeepPrevious.ProtoReflect.Descriptor instead.
 func (*KeepPrevious) Descriptor() ([]byte, []int) {
-	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{4}
+	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *KeepPrevious) GetVersions() uint32 {
@@ -516,6 +777,61 @@ func (x *KeepPrevious) GetOver() *durationpb.Duration {
 	return nil
 }
 
+type StructField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Primitive Primitive `protobuf:"varint,2,opt,name=primitive,proto3,enum=core.v1alpha1.Primitive" json:"primitive,omitempty"`
+}
+
+func (x *StructField) Reset() {
+	*x = StructField{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_v1alpha1_types_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StructField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StructField) ProtoMessage() {}
+
+func (x *StructField) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_types_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StructField.ProtoReflect.Descriptor instead.
+func (*StructField) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StructField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StructField) GetPrimitive() Primitive {
+	if x != nil {
+		return x.Primitive
+	}
+	return Primitive_PRIMITIVE_UNSPECIFIED
+}
+
 type FeatureDescriptor struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -532,12 +848,17 @@ type FeatureDescriptor struct {
 	Builder      string               `protobuf:"bytes,15,opt,name=builder,proto3" json:"builder,omitempty"`
 	DataSource   string               `protobuf:"bytes,16,opt,name=data_source,json=dataSource,proto3" json:"data_source,omitempty"`
 	RuntimeEnv   string               `protobuf:"bytes,17,opt,name=runtime_env,json=runtimeEnv,proto3" json:"runtime_env,omitempty"`
+	EmbeddingDim uint32               `protobuf:"varint,18,opt,name=embedding_dim,json=embeddingDim,proto3" json:"embedding_dim,omitempty"`
+	Fields       []*StructField       `protobuf:"bytes,19,rep,name=fields,proto3" json:"fields,omitempty"`
+	// The default value of the feature, that is served when the feature has no value.
+	DefaultValue *Value      `protobuf:"bytes,20,opt,name=default_value,json=defaultValue,proto3,oneof" json:"default_value,omitempty"`
+	OnError      ErrorPolicy `protobuf:"varint,21,opt,name=on_error,json=onError,proto3,enum=core.v1alpha1.ErrorPolicy" json:"on_error,omitempty"`
 }
 
 func (x *FeatureDescriptor) Reset() {
 	*x = FeatureDescriptor{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_core_v1alpha1_types_proto_msgTypes[5]
+		mi := &file_core_v1alpha1_types_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -550,7 +871,7 @@ func (x *FeatureDescriptor) String() string {
 func (*FeatureDescriptor) ProtoMessage() {}
 
 func (x *FeatureDescriptor) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1alpha1_types_proto_msgTypes[5]
+	mi := &file_core_v1alpha1_types_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -563,7 +884,7 @@ func (x *FeatureDescriptor) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FeatureDescriptor.ProtoReflect.Descriptor instead.
 func (*FeatureDescriptor) Descriptor() ([]byte, []int) {
-	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{5}
+	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *FeatureDescriptor) GetFqn() string {
@@ -643,6 +964,34 @@ func (x *FeatureDescriptor) GetRuntimeEnv() string {
 	return ""
 }
 
+func (x *FeatureDescriptor) GetEmbeddingDim() uint32 {
+	if x != nil {
+		return x.EmbeddingDim
+	}
+	return 0
+}
+
+func (x *FeatureDescriptor) GetFields() []*StructField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *FeatureDescriptor) GetDefaultValue() *Value {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return nil
+}
+
+func (x *FeatureDescriptor) GetOnError() ErrorPolicy {
+	if x != nil {
+		return x.OnError
+	}
+	return ErrorPolicy_ERROR_POLICY_UNSPECIFIED
+}
+
 type FeatureValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -653,12 +1002,14 @@ type FeatureValue struct {
 	Value     *Value                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
 	Timestamp *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Fresh     bool                   `protobuf:"varint,5,opt,name=fresh,proto3" json:"fresh,omitempty"`
+	// Degraded lists the inputs that were missing, and handled by a failure policy, while calculating the value.
+	Degraded []*DegradedInput `protobuf:"bytes,6,rep,name=degraded,proto3" json:"degraded,omitempty"`
 }
 
 func (x *FeatureValue) Reset() {
 	*x = FeatureValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_core_v1alpha1_types_proto_msgTypes[6]
+		mi := &file_core_v1alpha1_types_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -671,7 +1022,7 @@ func (x *FeatureValue) String() string {
 func (*FeatureValue) ProtoMessage() {}
 
 func (x *FeatureValue) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1alpha1_types_proto_msgTypes[6]
+	mi := &file_core_v1alpha1_types_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -684,7 +1035,7 @@ func (x *FeatureValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FeatureValue.ProtoReflect.Descriptor instead.
 func (*FeatureValue) Descriptor() ([]byte, []int) {
-	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{6}
+	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *FeatureValue) GetFqn() string {
@@ -722,6 +1073,77 @@ func (x *FeatureValue) GetFresh() bool {
 	return false
 }
 
+func (x *FeatureValue) GetDegraded() []*DegradedInput {
+	if x != nil {
+		return x.Degraded
+	}
+	return nil
+}
+
+// DegradedInput is an input of a value that was missing, and handled by a failure policy.
+type DegradedInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Selector string `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	Policy   string `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	Error    string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *DegradedInput) Reset() {
+	*x = DegradedInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_core_v1alpha1_types_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DegradedInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DegradedInput) ProtoMessage() {}
+
+func (x *DegradedInput) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1alpha1_types_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DegradedInput.ProtoReflect.Descriptor instead.
+func (*DegradedInput) Descriptor() ([]byte, []int) {
+	return file_core_v1alpha1_types_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DegradedInput) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *DegradedInput) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *DegradedInput) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 var File_core_v1alpha1_types_proto protoreflect.FileDescriptor
 
 var file_core_v1alpha1_types_proto_rawDesc = []byte{
@@ -750,120 +1172,197 @@ var file_core_v1alpha1_types_proto_rawDesc = []byte{
 	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x35, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12,
 	0x2d, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
 	0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
-	0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x82,
-	0x01, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3a, 0x0a, 0x0c, 0x73, 0x63, 0x61, 0x6c,
+	0x53, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x27,
+	0x0a, 0x09, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x02, 0x42, 0x02, 0x10, 0x01, 0x52,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x94, 0x01, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x12, 0x39, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0x4f, 0x0a,
+	0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x83,
+	0x02, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3a, 0x0a, 0x0c, 0x73, 0x63, 0x61, 0x6c,
 	0x61, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
 	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53,
 	0x63, 0x61, 0x6c, 0x61, 0x72, 0x48, 0x00, 0x52, 0x0b, 0x73, 0x63, 0x61, 0x6c, 0x61, 0x72, 0x56,
 	0x61, 0x6c, 0x75, 0x65, 0x12, 0x34, 0x0a, 0x0a, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x76, 0x61, 0x6c,
 	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
 	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52,
-	0x09, 0x6c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x22, 0x43, 0x0a, 0x0f, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61,
-	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e,
-	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x59, 0x0a, 0x0c, 0x4b, 0x65, 0x65, 0x70,
-	0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04, 0x6f,
-	0x76, 0x65, 0x72, 0x22, 0xc7, 0x04, 0x0a, 0x11, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x44,
-	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12, 0x3e, 0x0a, 0x03, 0x66, 0x71, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2c, 0xfa, 0x42, 0x29, 0x72, 0x27, 0x32, 0x25, 0x28,
-	0x69, 0x3f, 0x29, 0x5e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5c, 0x2d, 0x5c, 0x2e, 0x5d,
-	0x2a, 0x29, 0x28, 0x5c, 0x5b, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39, 0x5d, 0x29, 0x2a, 0x5c,
-	0x5d, 0x29, 0x3f, 0x24, 0x52, 0x03, 0x66, 0x71, 0x6e, 0x12, 0x40, 0x0a, 0x09, 0x70, 0x72, 0x69,
-	0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x63,
-	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x69,
-	0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01,
-	0x52, 0x09, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x3a, 0x0a, 0x04, 0x61,
-	0x67, 0x67, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65,
-	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x46, 0x6e,
-	0x42, 0x0f, 0xfa, 0x42, 0x0c, 0x92, 0x01, 0x09, 0x18, 0x01, 0x22, 0x05, 0x82, 0x01, 0x02, 0x10,
-	0x01, 0x52, 0x04, 0x61, 0x67, 0x67, 0x72, 0x12, 0x37, 0x0a, 0x09, 0x66, 0x72, 0x65, 0x73, 0x68,
-	0x6e, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x66, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73,
-	0x12, 0x37, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20,
+	0x09, 0x6c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x43, 0x0a, 0x0f, 0x65, 0x6d,
+	0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x2e, 0x45, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x48, 0x00, 0x52,
+	0x0e, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x3a, 0x0a, 0x0c, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x48, 0x00, 0x52, 0x0b,
+	0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0x43, 0x0a, 0x0f, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x22, 0x59, 0x0a, 0x0c, 0x4b, 0x65, 0x65,
+	0x70, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x04, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09,
-	0x73, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d,
-	0x65, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x45,
-	0x0a, 0x0d, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4b, 0x65, 0x65, 0x70, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f,
-	0x75, 0x73, 0x48, 0x00, 0x52, 0x0c, 0x6b, 0x65, 0x65, 0x70, 0x50, 0x72, 0x65, 0x76, 0x69, 0x6f,
-	0x75, 0x73, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x08, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x69,
-	0x6c, 0x64, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x62, 0x75, 0x69, 0x6c,
-	0x64, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f,
-	0x65, 0x6e, 0x76, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x75, 0x6e, 0x74, 0x69,
-	0x6d, 0x65, 0x45, 0x6e, 0x76, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x70,
-	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x4a, 0x04, 0x08, 0x09, 0x10, 0x0f, 0x22, 0xbe, 0x02,
-	0x0a, 0x0c, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3e,
-	0x0a, 0x03, 0x66, 0x71, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2c, 0xfa, 0x42, 0x29,
-	0x72, 0x27, 0x32, 0x25, 0x28, 0x69, 0x3f, 0x29, 0x5e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a, 0x39,
-	0x5c, 0x2d, 0x5c, 0x2e, 0x5d, 0x2a, 0x29, 0x28, 0x5c, 0x5b, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a,
-	0x39, 0x5d, 0x29, 0x2a, 0x5c, 0x5d, 0x29, 0x3f, 0x24, 0x52, 0x03, 0x66, 0x71, 0x6e, 0x12, 0x39,
-	0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x63,
-	0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x46, 0x65, 0x61,
-	0x74, 0x75, 0x72, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12,
-	0x14, 0x0a, 0x05, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
-	0x66, 0x72, 0x65, 0x73, 0x68, 0x1a, 0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x9d,
-	0x02, 0x0a, 0x09, 0x50, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x19, 0x0a, 0x15,
-	0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
-	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x52, 0x49, 0x4d, 0x49,
-	0x54, 0x49, 0x56, 0x45, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x15, 0x0a,
-	0x11, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x47,
-	0x45, 0x52, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56,
-	0x45, 0x5f, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x49,
-	0x4d, 0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x04, 0x12, 0x17, 0x0a,
-	0x13, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x53,
-	0x54, 0x41, 0x4d, 0x50, 0x10, 0x05, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54,
-	0x49, 0x56, 0x45, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10,
-	0x0a, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x49,
-	0x4e, 0x54, 0x45, 0x47, 0x45, 0x52, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x0b, 0x12, 0x18, 0x0a,
-	0x14, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x46, 0x4c, 0x4f, 0x41, 0x54,
-	0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x0c, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x52, 0x49, 0x4d, 0x49,
-	0x54, 0x49, 0x56, 0x45, 0x5f, 0x42, 0x4f, 0x4f, 0x4c, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x0d,
-	0x12, 0x1c, 0x0a, 0x18, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x54, 0x49,
-	0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x0e, 0x2a, 0x78,
-	0x0a, 0x06, 0x41, 0x67, 0x67, 0x72, 0x46, 0x6e, 0x12, 0x17, 0x0a, 0x13, 0x41, 0x47, 0x47, 0x52,
-	0x5f, 0x46, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
-	0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x53, 0x55, 0x4d,
-	0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x41, 0x56,
-	0x47, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x4d,
-	0x41, 0x58, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f,
-	0x4d, 0x49, 0x4e, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e,
-	0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x05, 0x42, 0xbd, 0x01, 0x0a, 0x11, 0x63, 0x6f, 0x6d,
-	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x42, 0x0a,
-	0x54, 0x79, 0x70, 0x65, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x47, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x2d,
-	0x6d, 0x6c, 0x2f, 0x72, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f,
-	0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x3b, 0x63, 0x6f, 0x72, 0x65, 0x76, 0x31, 0x61,
-	0x6c, 0x70, 0x68, 0x61, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x58, 0x58, 0xaa, 0x02, 0x0d, 0x43, 0x6f,
-	0x72, 0x65, 0x2e, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xca, 0x02, 0x0d, 0x43, 0x6f,
-	0x72, 0x65, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xe2, 0x02, 0x19, 0x43, 0x6f,
-	0x72, 0x65, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0e, 0x43, 0x6f, 0x72, 0x65, 0x3a, 0x3a,
-	0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04,
+	0x6f, 0x76, 0x65, 0x72, 0x22, 0x63, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x70, 0x72, 0x69, 0x6d, 0x69,
+	0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x6d, 0x69,
+	0x74, 0x69, 0x76, 0x65, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x09,
+	0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x22, 0xb3, 0x06, 0x0a, 0x11, 0x46, 0x65,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x12,
+	0x3e, 0x0a, 0x03, 0x66, 0x71, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2c, 0xfa, 0x42,
+	0x29, 0x72, 0x27, 0x32, 0x25, 0x28, 0x69, 0x3f, 0x29, 0x5e, 0x28, 0x5b, 0x61, 0x30, 0x2d, 0x7a,
+	0x39, 0x5c, 0x2d, 0x5c, 0x2e, 0x5d, 0x2a, 0x29, 0x28, 0x5c, 0x5b, 0x28, 0x5b, 0x61, 0x30, 0x2d,
+	0x7a, 0x39, 0x5d, 0x29, 0x2a, 0x5c, 0x5d, 0x29, 0x3f, 0x24, 0x52, 0x03, 0x66, 0x71, 0x6e, 0x12,
+	0x40, 0x0a, 0x09, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68,
+	0x61, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76, 0x65, 0x42, 0x08, 0xfa, 0x42,
+	0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x09, 0x70, 0x72, 0x69, 0x6d, 0x69, 0x74, 0x69, 0x76,
+	0x65, 0x12, 0x3a, 0x0a, 0x04, 0x61, 0x67, 0x67, 0x72, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32,
+	0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e,
+	0x41, 0x67, 0x67, 0x72, 0x46, 0x6e, 0x42, 0x0f, 0xfa, 0x42, 0x0c, 0x92, 0x01, 0x09, 0x18, 0x01,
+	0x22, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x04, 0x61, 0x67, 0x67, 0x72, 0x12, 0x37, 0x0a,
+	0x09, 0x66, 0x72, 0x65, 0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x66, 0x72, 0x65,
+	0x73, 0x68, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x37, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x6e,
+	0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x12,
+	0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x12, 0x45, 0x0a, 0x0d, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x70, 0x72, 0x65,
+	0x76, 0x69, 0x6f, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x4b, 0x65, 0x65, 0x70,
+	0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x48, 0x00, 0x52, 0x0c, 0x6b, 0x65, 0x65, 0x70,
+	0x50, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x88, 0x01, 0x01, 0x12, 0x12, 0x0a, 0x04, 0x6b,
+	0x65, 0x79, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x61, 0x74,
+	0x61, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x64, 0x61, 0x74, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x75,
+	0x6e, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x65, 0x6e, 0x76, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x45, 0x6e, 0x76, 0x12, 0x23, 0x0a, 0x0d, 0x65,
+	0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x64, 0x69, 0x6d, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0c, 0x65, 0x6d, 0x62, 0x65, 0x64, 0x64, 0x69, 0x6e, 0x67, 0x44, 0x69, 0x6d,
+	0x12, 0x32, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x66, 0x69,
+	0x65, 0x6c, 0x64, 0x73, 0x12, 0x3e, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f,
+	0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x48, 0x01, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x3f, 0x0a, 0x08, 0x6f, 0x6e, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x15, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31,
+	0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x82, 0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x6f, 0x6e,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6b, 0x65, 0x65, 0x70, 0x5f, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x4a, 0x04, 0x08, 0x09, 0x10, 0x0f, 0x22,
+	0xf8, 0x02, 0x0a, 0x0c, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x3e, 0x0a, 0x03, 0x66, 0x71, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x2c, 0xfa,
+	0x42, 0x29, 0x72, 0x27, 0x32, 0x25, 0x28, 0x69, 0x3f, 0x29, 0x5e, 0x28, 0x5b, 0x61, 0x30, 0x2d,
+	0x7a, 0x39, 0x5c, 0x2d, 0x5c, 0x2e, 0x5d, 0x2a, 0x29, 0x28, 0x5c, 0x5b, 0x28, 0x5b, 0x61, 0x30,
+	0x2d, 0x7a, 0x39, 0x5d, 0x29, 0x2a, 0x5c, 0x5d, 0x29, 0x3f, 0x24, 0x52, 0x03, 0x66, 0x71, 0x6e,
+	0x12, 0x39, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x25,
+	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x46,
+	0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x2e, 0x4b, 0x65, 0x79, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x05, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12, 0x38, 0x0a, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61,
+	0x64, 0x65, 0x64, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x6f, 0x72, 0x65,
+	0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x2e, 0x44, 0x65, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x08, 0x64, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65,
+	0x64, 0x1a, 0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x59, 0x0a, 0x0d, 0x44, 0x65,
+	0x67, 0x72, 0x61, 0x64, 0x65, 0x64, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x2a, 0xcc, 0x02, 0x0a, 0x09, 0x50, 0x72, 0x69, 0x6d, 0x69, 0x74,
+	0x69, 0x76, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14,
+	0x0a, 0x10, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x53, 0x54, 0x52, 0x49,
+	0x4e, 0x47, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56,
+	0x45, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x47, 0x45, 0x52, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x50,
+	0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x10, 0x03,
+	0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x42, 0x4f,
+	0x4f, 0x4c, 0x10, 0x04, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56,
+	0x45, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x10, 0x05, 0x12, 0x19, 0x0a,
+	0x15, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x4e,
+	0x47, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x0a, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x52, 0x49, 0x4d,
+	0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x47, 0x45, 0x52, 0x5f, 0x4c, 0x49,
+	0x53, 0x54, 0x10, 0x0b, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56,
+	0x45, 0x5f, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x0c, 0x12, 0x17,
+	0x0a, 0x13, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x42, 0x4f, 0x4f, 0x4c,
+	0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x0d, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x52, 0x49, 0x4d, 0x49,
+	0x54, 0x49, 0x56, 0x45, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x53, 0x54, 0x41, 0x4d, 0x50, 0x5f, 0x4c,
+	0x49, 0x53, 0x54, 0x10, 0x0e, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49,
+	0x56, 0x45, 0x5f, 0x45, 0x4d, 0x42, 0x45, 0x44, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x14, 0x12, 0x14,
+	0x0a, 0x10, 0x50, 0x52, 0x49, 0x4d, 0x49, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x53, 0x54, 0x52, 0x55,
+	0x43, 0x54, 0x10, 0x15, 0x2a, 0x96, 0x02, 0x0a, 0x06, 0x41, 0x67, 0x67, 0x72, 0x46, 0x6e, 0x12,
+	0x17, 0x0a, 0x13, 0x41, 0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x47, 0x47, 0x52,
+	0x5f, 0x46, 0x4e, 0x5f, 0x53, 0x55, 0x4d, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x47, 0x47,
+	0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x41, 0x56, 0x47, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x47,
+	0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x4d, 0x41, 0x58, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x41,
+	0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x4d, 0x49, 0x4e, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d,
+	0x41, 0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x10, 0x05, 0x12,
+	0x1a, 0x0a, 0x16, 0x41, 0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x43, 0x4f, 0x55, 0x4e, 0x54,
+	0x5f, 0x44, 0x49, 0x53, 0x54, 0x49, 0x4e, 0x43, 0x54, 0x10, 0x06, 0x12, 0x12, 0x0a, 0x0e, 0x41,
+	0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x53, 0x54, 0x44, 0x44, 0x45, 0x56, 0x10, 0x07, 0x12,
+	0x14, 0x0a, 0x10, 0x41, 0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x56, 0x41, 0x52, 0x49, 0x41,
+	0x4e, 0x43, 0x45, 0x10, 0x08, 0x12, 0x11, 0x0a, 0x0d, 0x41, 0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e,
+	0x5f, 0x46, 0x49, 0x52, 0x53, 0x54, 0x10, 0x09, 0x12, 0x10, 0x0a, 0x0c, 0x41, 0x47, 0x47, 0x52,
+	0x5f, 0x46, 0x4e, 0x5f, 0x4c, 0x41, 0x53, 0x54, 0x10, 0x0a, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x47,
+	0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x50, 0x35, 0x30, 0x10, 0x0b, 0x12, 0x0f, 0x0a, 0x0b, 0x41,
+	0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x50, 0x39, 0x35, 0x10, 0x0c, 0x12, 0x0f, 0x0a, 0x0b,
+	0x41, 0x47, 0x47, 0x52, 0x5f, 0x46, 0x4e, 0x5f, 0x50, 0x39, 0x39, 0x10, 0x0d, 0x2a, 0x79, 0x0a,
+	0x0b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1c, 0x0a, 0x18,
+	0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x52,
+	0x52, 0x4f, 0x52, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x10,
+	0x01, 0x12, 0x18, 0x0a, 0x14, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43,
+	0x59, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x5f, 0x4c, 0x41, 0x53, 0x54,
+	0x5f, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x03, 0x2a, 0x53, 0x0a, 0x08, 0x46, 0x61, 0x6c, 0x6c,
+	0x62, 0x61, 0x63, 0x6b, 0x12, 0x18, 0x0a, 0x14, 0x46, 0x41, 0x4c, 0x4c, 0x42, 0x41, 0x43, 0x4b,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14,
+	0x0a, 0x10, 0x46, 0x41, 0x4c, 0x4c, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x44, 0x45, 0x46, 0x41, 0x55,
+	0x4c, 0x54, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x46, 0x41, 0x4c, 0x4c, 0x42, 0x41, 0x43, 0x4b,
+	0x5f, 0x4c, 0x41, 0x53, 0x54, 0x5f, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x02, 0x42, 0xbd, 0x01,
+	0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x76, 0x31, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x31, 0x42, 0x0a, 0x54, 0x79, 0x70, 0x65, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50,
+	0x01, 0x5a, 0x47, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x72, 0x61,
+	0x70, 0x74, 0x6f, 0x72, 0x2d, 0x6d, 0x6c, 0x2f, 0x72, 0x61, 0x70, 0x74, 0x6f, 0x72, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x2f,
+	0x63, 0x6f, 0x72, 0x65, 0x2f, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x3b, 0x63, 0x6f,
+	0x72, 0x65, 0x76, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0xa2, 0x02, 0x03, 0x43, 0x58, 0x58,
+	0xaa, 0x02, 0x0d, 0x43, 0x6f, 0x72, 0x65, 0x2e, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0xca, 0x02, 0x0d, 0x43, 0x6f, 0x72, 0x65, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0xe2, 0x02, 0x19, 0x43, 0x6f, 0x72, 0x65, 0x5c, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31,
+	0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0e, 0x43,
+	0x6f, 0x72, 0x65, 0x3a, 0x3a, 0x56, 0x31, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -878,42 +1377,58 @@ func file_core_v1alpha1_types_proto_rawDescGZIP() []byte {
 	return file_core_v1alpha1_types_proto_rawDescData
 }
 
-var file_core_v1alpha1_types_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_core_v1alpha1_types_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_core_v1alpha1_types_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_core_v1alpha1_types_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_core_v1alpha1_types_proto_goTypes = []interface{}{
 	(Primitive)(0),                // 0: core.v1alpha1.Primitive
 	(AggrFn)(0),                   // 1: core.v1alpha1.AggrFn
-	(*Scalar)(nil),                // 2: core.v1alpha1.Scalar
-	(*List)(nil),                  // 3: core.v1alpha1.List
-	(*Value)(nil),                 // 4: core.v1alpha1.Value
-	(*ObjectReference)(nil),       // 5: core.v1alpha1.ObjectReference
-	(*KeepPrevious)(nil),          // 6: core.v1alpha1.KeepPrevious
-	(*FeatureDescriptor)(nil),     // 7: core.v1alpha1.FeatureDescriptor
-	(*FeatureValue)(nil),          // 8: core.v1alpha1.FeatureValue
-	nil,                           // 9: core.v1alpha1.FeatureValue.KeysEntry
-	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
-	(*durationpb.Duration)(nil),   // 11: google.protobuf.Duration
+	(ErrorPolicy)(0),              // 2: core.v1alpha1.ErrorPolicy
+	(Fallback)(0),                 // 3: core.v1alpha1.Fallback
+	(*Scalar)(nil),                // 4: core.v1alpha1.Scalar
+	(*List)(nil),                  // 5: core.v1alpha1.List
+	(*Embedding)(nil),             // 6: core.v1alpha1.Embedding
+	(*Struct)(nil),                // 7: core.v1alpha1.Struct
+	(*Value)(nil),                 // 8: core.v1alpha1.Value
+	(*ObjectReference)(nil),       // 9: core.v1alpha1.ObjectReference
+	(*KeepPrevious)(nil),          // 10: core.v1alpha1.KeepPrevious
+	(*StructField)(nil),           // 11: core.v1alpha1.StructField
+	(*FeatureDescriptor)(nil),     // 12: core.v1alpha1.FeatureDescriptor
+	(*FeatureValue)(nil),          // 13: core.v1alpha1.FeatureValue
+	(*DegradedInput)(nil),         // 14: core.v1alpha1.DegradedInput
+	nil,                           // 15: core.v1alpha1.Struct.FieldsEntry
+	nil,                           // 16: core.v1alpha1.FeatureValue.KeysEntry
+	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 18: google.protobuf.Duration
 }
 var file_core_v1alpha1_types_proto_depIdxs = []int32{
-	10, // 0: core.v1alpha1.Scalar.timestamp_value:type_name -> google.protobuf.Timestamp
-	2,  // 1: core.v1alpha1.List.values:type_name -> core.v1alpha1.Scalar
-	2,  // 2: core.v1alpha1.Value.scalar_value:type_name -> core.v1alpha1.Scalar
-	3,  // 3: core.v1alpha1.Value.list_value:type_name -> core.v1alpha1.List
-	11, // 4: core.v1alpha1.KeepPrevious.over:type_name -> google.protobuf.Duration
-	0,  // 5: core.v1alpha1.FeatureDescriptor.primitive:type_name -> core.v1alpha1.Primitive
-	1,  // 6: core.v1alpha1.FeatureDescriptor.aggr:type_name -> core.v1alpha1.AggrFn
-	11, // 7: core.v1alpha1.FeatureDescriptor.freshness:type_name -> google.protobuf.Duration
-	11, // 8: core.v1alpha1.FeatureDescriptor.staleness:type_name -> google.protobuf.Duration
-	11, // 9: core.v1alpha1.FeatureDescriptor.timeout:type_name -> google.protobuf.Duration
-	6,  // 10: core.v1alpha1.FeatureDescriptor.keep_previous:type_name -> core.v1alpha1.KeepPrevious
-	9,  // 11: core.v1alpha1.FeatureValue.keys:type_name -> core.v1alpha1.FeatureValue.KeysEntry
-	4,  // 12: core.v1alpha1.FeatureValue.value:type_name -> core.v1alpha1.Value
-	10, // 13: core.v1alpha1.FeatureValue.timestamp:type_name -> google.protobuf.Timestamp
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	17, // 0: core.v1alpha1.Scalar.timestamp_value:type_name -> google.protobuf.Timestamp
+	4,  // 1: core.v1alpha1.List.values:type_name -> core.v1alpha1.Scalar
+	15, // 2: core.v1alpha1.Struct.fields:type_name -> core.v1alpha1.Struct.FieldsEntry
+	4,  // 3: core.v1alpha1.Value.scalar_value:type_name -> core.v1alpha1.Scalar
+	5,  // 4: core.v1alpha1.Value.list_value:type_name -> core.v1alpha1.List
+	6,  // 5: core.v1alpha1.Value.embedding_value:type_name -> core.v1alpha1.Embedding
+	7,  // 6: core.v1alpha1.Value.struct_value:type_name -> core.v1alpha1.Struct
+	18, // 7: core.v1alpha1.KeepPrevious.over:type_name -> google.protobuf.Duration
+	0,  // 8: core.v1alpha1.StructField.primitive:type_name -> core.v1alpha1.Primitive
+	0,  // 9: core.v1alpha1.FeatureDescriptor.primitive:type_name -> core.v1alpha1.Primitive
+	1,  // 10: core.v1alpha1.FeatureDescriptor.aggr:type_name -> core.v1alpha1.AggrFn
+	18, // 11: core.v1alpha1.FeatureDescriptor.freshness:type_name -> google.protobuf.Duration
+	18, // 12: core.v1alpha1.FeatureDescriptor.staleness:type_name -> google.protobuf.Duration
+	18, // 13: core.v1alpha1.FeatureDescriptor.timeout:type_name -> google.protobuf.Duration
+	10, // 14: core.v1alpha1.FeatureDescriptor.keep_previous:type_name -> core.v1alpha1.KeepPrevious
+	11, // 15: core.v1alpha1.FeatureDescriptor.fields:type_name -> core.v1alpha1.StructField
+	8,  // 16: core.v1alpha1.FeatureDescriptor.default_value:type_name -> core.v1alpha1.Value
+	2,  // 17: core.v1alpha1.FeatureDescriptor.on_error:type_name -> core.v1alpha1.ErrorPolicy
+	16, // 18: core.v1alpha1.FeatureValue.keys:type_name -> core.v1alpha1.FeatureValue.KeysEntry
+	8,  // 19: core.v1alpha1.FeatureValue.value:type_name -> core.v1alpha1.Value
+	17, // 20: core.v1alpha1.FeatureValue.timestamp:type_name -> google.protobuf.Timestamp
+	14, // 21: core.v1alpha1.FeatureValue.degraded:type_name -> core.v1alpha1.DegradedInput
+	8,  // 22: core.v1alpha1.Struct.FieldsEntry.value:type_name -> core.v1alpha1.Value
+	23, // [23:23] is the sub-list for method output_type
+	23, // [23:23] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_core_v1alpha1_types_proto_init() }
@@ -947,7 +1462,7 @@ func file_core_v1alpha1_types_proto_init() {
 			}
 		}
 		file_core_v1alpha1_types_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Value); i {
+			switch v := v.(*Embedding); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -959,7 +1474,7 @@ func file_core_v1alpha1_types_proto_init() {
 			}
 		}
 		file_core_v1alpha1_types_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ObjectReference); i {
+			switch v := v.(*Struct); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -971,7 +1486,7 @@ func file_core_v1alpha1_types_proto_init() {
 			}
 		}
 		file_core_v1alpha1_types_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*KeepPrevious); i {
+			switch v := v.(*Value); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -983,7 +1498,7 @@ func file_core_v1alpha1_types_proto_init() {
 			}
 		}
 		file_core_v1alpha1_types_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FeatureDescriptor); i {
+			switch v := v.(*ObjectReference); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -995,6 +1510,42 @@ func file_core_v1alpha1_types_proto_init() {
 			}
 		}
 		file_core_v1alpha1_types_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeepPrevious); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_v1alpha1_types_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StructField); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_v1alpha1_types_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FeatureDescriptor); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_core_v1alpha1_types_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*FeatureValue); i {
 			case 0:
 				return &v.state
@@ -1006,6 +1557,18 @@ func file_core_v1alpha1_types_proto_init() {
 				return nil
 			}
 		}
+		file_core_v1alpha1_types_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DegradedInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_core_v1alpha1_types_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*Scalar_StringValue)(nil),
@@ -1014,18 +1577,20 @@ func file_core_v1alpha1_types_proto_init() {
 		(*Scalar_BoolValue)(nil),
 		(*Scalar_TimestampValue)(nil),
 	}
-	file_core_v1alpha1_types_proto_msgTypes[2].OneofWrappers = []interface{}{
+	file_core_v1alpha1_types_proto_msgTypes[4].OneofWrappers = []interface{}{
 		(*Value_ScalarValue)(nil),
 		(*Value_ListValue)(nil),
+		(*Value_EmbeddingValue)(nil),
+		(*Value_StructValue)(nil),
 	}
-	file_core_v1alpha1_types_proto_msgTypes[5].OneofWrappers = []interface{}{}
+	file_core_v1alpha1_types_proto_msgTypes[8].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_core_v1alpha1_types_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   8,
+			NumEnums:      4,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
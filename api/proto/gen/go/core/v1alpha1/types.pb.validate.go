@@ -359,6 +359,249 @@ var _ interface {
 	ErrorName() string
 } = ListValidationError{}
 
+// Validate checks the field values on Embedding with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Embedding) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Embedding with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in EmbeddingMultiError, or nil
+// if none found.
+func (m *Embedding) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Embedding) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return EmbeddingMultiError(errors)
+	}
+
+	return nil
+}
+
+// EmbeddingMultiError is an error wrapping multiple validation errors returned
+// by Embedding.ValidateAll() if the designated constraints aren't met.
+type EmbeddingMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EmbeddingMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EmbeddingMultiError) AllErrors() []error { return m }
+
+// EmbeddingValidationError is the validation error returned by
+// Embedding.Validate if the designated constraints aren't met.
+type EmbeddingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EmbeddingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EmbeddingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EmbeddingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EmbeddingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EmbeddingValidationError) ErrorName() string { return "EmbeddingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EmbeddingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEmbedding.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EmbeddingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EmbeddingValidationError{}
+
+// Validate checks the field values on Struct with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Struct) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Struct with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in StructMultiError, or nil if none found.
+func (m *Struct) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Struct) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	{
+		sorted_keys := make([]string, len(m.GetFields()))
+		i := 0
+		for key := range m.GetFields() {
+			sorted_keys[i] = key
+			i++
+		}
+		sort.Slice(sorted_keys, func(i, j int) bool { return sorted_keys[i] < sorted_keys[j] })
+		for _, key := range sorted_keys {
+			val := m.GetFields()[key]
+			_ = val
+
+			// no validation rules for Fields[key]
+
+			if all {
+				switch v := interface{}(val).(type) {
+				case interface{ ValidateAll() error }:
+					if err := v.ValidateAll(); err != nil {
+						errors = append(errors, StructValidationError{
+							field:  fmt.Sprintf("Fields[%v]", key),
+							reason: "embedded message failed validation",
+							cause:  err,
+						})
+					}
+				case interface{ Validate() error }:
+					if err := v.Validate(); err != nil {
+						errors = append(errors, StructValidationError{
+							field:  fmt.Sprintf("Fields[%v]", key),
+							reason: "embedded message failed validation",
+							cause:  err,
+						})
+					}
+				}
+			} else if v, ok := interface{}(val).(interface{ Validate() error }); ok {
+				if err := v.Validate(); err != nil {
+					return StructValidationError{
+						field:  fmt.Sprintf("Fields[%v]", key),
+						reason: "embedded message failed validation",
+						cause:  err,
+					}
+				}
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return StructMultiError(errors)
+	}
+
+	return nil
+}
+
+// StructMultiError is an error wrapping multiple validation errors returned by
+// Struct.ValidateAll() if the designated constraints aren't met.
+type StructMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StructMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StructMultiError) AllErrors() []error { return m }
+
+// StructValidationError is the validation error returned by Struct.Validate if
+// the designated constraints aren't met.
+type StructValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StructValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StructValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StructValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StructValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StructValidationError) ErrorName() string { return "StructValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StructValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStruct.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StructValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StructValidationError{}
+
 // Validate checks the field values on Value with the rules defined in the
 // proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -463,6 +706,88 @@ func (m *Value) validate(all bool) error {
 			}
 		}
 
+	case *Value_EmbeddingValue:
+		if v == nil {
+			err := ValueValidationError{
+				field:  "Value",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetEmbeddingValue()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ValueValidationError{
+						field:  "EmbeddingValue",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ValueValidationError{
+						field:  "EmbeddingValue",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetEmbeddingValue()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ValueValidationError{
+					field:  "EmbeddingValue",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *Value_StructValue:
+		if v == nil {
+			err := ValueValidationError{
+				field:  "Value",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetStructValue()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ValueValidationError{
+						field:  "StructValue",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ValueValidationError{
+						field:  "StructValue",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetStructValue()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ValueValidationError{
+					field:  "StructValue",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
 	default:
 		_ = v // ensures v is used
 	}
@@ -778,6 +1103,118 @@ var _ interface {
 	ErrorName() string
 } = KeepPreviousValidationError{}
 
+// Validate checks the field values on StructField with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *StructField) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on StructField with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in StructFieldMultiError, or
+// nil if none found.
+func (m *StructField) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *StructField) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	if _, ok := Primitive_name[int32(m.GetPrimitive())]; !ok {
+		err := StructFieldValidationError{
+			field:  "Primitive",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return StructFieldMultiError(errors)
+	}
+
+	return nil
+}
+
+// StructFieldMultiError is an error wrapping multiple validation errors
+// returned by StructField.ValidateAll() if the designated constraints aren't met.
+type StructFieldMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m StructFieldMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m StructFieldMultiError) AllErrors() []error { return m }
+
+// StructFieldValidationError is the validation error returned by
+// StructField.Validate if the designated constraints aren't met.
+type StructFieldValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e StructFieldValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e StructFieldValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e StructFieldValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e StructFieldValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e StructFieldValidationError) ErrorName() string { return "StructFieldValidationError" }
+
+// Error satisfies the builtin error interface
+func (e StructFieldValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sStructField.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = StructFieldValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = StructFieldValidationError{}
+
 // Validate checks the field values on FeatureDescriptor with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
@@ -946,6 +1383,53 @@ func (m *FeatureDescriptor) validate(all bool) error {
 
 	// no validation rules for RuntimeEnv
 
+	// no validation rules for EmbeddingDim
+
+	for idx, item := range m.GetFields() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FeatureDescriptorValidationError{
+						field:  fmt.Sprintf("Fields[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FeatureDescriptorValidationError{
+						field:  fmt.Sprintf("Fields[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FeatureDescriptorValidationError{
+					field:  fmt.Sprintf("Fields[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if _, ok := ErrorPolicy_name[int32(m.GetOnError())]; !ok {
+		err := FeatureDescriptorValidationError{
+			field:  "OnError",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
 	if m.KeepPrevious != nil {
 
 		if all {
@@ -979,6 +1463,39 @@ func (m *FeatureDescriptor) validate(all bool) error {
 
 	}
 
+	if m.DefaultValue != nil {
+
+		if all {
+			switch v := interface{}(m.GetDefaultValue()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FeatureDescriptorValidationError{
+						field:  "DefaultValue",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FeatureDescriptorValidationError{
+						field:  "DefaultValue",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetDefaultValue()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FeatureDescriptorValidationError{
+					field:  "DefaultValue",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return FeatureDescriptorMultiError(errors)
 	}
@@ -1156,6 +1673,40 @@ func (m *FeatureValue) validate(all bool) error {
 
 	// no validation rules for Fresh
 
+	for idx, item := range m.GetDegraded() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FeatureValueValidationError{
+						field:  fmt.Sprintf("Degraded[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FeatureValueValidationError{
+						field:  fmt.Sprintf("Degraded[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FeatureValueValidationError{
+					field:  fmt.Sprintf("Degraded[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if len(errors) > 0 {
 		return FeatureValueMultiError(errors)
 	}
@@ -1234,3 +1785,109 @@ var _ interface {
 } = FeatureValueValidationError{}
 
 var _FeatureValue_Fqn_Pattern = regexp.MustCompile("(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$")
+
+// Validate checks the field values on DegradedInput with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *DegradedInput) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DegradedInput with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in DegradedInputMultiError, or
+// nil if none found.
+func (m *DegradedInput) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DegradedInput) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Selector
+
+	// no validation rules for Policy
+
+	// no validation rules for Error
+
+	if len(errors) > 0 {
+		return DegradedInputMultiError(errors)
+	}
+
+	return nil
+}
+
+// DegradedInputMultiError is an error wrapping multiple validation errors
+// returned by DegradedInput.ValidateAll() if the designated constraints
+// aren't met.
+type DegradedInputMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DegradedInputMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DegradedInputMultiError) AllErrors() []error { return m }
+
+// DegradedInputValidationError is the validation error returned by
+// DegradedInput.Validate if the designated constraints aren't met.
+type DegradedInputValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DegradedInputValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DegradedInputValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DegradedInputValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DegradedInputValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DegradedInputValidationError) ErrorName() string { return "DegradedInputValidationError" }
+
+// Error satisfies the builtin error interface
+func (e DegradedInputValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDegradedInput.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DegradedInputValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DegradedInputValidationError{}
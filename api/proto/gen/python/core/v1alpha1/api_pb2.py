@@ -19,7 +19,7 @@ from validate import validate_pb2 as validate_dot_validate__pb2
 from protoc_gen_openapiv2.options import annotations_pb2 as protoc__gen__openapiv2_dot_options_dot_annotations__pb2
 
 
-DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x17\x63ore/v1alpha1/api.proto\x12\rcore.v1alpha1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x19\x63ore/v1alpha1/types.proto\x1a\x17validate/validate.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x8e\x03\n\nGetRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\xef\x01\n\x08selector\x18\x02 \x01(\tB\xd2\x01\xfa\x42\xce\x01r\xcb\x01\x32\xc8\x01(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(\\+(?P<aggrFn>([a-z]+_*[a-z]+)))?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$R\x08selector\x12\x37\n\x04keys\x18\x03 \x03(\x0b\x32#.core.v1alpha1.GetRequest.KeysEntryR\x04keys\x1a\x37\n\tKeysEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"\xaf\x01\n\x0bGetResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\x31\n\x05value\x18\x02 \x01(\x0b\x32\x1b.core.v1alpha1.FeatureValueR\x05value\x12O\n\x12\x66\x65\x61ture_descriptor\x18\x03 \x01(\x0b\x32 .core.v1alpha1.FeatureDescriptorR\x11\x66\x65\x61tureDescriptor\"\xaa\x02\n\x18\x46\x65\x61tureDescriptorRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\xef\x01\n\x08selector\x18\x02 \x01(\tB\xd2\x01\xfa\x42\xce\x01r\xcb\x01\x32\xc8\x01(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(\\+(?P<aggrFn>([a-z]+_*[a-z]+)))?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$R\x08selector\"\x8a\x01\n\x19\x46\x65\x61tureDescriptorResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12O\n\x12\x66\x65\x61ture_descriptor\x18\x02 \x01(\x0b\x32 .core.v1alpha1.FeatureDescriptorR\x11\x66\x65\x61tureDescriptor\"\xcc\x02\n\nSetRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12H\n\x08selector\x18\x02 \x01(\tB,\xfa\x42)r\'2%(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$R\x08selector\x12\x37\n\x04keys\x18\x03 \x03(\x0b\x32#.core.v1alpha1.SetRequest.KeysEntryR\x04keys\x12*\n\x05value\x18\x04 \x01(\x0b\x32\x14.core.v1alpha1.ValueR\x05value\x12\x38\n\ttimestamp\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\x1a\x37\n\tKeysEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"e\n\x0bSetResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\x38\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\"\xc9\x02\n\rAppendRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12>\n\x03\x66qn\x18\x02 \x01(\tB,\xfa\x42)r\'2%(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$R\x03\x66qn\x12:\n\x04keys\x18\x03 \x03(\x0b\x32&.core.v1alpha1.AppendRequest.KeysEntryR\x04keys\x12+\n\x05value\x18\x04 \x01(\x0b\x32\x15.core.v1alpha1.ScalarR\x05value\x12\x38\n\ttimestamp\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\x1a\x37\n\tKeysEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"h\n\x0e\x41ppendResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\x38\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\"\xc5\x02\n\x0bIncrRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12>\n\x03\x66qn\x18\x02 \x01(\tB,\xfa\x42)r\'2%(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$R\x03\x66qn\x12\x38\n\x04keys\x18\x03 \x03(\x0b\x32$.core.v1alpha1.IncrRequest.KeysEntryR\x04keys\x12+\n\x05value\x18\x04 \x01(\x0b\x32\x15.core.v1alpha1.ScalarR\x05value\x12\x38\n\ttimestamp\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\x1a\x37\n\tKeysEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"f\n\x0cIncrResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\x38\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\"\xd2\x02\n\rUpdateRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12H\n\x08selector\x18\x02 \x01(\tB,\xfa\x42)r\'2%(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$R\x08selector\x12:\n\x04keys\x18\x03 \x03(\x0b\x32&.core.v1alpha1.UpdateRequest.KeysEntryR\x04keys\x12*\n\x05value\x18\x04 \x01(\x0b\x32\x14.core.v1alpha1.ValueR\x05value\x12\x38\n\ttimestamp\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\x1a\x37\n\tKeysEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"h\n\x0eUpdateResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\x38\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp2\xcb\x04\n\rEngineService\x12\x83\x01\n\x11\x46\x65\x61tureDescriptor\x12\'.core.v1alpha1.FeatureDescriptorRequest\x1a(.core.v1alpha1.FeatureDescriptorResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x42\x13\n\x04HEAD\x12\x0b/{selector}\x12Q\n\x03Get\x12\x19.core.v1alpha1.GetRequest\x1a\x1a.core.v1alpha1.GetResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\x0b/{selector}\x12Q\n\x03Set\x12\x19.core.v1alpha1.SetRequest\x1a\x1a.core.v1alpha1.SetResponse\"\x13\x82\xd3\xe4\x93\x02\r\x1a\x0b/{selector}\x12\\\n\x06\x41ppend\x12\x1c.core.v1alpha1.AppendRequest\x1a\x1d.core.v1alpha1.AppendResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\"\r/{fqn}/append\x12T\n\x04Incr\x12\x1a.core.v1alpha1.IncrRequest\x1a\x1b.core.v1alpha1.IncrResponse\"\x13\x82\xd3\xe4\x93\x02\r\"\x0b/{fqn}/incr\x12Z\n\x06Update\x12\x1c.core.v1alpha1.UpdateRequest\x1a\x1d.core.v1alpha1.UpdateResponse\"\x13\x82\xd3\xe4\x93\x02\r\"\x0b/{selector}B\xf5\x02\n\x11\x63om.core.v1alpha1B\x08\x41piProtoP\x01ZGgithub.com/raptor-ml/raptor/api/proto/gen/go/core/v1alpha1;corev1alpha1\xa2\x02\x03\x43XX\xaa\x02\rCore.V1alpha1\xca\x02\rCore\\V1alpha1\xe2\x02\x19\x43ore\\V1alpha1\\GPBMetadata\xea\x02\x0e\x43ore::V1alpha1\x92\x41\xb6\x01\x12[\n\x08\x43ore API\x12OProvides access low-level operations over feature values and model predictions.\x1a\'raptor-core-service.raptor-system:60001*\x01\x01r+\n\x16Official documentation\x12\x11https://raptor.mlb\x06proto3')
+DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(b'\n\x17\x63ore/v1alpha1/api.proto\x12\rcore.v1alpha1\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x19\x63ore/v1alpha1/types.proto\x1a\x17validate/validate.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xe8\x03\n\nGetRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\xc9\x02\n\x08selector\x18\x02 \x01(\tB\xac\x02\xfa\x42\xa8\x02r\xa5\x02\x32\xa2\x02(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$R\x08selector\x12\x37\n\x04keys\x18\x03 \x03(\x0b\x32#.core.v1alpha1.GetRequest.KeysEntryR\x04keys\x1a\x37\n\tKeysEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"\xe4\x01\n\x0bGetResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\x31\n\x05value\x18\x02 \x01(\x0b\x32\x1b.core.v1alpha1.FeatureValueR\x05value\x12O\n\x12\x66\x65\x61ture_descriptor\x18\x03 \x01(\x0b\x32 .core.v1alpha1.FeatureDescriptorR\x11\x66\x65\x61tureDescriptor\x12\x33\n\x08\x66\x61llback\x18\x04 \x01(\x0e\x32\x17.core.v1alpha1.FallbackR\x08\x66\x61llback\"l\n\x0f\x42\x61tchGetRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12;\n\x05items\x18\x02 \x03(\x0b\x32\x1b.core.v1alpha1.BatchGetItemB\x08\xfa\x42\x05\x92\x01\x02\x08\x01R\x05items\"\xce\x03\n\x0c\x42\x61tchGetItem\x12\xc9\x02\n\x08selector\x18\x01 \x01(\tB\xac\x02\xfa\x42\xa8\x02r\xa5\x02\x32\xa2\x02(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$R\x08selector\x12\x39\n\x04keys\x18\x02 \x03(\x0b\x32%.core.v1alpha1.BatchGetItem.KeysEntryR\x04keys\x1a\x37\n\tKeysEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"i\n\x10\x42\x61tchGetResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\x37\n\x07results\x18\x02 \x03(\x0b\x32\x1d.core.v1alpha1.BatchGetResultR\x07results\"\xf3\x01\n\x0e\x42\x61tchGetResult\x12\x31\n\x05value\x18\x01 \x01(\x0b\x32\x1b.core.v1alpha1.FeatureValueR\x05value\x12O\n\x12\x66\x65\x61ture_descriptor\x18\x02 \x01(\x0b\x32 .core.v1alpha1.FeatureDescriptorR\x11\x66\x65\x61tureDescriptor\x12\x14\n\x05\x65rror\x18\x03 \x01(\tR\x05\x65rror\x12\x12\n\x04\x63ode\x18\x04 \x01(\rR\x04\x63ode\x12\x33\n\x08\x66\x61llback\x18\x05 \x01(\x0e\x32\x17.core.v1alpha1.FallbackR\x08\x66\x61llback\"\xf7\x03\n\x0cWatchRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\xd4\x02\n\tselectors\x18\x02 \x03(\tB\xb5\x02\xfa\x42\xb1\x02\x92\x01\xad\x02\x08\x01\"\xa8\x02r\xa5\x02\x32\xa2\x02(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$R\tselectors\x12\x39\n\x04keys\x18\x03 \x03(\x0b\x32%.core.v1alpha1.WatchRequest.KeysEntryR\x04keys\x1a\x37\n\tKeysEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"|\n\rWatchResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\x1a\n\x08selector\x18\x02 \x01(\tR\x08selector\x12\x31\n\x05value\x18\x03 \x01(\x0b\x32\x1b.core.v1alpha1.FeatureValueR\x05value\"\x84\x03\n\x18\x46\x65\x61tureDescriptorRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\xc9\x02\n\x08selector\x18\x02 \x01(\tB\xac\x02\xfa\x42\xa8\x02r\xa5\x02\x32\xa2\x02(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$R\x08selector\"\x8a\x01\n\x19\x46\x65\x61tureDescriptorResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12O\n\x12\x66\x65\x61ture_descriptor\x18\x02 \x01(\x0b\x32 .core.v1alpha1.FeatureDescriptorR\x11\x66\x65\x61tureDescriptor\"\xcc\x02\n\nSetRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12H\n\x08selector\x18\x02 \x01(\tB,\xfa\x42)r\'2%(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$R\x08selector\x12\x37\n\x04keys\x18\x03 \x03(\x0b\x32#.core.v1alpha1.SetRequest.KeysEntryR\x04keys\x12*\n\x05value\x18\x04 \x01(\x0b\x32\x14.core.v1alpha1.ValueR\x05value\x12\x38\n\ttimestamp\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\x1a\x37\n\tKeysEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"e\n\x0bSetResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\x38\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\"\xc9\x02\n\rAppendRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12>\n\x03\x66qn\x18\x02 \x01(\tB,\xfa\x42)r\'2%(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$R\x03\x66qn\x12:\n\x04keys\x18\x03 \x03(\x0b\x32&.core.v1alpha1.AppendRequest.KeysEntryR\x04keys\x12+\n\x05value\x18\x04 \x01(\x0b\x32\x15.core.v1alpha1.ScalarR\x05value\x12\x38\n\ttimestamp\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\x1a\x37\n\tKeysEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"h\n\x0e\x41ppendResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\x38\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\"\xc5\x02\n\x0bIncrRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12>\n\x03\x66qn\x18\x02 \x01(\tB,\xfa\x42)r\'2%(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$R\x03\x66qn\x12\x38\n\x04keys\x18\x03 \x03(\x0b\x32$.core.v1alpha1.IncrRequest.KeysEntryR\x04keys\x12+\n\x05value\x18\x04 \x01(\x0b\x32\x15.core.v1alpha1.ScalarR\x05value\x12\x38\n\ttimestamp\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\x1a\x37\n\tKeysEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"f\n\x0cIncrResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\x38\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\"\xd2\x02\n\rUpdateRequest\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12H\n\x08selector\x18\x02 \x01(\tB,\xfa\x42)r\'2%(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$R\x08selector\x12:\n\x04keys\x18\x03 \x03(\x0b\x32&.core.v1alpha1.UpdateRequest.KeysEntryR\x04keys\x12*\n\x05value\x18\x04 \x01(\x0b\x32\x14.core.v1alpha1.ValueR\x05value\x12\x38\n\ttimestamp\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\x1a\x37\n\tKeysEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\"h\n\x0eUpdateResponse\x12\x1c\n\x04uuid\x18\x01 \x01(\tB\x08\xfa\x42\x05r\x03\xb0\x01\x01R\x04uuid\x12\x38\n\ttimestamp\x18\x02 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\"\xba\x02\n\rIngestRequest\x12\x32\n\x06method\x18\x01 \x01(\x0e\x32\x1a.core.v1alpha1.WriteMethodR\x06method\x12\x1a\n\x08selector\x18\x02 \x01(\tR\x08selector\x12:\n\x04keys\x18\x03 \x03(\x0b\x32&.core.v1alpha1.IngestRequest.KeysEntryR\x04keys\x12*\n\x05value\x18\x04 \x01(\x0b\x32\x14.core.v1alpha1.ValueR\x05value\x12\x38\n\ttimestamp\x18\x05 \x01(\x0b\x32\x1a.google.protobuf.TimestampR\ttimestamp\x1a\x37\n\tKeysEntry\x12\x10\n\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n\x05value\x18\x02 \x01(\tR\x05value:\x02\x38\x01\";\n\rIngestFailure\x12\x14\n\x05index\x18\x01 \x01(\x04R\x05index\x12\x14\n\x05\x65rror\x18\x02 \x01(\tR\x05\x65rror\"\x9c\x01\n\x0eIngestResponse\x12\x1a\n\x08received\x18\x01 \x01(\x04R\x08received\x12\x1c\n\tsucceeded\x18\x02 \x01(\x04R\tsucceeded\x12\x16\n\x06\x66\x61iled\x18\x03 \x01(\x04R\x06\x66\x61iled\x12\x38\n\x08\x66\x61ilures\x18\x04 \x03(\x0b\x32\x1c.core.v1alpha1.IngestFailureR\x08\x66\x61ilures*\x8a\x01\n\x0bWriteMethod\x12\x1c\n\x18WRITE_METHOD_UNSPECIFIED\x10\x00\x12\x14\n\x10WRITE_METHOD_SET\x10\x01\x12\x17\n\x13WRITE_METHOD_APPEND\x10\x02\x12\x15\n\x11WRITE_METHOD_INCR\x10\x03\x12\x17\n\x13WRITE_METHOD_UPDATE\x10\x04\x32\xd8\x06\n\rEngineService\x12\x83\x01\n\x11\x46\x65\x61tureDescriptor\x12\'.core.v1alpha1.FeatureDescriptorRequest\x1a(.core.v1alpha1.FeatureDescriptorResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x42\x13\n\x04HEAD\x12\x0b/{selector}\x12Q\n\x03Get\x12\x19.core.v1alpha1.GetRequest\x1a\x1a.core.v1alpha1.GetResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\x0b/{selector}\x12\x62\n\x08\x42\x61tchGet\x12\x1e.core.v1alpha1.BatchGetRequest\x1a\x1f.core.v1alpha1.BatchGetResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\"\n/batch/get:\x01*\x12^\n\x05Watch\x12\x1b.core.v1alpha1.WatchRequest\x1a\x1c.core.v1alpha1.WatchResponse\"\x18\x82\xd3\xe4\x93\x02\x12\"\r/watch/stream:\x01*0\x01\x12Q\n\x03Set\x12\x19.core.v1alpha1.SetRequest\x1a\x1a.core.v1alpha1.SetResponse\"\x13\x82\xd3\xe4\x93\x02\r\x1a\x0b/{selector}\x12\\\n\x06\x41ppend\x12\x1c.core.v1alpha1.AppendRequest\x1a\x1d.core.v1alpha1.AppendResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\"\r/{fqn}/append\x12T\n\x04Incr\x12\x1a.core.v1alpha1.IncrRequest\x1a\x1b.core.v1alpha1.IncrResponse\"\x13\x82\xd3\xe4\x93\x02\r\"\x0b/{fqn}/incr\x12Z\n\x06Update\x12\x1c.core.v1alpha1.UpdateRequest\x1a\x1d.core.v1alpha1.UpdateResponse\"\x13\x82\xd3\xe4\x93\x02\r\"\x0b/{selector}\x12G\n\x06Ingest\x12\x1c.core.v1alpha1.IngestRequest\x1a\x1d.core.v1alpha1.IngestResponse(\x01\x42\xf5\x02\n\x11\x63om.core.v1alpha1B\x08\x41piProtoP\x01ZGgithub.com/raptor-ml/raptor/api/proto/gen/go/core/v1alpha1;corev1alpha1\xa2\x02\x03\x43XX\xaa\x02\rCore.V1alpha1\xca\x02\rCore\\V1alpha1\xe2\x02\x19\x43ore\\V1alpha1\\GPBMetadata\xea\x02\x0e\x43ore::V1alpha1\x92\x41\xb6\x01\x12[\n\x08\x43ore API\x12OProvides access low-level operations over feature values and model predictions.\x1a\'raptor-core-service.raptor-system:60001*\x01\x01r+\n\x16Official documentation\x12\x11https://raptor.mlb\x06proto3')
 
 _globals = globals()
 _builder.BuildMessageAndEnumDescriptors(DESCRIPTOR, _globals)
@@ -32,13 +32,31 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _globals['_GETREQUEST'].fields_by_name['uuid']._options = None
   _globals['_GETREQUEST'].fields_by_name['uuid']._serialized_options = b'\372B\005r\003\260\001\001'
   _globals['_GETREQUEST'].fields_by_name['selector']._options = None
-  _globals['_GETREQUEST'].fields_by_name['selector']._serialized_options = b'\372B\316\001r\313\0012\310\001(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(\\+(?P<aggrFn>([a-z]+_*[a-z]+)))?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$'
+  _globals['_GETREQUEST'].fields_by_name['selector']._serialized_options = b'\372B\250\002r\245\0022\242\002(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$'
   _globals['_GETRESPONSE'].fields_by_name['uuid']._options = None
   _globals['_GETRESPONSE'].fields_by_name['uuid']._serialized_options = b'\372B\005r\003\260\001\001'
+  _globals['_BATCHGETREQUEST'].fields_by_name['uuid']._options = None
+  _globals['_BATCHGETREQUEST'].fields_by_name['uuid']._serialized_options = b'\372B\005r\003\260\001\001'
+  _globals['_BATCHGETREQUEST'].fields_by_name['items']._options = None
+  _globals['_BATCHGETREQUEST'].fields_by_name['items']._serialized_options = b'\372B\005\222\001\002\010\001'
+  _globals['_BATCHGETITEM_KEYSENTRY']._options = None
+  _globals['_BATCHGETITEM_KEYSENTRY']._serialized_options = b'8\001'
+  _globals['_BATCHGETITEM'].fields_by_name['selector']._options = None
+  _globals['_BATCHGETITEM'].fields_by_name['selector']._serialized_options = b'\372B\250\002r\245\0022\242\002(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$'
+  _globals['_BATCHGETRESPONSE'].fields_by_name['uuid']._options = None
+  _globals['_BATCHGETRESPONSE'].fields_by_name['uuid']._serialized_options = b'\372B\005r\003\260\001\001'
+  _globals['_WATCHREQUEST_KEYSENTRY']._options = None
+  _globals['_WATCHREQUEST_KEYSENTRY']._serialized_options = b'8\001'
+  _globals['_WATCHREQUEST'].fields_by_name['uuid']._options = None
+  _globals['_WATCHREQUEST'].fields_by_name['uuid']._serialized_options = b'\372B\005r\003\260\001\001'
+  _globals['_WATCHREQUEST'].fields_by_name['selectors']._options = None
+  _globals['_WATCHREQUEST'].fields_by_name['selectors']._serialized_options = b'\372B\261\002\222\001\255\002\010\001\"\250\002r\245\0022\242\002(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$'
+  _globals['_WATCHRESPONSE'].fields_by_name['uuid']._options = None
+  _globals['_WATCHRESPONSE'].fields_by_name['uuid']._serialized_options = b'\372B\005r\003\260\001\001'
   _globals['_FEATUREDESCRIPTORREQUEST'].fields_by_name['uuid']._options = None
   _globals['_FEATUREDESCRIPTORREQUEST'].fields_by_name['uuid']._serialized_options = b'\372B\005r\003\260\001\001'
   _globals['_FEATUREDESCRIPTORREQUEST'].fields_by_name['selector']._options = None
-  _globals['_FEATUREDESCRIPTORREQUEST'].fields_by_name['selector']._serialized_options = b'\372B\316\001r\313\0012\310\001(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(\\+(?P<aggrFn>([a-z]+_*[a-z]+)))?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$'
+  _globals['_FEATUREDESCRIPTORREQUEST'].fields_by_name['selector']._serialized_options = b'\372B\250\002r\245\0022\242\002(?si)^((?P<namespace>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})\\.)?(?P<name>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256})(:(?P<field>([a0-z9]+[a0-z9_]*[a0-z9]+){1,256}))?(\\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\\[(?P<encoding>([a-z]+_*[a-z]+))])?$'
   _globals['_FEATUREDESCRIPTORRESPONSE'].fields_by_name['uuid']._options = None
   _globals['_FEATUREDESCRIPTORRESPONSE'].fields_by_name['uuid']._serialized_options = b'\372B\005r\003\260\001\001'
   _globals['_SETREQUEST_KEYSENTRY']._options = None
@@ -73,10 +91,16 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _globals['_UPDATEREQUEST'].fields_by_name['selector']._serialized_options = b'\372B)r\'2%(i?)^([a0-z9\\-\\.]*)(\\[([a0-z9])*\\])?$'
   _globals['_UPDATERESPONSE'].fields_by_name['uuid']._options = None
   _globals['_UPDATERESPONSE'].fields_by_name['uuid']._serialized_options = b'\372B\005r\003\260\001\001'
+  _globals['_INGESTREQUEST_KEYSENTRY']._options = None
+  _globals['_INGESTREQUEST_KEYSENTRY']._serialized_options = b'8\001'
   _globals['_ENGINESERVICE'].methods_by_name['FeatureDescriptor']._options = None
   _globals['_ENGINESERVICE'].methods_by_name['FeatureDescriptor']._serialized_options = b'\202\323\344\223\002\025B\023\n\004HEAD\022\013/{selector}'
   _globals['_ENGINESERVICE'].methods_by_name['Get']._options = None
   _globals['_ENGINESERVICE'].methods_by_name['Get']._serialized_options = b'\202\323\344\223\002\r\022\013/{selector}'
+  _globals['_ENGINESERVICE'].methods_by_name['BatchGet']._options = None
+  _globals['_ENGINESERVICE'].methods_by_name['BatchGet']._serialized_options = b'\202\323\344\223\002\017\"\n/batch/get:\001*'
+  _globals['_ENGINESERVICE'].methods_by_name['Watch']._options = None
+  _globals['_ENGINESERVICE'].methods_by_name['Watch']._serialized_options = b'\202\323\344\223\002\022\"\r/watch/stream:\001*'
   _globals['_ENGINESERVICE'].methods_by_name['Set']._options = None
   _globals['_ENGINESERVICE'].methods_by_name['Set']._serialized_options = b'\202\323\344\223\002\r\032\013/{selector}'
   _globals['_ENGINESERVICE'].methods_by_name['Append']._options = None
@@ -85,40 +109,66 @@ if _descriptor._USE_C_DESCRIPTORS == False:
   _globals['_ENGINESERVICE'].methods_by_name['Incr']._serialized_options = b'\202\323\344\223\002\r\"\013/{fqn}/incr'
   _globals['_ENGINESERVICE'].methods_by_name['Update']._options = None
   _globals['_ENGINESERVICE'].methods_by_name['Update']._serialized_options = b'\202\323\344\223\002\r\"\013/{selector}'
+  _globals['_WRITEMETHOD']._serialized_start=5312
+  _globals['_WRITEMETHOD']._serialized_end=5450
   _globals['_GETREQUEST']._serialized_start=206
-  _globals['_GETREQUEST']._serialized_end=604
-  _globals['_GETREQUEST_KEYSENTRY']._serialized_start=549
-  _globals['_GETREQUEST_KEYSENTRY']._serialized_end=604
-  _globals['_GETRESPONSE']._serialized_start=607
-  _globals['_GETRESPONSE']._serialized_end=782
-  _globals['_FEATUREDESCRIPTORREQUEST']._serialized_start=785
-  _globals['_FEATUREDESCRIPTORREQUEST']._serialized_end=1083
-  _globals['_FEATUREDESCRIPTORRESPONSE']._serialized_start=1086
-  _globals['_FEATUREDESCRIPTORRESPONSE']._serialized_end=1224
-  _globals['_SETREQUEST']._serialized_start=1227
-  _globals['_SETREQUEST']._serialized_end=1559
-  _globals['_SETREQUEST_KEYSENTRY']._serialized_start=549
-  _globals['_SETREQUEST_KEYSENTRY']._serialized_end=604
-  _globals['_SETRESPONSE']._serialized_start=1561
-  _globals['_SETRESPONSE']._serialized_end=1662
-  _globals['_APPENDREQUEST']._serialized_start=1665
-  _globals['_APPENDREQUEST']._serialized_end=1994
-  _globals['_APPENDREQUEST_KEYSENTRY']._serialized_start=549
-  _globals['_APPENDREQUEST_KEYSENTRY']._serialized_end=604
-  _globals['_APPENDRESPONSE']._serialized_start=1996
-  _globals['_APPENDRESPONSE']._serialized_end=2100
-  _globals['_INCRREQUEST']._serialized_start=2103
-  _globals['_INCRREQUEST']._serialized_end=2428
-  _globals['_INCRREQUEST_KEYSENTRY']._serialized_start=549
-  _globals['_INCRREQUEST_KEYSENTRY']._serialized_end=604
-  _globals['_INCRRESPONSE']._serialized_start=2430
-  _globals['_INCRRESPONSE']._serialized_end=2532
-  _globals['_UPDATEREQUEST']._serialized_start=2535
-  _globals['_UPDATEREQUEST']._serialized_end=2873
-  _globals['_UPDATEREQUEST_KEYSENTRY']._serialized_start=549
-  _globals['_UPDATEREQUEST_KEYSENTRY']._serialized_end=604
-  _globals['_UPDATERESPONSE']._serialized_start=2875
-  _globals['_UPDATERESPONSE']._serialized_end=2979
-  _globals['_ENGINESERVICE']._serialized_start=2982
-  _globals['_ENGINESERVICE']._serialized_end=3569
+  _globals['_GETREQUEST']._serialized_end=694
+  _globals['_GETREQUEST_KEYSENTRY']._serialized_start=639
+  _globals['_GETREQUEST_KEYSENTRY']._serialized_end=694
+  _globals['_GETRESPONSE']._serialized_start=697
+  _globals['_GETRESPONSE']._serialized_end=925
+  _globals['_BATCHGETREQUEST']._serialized_start=927
+  _globals['_BATCHGETREQUEST']._serialized_end=1035
+  _globals['_BATCHGETITEM']._serialized_start=1038
+  _globals['_BATCHGETITEM']._serialized_end=1500
+  _globals['_BATCHGETITEM_KEYSENTRY']._serialized_start=639
+  _globals['_BATCHGETITEM_KEYSENTRY']._serialized_end=694
+  _globals['_BATCHGETRESPONSE']._serialized_start=1502
+  _globals['_BATCHGETRESPONSE']._serialized_end=1607
+  _globals['_BATCHGETRESULT']._serialized_start=1610
+  _globals['_BATCHGETRESULT']._serialized_end=1853
+  _globals['_WATCHREQUEST']._serialized_start=1856
+  _globals['_WATCHREQUEST']._serialized_end=2359
+  _globals['_WATCHREQUEST_KEYSENTRY']._serialized_start=639
+  _globals['_WATCHREQUEST_KEYSENTRY']._serialized_end=694
+  _globals['_WATCHRESPONSE']._serialized_start=2361
+  _globals['_WATCHRESPONSE']._serialized_end=2485
+  _globals['_FEATUREDESCRIPTORREQUEST']._serialized_start=2488
+  _globals['_FEATUREDESCRIPTORREQUEST']._serialized_end=2876
+  _globals['_FEATUREDESCRIPTORRESPONSE']._serialized_start=2879
+  _globals['_FEATUREDESCRIPTORRESPONSE']._serialized_end=3017
+  _globals['_SETREQUEST']._serialized_start=3020
+  _globals['_SETREQUEST']._serialized_end=3352
+  _globals['_SETREQUEST_KEYSENTRY']._serialized_start=639
+  _globals['_SETREQUEST_KEYSENTRY']._serialized_end=694
+  _globals['_SETRESPONSE']._serialized_start=3354
+  _globals['_SETRESPONSE']._serialized_end=3455
+  _globals['_APPENDREQUEST']._serialized_start=3458
+  _globals['_APPENDREQUEST']._serialized_end=3787
+  _globals['_APPENDREQUEST_KEYSENTRY']._serialized_start=639
+  _globals['_APPENDREQUEST_KEYSENTRY']._serialized_end=694
+  _globals['_APPENDRESPONSE']._serialized_start=3789
+  _globals['_APPENDRESPONSE']._serialized_end=3893
+  _globals['_INCRREQUEST']._serialized_start=3896
+  _globals['_INCRREQUEST']._serialized_end=4221
+  _globals['_INCRREQUEST_KEYSENTRY']._serialized_start=639
+  _globals['_INCRREQUEST_KEYSENTRY']._serialized_end=694
+  _globals['_INCRRESPONSE']._serialized_start=4223
+  _globals['_INCRRESPONSE']._serialized_end=4325
+  _globals['_UPDATEREQUEST']._serialized_start=4328
+  _globals['_UPDATEREQUEST']._serialized_end=4666
+  _globals['_UPDATEREQUEST_KEYSENTRY']._serialized_start=639
+  _globals['_UPDATEREQUEST_KEYSENTRY']._serialized_end=694
+  _globals['_UPDATERESPONSE']._serialized_start=4668
+  _globals['_UPDATERESPONSE']._serialized_end=4772
+  _globals['_INGESTREQUEST']._serialized_start=4775
+  _globals['_INGESTREQUEST']._serialized_end=5089
+  _globals['_INGESTREQUEST_KEYSENTRY']._serialized_start=639
+  _globals['_INGESTREQUEST_KEYSENTRY']._serialized_end=694
+  _globals['_INGESTFAILURE']._serialized_start=5091
+  _globals['_INGESTFAILURE']._serialized_end=5150
+  _globals['_INGESTRESPONSE']._serialized_start=5153
+  _globals['_INGESTRESPONSE']._serialized_end=5309
+  _globals['_ENGINESERVICE']._serialized_start=5453
+  _globals['_ENGINESERVICE']._serialized_end=6309
 # @@protoc_insertion_point(module_scope)
@@ -4,12 +4,26 @@ from core.v1alpha1 import types_pb2 as _types_pb2
 from validate import validate_pb2 as _validate_pb2
 from protoc_gen_openapiv2.options import annotations_pb2 as _annotations_pb2_1
 from google.protobuf.internal import containers as _containers
+from google.protobuf.internal import enum_type_wrapper as _enum_type_wrapper
 from google.protobuf import descriptor as _descriptor
 from google.protobuf import message as _message
-from typing import ClassVar as _ClassVar, Mapping as _Mapping, Optional as _Optional, Union as _Union
+from typing import ClassVar as _ClassVar, Iterable as _Iterable, Mapping as _Mapping, Optional as _Optional, Union as _Union
 
 DESCRIPTOR: _descriptor.FileDescriptor
 
+class WriteMethod(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    WRITE_METHOD_UNSPECIFIED: _ClassVar[WriteMethod]
+    WRITE_METHOD_SET: _ClassVar[WriteMethod]
+    WRITE_METHOD_APPEND: _ClassVar[WriteMethod]
+    WRITE_METHOD_INCR: _ClassVar[WriteMethod]
+    WRITE_METHOD_UPDATE: _ClassVar[WriteMethod]
+WRITE_METHOD_UNSPECIFIED: WriteMethod
+WRITE_METHOD_SET: WriteMethod
+WRITE_METHOD_APPEND: WriteMethod
+WRITE_METHOD_INCR: WriteMethod
+WRITE_METHOD_UPDATE: WriteMethod
+
 class GetRequest(_message.Message):
     __slots__ = ("uuid", "selector", "keys")
     class KeysEntry(_message.Message):
@@ -28,14 +42,88 @@ class GetRequest(_message.Message):
     def __init__(self, uuid: _Optional[str] = ..., selector: _Optional[str] = ..., keys: _Optional[_Mapping[str, str]] = ...) -> None: ...
 
 class GetResponse(_message.Message):
-    __slots__ = ("uuid", "value", "feature_descriptor")
+    __slots__ = ("uuid", "value", "feature_descriptor", "fallback")
     UUID_FIELD_NUMBER: _ClassVar[int]
     VALUE_FIELD_NUMBER: _ClassVar[int]
     FEATURE_DESCRIPTOR_FIELD_NUMBER: _ClassVar[int]
+    FALLBACK_FIELD_NUMBER: _ClassVar[int]
     uuid: str
     value: _types_pb2.FeatureValue
     feature_descriptor: _types_pb2.FeatureDescriptor
-    def __init__(self, uuid: _Optional[str] = ..., value: _Optional[_Union[_types_pb2.FeatureValue, _Mapping]] = ..., feature_descriptor: _Optional[_Union[_types_pb2.FeatureDescriptor, _Mapping]] = ...) -> None: ...
+    fallback: _types_pb2.Fallback
+    def __init__(self, uuid: _Optional[str] = ..., value: _Optional[_Union[_types_pb2.FeatureValue, _Mapping]] = ..., feature_descriptor: _Optional[_Union[_types_pb2.FeatureDescriptor, _Mapping]] = ..., fallback: _Optional[_Union[_types_pb2.Fallback, str]] = ...) -> None: ...
+
+class BatchGetRequest(_message.Message):
+    __slots__ = ("uuid", "items")
+    UUID_FIELD_NUMBER: _ClassVar[int]
+    ITEMS_FIELD_NUMBER: _ClassVar[int]
+    uuid: str
+    items: _containers.RepeatedCompositeFieldContainer[BatchGetItem]
+    def __init__(self, uuid: _Optional[str] = ..., items: _Optional[_Iterable[_Union[BatchGetItem, _Mapping]]] = ...) -> None: ...
+
+class BatchGetItem(_message.Message):
+    __slots__ = ("selector", "keys")
+    class KeysEntry(_message.Message):
+        __slots__ = ("key", "value")
+        KEY_FIELD_NUMBER: _ClassVar[int]
+        VALUE_FIELD_NUMBER: _ClassVar[int]
+        key: str
+        value: str
+        def __init__(self, key: _Optional[str] = ..., value: _Optional[str] = ...) -> None: ...
+    SELECTOR_FIELD_NUMBER: _ClassVar[int]
+    KEYS_FIELD_NUMBER: _ClassVar[int]
+    selector: str
+    keys: _containers.ScalarMap[str, str]
+    def __init__(self, selector: _Optional[str] = ..., keys: _Optional[_Mapping[str, str]] = ...) -> None: ...
+
+class BatchGetResponse(_message.Message):
+    __slots__ = ("uuid", "results")
+    UUID_FIELD_NUMBER: _ClassVar[int]
+    RESULTS_FIELD_NUMBER: _ClassVar[int]
+    uuid: str
+    results: _containers.RepeatedCompositeFieldContainer[BatchGetResult]
+    def __init__(self, uuid: _Optional[str] = ..., results: _Optional[_Iterable[_Union[BatchGetResult, _Mapping]]] = ...) -> None: ...
+
+class BatchGetResult(_message.Message):
+    __slots__ = ("value", "feature_descriptor", "error", "code", "fallback")
+    VALUE_FIELD_NUMBER: _ClassVar[int]
+    FEATURE_DESCRIPTOR_FIELD_NUMBER: _ClassVar[int]
+    ERROR_FIELD_NUMBER: _ClassVar[int]
+    CODE_FIELD_NUMBER: _ClassVar[int]
+    FALLBACK_FIELD_NUMBER: _ClassVar[int]
+    value: _types_pb2.FeatureValue
+    feature_descriptor: _types_pb2.FeatureDescriptor
+    error: str
+    code: int
+    fallback: _types_pb2.Fallback
+    def __init__(self, value: _Optional[_Union[_types_pb2.FeatureValue, _Mapping]] = ..., feature_descriptor: _Optional[_Union[_types_pb2.FeatureDescriptor, _Mapping]] = ..., error: _Optional[str] = ..., code: _Optional[int] = ..., fallback: _Optional[_Union[_types_pb2.Fallback, str]] = ...) -> None: ...
+
+class WatchRequest(_message.Message):
+    __slots__ = ("uuid", "selectors", "keys")
+    class KeysEntry(_message.Message):
+        __slots__ = ("key", "value")
+        KEY_FIELD_NUMBER: _ClassVar[int]
+        VALUE_FIELD_NUMBER: _ClassVar[int]
+        key: str
+        value: str
+        def __init__(self, key: _Optional[str] = ..., value: _Optional[str] = ...) -> None: ...
+    UUID_FIELD_NUMBER: _ClassVar[int]
+    SELECTORS_FIELD_NUMBER: _ClassVar[int]
+    KEYS_FIELD_NUMBER: _ClassVar[int]
+    uuid: str
+    selectors: _containers.RepeatedScalarFieldContainer[str]
+    keys: _containers.ScalarMap[str, str]
+    def __init__(self, uuid: _Optional[str] = ..., selectors: _Optional[_Iterable[str]] = ..., keys: _Optional[_Mapping[str, str]] = ...) -> None: ...
+
+class WatchResponse(_message.Message):
+    __slots__ = ("uuid", "selector", "value")
+    UUID_FIELD_NUMBER: _ClassVar[int]
+    SELECTOR_FIELD_NUMBER: _ClassVar[int]
+    VALUE_FIELD_NUMBER: _ClassVar[int]
+    uuid: str
+    selector: str
+    value: _types_pb2.FeatureValue
+    def __init__(self, uuid: _Optional[str] = ..., selector: _Optional[str] = ..., value: _Optional[_Union[_types_pb2.FeatureValue, _Mapping]] = ...) -> None: ...
 
 class FeatureDescriptorRequest(_message.Message):
     __slots__ = ("uuid", "selector")
@@ -168,3 +256,44 @@ class UpdateResponse(_message.Message):
     uuid: str
     timestamp: _timestamp_pb2.Timestamp
     def __init__(self, uuid: _Optional[str] = ..., timestamp: _Optional[_Union[_timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
+
+class IngestRequest(_message.Message):
+    __slots__ = ("method", "selector", "keys", "value", "timestamp")
+    class KeysEntry(_message.Message):
+        __slots__ = ("key", "value")
+        KEY_FIELD_NUMBER: _ClassVar[int]
+        VALUE_FIELD_NUMBER: _ClassVar[int]
+        key: str
+        value: str
+        def __init__(self, key: _Optional[str] = ..., value: _Optional[str] = ...) -> None: ...
+    METHOD_FIELD_NUMBER: _ClassVar[int]
+    SELECTOR_FIELD_NUMBER: _ClassVar[int]
+    KEYS_FIELD_NUMBER: _ClassVar[int]
+    VALUE_FIELD_NUMBER: _ClassVar[int]
+    TIMESTAMP_FIELD_NUMBER: _ClassVar[int]
+    method: WriteMethod
+    selector: str
+    keys: _containers.ScalarMap[str, str]
+    value: _types_pb2.Value
+    timestamp: _timestamp_pb2.Timestamp
+    def __init__(self, method: _Optional[_Union[WriteMethod, str]] = ..., selector: _Optional[str] = ..., keys: _Optional[_Mapping[str, str]] = ..., value: _Optional[_Union[_types_pb2.Value, _Mapping]] = ..., timestamp: _Optional[_Union[_timestamp_pb2.Timestamp, _Mapping]] = ...) -> None: ...
+
+class IngestFailure(_message.Message):
+    __slots__ = ("index", "error")
+    INDEX_FIELD_NUMBER: _ClassVar[int]
+    ERROR_FIELD_NUMBER: _ClassVar[int]
+    index: int
+    error: str
+    def __init__(self, index: _Optional[int] = ..., error: _Optional[str] = ...) -> None: ...
+
+class IngestResponse(_message.Message):
+    __slots__ = ("received", "succeeded", "failed", "failures")
+    RECEIVED_FIELD_NUMBER: _ClassVar[int]
+    SUCCEEDED_FIELD_NUMBER: _ClassVar[int]
+    FAILED_FIELD_NUMBER: _ClassVar[int]
+    FAILURES_FIELD_NUMBER: _ClassVar[int]
+    received: int
+    succeeded: int
+    failed: int
+    failures: _containers.RepeatedCompositeFieldContainer[IngestFailure]
+    def __init__(self, received: _Optional[int] = ..., succeeded: _Optional[int] = ..., failed: _Optional[int] = ..., failures: _Optional[_Iterable[_Union[IngestFailure, _Mapping]]] = ...) -> None: ...
@@ -29,6 +29,16 @@ class EngineServiceStub(object):
                 request_serializer=core_dot_v1alpha1_dot_api__pb2.GetRequest.SerializeToString,
                 response_deserializer=core_dot_v1alpha1_dot_api__pb2.GetResponse.FromString,
                 )
+        self.BatchGet = channel.unary_unary(
+                '/core.v1alpha1.EngineService/BatchGet',
+                request_serializer=core_dot_v1alpha1_dot_api__pb2.BatchGetRequest.SerializeToString,
+                response_deserializer=core_dot_v1alpha1_dot_api__pb2.BatchGetResponse.FromString,
+                )
+        self.Watch = channel.unary_stream(
+                '/core.v1alpha1.EngineService/Watch',
+                request_serializer=core_dot_v1alpha1_dot_api__pb2.WatchRequest.SerializeToString,
+                response_deserializer=core_dot_v1alpha1_dot_api__pb2.WatchResponse.FromString,
+                )
         self.Set = channel.unary_unary(
                 '/core.v1alpha1.EngineService/Set',
                 request_serializer=core_dot_v1alpha1_dot_api__pb2.SetRequest.SerializeToString,
@@ -49,6 +59,11 @@ class EngineServiceStub(object):
                 request_serializer=core_dot_v1alpha1_dot_api__pb2.UpdateRequest.SerializeToString,
                 response_deserializer=core_dot_v1alpha1_dot_api__pb2.UpdateResponse.FromString,
                 )
+        self.Ingest = channel.stream_unary(
+                '/core.v1alpha1.EngineService/Ingest',
+                request_serializer=core_dot_v1alpha1_dot_api__pb2.IngestRequest.SerializeToString,
+                response_deserializer=core_dot_v1alpha1_dot_api__pb2.IngestResponse.FromString,
+                )
 
 
 class EngineServiceServicer(object):
@@ -73,6 +88,21 @@ class EngineServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def BatchGet(self, request, context):
+        """BatchGet returns the feature values or model predictions for multiple selectors and keys at once.
+        Each result carries its own error, so a single failing item doesn't fail the whole batch.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
+    def Watch(self, request, context):
+        """Watch streams the feature values for the given selectors whenever they are updated.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
     def Set(self, request, context):
         """Set sets the feature value for the given selector.
         """
@@ -101,6 +131,13 @@ class EngineServiceServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def Ingest(self, request_iterator, context):
+        """Ingest applies a stream of write operations, and returns a summary with the per-record failures.
+        """
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_EngineServiceServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -114,6 +151,16 @@ def add_EngineServiceServicer_to_server(servicer, server):
                     request_deserializer=core_dot_v1alpha1_dot_api__pb2.GetRequest.FromString,
                     response_serializer=core_dot_v1alpha1_dot_api__pb2.GetResponse.SerializeToString,
             ),
+            'BatchGet': grpc.unary_unary_rpc_method_handler(
+                    servicer.BatchGet,
+                    request_deserializer=core_dot_v1alpha1_dot_api__pb2.BatchGetRequest.FromString,
+                    response_serializer=core_dot_v1alpha1_dot_api__pb2.BatchGetResponse.SerializeToString,
+            ),
+            'Watch': grpc.unary_stream_rpc_method_handler(
+                    servicer.Watch,
+                    request_deserializer=core_dot_v1alpha1_dot_api__pb2.WatchRequest.FromString,
+                    response_serializer=core_dot_v1alpha1_dot_api__pb2.WatchResponse.SerializeToString,
+            ),
             'Set': grpc.unary_unary_rpc_method_handler(
                     servicer.Set,
                     request_deserializer=core_dot_v1alpha1_dot_api__pb2.SetRequest.FromString,
@@ -134,6 +181,11 @@ def add_EngineServiceServicer_to_server(servicer, server):
                     request_deserializer=core_dot_v1alpha1_dot_api__pb2.UpdateRequest.FromString,
                     response_serializer=core_dot_v1alpha1_dot_api__pb2.UpdateResponse.SerializeToString,
             ),
+            'Ingest': grpc.stream_unary_rpc_method_handler(
+                    servicer.Ingest,
+                    request_deserializer=core_dot_v1alpha1_dot_api__pb2.IngestRequest.FromString,
+                    response_serializer=core_dot_v1alpha1_dot_api__pb2.IngestResponse.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'core.v1alpha1.EngineService', rpc_method_handlers)
@@ -183,6 +235,40 @@ class EngineService(object):
             options, channel_credentials,
             insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
 
+    @staticmethod
+    def BatchGet(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(request, target, '/core.v1alpha1.EngineService/BatchGet',
+            core_dot_v1alpha1_dot_api__pb2.BatchGetRequest.SerializeToString,
+            core_dot_v1alpha1_dot_api__pb2.BatchGetResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def Watch(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_stream(request, target, '/core.v1alpha1.EngineService/Watch',
+            core_dot_v1alpha1_dot_api__pb2.WatchRequest.SerializeToString,
+            core_dot_v1alpha1_dot_api__pb2.WatchResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
     @staticmethod
     def Set(request,
             target,
@@ -250,3 +336,20 @@ class EngineService(object):
             core_dot_v1alpha1_dot_api__pb2.UpdateResponse.FromString,
             options, channel_credentials,
             insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
+
+    @staticmethod
+    def Ingest(request_iterator,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.stream_unary(request_iterator, target, '/core.v1alpha1.EngineService/Ingest',
+            core_dot_v1alpha1_dot_api__pb2.IngestRequest.SerializeToString,
+            core_dot_v1alpha1_dot_api__pb2.IngestResponse.FromString,
+            options, channel_credentials,
+            insecure, call_credentials, compression, wait_for_ready, timeout, metadata)
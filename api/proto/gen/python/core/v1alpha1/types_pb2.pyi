@@ -22,6 +22,8 @@ class Primitive(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
     PRIMITIVE_FLOAT_LIST: _ClassVar[Primitive]
     PRIMITIVE_BOOL_LIST: _ClassVar[Primitive]
     PRIMITIVE_TIMESTAMP_LIST: _ClassVar[Primitive]
+    PRIMITIVE_EMBEDDING: _ClassVar[Primitive]
+    PRIMITIVE_STRUCT: _ClassVar[Primitive]
 
 class AggrFn(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
     __slots__ = ()
@@ -31,6 +33,27 @@ class AggrFn(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
     AGGR_FN_MAX: _ClassVar[AggrFn]
     AGGR_FN_MIN: _ClassVar[AggrFn]
     AGGR_FN_COUNT: _ClassVar[AggrFn]
+    AGGR_FN_COUNT_DISTINCT: _ClassVar[AggrFn]
+    AGGR_FN_STDDEV: _ClassVar[AggrFn]
+    AGGR_FN_VARIANCE: _ClassVar[AggrFn]
+    AGGR_FN_FIRST: _ClassVar[AggrFn]
+    AGGR_FN_LAST: _ClassVar[AggrFn]
+    AGGR_FN_P50: _ClassVar[AggrFn]
+    AGGR_FN_P95: _ClassVar[AggrFn]
+    AGGR_FN_P99: _ClassVar[AggrFn]
+
+class ErrorPolicy(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    ERROR_POLICY_UNSPECIFIED: _ClassVar[ErrorPolicy]
+    ERROR_POLICY_FAIL: _ClassVar[ErrorPolicy]
+    ERROR_POLICY_DEFAULT: _ClassVar[ErrorPolicy]
+    ERROR_POLICY_LAST_KNOWN: _ClassVar[ErrorPolicy]
+
+class Fallback(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    FALLBACK_UNSPECIFIED: _ClassVar[Fallback]
+    FALLBACK_DEFAULT: _ClassVar[Fallback]
+    FALLBACK_LAST_KNOWN: _ClassVar[Fallback]
 PRIMITIVE_UNSPECIFIED: Primitive
 PRIMITIVE_STRING: Primitive
 PRIMITIVE_INTEGER: Primitive
@@ -42,12 +65,29 @@ PRIMITIVE_INTEGER_LIST: Primitive
 PRIMITIVE_FLOAT_LIST: Primitive
 PRIMITIVE_BOOL_LIST: Primitive
 PRIMITIVE_TIMESTAMP_LIST: Primitive
+PRIMITIVE_EMBEDDING: Primitive
+PRIMITIVE_STRUCT: Primitive
 AGGR_FN_UNSPECIFIED: AggrFn
 AGGR_FN_SUM: AggrFn
 AGGR_FN_AVG: AggrFn
 AGGR_FN_MAX: AggrFn
 AGGR_FN_MIN: AggrFn
 AGGR_FN_COUNT: AggrFn
+AGGR_FN_COUNT_DISTINCT: AggrFn
+AGGR_FN_STDDEV: AggrFn
+AGGR_FN_VARIANCE: AggrFn
+AGGR_FN_FIRST: AggrFn
+AGGR_FN_LAST: AggrFn
+AGGR_FN_P50: AggrFn
+AGGR_FN_P95: AggrFn
+AGGR_FN_P99: AggrFn
+ERROR_POLICY_UNSPECIFIED: ErrorPolicy
+ERROR_POLICY_FAIL: ErrorPolicy
+ERROR_POLICY_DEFAULT: ErrorPolicy
+ERROR_POLICY_LAST_KNOWN: ErrorPolicy
+FALLBACK_UNSPECIFIED: Fallback
+FALLBACK_DEFAULT: Fallback
+FALLBACK_LAST_KNOWN: Fallback
 
 class Scalar(_message.Message):
     __slots__ = ("string_value", "int_value", "float_value", "bool_value", "timestamp_value")
@@ -69,13 +109,36 @@ class List(_message.Message):
     values: _containers.RepeatedCompositeFieldContainer[Scalar]
     def __init__(self, values: _Optional[_Iterable[_Union[Scalar, _Mapping]]] = ...) -> None: ...
 
+class Embedding(_message.Message):
+    __slots__ = ("values",)
+    VALUES_FIELD_NUMBER: _ClassVar[int]
+    values: _containers.RepeatedScalarFieldContainer[float]
+    def __init__(self, values: _Optional[_Iterable[float]] = ...) -> None: ...
+
+class Struct(_message.Message):
+    __slots__ = ("fields",)
+    class FieldsEntry(_message.Message):
+        __slots__ = ("key", "value")
+        KEY_FIELD_NUMBER: _ClassVar[int]
+        VALUE_FIELD_NUMBER: _ClassVar[int]
+        key: str
+        value: Value
+        def __init__(self, key: _Optional[str] = ..., value: _Optional[_Union[Value, _Mapping]] = ...) -> None: ...
+    FIELDS_FIELD_NUMBER: _ClassVar[int]
+    fields: _containers.MessageMap[str, Value]
+    def __init__(self, fields: _Optional[_Mapping[str, Value]] = ...) -> None: ...
+
 class Value(_message.Message):
-    __slots__ = ("scalar_value", "list_value")
+    __slots__ = ("scalar_value", "list_value", "embedding_value", "struct_value")
     SCALAR_VALUE_FIELD_NUMBER: _ClassVar[int]
     LIST_VALUE_FIELD_NUMBER: _ClassVar[int]
+    EMBEDDING_VALUE_FIELD_NUMBER: _ClassVar[int]
+    STRUCT_VALUE_FIELD_NUMBER: _ClassVar[int]
     scalar_value: Scalar
     list_value: List
-    def __init__(self, scalar_value: _Optional[_Union[Scalar, _Mapping]] = ..., list_value: _Optional[_Union[List, _Mapping]] = ...) -> None: ...
+    embedding_value: Embedding
+    struct_value: Struct
+    def __init__(self, scalar_value: _Optional[_Union[Scalar, _Mapping]] = ..., list_value: _Optional[_Union[List, _Mapping]] = ..., embedding_value: _Optional[_Union[Embedding, _Mapping]] = ..., struct_value: _Optional[_Union[Struct, _Mapping]] = ...) -> None: ...
 
 class ObjectReference(_message.Message):
     __slots__ = ("name", "namespace")
@@ -93,8 +156,16 @@ class KeepPrevious(_message.Message):
     over: _duration_pb2.Duration
     def __init__(self, versions: _Optional[int] = ..., over: _Optional[_Union[_duration_pb2.Duration, _Mapping]] = ...) -> None: ...
 
+class StructField(_message.Message):
+    __slots__ = ("name", "primitive")
+    NAME_FIELD_NUMBER: _ClassVar[int]
+    PRIMITIVE_FIELD_NUMBER: _ClassVar[int]
+    name: str
+    primitive: Primitive
+    def __init__(self, name: _Optional[str] = ..., primitive: _Optional[_Union[Primitive, str]] = ...) -> None: ...
+
 class FeatureDescriptor(_message.Message):
-    __slots__ = ("fqn", "primitive", "aggr", "freshness", "staleness", "timeout", "keep_previous", "keys", "builder", "data_source", "runtime_env")
+    __slots__ = ("fqn", "primitive", "aggr", "freshness", "staleness", "timeout", "keep_previous", "keys", "builder", "data_source", "runtime_env", "embedding_dim", "fields", "default_value", "on_error")
     FQN_FIELD_NUMBER: _ClassVar[int]
     PRIMITIVE_FIELD_NUMBER: _ClassVar[int]
     AGGR_FIELD_NUMBER: _ClassVar[int]
@@ -106,6 +177,10 @@ class FeatureDescriptor(_message.Message):
     BUILDER_FIELD_NUMBER: _ClassVar[int]
     DATA_SOURCE_FIELD_NUMBER: _ClassVar[int]
     RUNTIME_ENV_FIELD_NUMBER: _ClassVar[int]
+    EMBEDDING_DIM_FIELD_NUMBER: _ClassVar[int]
+    FIELDS_FIELD_NUMBER: _ClassVar[int]
+    DEFAULT_VALUE_FIELD_NUMBER: _ClassVar[int]
+    ON_ERROR_FIELD_NUMBER: _ClassVar[int]
     fqn: str
     primitive: Primitive
     aggr: _containers.RepeatedScalarFieldContainer[AggrFn]
@@ -117,10 +192,14 @@ class FeatureDescriptor(_message.Message):
     builder: str
     data_source: str
     runtime_env: str
-    def __init__(self, fqn: _Optional[str] = ..., primitive: _Optional[_Union[Primitive, str]] = ..., aggr: _Optional[_Iterable[_Union[AggrFn, str]]] = ..., freshness: _Optional[_Union[_duration_pb2.Duration, _Mapping]] = ..., staleness: _Optional[_Union[_duration_pb2.Duration, _Mapping]] = ..., timeout: _Optional[_Union[_duration_pb2.Duration, _Mapping]] = ..., keep_previous: _Optional[_Union[KeepPrevious, _Mapping]] = ..., keys: _Optional[_Iterable[str]] = ..., builder: _Optional[str] = ..., data_source: _Optional[str] = ..., runtime_env: _Optional[str] = ...) -> None: ...
+    embedding_dim: int
+    fields: _containers.RepeatedCompositeFieldContainer[StructField]
+    default_value: Value
+    on_error: ErrorPolicy
+    def __init__(self, fqn: _Optional[str] = ..., primitive: _Optional[_Union[Primitive, str]] = ..., aggr: _Optional[_Iterable[_Union[AggrFn, str]]] = ..., freshness: _Optional[_Union[_duration_pb2.Duration, _Mapping]] = ..., staleness: _Optional[_Union[_duration_pb2.Duration, _Mapping]] = ..., timeout: _Optional[_Union[_duration_pb2.Duration, _Mapping]] = ..., keep_previous: _Optional[_Union[KeepPrevious, _Mapping]] = ..., keys: _Optional[_Iterable[str]] = ..., builder: _Optional[str] = ..., data_source: _Optional[str] = ..., runtime_env: _Optional[str] = ..., embedding_dim: _Optional[int] = ..., fields: _Optional[_Iterable[_Union[StructField, _Mapping]]] = ..., default_value: _Optional[_Union[Value, _Mapping]] = ..., on_error: _Optional[_Union[ErrorPolicy, str]] = ...) -> None: ...
 
 class FeatureValue(_message.Message):
-    __slots__ = ("fqn", "keys", "value", "timestamp", "fresh")
+    __slots__ = ("fqn", "keys", "value", "timestamp", "fresh", "degraded")
     class KeysEntry(_message.Message):
         __slots__ = ("key", "value")
         KEY_FIELD_NUMBER: _ClassVar[int]
@@ -133,9 +212,21 @@ class FeatureValue(_message.Message):
     VALUE_FIELD_NUMBER: _ClassVar[int]
     TIMESTAMP_FIELD_NUMBER: _ClassVar[int]
     FRESH_FIELD_NUMBER: _ClassVar[int]
+    DEGRADED_FIELD_NUMBER: _ClassVar[int]
     fqn: str
     keys: _containers.ScalarMap[str, str]
     value: Value
     timestamp: _timestamp_pb2.Timestamp
     fresh: bool
-    def __init__(self, fqn: _Optional[str] = ..., keys: _Optional[_Mapping[str, str]] = ..., value: _Optional[_Union[Value, _Mapping]] = ..., timestamp: _Optional[_Union[_timestamp_pb2.Timestamp, _Mapping]] = ..., fresh: bool = ...) -> None: ...
+    degraded: _containers.RepeatedCompositeFieldContainer[DegradedInput]
+    def __init__(self, fqn: _Optional[str] = ..., keys: _Optional[_Mapping[str, str]] = ..., value: _Optional[_Union[Value, _Mapping]] = ..., timestamp: _Optional[_Union[_timestamp_pb2.Timestamp, _Mapping]] = ..., fresh: bool = ..., degraded: _Optional[_Iterable[_Union[DegradedInput, _Mapping]]] = ...) -> None: ...
+
+class DegradedInput(_message.Message):
+    __slots__ = ("selector", "policy", "error")
+    SELECTOR_FIELD_NUMBER: _ClassVar[int]
+    POLICY_FIELD_NUMBER: _ClassVar[int]
+    ERROR_FIELD_NUMBER: _ClassVar[int]
+    selector: str
+    policy: str
+    error: str
+    def __init__(self, selector: _Optional[str] = ..., policy: _Optional[str] = ..., error: _Optional[str] = ...) -> None: ...
@@ -27,6 +27,32 @@ type Value struct {
 	Value     any       `json:"value"`
 	Timestamp time.Time `json:"timestamp"`
 	Fresh     bool      `json:"fresh"`
+	// Degraded lists the inputs that were missing while calculating the value, and how they were handled.
+	// It's empty when the value was calculated out of all of its inputs.
+	Degraded []DegradedInput `json:"degraded,omitempty"`
+	// Fallback indicates that the value was served by a fallback, instead of being calculated.
+	// It's empty when the value was calculated.
+	Fallback Fallback `json:"fallback,omitempty"`
+}
+
+// Fallback is the kind of fallback that served a value
+type Fallback string
+
+const (
+	// FallbackDefault is set when the value is the default value of the feature
+	FallbackDefault Fallback = "default"
+	// FallbackLastKnown is set when the value is the last known value of the feature, that may be stale
+	FallbackLastKnown Fallback = "lastKnown"
+)
+
+// DegradedInput is an input of a value that was missing, and was handled by a failure policy
+type DegradedInput struct {
+	// Selector of the missing input
+	Selector string `json:"selector"`
+	// Policy that handled the missing input, i.e. `omit`, `default` or `impute`
+	Policy string `json:"policy"`
+	// Error that caused the input to be missing, if any
+	Error string `json:"error,omitempty"`
 }
 
 // WindowResultMap is a map of AggrFn and their aggregated results
@@ -34,16 +60,16 @@ type WindowResultMap map[AggrFn]float64
 
 // RawBucket is the data that is stored in the raw bucket.
 type RawBucket struct {
-	FQN         string          `json:"FQN"`
-	Bucket      string          `json:"bucket"`
-	EncodedKeys string          `json:"encoded_keys"`
-	Data        WindowResultMap `json:"raw"`
+	FQN         string     `json:"FQN"`
+	Bucket      string     `json:"bucket"`
+	EncodedKeys string     `json:"encoded_keys"`
+	Data        BucketData `json:"raw"`
 }
 type RawBuckets []RawBucket
 
 // LowLevelValue is a low level value that can be cast to any type
 type LowLevelValue interface {
-	~int | ~string | ~float64 | time.Time | ~[]int | ~[]string | ~[]float64 | ~[]time.Time | WindowResultMap
+	~int | ~string | ~float64 | time.Time | ~[]int | ~[]string | ~[]float64 | ~[]time.Time | WindowResultMap | BucketData | Embedding | Struct
 }
 
 // ToLowLevelValue returns the low level value of the feature
@@ -85,6 +111,11 @@ type State interface {
 	// Buckets should last *at least* as long as the feature's staleness time + DeadGracePeriod
 	WindowAdd(ctx context.Context, fd FeatureDescriptor, keys Keys, val any, timestamp time.Time) error
 
+	// WindowMerge merges the raw data into the bucket of the window that contains the timestamp.
+	// It is used to restore buckets out of their historical records, since the raw data of some aggregations
+	// (e.g. the sketches of the distinct count and the percentiles) can't be reproduced by adding values.
+	WindowMerge(ctx context.Context, fd FeatureDescriptor, keys Keys, data BucketData, timestamp time.Time) error
+
 	// WindowBuckets returns the list of RawBuckets for the feature and specific Keys.
 	WindowBuckets(ctx context.Context, fd FeatureDescriptor, keys Keys, buckets []string) (RawBuckets, error)
 
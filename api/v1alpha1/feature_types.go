@@ -26,13 +26,28 @@ import (
 )
 
 // AggrFn defines the type of aggregation
-// +kubebuilder:validation:Enum=count;min;max;sum;avg;mean
+// +kubebuilder:validation:Enum=count;min;max;sum;avg;mean;count_distinct;distinct_count;approx_distinct_count;stddev;variance;first;last;p50;median;p95;p99
 type AggrFn string
 
-// PrimitiveType defines the type of primitive
-// +kubebuilder:validation:Enum=int;float;string;bool;timestamp;[]int;[]float;[]string;[]bool;[]timestamp
+// PrimitiveType defines the type of primitive.
+// Embeddings are defined with their dimension, i.e. `embedding(128)`.
+// Structs are defined with `struct`, and their fields are defined in FeatureSpec.Fields.
+// +kubebuilder:validation:Pattern=`^(int|float|string|bool|timestamp|\[\](int|float|string|bool|timestamp)|embedding\([1-9][0-9]*\)|struct)$`
 type PrimitiveType string
 
+// ErrorPolicy defines how a read of a feature is handled when the feature-value failed to be calculated.
+// +kubebuilder:validation:Enum=fail;default;lastKnown
+type ErrorPolicy string
+
+const (
+	// ErrorPolicyFail fails the read
+	ErrorPolicyFail ErrorPolicy = "fail"
+	// ErrorPolicyDefault responds with the default value of the feature
+	ErrorPolicyDefault ErrorPolicy = "default"
+	// ErrorPolicyLastKnown responds with the last known value of the feature, regardless of its staleness
+	ErrorPolicyLastKnown ErrorPolicy = "lastKnown"
+)
+
 // FeatureSpec defines the desired state of Feature
 type FeatureSpec struct {
 	// Primitive defines the type of the underlying feature-value that a Feature should respond with.
@@ -40,6 +55,12 @@ type FeatureSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Primitive Type"
 	Primitive PrimitiveType `json:"primitive"`
 
+	// Fields defines the schema of a `struct` feature-value.
+	// +optional
+	// +nullable
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Struct Fields"
+	Fields []StructField `json:"fields,omitempty"`
+
 	// Freshness defines the age of a feature-value(time since the value has set) to consider as *fresh*.
 	// Fresh values doesn't require re-ingestion
 	// +kubebuilder:validation:Required
@@ -78,6 +99,104 @@ type FeatureSpec struct {
 	// +kubebuilder:validation:Required
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Builder"
 	Builder FeatureBuilder `json:"builder"`
+
+	// Encodings defines the configuration of the encodings that can be requested by the selector, i.e. `ns.name[onehot]`.
+	// It's a map from the encoding name to its configuration, e.g. `onehot: {vocabulary: [a, b]}`.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +optional
+	// +nullable
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Encodings"
+	Encodings json.RawMessage `json:"encodings,omitempty"`
+
+	// Default is the value that is returned when the feature has no value for the requested keys.
+	// It must match the feature's primitive.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +optional
+	// +nullable
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Default Value"
+	Default json.RawMessage `json:"default,omitempty"`
+
+	// OnError defines how a read of the feature is handled when the feature-value failed to be calculated:
+	// `fail` the read (the default), respond with the `default` value, or respond with the `lastKnown` value regardless
+	// of its staleness. The `lastKnown` policy is not supported for windowed features.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="On Error"
+	OnError ErrorPolicy `json:"onError,omitempty"`
+
+	// Constraints defines the constraints of the feature-values that are written to the state.
+	// +optional
+	// +nullable
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Constraints"
+	Constraints *FeatureConstraints `json:"constraints,omitempty"`
+}
+
+// ConstraintMode defines how a written feature-value that violates the constraints is handled.
+// +kubebuilder:validation:Enum=reject;clamp;log
+type ConstraintMode string
+
+const (
+	// ConstraintModeReject rejects the write
+	ConstraintModeReject ConstraintMode = "reject"
+	// ConstraintModeClamp clamps the value to the min/max and the list to the max items. Other violations are rejected.
+	ConstraintModeClamp ConstraintMode = "clamp"
+	// ConstraintModeLog logs the violation, and accepts the value as is
+	ConstraintModeLog ConstraintMode = "log"
+)
+
+// FeatureConstraints defines the constraints of the feature-values. For list features, the constraints of the values
+// apply to each of the list items.
+type FeatureConstraints struct {
+	// Mode defines how a value that violates the constraints is handled: `reject` the write (the default), `clamp`
+	// the value, or `log` the violation and accept the value.
+	// +optional
+	Mode ConstraintMode `json:"mode,omitempty"`
+
+	// Min is the minimum of numeric values.
+	// +kubebuilder:validation:Type=number
+	// +optional
+	// +nullable
+	Min *json.Number `json:"min,omitempty"`
+
+	// Max is the maximum of numeric values.
+	// +kubebuilder:validation:Type=number
+	// +optional
+	// +nullable
+	Max *json.Number `json:"max,omitempty"`
+
+	// AllowedValues is the list of the allowed values.
+	// +optional
+	// +nullable
+	AllowedValues []string `json:"allowedValues,omitempty"`
+
+	// Pattern is a regular expression that string values must match.
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+
+	// MaxItems is the maximum length of list values.
+	// It's enforced when the whole list is written, but not on appends, which don't read the list from the state.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +nullable
+	MaxItems *int `json:"maxItems,omitempty"`
+
+	// NotNull rejects null values and empty strings.
+	// +optional
+	NotNull bool `json:"notNull,omitempty"`
+}
+
+// StructField defines a single field of a `struct` feature-value.
+type StructField struct {
+	// Name of the field.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]+(?:_[a-z0-9]+)*$`
+	Name string `json:"name"`
+
+	// Primitive defines the type of the field. Fields can't be nested structs or embeddings.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^(int|float|string|bool|timestamp|\[\](int|float|string|bool|timestamp))$`
+	Primitive PrimitiveType `json:"primitive"`
 }
 
 type KeepPrevious struct {
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,9 +26,49 @@ import (
 )
 
 // ModelServer defines the backend inference server for the model.
-// +kubebuilder:validation:Enum=sagemaker-ack
+// +kubebuilder:validation:Enum=sagemaker-ack;kserve-v2
 type ModelServer string
 
+// MissingFeaturePolicy defines how a feature of the model is handled when it has no value, or failed to be retrieved.
+// +kubebuilder:validation:Enum=required;omit;default;impute
+type MissingFeaturePolicy string
+
+const (
+	// MissingFeaturePolicyRequired fails the prediction.
+	MissingFeaturePolicyRequired MissingFeaturePolicy = "required"
+	// MissingFeaturePolicyOmit sends the feature-set to the model server without the feature.
+	MissingFeaturePolicyOmit MissingFeaturePolicy = "omit"
+	// MissingFeaturePolicyDefault replaces the feature with a default value.
+	MissingFeaturePolicyDefault MissingFeaturePolicy = "default"
+	// MissingFeaturePolicyImpute replaces the feature with a statistic of its recent historical values.
+	MissingFeaturePolicyImpute MissingFeaturePolicy = "impute"
+)
+
+// ModelFeaturePolicy defines how a missing feature of the model is handled.
+type ModelFeaturePolicy struct {
+	// Feature is the FQN of the feature, as specified in the model's features.
+	// +kubebuilder:validation:Required
+	Feature string `json:"feature"`
+
+	// OnMissing defines how the feature is handled when it has no value, or failed to be retrieved.
+	// Features without a policy are omitted when they fail to be retrieved, and are passed as is when they have no value.
+	// +kubebuilder:validation:Required
+	OnMissing MissingFeaturePolicy `json:"onMissing"`
+
+	// Default is the value that replaces the feature when the policy is `default`.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +optional
+	// +nullable
+	Default json.RawMessage `json:"default,omitempty"`
+
+	// Statistic is the statistic of the recent historical values of the feature that replaces the feature when the
+	// policy is `impute`. Only numeric features can be imputed.
+	// +kubebuilder:validation:Enum=avg;mean;median;p50;p95;p99;min;max;last
+	// +optional
+	Statistic string `json:"statistic,omitempty"`
+}
+
 // ModelSpec defines the list of feature FQNs that are enabled for a given feature set
 type ModelSpec struct {
 	// Freshness defines the age of a prediction-result(time since the value has set) to consider as *fresh*.
@@ -54,6 +95,12 @@ type ModelSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Features"
 	Features []string `json:"features"`
 
+	// FeaturePolicies defines how the features are handled when they have no value, or failed to be retrieved.
+	// +optional
+	// +nullable
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Feature Policies"
+	FeaturePolicies []ModelFeaturePolicy `json:"featurePolicies,omitempty"`
+
 	// KeyFeature is the feature FQN that is used to align the rest of the features with their timestamp.
 	// If this is unset, the first feature in the list will be used.
 	// +optional
@@ -226,6 +226,41 @@ func (in *FeatureBuilder) DeepCopy() *FeatureBuilder {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FeatureConstraints) DeepCopyInto(out *FeatureConstraints) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(json.Number)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(json.Number)
+		**out = **in
+	}
+	if in.AllowedValues != nil {
+		in, out := &in.AllowedValues, &out.AllowedValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxItems != nil {
+		in, out := &in.MaxItems, &out.MaxItems
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureConstraints.
+func (in *FeatureConstraints) DeepCopy() *FeatureConstraints {
+	if in == nil {
+		return nil
+	}
+	out := new(FeatureConstraints)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureList) DeepCopyInto(out *FeatureList) {
 	*out = *in
@@ -261,6 +296,11 @@ func (in *FeatureList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureSpec) DeepCopyInto(out *FeatureSpec) {
 	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]StructField, len(*in))
+		copy(*out, *in)
+	}
 	out.Freshness = in.Freshness
 	out.Staleness = in.Staleness
 	out.Timeout = in.Timeout
@@ -280,6 +320,21 @@ func (in *FeatureSpec) DeepCopyInto(out *FeatureSpec) {
 		**out = **in
 	}
 	in.Builder.DeepCopyInto(&out.Builder)
+	if in.Encodings != nil {
+		in, out := &in.Encodings, &out.Encodings
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Constraints != nil {
+		in, out := &in.Constraints, &out.Constraints
+		*out = new(FeatureConstraints)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FeatureSpec.
@@ -387,6 +442,26 @@ func (in *ModelList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelFeaturePolicy) DeepCopyInto(out *ModelFeaturePolicy) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelFeaturePolicy.
+func (in *ModelFeaturePolicy) DeepCopy() *ModelFeaturePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelFeaturePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
 	*out = *in
@@ -398,6 +473,13 @@ func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.FeaturePolicies != nil {
+		in, out := &in.FeaturePolicies, &out.FeaturePolicies
+		*out = make([]ModelFeaturePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make([]string, len(*in))
@@ -477,3 +559,18 @@ func (in *ResourceReference) DeepCopy() *ResourceReference {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StructField) DeepCopyInto(out *StructField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StructField.
+func (in *StructField) DeepCopy() *StructField {
+	if in == nil {
+		return nil
+	}
+	out := new(StructField)
+	in.DeepCopyInto(out)
+	return out
+}
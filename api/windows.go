@@ -38,6 +38,14 @@ const (
 	AggrFnMax
 	AggrFnMin
 	AggrFnCount
+	AggrFnCountDistinct
+	AggrFnStddev
+	AggrFnVariance
+	AggrFnFirst
+	AggrFnLast
+	AggrFnP50
+	AggrFnP95
+	AggrFnP99
 )
 
 func (w AggrFn) String() string {
@@ -52,11 +60,32 @@ func (w AggrFn) String() string {
 		return "min"
 	case AggrFnCount:
 		return "count"
+	case AggrFnCountDistinct:
+		return "count_distinct"
+	case AggrFnStddev:
+		return "stddev"
+	case AggrFnVariance:
+		return "variance"
+	case AggrFnFirst:
+		return "first"
+	case AggrFnLast:
+		return "last"
+	case AggrFnP50:
+		return "p50"
+	case AggrFnP95:
+		return "p95"
+	case AggrFnP99:
+		return "p99"
 	default:
 		return "unknown"
 	}
 }
 
+// Numeric returns true if the aggregation function can only aggregate numeric values.
+func (w AggrFn) Numeric() bool {
+	return w != AggrFnCount && w != AggrFnCountDistinct
+}
+
 func StringsToAggrFns(fns []string) ([]AggrFn, error) {
 	aggrFnsMap := make(map[AggrFn]bool)
 	for _, fn := range fns {
@@ -87,6 +116,22 @@ func StringToAggrFn(s string) AggrFn {
 		return AggrFnMax
 	case "count":
 		return AggrFnCount
+	case "count_distinct", "distinct_count", "approx_distinct_count":
+		return AggrFnCountDistinct
+	case "stddev":
+		return AggrFnStddev
+	case "variance":
+		return AggrFnVariance
+	case "first":
+		return AggrFnFirst
+	case "last":
+		return AggrFnLast
+	case "p50", "median":
+		return AggrFnP50
+	case "p95":
+		return AggrFnP95
+	case "p99":
+		return AggrFnP99
 	default:
 		return AggrFnUnknown
 	}
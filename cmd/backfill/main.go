@@ -0,0 +1,157 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// backfill re-materializes the online state of the features out of their historical records.
+//
+// It restores the latest values that are not stale yet (including the previous versions that are kept), and the
+// buckets of windowed features that are still alive. Data that is already in the state is never overridden, so it
+// is safe to run it while the features are being updated, and to re-run it after a failure. With `--checkpoint`,
+// features that were completed by a previous run are skipped.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/internal/featureloader"
+	"github.com/raptor-ml/raptor/internal/version"
+	"github.com/raptor-ml/raptor/pkg/historical"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	// to ensure that exec-entrypoint and run can make use of them.
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	_ "github.com/raptor-ml/raptor/internal/plugins"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(manifests.AddToScheme(scheme))
+}
+
+func main() {
+	pflag.StringSlice("features", nil, "The FQNs of the Features to backfill. If not set, all the Features are backfilled.")
+	pflag.StringSlice("manifests", nil, "Paths to Feature manifests (files or directories). "+
+		"If not set, the Features are read from the Kubernetes cluster.")
+	pflag.String("namespace", "", "The namespace of the Features to read from the cluster. If not set, all namespaces are used.")
+	pflag.String("checkpoint", "", "Path to a file that keeps the progress of the backfill, so it can be resumed.")
+	pflag.String("state-provider", "redis", "The state provider.")
+	pflag.String("historical-reader-provider", "local-parquet", "The historical reader provider.")
+	pflag.Bool("dev", false, "Set as production")
+
+	zapOpts := zap.Options{}
+	zapOpts.BindFlags(flag.CommandLine)
+	orFail(plugins.BindConfig(pflag.CommandLine), "failed to bind plugins' config")
+
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.Parse()
+	orFail(viper.BindPFlags(pflag.CommandLine), "failed to bind flags")
+
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
+
+	zapOpts.Development = viper.GetBool("dev")
+	logger := zap.New(zap.UseFlagOptions(&zapOpts))
+	ctrl.SetLogger(logger)
+
+	setupLog.WithValues("version", version.Version).Info("Initializing Backfill...")
+
+	ctx := ctrl.SetupSignalHandler()
+
+	var all map[string]api.FeatureDescriptor
+	var err error
+	if paths := viper.GetStringSlice("manifests"); len(paths) > 0 {
+		all, err = featureloader.FromManifests(paths)
+		orFail(err, "failed to load Feature manifests")
+	} else {
+		k, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+		orFail(err, "failed to create kubernetes client")
+		all, err = featureloader.FromCluster(ctx, k, viper.GetString("namespace"))
+		orFail(err, "failed to list Features")
+	}
+
+	var fds []api.FeatureDescriptor
+	if selected := viper.GetStringSlice("features"); len(selected) > 0 {
+		for _, s := range selected {
+			fqn, err := api.NormalizeFQN(s, featureloader.DefaultNamespace)
+			orFail(err, "invalid feature", "feature", s)
+			fd, ok := all[fqn]
+			if !ok {
+				orFail(fmt.Errorf("%w: %s", api.ErrFeatureNotFound, fqn), "invalid feature", "feature", s)
+			}
+			fds = append(fds, fd)
+		}
+	} else {
+		for _, fd := range all {
+			fds = append(fds, fd)
+		}
+		sort.Slice(fds, func(i, j int) bool {
+			return fds[i].FQN < fds[j].FQN
+		})
+	}
+
+	state, err := plugins.NewState(viper.GetString("state-provider"), viper.GetViper())
+	orFail(err, "failed to create state")
+	defer plugins.Close(state)
+
+	reader, err := plugins.NewHistoricalReader(viper.GetString("historical-reader-provider"), viper.GetViper())
+	orFail(err, "failed to create historical reader")
+	defer reader.Close(ctx)
+
+	b := &historical.Backfiller{
+		Reader:     reader,
+		State:      state,
+		Checkpoint: viper.GetString("checkpoint"),
+		Logger:     logger.WithName("backfill"),
+	}
+	orFail(b.Backfill(ctx, fds), "failed to backfill")
+
+	setupLog.Info("backfill is done", "features", len(fds))
+}
+
+func orFail(err error, message string, keyAndValues ...any) {
+	if err != nil {
+		if setupLog.GetSink() == nil {
+			_, _ = fmt.Fprint(os.Stderr, append([]any{"error", err, "message", message}, keyAndValues...)...)
+		} else {
+			setupLog.Error(err, message, keyAndValues...)
+		}
+		os.Exit(1)
+	}
+}
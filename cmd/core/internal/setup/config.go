@@ -19,11 +19,13 @@ package setup
 import (
 	"flag"
 	"github.com/raptor-ml/raptor/pkg/plugins"
+	"github.com/raptor-ml/raptor/pkg/tracing"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"strings"
+	"time"
 )
 
 var updatesAllowed = false
@@ -33,6 +35,11 @@ func InitConfig() {
 		"Enabling this will ensure there is only one active controller manager.")
 	pflag.String("metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	pflag.Bool("metrics-secure-serving", true, "Enable serving the metrics securely.")
+	pflag.StringSlice("metrics-features-allowlist", nil, "FQN glob patterns (i.e. `default.*`) of the features "+
+		"that are labeled in the per-feature metrics. The rest of the features are aggregated under `_other`. "+
+		"Allows all the features when empty.")
+	pflag.Int("metrics-features-max", 500, "The maximum number of features that are labeled in the per-feature "+
+		"metrics. Zero means unlimited.")
 	pflag.String("health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	pflag.String("accessor-grpc-address", ":60000", "The address the grpc accessor binds to.")
 	pflag.String("accessor-http-address", ":60001", "The address the http accessor binds to.")
@@ -43,7 +50,15 @@ func InitConfig() {
 	pflag.String("usage-reporting-uid", "", "Usage reporting Unique Identifier. "+
 		"You can use this to set a unique identifier for your cluster.")
 	pflag.String("state-provider", "redis", "The state provider.")
-	pflag.String("notifier-provider", "redis", "The notifier provider.")
+	pflag.String("notifier-provider", "redis", "The notifier provider. Defaults to `memory` when the state "+
+		"provider is `memory` or `bolt`, which only reaches a historian that runs in the same process.")
+	pflag.Bool("watch-notifications", false, "Publish the writes to the notifier, so the watchers of other "+
+		"instances are notified of them. When disabled, the watchers are notified only of the writes of their instance.")
+	pflag.String("historical-reader-provider", "", "The historical reader provider, used to impute missing "+
+		"features of models. Imputation is disabled when empty.")
+	pflag.Duration("imputation-lookback", 24*time.Hour, "The period of the historical values that the imputation "+
+		"statistics are calculated out of.")
+	pflag.Duration("imputation-refresh", time.Hour, "The interval to recalculate the imputation statistics at.")
 	pflag.Bool("disable-cert-management", false, "Setting this flag will disable the automatically "+
 		"certificate binding to the K8s API webhooks.")
 	pflag.Bool("no-webhooks", false, "Setting this flag will disable the K8s API webhook.")
@@ -53,6 +68,7 @@ func InitConfig() {
 	zapOpts := zap.Options{}
 	zapOpts.BindFlags(flag.CommandLine)
 	OrFail(plugins.BindConfig(pflag.CommandLine), "Failed to bind plugins' config")
+	OrFail(tracing.BindConfig(pflag.CommandLine), "Failed to bind tracing config")
 
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 	pflag.Parse()
@@ -60,6 +76,7 @@ func InitConfig() {
 
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
 	viper.AutomaticEnv()
+	OrFail(plugins.ResolveNotifierProvider(viper.GetViper()), "Invalid notifier provider")
 
 	if viper.GetBool("dev") {
 		zapOpts.Development = true
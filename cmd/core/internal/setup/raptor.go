@@ -17,6 +17,7 @@ limitations under the License.
 package setup
 
 import (
+	"context"
 	"fmt"
 	"github.com/raptor-ml/raptor/api"
 	"github.com/raptor-ml/raptor/internal/accessor"
@@ -25,8 +26,11 @@ import (
 	"github.com/raptor-ml/raptor/internal/historian"
 	opctrl "github.com/raptor-ml/raptor/internal/operator"
 	"github.com/raptor-ml/raptor/internal/stats"
+	"github.com/raptor-ml/raptor/internal/version"
+	"github.com/raptor-ml/raptor/pkg/historical"
 	"github.com/raptor-ml/raptor/pkg/plugins"
 	"github.com/raptor-ml/raptor/pkg/runtimemanager"
+	"github.com/raptor-ml/raptor/pkg/tracing"
 	"github.com/spf13/viper"
 	"net/http"
 	"os"
@@ -37,6 +41,7 @@ import (
 func setupStats(mgr manager.Manager) {
 	// Setup usage reports
 	stats.UID = viper.GetString("usage-reporting-uid")
+	stats.SetFeatureLabelLimits(viper.GetStringSlice("metrics-features-allowlist"), viper.GetInt("metrics-features-max"))
 	OrFail(mgr.Add(stats.Run(
 		mgr.GetConfig(),
 		mgr.GetClient(),
@@ -61,6 +66,10 @@ func historianClient(mgr manager.Manager) historian.Client {
 		WriteNotificationWorkers:   5,
 	})
 	OrFail(hsc.WithManager(mgr), "failed to create historian client")
+	OrFail(mgr.Add(accessor.NoLeaderRunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return plugins.Close(collectNotifier, writeNotifier)
+	})), "unable to add notifiers shutdown")
 
 	return hsc
 }
@@ -130,16 +139,56 @@ func operatorControllers(mgr manager.Manager, rm api.RuntimeManager) {
 	}
 }
 
+func setupTracing(mgr manager.Manager) {
+	shutdown, err := tracing.Setup(context.Background(), viper.GetViper(), "raptor-core", version.Version)
+	OrFail(err, "unable to setup tracing")
+
+	// Flush the pending spans when the manager stops
+	OrFail(mgr.Add(accessor.NoLeaderRunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return shutdown(context.Background())
+	})), "unable to add tracing shutdown")
+}
+
+func historicalStatistics(mgr manager.Manager) api.HistoricalStatistics {
+	provider := viper.GetString("historical-reader-provider")
+	if provider == "" {
+		return nil
+	}
+
+	reader, err := plugins.NewHistoricalReader(provider, viper.GetViper())
+	OrFail(err, fmt.Sprintf("failed to create historical reader for provider %s", provider))
+	OrFail(mgr.Add(accessor.NoLeaderRunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return reader.Close(context.Background())
+	})), "unable to add historical reader shutdown")
+
+	return &historical.Statistics{
+		Reader:   reader,
+		Lookback: viper.GetDuration("imputation-lookback"),
+		TTL:      viper.GetDuration("imputation-refresh"),
+	}
+}
+
 func Core(mgr manager.Manager, certsReady chan struct{}) {
 	// Setup usage reporting
 	setupStats(mgr)
 
+	// Setup tracing
+	setupTracing(mgr)
+
 	// Create a Historian Client
 	hsc := historianClient(mgr)
 
 	// Create the state
 	state, err := plugins.NewState(viper.GetString("state-provider"), viper.GetViper())
 	OrFail(err, fmt.Sprintf("failed to create state for provider %s", viper.GetString("state-provider")))
+	// the state is wrapped with tracing below, so the provider's state is closed
+	provider := state
+	OrFail(mgr.Add(accessor.NoLeaderRunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return plugins.Close(provider)
+	})), "unable to add state shutdown")
 
 	err = mgr.AddHealthzCheck("state", func(req *http.Request) error {
 		return state.Ping(req.Context())
@@ -149,6 +198,7 @@ func Core(mgr manager.Manager, certsReady chan struct{}) {
 		return state.Ping(req.Context())
 	})
 	OrFail(err, "unable to add ready check for state")
+	state = tracing.State(state, viper.GetString("state-provider"))
 
 	ns, err := getInClusterNamespace()
 	OrFail(err, "unable to get in-cluster namespace. Please set the system-namespace flag")
@@ -161,8 +211,19 @@ func Core(mgr manager.Manager, certsReady chan struct{}) {
 	rm, err := runtimemanager.New(mgr, ns, podname)
 	OrFail(err, "unable to create python runtime manager")
 
+	// Without a watch notifier, the writes are not published, and the watchers get only the writes of this instance
+	var watchNotifier api.Notifier[api.WatchNotification]
+	if viper.GetBool("watch-notifications") {
+		watchNotifier, err = plugins.NewWatchNotifier(viper.GetString("notifier-provider"), viper.GetViper())
+		OrFail(err, "failed to create watch notifier")
+		OrFail(mgr.Add(accessor.NoLeaderRunnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return plugins.Close(watchNotifier)
+		})), "unable to add watch notifier shutdown")
+	}
+
 	// Create a new Core engine
-	eng := engine.New(state, hsc, rm, ctrl.Log.WithName("engine"))
+	eng := engine.New(state, hsc, rm, watchNotifier, historicalStatistics(mgr), ctrl.Log.WithName("engine"))
 
 	// Create a new Accessor
 	acc := accessor.New(eng, ctrl.Log.WithName("accessor"))
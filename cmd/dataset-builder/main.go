@@ -0,0 +1,257 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// dataset-builder builds a point-in-time correct dataset out of the historical records of the features.
+//
+// It takes a CSV of entities (a timestamp column, and a column for each key) and a list of Feature Selectors,
+// and writes a CSV with the value of each selector as it was known at the time of each entity.
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/internal/featureloader"
+	"github.com/raptor-ml/raptor/internal/version"
+	"github.com/raptor-ml/raptor/pkg/historical"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	// to ensure that exec-entrypoint and run can make use of them.
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	_ "github.com/raptor-ml/raptor/internal/plugins"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(manifests.AddToScheme(scheme))
+}
+
+func main() {
+	pflag.String("entities", "", "Path to a CSV file of entities. Use `-` for stdin.")
+	pflag.String("timestamp-column", "timestamp", "The column of the entity's timestamp. All the other columns are keys.")
+	pflag.StringSlice("features", nil, "The Feature Selectors to retrieve.")
+	pflag.StringSlice("manifests", nil, "Paths to Feature manifests (files or directories). "+
+		"If not set, the Features are read from the Kubernetes cluster.")
+	pflag.String("output", "-", "Path to write the dataset CSV to. Use `-` for stdout.")
+	pflag.String("historical-reader-provider", "local-parquet", "The historical reader provider.")
+	pflag.Bool("dev", false, "Set as production")
+
+	zapOpts := zap.Options{}
+	zapOpts.BindFlags(flag.CommandLine)
+	orFail(plugins.BindConfig(pflag.CommandLine), "failed to bind plugins' config")
+
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.Parse()
+	orFail(viper.BindPFlags(pflag.CommandLine), "failed to bind flags")
+
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
+
+	zapOpts.Development = viper.GetBool("dev")
+	logger := zap.New(zap.UseFlagOptions(&zapOpts))
+	ctrl.SetLogger(logger)
+
+	setupLog.WithValues("version", version.Version).Info("Initializing Dataset Builder...")
+
+	ctx := ctrl.SetupSignalHandler()
+
+	selectors := viper.GetStringSlice("features")
+	if len(selectors) == 0 {
+		orFail(fmt.Errorf("no features were selected"), "invalid arguments")
+	}
+
+	var getter api.FeatureDescriptorGetter
+	if paths := viper.GetStringSlice("manifests"); len(paths) > 0 {
+		fds, err := featureloader.FromManifests(paths)
+		orFail(err, "failed to load Feature manifests")
+		getter = featureloader.Getter(fds)
+	} else {
+		k, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+		orFail(err, "failed to create kubernetes client")
+		getter = featureloader.ClusterGetter(k)
+	}
+
+	in, err := openInput(viper.GetString("entities"))
+	orFail(err, "failed to open entities")
+	defer in.Close()
+	header, entities, err := readEntities(in, viper.GetString("timestamp-column"))
+	orFail(err, "failed to read entities")
+
+	reader, err := plugins.NewHistoricalReader(viper.GetString("historical-reader-provider"), viper.GetViper())
+	orFail(err, "failed to create historical reader")
+	defer reader.Close(ctx)
+
+	rows, err := historical.Retrieve(ctx, reader, getter, entities, selectors)
+	orFail(err, "failed to retrieve the dataset")
+
+	out, err := openOutput(viper.GetString("output"))
+	orFail(err, "failed to open output")
+	defer out.Close()
+	orFail(writeRows(out, header, selectors, rows), "failed to write the dataset")
+
+	setupLog.Info("dataset is ready", "rows", len(rows))
+}
+
+func readEntities(r io.Reader, tsColumn string) ([]string, []historical.Entity, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	tsIdx := -1
+	for i, h := range header {
+		if h == tsColumn {
+			tsIdx = i
+		}
+	}
+	if tsIdx < 0 {
+		return nil, nil, fmt.Errorf("timestamp column `%s` is missing", tsColumn)
+	}
+
+	var entities []historical.Entity
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		ts, err := parseTimestamp(rec[tsIdx])
+		if err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		e := historical.Entity{Keys: api.Keys{}, Timestamp: ts}
+		for i, v := range rec {
+			if i != tsIdx {
+				e.Keys[header[i]] = v
+			}
+		}
+		entities = append(entities, e)
+	}
+	return header, entities, nil
+}
+
+func parseTimestamp(s string) (time.Time, error) {
+	if ts, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return ts, nil
+	}
+	sec, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp `%s`. expected RFC3339 or unix seconds", s)
+	}
+	return time.Unix(0, int64(sec*float64(time.Second))), nil
+}
+
+func writeRows(w io.Writer, header []string, selectors []string, rows []historical.Row) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append(append([]string{}, header...), selectors...)); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		rec := make([]string, 0, len(header)+len(selectors))
+		for _, h := range header {
+			if v, ok := r.Keys[h]; ok {
+				rec = append(rec, v)
+			} else {
+				rec = append(rec, r.Timestamp.Format(time.RFC3339Nano))
+			}
+		}
+		for _, s := range selectors {
+			v, err := formatValue(r.Values[s])
+			if err != nil {
+				return err
+			}
+			rec = append(rec, v)
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatValue(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case string, int, float64, bool:
+		return api.ScalarString(v), nil
+	default:
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+}
+
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "" {
+		return nil, fmt.Errorf("--entities is required")
+	}
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
+
+func openOutput(path string) (io.WriteCloser, error) {
+	if path == "" || path == "-" {
+		return os.Stdout, nil
+	}
+	return os.Create(path)
+}
+
+func orFail(err error, message string, keyAndValues ...any) {
+	if err != nil {
+		if setupLog.GetSink() == nil {
+			_, _ = fmt.Fprint(os.Stderr, append([]any{"error", err, "message", message}, keyAndValues...)...)
+		} else {
+			setupLog.Error(err, message, keyAndValues...)
+		}
+		os.Exit(1)
+	}
+}
@@ -69,7 +69,8 @@ func main() {
 	pflag.Bool("dev", false, "Set as production")
 
 	pflag.String("state-provider", "redis", "The state provider.")
-	pflag.String("notifier-provider", "redis", "The notifier provider.")
+	pflag.String("notifier-provider", "redis", "The notifier provider. Defaults to `memory` when the state "+
+		"provider is `memory` or `bolt`, which only works when the historian runs in the same process as the core.")
 	pflag.String("historical-writer-provider", "s3-parquet", "The historical writer provider.")
 
 	zapOpts := zap.Options{}
@@ -82,6 +83,7 @@ func main() {
 
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
 	viper.AutomaticEnv()
+	orFail(plugins.ResolveNotifierProvider(viper.GetViper()), "invalid notifier provider")
 
 	zapOpts.Development = viper.GetBool("dev")
 	logger := zap.New(zap.UseFlagOptions(&zapOpts))
@@ -107,12 +109,14 @@ func main() {
 	// Create the state
 	state, err := plugins.NewState(viper.GetString("state-provider"), viper.GetViper())
 	orFail(err, fmt.Sprintf("failed to create state for provider %s", viper.GetString("provider")))
+	defer plugins.Close(state)
 
 	// Create Notifiers
 	collectNotifier, err := plugins.NewCollectNotifier(viper.GetString("notifier-provider"), viper.GetViper())
 	orFail(err, "failed to create collect notifier")
 	writeNotifier, err := plugins.NewWriteNotifier(viper.GetString("notifier-provider"), viper.GetViper())
 	orFail(err, "failed to create collect notifier")
+	defer plugins.Close(collectNotifier, writeNotifier)
 
 	// Historical Writer
 	historicalWriter, err := plugins.NewHistoricalWriter(viper.GetString("historical-writer-provider"), viper.GetViper())
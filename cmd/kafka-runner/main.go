@@ -0,0 +1,249 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	grpcMiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpcRetry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/raptor-ml/raptor/api"
+	coreApi "github.com/raptor-ml/raptor/api/proto/gen/go/core/v1alpha1"
+	"github.com/raptor-ml/raptor/internal/plugins/builders/kafka"
+	"github.com/raptor-ml/raptor/internal/version"
+	"github.com/raptor-ml/raptor/pkg/runtimemanager"
+	"github.com/raptor-ml/raptor/pkg/sdk"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	// to ensure that exec-entrypoint and run can make use of them.
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+
+	utilruntime.Must(manifests.AddToScheme(scheme))
+}
+
+func main() {
+	pflag.String("data-source-resource", "", "The name of the DataSource resource.")
+	pflag.String("data-source-namespace", "", "The namespace of the DataSource resource.")
+	pflag.String("core-grpc-url", "", "The address of the Core's gRPC server.")
+	pflag.String("metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	pflag.Duration("features-resync-period", 30*time.Second, "The interval to reload the Features that are using "+
+		"the DataSource at, so added, changed and removed Features are picked up without a restart.")
+	pflag.Bool("dev", false, "Set as production")
+
+	zapOpts := zap.Options{}
+	zapOpts.BindFlags(flag.CommandLine)
+
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.Parse()
+	orFail(viper.BindPFlags(pflag.CommandLine), "failed to bind flags")
+
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
+
+	zapOpts.Development = viper.GetBool("dev")
+	logger := zap.New(zap.UseFlagOptions(&zapOpts))
+	ctrl.SetLogger(logger)
+
+	setupLog.WithValues("version", version.Version).Info("Initializing Kafka Runner...")
+
+	ctx := ctrl.SetupSignalHandler()
+
+	k, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	orFail(err, "failed to create kubernetes client")
+
+	srcKey := client.ObjectKey{
+		Name:      viper.GetString("data-source-resource"),
+		Namespace: viper.GetString("data-source-namespace"),
+	}
+	src := &manifests.DataSource{}
+	err = k.Get(ctx, srcKey, src)
+	orFail(err, "failed to get DataSource")
+
+	pc, err := src.ParseConfig(ctx, k)
+	orFail(err, "failed to parse DataSource config")
+	cfg, err := kafka.ParseConfig(pc)
+	orFail(err, "invalid DataSource config")
+
+	decoder, err := kafka.NewDecoder(cfg, src.Spec.Schema)
+	orFail(err, "failed to create a message decoder")
+
+	rm, err := runtimemanager.New(nil, src.GetNamespace(), "")
+	orFail(err, "failed to create runtime manager")
+
+	fl := &featureLoader{client: k, runtime: rm, src: srcKey}
+	fds, _, err := fl.load(ctx)
+	orFail(err, "failed to load features")
+
+	cc, err := grpc.Dial(
+		viper.GetString("core-grpc-url"),
+		grpc.WithUnaryInterceptor(grpcMiddleware.ChainUnaryClient(
+			grpcRetry.UnaryClientInterceptor(),
+		)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	orFail(err, "failed to dial the core")
+	defer cc.Close()
+
+	r := &kafka.Runner{
+		Config:         cfg,
+		KeyFields:      src.Spec.KeyFields,
+		TimestampField: src.Spec.TimestampField,
+		Decoder:        decoder,
+		Runtime:        rm,
+		Engine:         sdk.NewGRPCEngine(coreApi.NewEngineServiceClient(cc)),
+		Logger:         logger.WithName("kafka"),
+	}
+
+	r.SetFeatures(fds)
+	go fl.sync(ctx, r, viper.GetDuration("features-resync-period"))
+	go serveMetrics(viper.GetString("metrics-bind-address"))
+
+	setupLog.Info("starting to consume", "topic", cfg.Topic, "features", len(fds))
+	orFail(r.Run(ctx), "runner failed")
+}
+
+// serveMetrics exposes the metrics of the runner, i.e. the number of skipped messages
+func serveMetrics(addr string) {
+	if addr == "" || addr == "0" {
+		return
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{}))
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	if err := srv.ListenAndServe(); err != nil {
+		setupLog.Error(err, "failed to serve metrics")
+	}
+}
+
+// featureLoader loads the Features that are using the DataSource, and their programs
+type featureLoader struct {
+	client  client.Client
+	runtime api.RuntimeManager
+	src     client.ObjectKey
+
+	// version identifies the loaded Features by their FQNs and generations
+	version string
+}
+
+// load loads the programs of the Features that are using the DataSource into the runtime.
+// It returns false if the Features didn't change since the previous load, in which case nothing is loaded.
+func (fl *featureLoader) load(ctx context.Context) ([]api.FeatureDescriptor, bool, error) {
+	src := &manifests.DataSource{}
+	if err := fl.client.Get(ctx, fl.src, src); err != nil {
+		return nil, false, fmt.Errorf("failed to get DataSource: %w", err)
+	}
+
+	fts := make([]*manifests.Feature, 0, len(src.Status.Features))
+	versions := make([]string, 0, len(src.Status.Features))
+	for _, ref := range src.Status.Features {
+		ft := &manifests.Feature{}
+		if err := fl.client.Get(ctx, ref.ObjectKey(), ft); err != nil {
+			return nil, false, fmt.Errorf("failed to get Feature %s: %w", ref.FQN(), err)
+		}
+		fts = append(fts, ft)
+		versions = append(versions, fmt.Sprintf("%s@%d", ft.FQN(), ft.GetGeneration()))
+	}
+	sort.Strings(versions)
+	version := strings.Join(versions, ",")
+	if version == fl.version {
+		return nil, false, nil
+	}
+
+	var fds []api.FeatureDescriptor
+	for _, ft := range fts {
+		fd, err := api.FeatureDescriptorFromManifest(ft)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to parse Feature %s: %w", ft.FQN(), err)
+		}
+		if _, err := fl.runtime.LoadProgram(fd.RuntimeEnv, fd.FQN, ft.Spec.Builder.Code, ft.Spec.Builder.Packages); err != nil {
+			return nil, false, fmt.Errorf("failed to load the program of Feature %s: %w", fd.FQN, err)
+		}
+		fds = append(fds, *fd)
+	}
+	fl.version = version
+	return fds, true, nil
+}
+
+// sync periodically reloads the Features of the runner until the context is canceled.
+// If the Features fail to load, the runner keeps using the previous ones until the next attempt.
+func (fl *featureLoader) sync(ctx context.Context, r *kafka.Runner, period time.Duration) {
+	if period <= 0 {
+		return
+	}
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		fds, changed, err := fl.load(ctx)
+		if err != nil {
+			setupLog.Error(err, "failed to reload features")
+			continue
+		}
+		if changed {
+			setupLog.Info("features changed", "features", len(fds))
+			r.SetFeatures(fds)
+		}
+	}
+}
+
+func orFail(err error, message string, keyAndValues ...any) {
+	if err != nil {
+		if setupLog.GetSink() == nil {
+			_, _ = fmt.Fprint(os.Stderr, append([]any{"error", err, "message", message}, keyAndValues...)...)
+		} else {
+			setupLog.Error(err, message, keyAndValues...)
+		}
+		os.Exit(1)
+	}
+}
@@ -31,6 +31,8 @@ import (
 	protoApi "github.com/raptor-ml/raptor/api/proto/gen/go"
 	coreApi "github.com/raptor-ml/raptor/api/proto/gen/go/core/v1alpha1"
 	"github.com/raptor-ml/raptor/pkg/sdk"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 	"net"
@@ -63,6 +65,7 @@ func New(e api.FeatureManager, logger logr.Logger) Accessor {
 	metrics.Registry.MustRegister(grpcMetrics)
 
 	svc.server = grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.StreamInterceptor(grpcMiddleware.ChainStreamServer(
 			grpcCtxTags.StreamServerInterceptor(),
 			grpcMetrics.StreamServerInterceptor(),
@@ -152,7 +155,7 @@ func (a *accessor) HTTP(addr string, prefix string) NoLeaderRunnableFunc {
 		})
 
 		a.logger.WithValues("kind", "http", "addr", addr).Info("Starting Accessor HTTP server")
-		srv := http.Server{Handler: mux, Addr: addr}
+		srv := http.Server{Handler: otelhttp.NewHandler(mux, "accessor"), Addr: addr}
 		go func() {
 			<-ctx.Done()
 			_ = srv.Shutdown(context.TODO())
@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accessor
+
+import (
+	"context"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	coreApi "github.com/raptor-ml/raptor/api/proto/gen/go/core/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// routingServer records the methods that were called
+type routingServer struct {
+	coreApi.UnimplementedEngineServiceServer
+	mu     sync.Mutex
+	called []string
+}
+
+func (s *routingServer) record(method string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.called = append(s.called, method)
+}
+
+func (s *routingServer) calls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.called...)
+}
+
+func (s *routingServer) FeatureDescriptor(context.Context, *coreApi.FeatureDescriptorRequest) (*coreApi.FeatureDescriptorResponse, error) {
+	s.record("FeatureDescriptor")
+	return &coreApi.FeatureDescriptorResponse{}, nil
+}
+func (s *routingServer) Get(context.Context, *coreApi.GetRequest) (*coreApi.GetResponse, error) {
+	s.record("Get")
+	return &coreApi.GetResponse{}, nil
+}
+func (s *routingServer) BatchGet(context.Context, *coreApi.BatchGetRequest) (*coreApi.BatchGetResponse, error) {
+	s.record("BatchGet")
+	return &coreApi.BatchGetResponse{}, nil
+}
+func (s *routingServer) Watch(_ *coreApi.WatchRequest, stream coreApi.EngineService_WatchServer) error {
+	s.record("Watch")
+	return stream.Send(&coreApi.WatchResponse{})
+}
+func (s *routingServer) Set(context.Context, *coreApi.SetRequest) (*coreApi.SetResponse, error) {
+	s.record("Set")
+	return &coreApi.SetResponse{}, nil
+}
+func (s *routingServer) Append(context.Context, *coreApi.AppendRequest) (*coreApi.AppendResponse, error) {
+	s.record("Append")
+	return &coreApi.AppendResponse{}, nil
+}
+func (s *routingServer) Incr(context.Context, *coreApi.IncrRequest) (*coreApi.IncrResponse, error) {
+	s.record("Incr")
+	return &coreApi.IncrResponse{}, nil
+}
+func (s *routingServer) Update(context.Context, *coreApi.UpdateRequest) (*coreApi.UpdateResponse, error) {
+	s.record("Update")
+	return &coreApi.UpdateResponse{}, nil
+}
+
+// TestGatewayRouting checks that each HTTP route reaches its own method. The gateway's mux tries the handlers that
+// were registered last first, so a route that is matched by the pattern of a later method (e.g. `POST /{selector}`)
+// is shadowed by it.
+func TestGatewayRouting(t *testing.T) {
+	srv := &routingServer{}
+	gs := grpc.NewServer()
+	coreApi.RegisterEngineServiceServer(gs, srv)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go func() { _ = gs.Serve(l) }()
+	defer gs.Stop()
+
+	cc, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer cc.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mux := runtime.NewServeMux()
+	if err := coreApi.RegisterEngineServiceHandler(ctx, mux, cc); err != nil {
+		t.Fatalf("failed to register the gateway: %v", err)
+	}
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+		want   string
+	}{
+		{method: http.MethodHead, path: "/feature.default", want: "FeatureDescriptor"},
+		{method: http.MethodGet, path: "/feature.default", want: "Get"},
+		{method: http.MethodPost, path: "/batch/get", body: `{"items":[]}`, want: "BatchGet"},
+		{method: http.MethodPost, path: "/watch/stream", body: `{"selectors":["feature.default"]}`, want: "Watch"},
+		{method: http.MethodPut, path: "/feature.default", want: "Set"},
+		{method: http.MethodPost, path: "/feature.default/append", want: "Append"},
+		{method: http.MethodPost, path: "/feature.default/incr", want: "Incr"},
+		{method: http.MethodPost, path: "/feature.default", want: "Update"},
+		{method: http.MethodPost, path: "/batch", want: "Update"},
+		{method: http.MethodPost, path: "/watch", want: "Update"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			before := len(srv.calls())
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			calls := srv.calls()[before:]
+			if len(calls) != 1 || calls[0] != tt.want {
+				t.Errorf("expected a call to %s, got %v", tt.want, calls)
+			}
+		})
+	}
+}
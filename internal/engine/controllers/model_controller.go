@@ -94,6 +94,7 @@ func (r *ModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		ModelFramework:  model.Spec.ModelFramework,
 		ModelServer:     string(model.Spec.ModelServer),
 		InferenceConfig: cfg,
+		FeaturePolicies: model.Spec.FeaturePolicies,
 	}
 	ft.Spec.Builder.Raw, err = json.Marshal(md)
 	if err != nil {
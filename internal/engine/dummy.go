@@ -33,6 +33,9 @@ func (*Dummy) FeatureDescriptor(ctx context.Context, selector string) (api.Featu
 func (*Dummy) Get(ctx context.Context, selector string, keys api.Keys) (api.Value, api.FeatureDescriptor, error) {
 	return api.Value{}, api.FeatureDescriptor{}, nil
 }
+func (*Dummy) BatchGet(ctx context.Context, items []api.BatchGetItem) ([]api.BatchGetResult, error) {
+	return make([]api.BatchGetResult, len(items)), nil
+}
 func (*Dummy) Set(ctx context.Context, FQN string, keys api.Keys, val any, ts time.Time) error {
 	return nil
 }
@@ -45,7 +48,31 @@ func (*Dummy) Incr(ctx context.Context, FQN string, keys api.Keys, by any, ts ti
 func (*Dummy) Update(ctx context.Context, FQN string, keys api.Keys, val any, ts time.Time) error {
 	return nil
 }
+func (*Dummy) Ingest(ctx context.Context, records <-chan api.IngestRecord) (api.IngestSummary, error) {
+	var summary api.IngestSummary
+	for range records {
+		summary.Received++
+		summary.Succeeded++
+	}
+	return summary, nil
+}
+func (*Dummy) Watch(ctx context.Context, selectors []string, keys api.Keys) (<-chan api.WatchEvent, error) {
+	c := make(chan api.WatchEvent)
+	go func() {
+		<-ctx.Done()
+		close(c)
+	}()
+	return c, nil
+}
 
 func (d *Dummy) GetDataSource(_ string) (api.DataSource, error) {
 	return d.DataSource, nil
 }
+func (*Dummy) Statistic(ctx context.Context, fd api.FeatureDescriptor, fn api.AggrFn) (float64, error) {
+	return 0, nil
+}
+
+// HasStatistics returns true, since the configuration of the historical statistics is known only to the core.
+func (*Dummy) HasStatistics() bool {
+	return true
+}
@@ -24,28 +24,40 @@ import (
 	"github.com/raptor-ml/raptor/api"
 	"github.com/raptor-ml/raptor/internal/historian"
 	"github.com/raptor-ml/raptor/internal/stats"
+	"github.com/raptor-ml/raptor/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 )
 
 type engine struct {
-	features    sync.Map
-	dataSources sync.Map
-	state       api.State
-	historian   historian.Client
-	logger      logr.Logger
+	features      sync.Map
+	dataSources   sync.Map
+	state         api.State
+	historian     historian.Client
+	watchNotifier api.Notifier[api.WatchNotification]
+	statistics    api.HistoricalStatistics
+	watchers      watchers
+	logger        logr.Logger
 	api.RuntimeManager
 }
 
 // New creates a new engine manager
-func New(state api.State, h historian.Client, rm api.RuntimeManager, logger logr.Logger) api.ManagerEngine {
+// The watch notifier is used to fan out the updates to the watchers across all the instances. If it's nil, only the
+// updates of the current instance are sent to its watchers.
+// The historical statistics are used to impute missing values. If it's nil, imputation is not available.
+func New(state api.State, h historian.Client, rm api.RuntimeManager, wn api.Notifier[api.WatchNotification], hs api.HistoricalStatistics, logger logr.Logger) api.ManagerEngine {
 	if state == nil {
 		panic("state is nil")
 	}
 	e := &engine{
 		state:          state,
 		historian:      h,
+		watchNotifier:  wn,
+		statistics:     hs,
 		logger:         logger,
 		RuntimeManager: rm,
 	}
@@ -68,12 +80,20 @@ func (e *engine) Update(ctx context.Context, fqn string, keys api.Keys, val any,
 	defer stats.IncrFeatureUpdates()
 	return e.write(ctx, fqn, keys, val, ts, api.StateMethodUpdate)
 }
-func (e *engine) write(ctx context.Context, fqn string, keys api.Keys, val any, ts time.Time, method api.StateMethod) error {
+func (e *engine) write(ctx context.Context, fqn string, keys api.Keys, val any, ts time.Time, method api.StateMethod) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "engine."+method.String(), trace.WithAttributes(tracing.AttrSelector.String(fqn)))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	f, ctx, cancel, err := e.featureForRequest(ctx, fqn)
 	if err != nil {
 		return err
 	}
+	span.SetAttributes(tracing.AttrFQN.String(f.FQN))
 	defer cancel()
+	defer stats.ObservePipeline(f.FQN, method.String(), time.Now())
 
 	_, err = keys.Encode(f.FeatureDescriptor)
 	if err != nil {
@@ -87,23 +107,149 @@ func (e *engine) write(ctx context.Context, fqn string, keys api.Keys, val any,
 	return nil
 }
 
-func (e *engine) Get(ctx context.Context, selector string, keys api.Keys) (api.Value, api.FeatureDescriptor, error) {
+// ingestConcurrency is the maximum number of write pipelines that run concurrently for a single Ingest
+const ingestConcurrency = 32
+
+func (e *engine) Ingest(ctx context.Context, records <-chan api.IngestRecord) (api.IngestSummary, error) {
+	var summary api.IngestSummary
+	mu := sync.Mutex{}
+	fail := func(idx uint64, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		summary.Failed++
+		if len(summary.Failures) < api.MaxIngestFailures {
+			summary.Failures = append(summary.Failures, api.IngestFailure{Index: idx, Err: err})
+		}
+	}
+
+	var g errgroup.Group
+	g.SetLimit(ingestConcurrency)
+
+	// The records channel is always drained, so the producer never blocks
+	for rec := range records {
+		idx := summary.Received
+		summary.Received++
+		if err := ctx.Err(); err != nil {
+			fail(idx, err)
+			continue
+		}
+
+		rec := rec
+		g.Go(func() error {
+			if err := e.ingest(ctx, rec); err != nil {
+				fail(idx, err)
+				return nil
+			}
+			mu.Lock()
+			summary.Succeeded++
+			mu.Unlock()
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	sort.Slice(summary.Failures, func(i, j int) bool {
+		return summary.Failures[i].Index < summary.Failures[j].Index
+	})
+	return summary, nil
+}
+func (e *engine) ingest(ctx context.Context, rec api.IngestRecord) error {
+	switch rec.Method {
+	case api.StateMethodSet:
+		return e.Set(ctx, rec.Selector, rec.Keys, rec.Value, rec.Timestamp)
+	case api.StateMethodAppend:
+		return e.Append(ctx, rec.Selector, rec.Keys, rec.Value, rec.Timestamp)
+	case api.StateMethodIncr:
+		return e.Incr(ctx, rec.Selector, rec.Keys, rec.Value, rec.Timestamp)
+	case api.StateMethodUpdate:
+		return e.Update(ctx, rec.Selector, rec.Keys, rec.Value, rec.Timestamp)
+	default:
+		return fmt.Errorf("unsupported write method %d", rec.Method)
+	}
+}
+
+func (e *engine) Get(ctx context.Context, selector string, keys api.Keys) (_ api.Value, _ api.FeatureDescriptor, err error) {
 	defer stats.IncrFeatureGets()
+	ctx, span := tracing.Tracer().Start(ctx, "engine.Get", trace.WithAttributes(tracing.AttrSelector.String(selector)))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
 
 	ret := api.Value{Timestamp: time.Now()}
 	f, ctx, cancel, err := e.featureForRequest(ctx, selector)
 	if err != nil {
 		return ret, api.FeatureDescriptor{}, err
 	}
+	span.SetAttributes(tracing.AttrFQN.String(f.FQN))
 	defer cancel()
+	defer stats.ObservePipeline(f.FQN, api.StateMethodGet.String(), time.Now())
 
 	ret, err = e.readPipeline(f).Apply(ctx, keys, ret)
 	if err != nil && !(goerrors.Is(err, context.DeadlineExceeded) && ret.Value != nil && !ret.Fresh) {
-		return ret, f.FeatureDescriptor, fmt.Errorf("failed to GET value for feature %s with keys %s: %w", selector, keys, err)
+		ret, err = e.fallback(ctx, f, keys, err)
+		if err != nil {
+			return ret, f.FeatureDescriptor, fmt.Errorf("failed to GET value for feature %s with keys %s: %w", selector, keys, err)
+		}
+	} else if ret.Value == nil && f.Default != nil {
+		ret, err = e.defaultValue(ctx, f, keys)
+		if err != nil {
+			return ret, f.FeatureDescriptor, fmt.Errorf("failed to serve the default value for feature %s: %w", selector, err)
+		}
+	}
+	if ret.Fallback != "" {
+		span.SetAttributes(tracing.AttrFallback.String(string(ret.Fallback)))
+	}
+	if ret.Value != nil {
+		stats.ObserveValueAge(f.FQN, ret.Timestamp)
 	}
 	return ret, f.FeatureDescriptor, nil
 }
 
+func (e *engine) Statistic(ctx context.Context, fd api.FeatureDescriptor, fn api.AggrFn) (float64, error) {
+	if e.statistics == nil {
+		return 0, fmt.Errorf("historical statistics are not configured")
+	}
+	return e.statistics.Statistic(ctx, fd, fn)
+}
+
+func (e *engine) HasStatistics() bool {
+	return e.statistics != nil
+}
+
+// ExecuteProgram executes the program of the feature with the runtime manager, and records its duration
+func (e *engine) ExecuteProgram(ctx context.Context, env string, fqn string, keys api.Keys, row map[string]any, ts time.Time, dryRun bool) (val api.Value, _ api.Keys, err error) {
+	defer func(start time.Time) {
+		stats.ObserveRuntimeExecution(env, start, err)
+	}(time.Now())
+	return e.RuntimeManager.ExecuteProgram(ctx, env, fqn, keys, row, ts, dryRun)
+}
+
+// batchGetConcurrency is the maximum number of read pipelines that run concurrently for a single BatchGet
+const batchGetConcurrency = 32
+
+func (e *engine) BatchGet(ctx context.Context, items []api.BatchGetItem) ([]api.BatchGetResult, error) {
+	ret := make([]api.BatchGetResult, len(items))
+
+	var g errgroup.Group
+	g.SetLimit(batchGetConcurrency)
+	for i, item := range items {
+		i, item := i, item
+		g.Go(func() error {
+			val, fd, err := e.Get(ctx, item.Selector, item.Keys)
+			ret[i] = api.BatchGetResult{
+				Value:             val,
+				FeatureDescriptor: fd,
+				Err:               err,
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return ret, ctx.Err()
+}
+
 func (e *engine) FeatureDescriptor(ctx context.Context, selector string) (api.FeatureDescriptor, error) {
 	defer stats.IncrFeatureDescriptorReqs()
 	f, _, cancel, err := e.featureForRequest(ctx, selector)
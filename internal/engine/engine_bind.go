@@ -34,6 +34,18 @@ func FeatureWithEngine(e api.ExtendedManager, in *manifests.Feature) (*FeaturePi
 
 	ft := FeaturePipeliner{
 		FeatureDescriptor: *fd,
+		encoders:          make(map[string]api.Encoder, len(fd.Encodings)),
+	}
+	for name, spec := range fd.Encodings {
+		factory := plugins.Encoders.Get(name)
+		if factory == nil {
+			return nil, fmt.Errorf("encoding `%s` is not supported", name)
+		}
+		enc, err := factory(*fd, spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration for encoding `%s`: %w", name, err)
+		}
+		ft.encoders[name] = enc
 	}
 
 	if fd.DataSource != "" {
@@ -56,6 +68,7 @@ func FeatureWithEngine(e api.ExtendedManager, in *manifests.Feature) (*FeaturePi
 	if p := plugins.FeatureAppliers.Get(ft.Builder); p != nil {
 		err := p(ft.FeatureDescriptor, in.Spec.Builder, &ft, e)
 		if err != nil {
+			ft.Release()
 			return nil, err
 		}
 	} else {
@@ -70,12 +83,19 @@ func (e *engine) BindFeature(in *manifests.Feature) error {
 	if err != nil {
 		return fmt.Errorf("failed to parse FeatureDescriptor from CR: %w", err)
 	}
-	return e.bindFeature(ft)
+	if err := e.bindFeature(ft); err != nil {
+		ft.Release()
+		return err
+	}
+	return nil
 }
 
 func (e *engine) UnbindFeature(fqn string) error {
 	defer stats.DecNumberOfFeatures()
-	e.features.Delete(fqn)
+	if f, ok := e.features.LoadAndDelete(fqn); ok {
+		f.(*FeaturePipeliner).Release()
+	}
+	stats.DeleteFeatureMetrics(fqn)
 	e.logger.Info("feature unbound", "feature", fqn)
 	return nil
 }
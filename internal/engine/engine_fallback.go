@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"time"
+)
+
+// fallbackTimeout limits serving a fallback value, as the context of the request may have already expired
+const fallbackTimeout = time.Second
+
+// fallback serves a fallback value for a read that failed with the cause, according to the feature's error policy.
+func (e *engine) fallback(ctx context.Context, f *FeaturePipeliner, keys api.Keys, cause error) (api.Value, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fallbackTimeout)
+	defer cancel()
+
+	switch f.OnError {
+	case api.ErrorPolicyDefault:
+		return e.defaultValue(ctx, f, keys)
+	case api.ErrorPolicyLastKnown:
+		val, err := e.lastKnownValue(ctx, f, keys)
+		if err != nil {
+			return val, fmt.Errorf("%w (failed to get the last known value: %v)", cause, err)
+		}
+		if val.Value == nil {
+			if f.Default != nil {
+				return e.defaultValue(ctx, f, keys)
+			}
+			return val, cause
+		}
+		return val, nil
+	default:
+		return api.Value{}, cause
+	}
+}
+
+// defaultValue serves the default value of the feature
+func (e *engine) defaultValue(ctx context.Context, f *FeaturePipeliner, keys api.Keys) (api.Value, error) {
+	val := api.Value{Value: f.Default, Timestamp: time.Now(), Fallback: api.FallbackDefault}
+
+	af, _, err := parseContextSelector(ctx)
+	if err != nil {
+		return val, err
+	}
+	if f.ValidWindow() && af == api.AggrFnUnknown {
+		wrm := make(api.WindowResultMap, len(f.Aggr))
+		for _, fn := range f.Aggr {
+			wrm[fn] = f.Default.(float64)
+		}
+		val.Value = wrm
+	}
+	return e.fallbackPipeline(f).Apply(ctx, keys, val)
+}
+
+// lastKnownValue serves the last value of the feature (at the selected version) from the state, regardless of its
+// staleness. Windowed features don't support this policy, since their dead buckets are not kept in the state.
+func (e *engine) lastKnownValue(ctx context.Context, f *FeaturePipeliner, keys api.Keys) (api.Value, error) {
+	if f.DataSource == "" || f.ValidWindow() {
+		return api.Value{}, nil
+	}
+	_, version, err := parseContextSelector(ctx)
+	if err != nil {
+		return api.Value{}, err
+	}
+	v, err := e.state.Get(ctx, f.FeatureDescriptor, keys, version)
+	if err != nil || v == nil || v.Value == nil {
+		return api.Value{}, err
+	}
+
+	val := api.Value{Value: v.Value, Timestamp: v.Timestamp, Fallback: api.FallbackLastKnown}
+	return e.fallbackPipeline(f).Apply(ctx, keys, val)
+}
+
+// fallbackPipeline applies the selector to fallback values, the same as the read pipeline does to calculated values.
+// Fallback values are not cached.
+func (e *engine) fallbackPipeline(f *FeaturePipeliner) Pipeline {
+	return Pipeline{
+		Middlewares:       Middlewares{e.fieldMiddleware(), e.encodingMiddleware(f)},
+		FeatureDescriptor: f.FeatureDescriptor,
+	}
+}
+
+// parseContextSelector returns the aggregation function and the version of the selector of the read
+func parseContextSelector(ctx context.Context) (api.AggrFn, uint, error) {
+	selector, err := api.SelectorFromContext(ctx)
+	if err != nil {
+		return api.AggrFnUnknown, 0, fmt.Errorf("failed to get selector from context: %w", err)
+	}
+	sel, err := api.ParseSelectorStruct(selector)
+	if err != nil {
+		return api.AggrFnUnknown, 0, fmt.Errorf("failed to parse selector: %w", err)
+	}
+	return sel.AggrFn, sel.Version, nil
+}
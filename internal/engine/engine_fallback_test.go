@@ -0,0 +1,134 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"github.com/raptor-ml/raptor/api"
+	_ "github.com/raptor-ml/raptor/internal/plugins/encoders"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var errBuilder = errors.New("builder failed")
+
+// failingMiddleware fails the reads while `fail` is set, the same as a failing builder
+func failingMiddleware(fail *bool) api.Middleware {
+	return func(next api.MiddlewareHandler) api.MiddlewareHandler {
+		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
+			if *fail {
+				return val, errBuilder
+			}
+			return next(ctx, fd, keys, val)
+		}
+	}
+}
+
+func TestFallback(t *testing.T) {
+	e := newTestEngine(t)
+	ctx := context.Background()
+	fail := false
+	bind := func(fd api.FeatureDescriptor) {
+		t.Helper()
+		f := &FeaturePipeliner{FeatureDescriptor: fd}
+		f.AddPostGetMiddleware(0, failingMiddleware(&fail))
+		if err := e.bindFeature(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	withPolicy := func(name string, onError api.ErrorPolicy, def any) api.FeatureDescriptor {
+		fd := testFeature(name, api.PrimitiveTypeInteger)
+		fd.KeepPrevious = &api.KeepPrevious{Versions: 1, Over: time.Hour}
+		fd.OnError = onError
+		fd.Default = def
+		return fd
+	}
+	bind(withPolicy("fail", api.ErrorPolicyFail, 7))
+	bind(withPolicy("default", api.ErrorPolicyDefault, 7))
+	bind(withPolicy("last", api.ErrorPolicyLastKnown, 7))
+	bind(withPolicy("lastnodefault", api.ErrorPolicyLastKnown, nil))
+
+	window := testFeature("window", api.PrimitiveTypeFloat)
+	window.Aggr = []api.AggrFn{api.AggrFnSum, api.AggrFnCount}
+	window.Default = 0.0
+	window.OnError = api.ErrorPolicyDefault
+	bind(window)
+
+	// the features have the values 1 and 2 for the key 1, and no value for the key 2
+	for _, fqn := range []string{"fail.default", "default.default", "last.default", "lastnodefault.default"} {
+		for _, v := range []int{1, 2} {
+			if err := e.Set(ctx, fqn, api.Keys{"id": "1"}, v, time.Now()); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name         string
+		selector     string
+		id           string
+		fail         bool
+		want         any
+		wantFallback api.Fallback
+		wantErr      bool
+	}{
+		{name: "calculated", selector: "default.default", id: "1", want: 2},
+		{name: "default of a missing value", selector: "fail.default", id: "2", want: 7, wantFallback: api.FallbackDefault},
+		{name: "default of a missing value with an encoding", selector: "fail.default[log]", id: "2", want: 2.0794415416798357, wantFallback: api.FallbackDefault},
+		{name: "fail", selector: "fail.default", id: "1", fail: true, wantErr: true},
+		{name: "default on error", selector: "default.default", id: "1", fail: true, want: 7, wantFallback: api.FallbackDefault},
+		{name: "last known", selector: "last.default", id: "1", fail: true, want: 2, wantFallback: api.FallbackLastKnown},
+		{name: "last known previous version", selector: "last.default@-1", id: "1", fail: true, want: 1, wantFallback: api.FallbackLastKnown},
+		{name: "last known without a value falls back to the default", selector: "last.default", id: "2", fail: true, want: 7, wantFallback: api.FallbackDefault},
+		{name: "last known without a value and a default", selector: "lastnodefault.default", id: "2", fail: true, wantErr: true},
+		{name: "default of a window aggregation", selector: "window.default+sum", id: "1", want: 0.0, wantFallback: api.FallbackDefault},
+		{
+			name:         "default of a whole window",
+			selector:     "window.default",
+			id:           "1",
+			fail:         true,
+			want:         api.WindowResultMap{api.AggrFnSum: 0, api.AggrFnCount: 0},
+			wantFallback: api.FallbackDefault,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fail = tt.fail
+			defer func() { fail = false }()
+
+			val, _, err := e.Get(ctx, tt.selector, api.Keys{"id": tt.id})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, errBuilder) {
+					t.Errorf("expected the error of the builder, got %v", err)
+				}
+				return
+			}
+			if !reflect.DeepEqual(val.Value, tt.want) {
+				t.Errorf("Get() = %v (%T), want %v (%T)", val.Value, val.Value, tt.want, tt.want)
+			}
+			if val.Fallback != tt.wantFallback {
+				t.Errorf("expected the fallback %q, got %q", tt.wantFallback, val.Fallback)
+			}
+		})
+	}
+}
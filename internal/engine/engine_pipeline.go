@@ -20,6 +20,9 @@ import (
 	"context"
 	"fmt"
 	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/internal/stats"
+	"github.com/raptor-ml/raptor/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
 	"time"
 )
 
@@ -35,27 +38,38 @@ func (e *engine) getValueMiddleware() api.Middleware {
 				return val, fmt.Errorf("failed to get selector from context: %w", err)
 			}
 
-			_, _, af, ver, _, err := api.ParseSelector(selector)
+			sel, err := api.ParseSelectorStruct(selector)
 			if err != nil {
 				return val, fmt.Errorf("failed to parse selector: %w", err)
 			}
 
-			if ver > 0 {
+			if sel.Version > 0 {
 				if fd.KeepPrevious == nil {
 					return val, fmt.Errorf("selector specified a previous version, although the feature "+
 						"doesn't support it: %s", selector)
 				}
-				if ver > fd.KeepPrevious.Versions {
+				if sel.Version > fd.KeepPrevious.Versions {
 					return val, fmt.Errorf("selector specified a previous version(%d) which is greater than "+
-						"the configured keep_previous (%d): %s", ver, fd.KeepPrevious.Versions, selector)
+						"the configured keep_previous (%d): %s", sel.Version, fd.KeepPrevious.Versions, selector)
 				}
 			}
 
-			v, err := e.state.Get(ctx, fd, keys, ver)
+			// the state aggregates only the buckets within the requested lookback
+			stateFd := fd
+			if sel.Lookback > 0 {
+				stateFd, err = fd.WithLookback(sel.Lookback)
+				if err != nil {
+					return val, fmt.Errorf("invalid lookback for selector %s: %w", selector, err)
+				}
+			}
+
+			v, err := e.state.Get(ctx, stateFd, keys, sel.Version)
 			if err != nil {
 				return val, err
 			}
 
+			span := trace.SpanFromContext(ctx)
+			span.SetAttributes(tracing.AttrCacheHit.Bool(v != nil && v.Value != nil))
 			if v == nil {
 				return next(ctx, fd, keys, val)
 			}
@@ -64,16 +78,21 @@ func (e *engine) getValueMiddleware() api.Middleware {
 				return next(ctx, fd, keys, val)
 			}
 
+			span.SetAttributes(tracing.AttrFresh.Bool(v.Fresh))
+
 			// Mark the context as from cache.
 			ctx = context.WithValue(ctx, api.ContextKeyFromCache, v.Value != nil)
 			ctx = context.WithValue(ctx, api.ContextKeyCacheFresh, v.Fresh)
 
-			if fd.ValidWindow() && af != api.AggrFnUnknown {
+			if fd.ValidWindow() && sel.AggrFn != api.AggrFnUnknown {
 				val = api.Value{
-					Value:     api.ToLowLevelValue[api.WindowResultMap](v.Value)[af],
 					Timestamp: v.Timestamp,
 					Fresh:     v.Fresh,
 				}
+				// aggregations without a value (i.e. the variance of a single sample) are missing from the result
+				if r, ok := api.ToLowLevelValue[api.WindowResultMap](v.Value)[sel.AggrFn]; ok {
+					val.Value = r
+				}
 				return next(ctx, fd, keys, val)
 			}
 
@@ -85,16 +104,103 @@ func (e *engine) getValueMiddleware() api.Middleware {
 	}
 }
 
+// cacheStatsMiddleware counts the reads from the state by their result, as marked by getValueMiddleware
+func (e *engine) cacheStatsMiddleware() api.Middleware {
+	return func(next api.MiddlewareHandler) api.MiddlewareHandler {
+		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
+			if fd.DataSource == "" {
+				return next(ctx, fd, keys, val)
+			}
+
+			result := stats.CacheMiss
+			if fromCache, ok := ctx.Value(api.ContextKeyFromCache).(bool); ok && fromCache {
+				result = stats.CacheStale
+				if fresh, ok := ctx.Value(api.ContextKeyCacheFresh).(bool); ok && fresh {
+					result = stats.CacheHit
+				}
+			}
+			stats.IncrCacheResult(fd.FQN, result)
+			return next(ctx, fd, keys, val)
+		}
+	}
+}
+
+// fieldMiddleware extracts the selected field of a struct feature.
+// It runs after the value was cached, so the state always holds the whole struct.
+func (e *engine) fieldMiddleware() api.Middleware {
+	return func(next api.MiddlewareHandler) api.MiddlewareHandler {
+		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
+			selector, err := api.SelectorFromContext(ctx)
+			if err != nil {
+				return val, fmt.Errorf("failed to get selector from context: %w", err)
+			}
+			sel, err := api.ParseSelectorStruct(selector)
+			if err != nil {
+				return val, fmt.Errorf("failed to parse selector: %w", err)
+			}
+			if sel.Field == "" {
+				return next(ctx, fd, keys, val)
+			}
+
+			if fd.Primitive != api.PrimitiveTypeStruct {
+				return val, fmt.Errorf("selector %s specified a field, although the feature is not a struct", selector)
+			}
+			if _, ok := fd.Field(sel.Field); !ok {
+				return val, fmt.Errorf("field `%s` is not defined for feature %s", sel.Field, fd.FQN)
+			}
+			if val.Value != nil {
+				st, err := fd.StructValue(val.Value)
+				if err != nil {
+					return val, fmt.Errorf("value mismatch: %w", err)
+				}
+				val.Value = st[sel.Field]
+			}
+			return next(ctx, fd, keys, val)
+		}
+	}
+}
+
+// encodingMiddleware encodes the value with the encoding that was requested by the selector
+func (e *engine) encodingMiddleware(f *FeaturePipeliner) api.Middleware {
+	return func(next api.MiddlewareHandler) api.MiddlewareHandler {
+		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
+			selector, err := api.SelectorFromContext(ctx)
+			if err != nil {
+				return val, fmt.Errorf("failed to get selector from context: %w", err)
+			}
+			sel, err := api.ParseSelectorStruct(selector)
+			if err != nil {
+				return val, fmt.Errorf("failed to parse selector: %w", err)
+			}
+			if sel.Encoding == "" || val.Value == nil {
+				return next(ctx, fd, keys, val)
+			}
+
+			enc, err := f.Encoder(sel.Encoding)
+			if err != nil {
+				return val, err
+			}
+			v, err := enc(val.Value)
+			if err != nil {
+				return val, fmt.Errorf("failed to encode the value with `%s`: %w", sel.Encoding, err)
+			}
+			val.Value = v
+			return next(ctx, fd, keys, val)
+		}
+	}
+}
+
 func (e *engine) cachePostGetMiddleware(f *FeaturePipeliner) api.Middleware {
 	return func(next api.MiddlewareHandler) api.MiddlewareHandler {
 		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
-			// If the value is nil, we should not cache the value.
-			if val.Value == nil || fd.ValidWindow() || fd.DataSource == "" {
+			// If the value is nil or degraded, we should not cache the value.
+			if val.Value == nil || len(val.Degraded) > 0 || fd.ValidWindow() || fd.DataSource == "" {
 				return next(ctx, fd, keys, val)
 			}
 
-			if api.TypeDetect(val.Value) != fd.Primitive {
-				return val, fmt.Errorf("value mismatch: got value with a different type than the feature type")
+			val, err := conformValue(fd, val)
+			if err != nil {
+				return val, err
 			}
 
 			// If the flag `ContextKeyCachePostGet` is disabled, we should not cache the value.
@@ -109,7 +215,7 @@ func (e *engine) cachePostGetMiddleware(f *FeaturePipeliner) api.Middleware {
 
 			ctx2 := context.WithValue(context.Background(), api.ContextKeyLogger, api.LoggerFromContext(ctx))
 			go func(ctx context.Context, keys api.Keys, val api.Value) {
-				_, err := e.writePipeline(f, api.StateMethodSet).Apply(ctx, keys, val)
+				_, err := e.cacheWritePipeline(f).Apply(ctx, keys, val)
 				if err != nil {
 					logger := api.LoggerFromContext(ctx)
 					logger.Error(err, "failed to update the value to cache")
@@ -123,17 +229,84 @@ func (e *engine) cachePostGetMiddleware(f *FeaturePipeliner) api.Middleware {
 
 func (e *engine) readPipeline(f *FeaturePipeliner) Pipeline {
 	return Pipeline{
-		Middlewares:       append(append(f.preGet.Middlewares(), e.getValueMiddleware()), append(f.postGet.Middlewares(), e.cachePostGetMiddleware(f))...),
+		Middlewares:       append(append(f.preGet.Middlewares(), e.getValueMiddleware(), e.cacheStatsMiddleware()), append(f.postGet.Middlewares(), e.cachePostGetMiddleware(f), e.fieldMiddleware(), e.encodingMiddleware(f))...),
+		FeatureDescriptor: f.FeatureDescriptor,
+	}
+}
+
+// watchPipeline is the read pipeline of the values that are sent to the watchers once they were updated.
+// The value is read from the state as is, so the builders are not triggered and the reads are not counted.
+func (e *engine) watchPipeline(f *FeaturePipeliner) Pipeline {
+	return Pipeline{
+		Middlewares:       Middlewares{e.getValueMiddleware(), e.fieldMiddleware(), e.encodingMiddleware(f)},
 		FeatureDescriptor: f.FeatureDescriptor,
 	}
 }
 func (e *engine) writePipeline(f *FeaturePipeliner, method api.StateMethod) Pipeline {
 	return Pipeline{
-		Middlewares:       append(append(f.preSet.Middlewares(), e.setMiddleware(method)), f.postSet.Middlewares()...),
+		Middlewares:       append(append(f.preSet.Middlewares(), e.constraintsMiddleware(method), e.setMiddleware(method)), append(f.postSet.Middlewares(), e.watchMiddleware())...),
 		FeatureDescriptor: f.FeatureDescriptor,
 	}
 }
 
+// cacheWritePipeline is the write pipeline of the values that are written back to the state after they were read.
+// The watchers are not notified and the constraints are not enforced, since the value was already served.
+func (e *engine) cacheWritePipeline(f *FeaturePipeliner) Pipeline {
+	return Pipeline{
+		Middlewares:       append(append(f.preSet.Middlewares(), e.setMiddleware(api.StateMethodSet)), f.postSet.Middlewares()...),
+		FeatureDescriptor: f.FeatureDescriptor,
+	}
+}
+
+// constraintsMiddleware enforces the feature's constraints on the written value, according to their mode.
+// Increments are not checked, since they are relative to the value in the state. Appended values (including updates of
+// list features, which are appended by the state) are checked as items of the list.
+func (e *engine) constraintsMiddleware(method api.StateMethod) api.Middleware {
+	return func(next api.MiddlewareHandler) api.MiddlewareHandler {
+		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
+			if fd.Constraints == nil || method == api.StateMethodIncr {
+				return next(ctx, fd, keys, val)
+			}
+
+			check := fd.Constraints.Check
+			if method == api.StateMethodAppend || (method == api.StateMethodUpdate && updateAppends(fd)) {
+				check = fd.Constraints.CheckAppended
+			}
+			clamped, violations := check(val.Value)
+			if len(violations) == 0 {
+				return next(ctx, fd, keys, val)
+			}
+
+			clampable := true
+			for _, v := range violations {
+				stats.IncrConstraintViolation(fd.FQN, v.Constraint)
+				clampable = clampable && v.Clampable()
+			}
+			err := api.ViolationsError(violations)
+
+			switch fd.Constraints.Mode {
+			case api.ConstraintModeLog:
+				api.LoggerFromContext(ctx).Info("accepted a value that violates the constraints", "feature", fd.FQN,
+					"keys", keys, "violations", err.Error())
+			case api.ConstraintModeClamp:
+				if !clampable {
+					return val, err
+				}
+				val.Value = clamped
+			default:
+				return val, err
+			}
+			return next(ctx, fd, keys, val)
+		}
+	}
+}
+
+// updateAppends returns true if an update of the feature appends the value to the list in the state
+func updateAppends(fd api.FeatureDescriptor) bool {
+	return !fd.Primitive.Scalar() && !fd.ValidWindow() &&
+		fd.Primitive != api.PrimitiveTypeEmbedding && fd.Primitive != api.PrimitiveTypeStruct
+}
+
 func (e *engine) setMiddleware(method api.StateMethod) api.Middleware {
 	return func(next api.MiddlewareHandler) api.MiddlewareHandler {
 		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
@@ -141,8 +314,9 @@ func (e *engine) setMiddleware(method api.StateMethod) api.Middleware {
 				return next(ctx, fd, keys, val)
 			}
 
-			if api.TypeDetect(val.Value) != fd.Primitive {
-				return val, fmt.Errorf("value mismatch: got value with a different type than the feature type")
+			val, err := conformValue(fd, val)
+			if err != nil {
+				return val, err
 			}
 
 			encodedKeys, err := keys.Encode(fd)
@@ -183,3 +357,29 @@ func (e *engine) setMiddleware(method api.StateMethod) api.Middleware {
 		}
 	}
 }
+
+// conformValue validates the value matches the feature's primitive.
+// Embeddings are accepted as any list of numbers, so they are converted to api.Embedding and their dimension is validated.
+// Structs are accepted as any map, so they are converted to api.Struct and validated against the feature's fields.
+func conformValue(fd api.FeatureDescriptor, val api.Value) (api.Value, error) {
+	if fd.Primitive == api.PrimitiveTypeEmbedding {
+		emb, err := fd.EmbeddingValue(val.Value)
+		if err != nil {
+			return val, fmt.Errorf("value mismatch: %w", err)
+		}
+		val.Value = emb
+		return val, nil
+	}
+	if fd.Primitive == api.PrimitiveTypeStruct {
+		st, err := fd.StructValue(val.Value)
+		if err != nil {
+			return val, fmt.Errorf("value mismatch: %w", err)
+		}
+		val.Value = st
+		return val, nil
+	}
+	if api.TypeDetect(val.Value) != fd.Primitive {
+		return val, fmt.Errorf("value mismatch: got value with a different type than the feature type")
+	}
+	return val, nil
+}
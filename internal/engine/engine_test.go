@@ -0,0 +1,545 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"errors"
+	"github.com/go-logr/logr"
+	"github.com/raptor-ml/raptor/api"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"github.com/raptor-ml/raptor/internal/historian"
+	"github.com/raptor-ml/raptor/internal/plugins/providers/state/memory"
+	"github.com/raptor-ml/raptor/internal/stats"
+	"reflect"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestEngine creates an engine with an in-memory state
+func newTestEngine(t testing.TB) *engine {
+	t.Helper()
+	state := memory.New()
+	h := historian.NewClient(historian.ClientConfig{Logger: logr.Discard()})
+	return New(state, h, nil, nil, nil, logr.Discard()).(*engine)
+}
+
+// testFeature returns a feature that is stored in the state
+func testFeature(name string, primitive api.PrimitiveType) api.FeatureDescriptor {
+	return api.FeatureDescriptor{
+		FQN:        name + ".default",
+		Primitive:  primitive,
+		Freshness:  time.Minute,
+		Staleness:  time.Hour,
+		Keys:       []string{"id"},
+		DataSource: "source.default",
+		Builder:    "test",
+	}
+}
+
+func bindTestFeature(t *testing.T, e *engine, fd api.FeatureDescriptor) *FeaturePipeliner {
+	t.Helper()
+	f := &FeaturePipeliner{FeatureDescriptor: fd}
+	if err := e.bindFeature(f); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestBatchGet(t *testing.T) {
+	e := newTestEngine(t)
+	ctx := context.Background()
+	bindTestFeature(t, e, testFeature("a", api.PrimitiveTypeInteger))
+	bindTestFeature(t, e, testFeature("b", api.PrimitiveTypeString))
+	for id, v := range map[string]int{"1": 10, "2": 20} {
+		if err := e.Set(ctx, "a.default", api.Keys{"id": id}, v, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Set(ctx, "b.default", api.Keys{"id": "1"}, "hello", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		items   []api.BatchGetItem
+		want    []any
+		wantErr []error
+	}{
+		{
+			name: "ordering",
+			items: []api.BatchGetItem{
+				{Selector: "a.default", Keys: api.Keys{"id": "2"}},
+				{Selector: "b.default", Keys: api.Keys{"id": "1"}},
+				{Selector: "a.default", Keys: api.Keys{"id": "1"}},
+				{Selector: "a.default", Keys: api.Keys{"id": "2"}},
+				{Selector: "a.default", Keys: api.Keys{"id": "3"}},
+			},
+			want: []any{20, "hello", 10, 20, nil},
+		},
+		{
+			name: "per item errors",
+			items: []api.BatchGetItem{
+				{Selector: "missing.default", Keys: api.Keys{"id": "1"}},
+				{Selector: "a.default", Keys: api.Keys{"id": "1"}},
+				{Selector: "a.default@-1", Keys: api.Keys{"id": "1"}},
+			},
+			want:    []any{nil, 10, nil},
+			wantErr: []error{api.ErrFeatureNotFound, nil, errors.New("")},
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.BatchGet(ctx, tt.items)
+			if err != nil {
+				t.Fatalf("BatchGet() error = %v", err)
+			}
+			if len(got) != len(tt.items) {
+				t.Fatalf("expected %d results, got %d", len(tt.items), len(got))
+			}
+			for i, r := range got {
+				var wantErr error
+				if tt.wantErr != nil {
+					wantErr = tt.wantErr[i]
+				}
+				switch {
+				case wantErr == nil && r.Err != nil:
+					t.Errorf("item %d: unexpected error %v", i, r.Err)
+				case wantErr != nil && r.Err == nil:
+					t.Errorf("item %d: expected an error", i)
+				case wantErr != nil && wantErr.Error() != "" && !errors.Is(r.Err, wantErr):
+					t.Errorf("item %d: expected %v, got %v", i, wantErr, r.Err)
+				}
+				if r.Value.Value != tt.want[i] {
+					t.Errorf("item %d: expected %v, got %v", i, tt.want[i], r.Value.Value)
+				}
+				if r.Err == nil && r.FeatureDescriptor.FQN != tt.items[i].Selector {
+					t.Errorf("item %d: expected the descriptor of %s, got %s", i, tt.items[i].Selector, r.FeatureDescriptor.FQN)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchGetConcurrency(t *testing.T) {
+	e := newTestEngine(t)
+	f := bindTestFeature(t, e, testFeature("slow", api.PrimitiveTypeInteger))
+
+	var running, peak atomic.Int32
+	f.AddPreGetMiddleware(0, func(next api.MiddlewareHandler) api.MiddlewareHandler {
+		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return next(ctx, fd, keys, val)
+		}
+	})
+
+	items := make([]api.BatchGetItem, 4*batchGetConcurrency)
+	for i := range items {
+		items[i] = api.BatchGetItem{Selector: "slow.default", Keys: api.Keys{"id": "1"}}
+	}
+	got, err := e.BatchGet(context.Background(), items)
+	if err != nil {
+		t.Fatalf("BatchGet() error = %v", err)
+	}
+	if len(got) != len(items) {
+		t.Fatalf("expected %d results, got %d", len(items), len(got))
+	}
+	if p := peak.Load(); p > batchGetConcurrency || p < 2 {
+		t.Errorf("expected up to %d concurrent reads (and more than one), got %d", batchGetConcurrency, p)
+	}
+}
+
+func TestBatchGetCanceled(t *testing.T) {
+	e := newTestEngine(t)
+	bindTestFeature(t, e, testFeature("a", api.PrimitiveTypeInteger))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	got, err := e.BatchGet(ctx, []api.BatchGetItem{{Selector: "a.default", Keys: api.Keys{"id": "1"}}})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the context's error, got %v", err)
+	}
+	if len(got) != 1 {
+		t.Errorf("expected a result per item, got %d", len(got))
+	}
+}
+
+// ingest sends the records to Ingest through an unbuffered channel, as a producer would
+func ingest(ctx context.Context, e *engine, records []api.IngestRecord) (api.IngestSummary, error) {
+	c := make(chan api.IngestRecord)
+	go func() {
+		defer close(c)
+		for _, r := range records {
+			c <- r
+		}
+	}()
+	return e.Ingest(ctx, c)
+}
+
+func TestIngest(t *testing.T) {
+	e := newTestEngine(t)
+	bindTestFeature(t, e, testFeature("count", api.PrimitiveTypeInteger))
+	bindTestFeature(t, e, testFeature("tags", api.PrimitiveTypeStringList))
+	ctx := context.Background()
+	now := time.Now()
+
+	records := []api.IngestRecord{
+		{Method: api.StateMethodSet, Selector: "count.default", Keys: api.Keys{"id": "1"}, Value: 1, Timestamp: now},
+		{Method: api.StateMethodAppend, Selector: "tags.default", Keys: api.Keys{"id": "1"}, Value: []string{"a"}, Timestamp: now},
+		{Method: api.StateMethodSet, Selector: "missing.default", Keys: api.Keys{"id": "1"}, Value: 1, Timestamp: now},
+		{Method: api.StateMethodIncr, Selector: "count.default", Keys: api.Keys{"id": "2"}, Value: 2, Timestamp: now},
+		{Method: api.StateMethodSet, Selector: "count.default", Keys: api.Keys{"id": "3"}, Value: "nan", Timestamp: now},
+		{Method: api.StateMethodUpdate, Selector: "tags.default", Keys: api.Keys{"id": "2"}, Value: []string{"b", "c"}, Timestamp: now},
+		{Method: api.StateMethodGet, Selector: "count.default", Keys: api.Keys{"id": "1"}, Timestamp: now},
+	}
+	summary, err := ingest(ctx, e, records)
+	if err != nil {
+		t.Fatalf("Ingest() error = %v", err)
+	}
+	if summary.Received != 7 || summary.Succeeded != 4 || summary.Failed != 3 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	var failed []uint64
+	for _, f := range summary.Failures {
+		if f.Err == nil {
+			t.Errorf("expected the error of record %d", f.Index)
+		}
+		failed = append(failed, f.Index)
+	}
+	if len(failed) != 3 || failed[0] != 2 || failed[1] != 4 || failed[2] != 6 {
+		t.Errorf("expected the failures of records 2, 4 and 6 in order, got %v", failed)
+	}
+	if !errors.Is(summary.Failures[0].Err, api.ErrFeatureNotFound) {
+		t.Errorf("expected a not found error, got %v", summary.Failures[0].Err)
+	}
+
+	for _, tt := range []struct {
+		selector string
+		id       string
+		want     any
+	}{
+		{"count.default", "1", 1},
+		{"count.default", "2", 2},
+		{"count.default", "3", nil},
+		{"tags.default", "1", []string{"a"}},
+		{"tags.default", "2", []string{"b", "c"}},
+	} {
+		v, _, err := e.Get(ctx, tt.selector, api.Keys{"id": tt.id})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(v.Value, tt.want) {
+			t.Errorf("%s[%s]: expected %v, got %v", tt.selector, tt.id, tt.want, v.Value)
+		}
+	}
+}
+
+func TestIngestMaxFailures(t *testing.T) {
+	e := newTestEngine(t)
+	records := make([]api.IngestRecord, api.MaxIngestFailures+10)
+	for i := range records {
+		records[i] = api.IngestRecord{Method: api.StateMethodSet, Selector: "missing.default", Keys: api.Keys{"id": "1"}, Value: 1}
+	}
+
+	summary, err := ingest(context.Background(), e, records)
+	if err != nil {
+		t.Fatalf("Ingest() error = %v", err)
+	}
+	if summary.Received != uint64(len(records)) || summary.Failed != uint64(len(records)) || summary.Succeeded != 0 {
+		t.Errorf("unexpected summary counts %d/%d/%d", summary.Received, summary.Succeeded, summary.Failed)
+	}
+	if len(summary.Failures) != api.MaxIngestFailures {
+		t.Errorf("expected %d reported failures, got %d", api.MaxIngestFailures, len(summary.Failures))
+	}
+	for i := 1; i < len(summary.Failures); i++ {
+		if summary.Failures[i-1].Index >= summary.Failures[i].Index {
+			t.Fatalf("expected the failures to be sorted by their index")
+		}
+	}
+}
+
+func TestIngestDrainsCanceled(t *testing.T) {
+	e := newTestEngine(t)
+	bindTestFeature(t, e, testFeature("count", api.PrimitiveTypeInteger))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	records := make([]api.IngestRecord, 100)
+	for i := range records {
+		records[i] = api.IngestRecord{Method: api.StateMethodSet, Selector: "count.default", Keys: api.Keys{"id": "1"}, Value: i, Timestamp: time.Now()}
+	}
+
+	done := make(chan api.IngestSummary)
+	go func() {
+		// the producer would block forever if the channel wasn't drained
+		summary, err := ingest(ctx, e, records)
+		if err != nil {
+			t.Errorf("Ingest() error = %v", err)
+		}
+		done <- summary
+	}()
+	select {
+	case summary := <-done:
+		if summary.Received != 100 || summary.Failed != 100 {
+			t.Errorf("expected all the records to be received and failed, got %+v", summary)
+		}
+		for _, f := range summary.Failures {
+			if !errors.Is(f.Err, context.Canceled) {
+				t.Errorf("expected the context's error, got %v", f.Err)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected Ingest to drain the records")
+	}
+}
+
+// metricValue returns the value of a counter, or the number of samples of a histogram, with the given labels
+func metricValue(t *testing.T, name string, labels map[string]string) float64 {
+	t.Helper()
+	mfs, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mf := range mfs {
+		if mf.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range mf.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if v, ok := labels[l.GetName()]; ok && v != l.GetValue() {
+					continue metrics
+				}
+			}
+			if m.GetHistogram() != nil {
+				return float64(m.GetHistogram().GetSampleCount())
+			}
+			return m.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
+
+// stubRuntime fails the executions of the `fail` program
+type stubRuntime struct {
+	api.RuntimeManager
+}
+
+func (stubRuntime) ExecuteProgram(_ context.Context, _ string, fqn string, keys api.Keys, _ map[string]any, ts time.Time, _ bool) (api.Value, api.Keys, error) {
+	if fqn == "fail" {
+		return api.Value{}, nil, errors.New("execution failed")
+	}
+	return api.Value{Value: 1, Timestamp: ts}, keys, nil
+}
+
+func TestMetrics(t *testing.T) {
+	e := newTestEngine(t)
+	e.RuntimeManager = stubRuntime{}
+	ctx := context.Background()
+	fd := testFeature("metrics", api.PrimitiveTypeInteger)
+	bindTestFeature(t, e, fd)
+
+	cache := func(result string) float64 {
+		return metricValue(t, "core_feature_cache_total", map[string]string{"fqn": fd.FQN, "result": result})
+	}
+	pipeline := func(op string) float64 {
+		return metricValue(t, "core_feature_pipeline_duration_seconds", map[string]string{"fqn": fd.FQN, "operation": op})
+	}
+	get := func(id string) {
+		t.Helper()
+		if _, _, err := e.Get(ctx, fd.FQN, api.Keys{"id": id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	get("1")
+	if err := e.Set(ctx, fd.FQN, api.Keys{"id": "1"}, 1, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	get("1")
+	// the value is older than the freshness, but it's not stale yet
+	if err := e.Set(ctx, fd.FQN, api.Keys{"id": "2"}, 1, time.Now().Add(-2*fd.Freshness)); err != nil {
+		t.Fatal(err)
+	}
+	get("2")
+
+	for result, want := range map[string]float64{stats.CacheMiss: 1, stats.CacheHit: 1, stats.CacheStale: 1} {
+		if got := cache(result); got != want {
+			t.Errorf("expected %v cache reads with the result %s, got %v", want, result, got)
+		}
+	}
+	if got := pipeline("get"); got != 3 {
+		t.Errorf("expected 3 observations of the read pipeline, got %v", got)
+	}
+	if got := pipeline("set"); got != 2 {
+		t.Errorf("expected 2 observations of the write pipeline, got %v", got)
+	}
+	// the age is observed only for the reads that returned a value
+	if got := metricValue(t, "core_feature_value_age_seconds", map[string]string{"fqn": fd.FQN}); got != 2 {
+		t.Errorf("expected 2 observations of the value age, got %v", got)
+	}
+
+	for _, fqn := range []string{"ok", "fail"} {
+		_, _, _ = e.ExecuteProgram(ctx, "metrics-env", fqn, api.Keys{"id": "1"}, nil, time.Now(), false)
+	}
+	if got := metricValue(t, "core_runtime_execution_duration_seconds", map[string]string{"env": "metrics-env"}); got != 2 {
+		t.Errorf("expected 2 observations of the runtime executions, got %v", got)
+	}
+	if got := metricValue(t, "core_runtime_execution_errors_total", map[string]string{"env": "metrics-env"}); got != 1 {
+		t.Errorf("expected 1 runtime execution error, got %v", got)
+	}
+
+	// the metrics are removed when the feature is unbound
+	if err := e.UnbindFeature(fd.FQN); err != nil {
+		t.Fatal(err)
+	}
+	if got := cache(stats.CacheHit) + pipeline("get"); got != 0 {
+		t.Errorf("expected the metrics of the unbound feature to be removed, got %v", got)
+	}
+}
+
+func TestConformValue(t *testing.T) {
+	embedding := testFeature("emb", api.PrimitiveTypeEmbedding)
+	embedding.EmbeddingDim = 3
+
+	tests := []struct {
+		name    string
+		fd      api.FeatureDescriptor
+		val     any
+		want    any
+		wantErr bool
+	}{
+		{name: "embedding", fd: embedding, val: api.Embedding{1, 2, 3}, want: api.Embedding{1, 2, 3}},
+		{name: "embedding of floats", fd: embedding, val: []float64{1, 2, 3}, want: api.Embedding{1, 2, 3}},
+		{name: "embedding of any", fd: embedding, val: []any{1.0, 2, float32(3)}, want: api.Embedding{1, 2, 3}},
+		{name: "embedding too short", fd: embedding, val: []float64{1, 2}, wantErr: true},
+		{name: "embedding too long", fd: embedding, val: []float64{1, 2, 3, 4}, wantErr: true},
+		{name: "empty embedding", fd: embedding, val: []float64{}, wantErr: true},
+		{name: "embedding of strings", fd: embedding, val: []string{"1", "2", "3"}, wantErr: true},
+		{name: "integer", fd: testFeature("int", api.PrimitiveTypeInteger), val: 1, want: 1},
+		{name: "integer mismatch", fd: testFeature("int", api.PrimitiveTypeInteger), val: "1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := conformValue(tt.fd, api.Value{Value: tt.val})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("conformValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got.Value, tt.want) {
+				t.Errorf("conformValue() = %#v, want %#v", got.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetEmbedding(t *testing.T) {
+	e := newTestEngine(t)
+	ctx := context.Background()
+	fd := testFeature("emb", api.PrimitiveTypeEmbedding)
+	fd.EmbeddingDim = 3
+	bindTestFeature(t, e, fd)
+	keys := api.Keys{"id": "1"}
+
+	// a vector with the wrong dimension is rejected before it's written to the state
+	if err := e.Set(ctx, fd.FQN, keys, []float64{1, 2}, time.Now()); err == nil {
+		t.Fatal("expected a dimension mismatch error")
+	}
+	v, _, err := e.Get(ctx, fd.FQN, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Value != nil {
+		t.Fatalf("expected no value, got %v", v.Value)
+	}
+
+	if err := e.Set(ctx, fd.FQN, keys, []float64{1, 2, 3}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	v, _, err = e.Get(ctx, fd.FQN, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (api.Embedding{1, 2, 3}); !reflect.DeepEqual(v.Value, want) {
+		t.Errorf("expected %v, got %#v", want, v.Value)
+	}
+}
+
+func TestUnbindReleases(t *testing.T) {
+	e := newTestEngine(t)
+	released := 0
+	f := bindTestFeature(t, e, testFeature("a", api.PrimitiveTypeInteger))
+	f.AddUnbindHook(func() { released++ })
+
+	if err := e.UnbindFeature(f.FQN); err != nil {
+		t.Fatal(err)
+	}
+	if released != 1 {
+		t.Errorf("expected the unbind hook to be called once, got %d", released)
+	}
+	// unbinding a feature that isn't bound doesn't call the hooks again
+	if err := e.UnbindFeature(f.FQN); err != nil {
+		t.Fatal(err)
+	}
+	if released != 1 {
+		t.Errorf("expected the unbind hook to be called once, got %d", released)
+	}
+}
+
+func TestAppendedListConstraints(t *testing.T) {
+	e := newTestEngine(t)
+	ctx := context.Background()
+	fd := testFeature("tags", api.PrimitiveTypeStringList)
+	c, err := api.ConstraintsFromManifest(&manifests.FeatureConstraints{AllowedValues: []string{"a", "b"}}, fd.Primitive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd.Constraints = c
+	bindTestFeature(t, e, fd)
+	keys := api.Keys{"id": "1"}
+
+	// updates of a list feature are appended by the state, so they're checked like appended items
+	for _, write := range []func(context.Context, string, api.Keys, any, time.Time) error{e.Update, e.Append} {
+		if err := write(ctx, fd.FQN, keys, []string{"a"}, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		if err := write(ctx, fd.FQN, keys, []string{"b", "c"}, time.Now()); !errors.Is(err, api.ErrConstraintViolation) {
+			t.Fatalf("expected a constraint violation, got %v", err)
+		}
+	}
+
+	v, _, err := e.Get(ctx, fd.FQN, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(v.Value, []string{"a", "a"}) {
+		t.Errorf("expected only the allowed items to be appended, got %v", v.Value)
+	}
+}
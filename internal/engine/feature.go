@@ -18,9 +18,12 @@ package engine
 
 import (
 	"context"
+	"fmt"
 	"github.com/go-logr/logr"
 	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/pkg/plugins"
 	"sort"
+	"sync"
 	"time"
 )
 
@@ -32,6 +35,12 @@ type FeaturePipeliner struct {
 	postGet mws
 	preSet  mws
 	postSet mws
+
+	// encoders are the encoders that were configured in the feature's spec, and the ones that were created on demand
+	encoders   map[string]api.Encoder
+	encodersMu sync.RWMutex
+
+	unbindHooks []func()
 }
 
 // AddPreGetMiddleware adds a pre-get hook to the feature abstraction.
@@ -66,6 +75,54 @@ func (f *FeaturePipeliner) AddPostSetMiddleware(priority int, fn api.Middleware)
 	f.postSet = append(f.postSet, mw{fn: fn, priority: priority})
 }
 
+// AddUnbindHook adds a hook that is called once the feature is unbound.
+func (f *FeaturePipeliner) AddUnbindHook(fn func()) {
+	if fn == nil {
+		return
+	}
+	f.unbindHooks = append(f.unbindHooks, fn)
+}
+
+// Release calls the unbind hooks, so the resources that the plugins acquired for the feature are released.
+func (f *FeaturePipeliner) Release() {
+	for _, fn := range f.unbindHooks {
+		fn()
+	}
+	f.unbindHooks = nil
+}
+
+// Encoder returns the encoder of the feature by its name.
+// Encodings that weren't configured in the feature's spec are created without a configuration on their first use,
+// and are kept alongside the configured ones.
+func (f *FeaturePipeliner) Encoder(name string) (api.Encoder, error) {
+	f.encodersMu.RLock()
+	enc, ok := f.encoders[name]
+	f.encodersMu.RUnlock()
+	if ok {
+		return enc, nil
+	}
+
+	factory := plugins.Encoders.Get(name)
+	if factory == nil {
+		return nil, fmt.Errorf("encoding `%s` is not supported", name)
+	}
+
+	f.encodersMu.Lock()
+	defer f.encodersMu.Unlock()
+	if enc, ok := f.encoders[name]; ok {
+		return enc, nil
+	}
+	enc, err := factory(f.FeatureDescriptor, nil)
+	if err != nil {
+		return nil, err
+	}
+	if f.encoders == nil {
+		f.encoders = make(map[string]api.Encoder)
+	}
+	f.encoders[name] = enc
+	return enc, nil
+}
+
 // Context returns a new context with the feature attached.
 func (f *FeaturePipeliner) Context(ctx context.Context, selector string, logger logr.Logger) (context.Context, context.CancelFunc, error) {
 	ctx = context.WithValue(ctx, api.ContextKeyLogger, logger)
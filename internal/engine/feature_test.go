@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"github.com/raptor-ml/raptor/api"
+	_ "github.com/raptor-ml/raptor/internal/plugins/encoders"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// countingEncoderCalls counts the calls to the factory of the `counting` encoder
+var countingEncoderCalls atomic.Int64
+
+func init() {
+	plugins.Encoders.Register("counting", func(api.FeatureDescriptor, json.RawMessage) (api.Encoder, error) {
+		countingEncoderCalls.Add(1)
+		return func(val any) (any, error) { return val, nil }, nil
+	})
+}
+
+func TestFeatureEncoder(t *testing.T) {
+	configured := func(any) (any, error) { return "configured", nil }
+	f := &FeaturePipeliner{
+		FeatureDescriptor: testFeature("score", api.PrimitiveTypeFloat),
+		encoders:          map[string]api.Encoder{"minmax": configured},
+	}
+
+	tests := []struct {
+		name    string
+		val     any
+		want    any
+		wantErr bool
+	}{
+		// the encoders that were configured in the spec are preferred
+		{name: "minmax", val: 1.0, want: "configured"},
+		// registered encoders that don't require a configuration are created on demand
+		{name: "log", val: 0.0, want: 0.0},
+		// registered encoders that require a configuration fail without one
+		{name: "onehot", wantErr: true},
+		{name: "zscore", wantErr: true},
+		{name: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enc, err := f.Encoder(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Encoder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := enc(tt.val)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("encoder = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeatureEncoderCache(t *testing.T) {
+	countingEncoderCalls.Store(0)
+	f := &FeaturePipeliner{FeatureDescriptor: testFeature("score", api.PrimitiveTypeFloat)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := f.Encoder("counting"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := countingEncoderCalls.Load(); n != 1 {
+		t.Errorf("expected the encoder to be created once, got %d", n)
+	}
+
+	// the encoders of other features are created separately
+	other := &FeaturePipeliner{FeatureDescriptor: testFeature("other", api.PrimitiveTypeFloat)}
+	if _, err := other.Encoder("counting"); err != nil {
+		t.Fatal(err)
+	}
+	if n := countingEncoderCalls.Load(); n != 2 {
+		t.Errorf("expected an encoder per feature, got %d", n)
+	}
+}
@@ -19,6 +19,13 @@ package engine
 import (
 	"context"
 	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/pkg/tracing"
+	"go.opentelemetry.io/otel/trace"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
 )
 
 type Middlewares []api.Middleware
@@ -45,7 +52,7 @@ func (p Pipeline) apply(ctx context.Context, keys api.Keys, first api.Value) (ap
 		return val, nil
 	}
 	for i := len(p.Middlewares) - 1; i >= 0; i-- {
-		next = p.Middlewares[i](next)
+		next = traced(p.Middlewares[i])(next)
 	}
 	return next(ctx, p.FeatureDescriptor, keys, first)
 }
@@ -66,7 +73,7 @@ func (p Pipeline) applyWithTimeout(ctx context.Context, keys api.Keys, first api
 	var next api.MiddlewareHandler
 	next = handlerWithTimeout(nil, c)
 	for i := len(p.Middlewares) - 1; i >= 0; i-- {
-		next = handlerWithTimeout(p.Middlewares[i](next), c)
+		next = handlerWithTimeout(traced(p.Middlewares[i])(next), c)
 	}
 
 	var err error
@@ -88,3 +95,49 @@ func (p Pipeline) applyWithTimeout(ctx context.Context, keys api.Keys, first api
 		}
 	}
 }
+
+var closureSuffix = regexp.MustCompile(`(\.func\d+)+$|-fm$`)
+
+// middlewareNames caches the span names of the middlewares by the program counter of their function
+var middlewareNames sync.Map
+
+// middlewareName returns the name of the function that created the middleware, e.g. `getValueMiddleware`
+func middlewareName(mw api.Middleware) string {
+	pc := reflect.ValueOf(mw).Pointer()
+	if name, ok := middlewareNames.Load(pc); ok {
+		return name.(string)
+	}
+
+	name := "middleware"
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		name = closureSuffix.ReplaceAllString(fn.Name(), "")
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			name = name[i+1:]
+		}
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			name = name[i+1:]
+		}
+	}
+	middlewareNames.Store(pc, name)
+	return name
+}
+
+// traced wraps the middleware with a span. Since middlewares call the next one, the spans of the following
+// middlewares are nested within it. If tracing is disabled, the middleware is returned as is.
+func traced(mw api.Middleware) api.Middleware {
+	if !tracing.Enabled() {
+		return mw
+	}
+	name := "middleware." + middlewareName(mw)
+	return func(next api.MiddlewareHandler) api.MiddlewareHandler {
+		h := mw(next)
+		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
+			ctx, span := tracing.Tracer().Start(ctx, name, trace.WithAttributes(tracing.AttrFQN.String(fd.FQN)))
+			defer span.End()
+
+			val, err := h(ctx, fd, keys, val)
+			tracing.RecordError(span, err)
+			return val, err
+		}
+	}
+}
@@ -0,0 +1,298 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"sync"
+	"time"
+)
+
+const (
+	// watchBufferSize is the number of pending events a watcher can hold before updates are dropped
+	watchBufferSize = 128
+	// watchQueueSize is the number of pending notifications that are waiting to be dispatched to the watchers
+	watchQueueSize = 1024
+	// watchWorkers is the number of workers that dispatch the notifications to the watchers
+	watchWorkers = 8
+	// watchPublishers is the number of workers that publish the notifications of the writes to the watch notifier
+	watchPublishers = 4
+	// watchPublishTimeout is the maximum duration of publishing a single notification
+	watchPublishTimeout = 5 * time.Second
+)
+
+type watcher struct {
+	// selectors by FQN
+	selectors map[string][]string
+	keys      api.Keys
+	c         chan api.WatchEvent
+}
+
+func (w *watcher) match(fqn string, keys api.Keys) bool {
+	if _, ok := w.selectors[fqn]; !ok {
+		return false
+	}
+	for k, v := range w.keys {
+		if keys[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// watchers holds the local watchers of the instance.
+// While there are local watchers, it is subscribed to the watch notifier to receive the updates of all the instances,
+// and the notifications are dispatched to the watchers by a fixed number of workers.
+// The notifications of the writes are published to the watch notifier in the background by a fixed number of workers,
+// so the writes don't wait for the notifier.
+type watchers struct {
+	mu       sync.Mutex
+	watchers map[*watcher]struct{}
+	queue    chan api.WatchNotification
+	cancel   context.CancelFunc
+
+	publishOnce sync.Once
+	publish     chan api.WatchNotification
+}
+
+func (e *engine) Watch(ctx context.Context, selectors []string, keys api.Keys) (<-chan api.WatchEvent, error) {
+	if len(selectors) == 0 {
+		return nil, fmt.Errorf("at least one selector is required")
+	}
+
+	w := &watcher{
+		selectors: make(map[string][]string),
+		keys:      keys,
+		c:         make(chan api.WatchEvent, watchBufferSize),
+	}
+	for _, selector := range selectors {
+		if _, err := e.FeatureDescriptor(ctx, selector); err != nil {
+			return nil, err
+		}
+		fqn, err := api.NormalizeFQN(selector, "undefined-namespace")
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize Feature Selector `%s` as FQN: %w", selector, err)
+		}
+		w.selectors[fqn] = append(w.selectors[fqn], selector)
+	}
+
+	if err := e.addWatcher(w); err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		e.removeWatcher(w)
+	}()
+	return w.c, nil
+}
+
+func (e *engine) addWatcher(w *watcher) error {
+	e.watchers.mu.Lock()
+	defer e.watchers.mu.Unlock()
+
+	if e.watchers.watchers == nil {
+		e.watchers.watchers = make(map[*watcher]struct{})
+	}
+	if len(e.watchers.watchers) == 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		queue := make(chan api.WatchNotification, watchQueueSize)
+		if e.watchNotifier != nil {
+			c, err := e.watchNotifier.Subscribe(ctx)
+			if err != nil {
+				cancel()
+				return fmt.Errorf("failed to subscribe to watch notifications: %w", err)
+			}
+			go func() {
+				for n := range c {
+					select {
+					case queue <- n:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+		for i := 0; i < watchWorkers; i++ {
+			go e.watchWorker(ctx, queue)
+		}
+		e.watchers.queue = queue
+		e.watchers.cancel = cancel
+	}
+	e.watchers.watchers[w] = struct{}{}
+	return nil
+}
+
+func (e *engine) removeWatcher(w *watcher) {
+	e.watchers.mu.Lock()
+	defer e.watchers.mu.Unlock()
+
+	delete(e.watchers.watchers, w)
+	close(w.c)
+	if len(e.watchers.watchers) == 0 && e.watchers.cancel != nil {
+		e.watchers.cancel()
+		e.watchers.cancel = nil
+		e.watchers.queue = nil
+	}
+}
+
+func (e *engine) watchWorker(ctx context.Context, queue <-chan api.WatchNotification) {
+	for {
+		select {
+		case n := <-queue:
+			e.dispatchWatch(n)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// notifyWatch notifies all the instances that a value was committed to the state.
+// The notification is published in the background, and it's dropped if the publishers are lagging behind.
+// Without a watch notifier (i.e. when the watch notifications are disabled), only the local watchers are notified,
+// and the write costs nothing while there are none.
+func (e *engine) notifyWatch(fqn, encodedKeys string) {
+	n := api.WatchNotification{
+		FQN:         fqn,
+		EncodedKeys: encodedKeys,
+	}
+	if e.watchNotifier == nil {
+		e.enqueueWatch(n)
+		return
+	}
+
+	e.watchers.publishOnce.Do(func() {
+		e.watchers.publish = make(chan api.WatchNotification, watchQueueSize)
+		for i := 0; i < watchPublishers; i++ {
+			go e.watchPublisher(e.watchers.publish)
+		}
+	})
+	select {
+	case e.watchers.publish <- n:
+	default:
+		e.logger.Info("watch publish queue is full, dropping update", "fqn", fqn)
+	}
+}
+
+func (e *engine) watchPublisher(queue <-chan api.WatchNotification) {
+	for n := range queue {
+		ctx, cancel := context.WithTimeout(context.Background(), watchPublishTimeout)
+		if err := e.watchNotifier.Notify(ctx, n); err != nil {
+			e.logger.V(1).Info("failed to send watch notification", "fqn", n.FQN, "error", err.Error())
+		}
+		cancel()
+	}
+}
+
+// enqueueWatch queues the notification to be dispatched to the local watchers, if there are any
+func (e *engine) enqueueWatch(n api.WatchNotification) {
+	e.watchers.mu.Lock()
+	queue := e.watchers.queue
+	e.watchers.mu.Unlock()
+	if queue == nil {
+		return
+	}
+	select {
+	case queue <- n:
+	default:
+		e.logger.Info("watch queue is full, dropping update", "fqn", n.FQN)
+	}
+}
+
+// dispatchWatch sends the updated value to the local watchers that match the notification
+func (e *engine) dispatchWatch(n api.WatchNotification) {
+	fd, err := e.FeatureDescriptor(context.Background(), n.FQN)
+	if err != nil {
+		// the feature is not bound to this instance (yet)
+		return
+	}
+	keys := api.Keys{}
+	if err := keys.Decode(n.EncodedKeys, fd); err != nil {
+		e.logger.Error(err, "failed to decode keys of watch notification", "fqn", n.FQN)
+		return
+	}
+
+	var matched []*watcher
+	e.watchers.mu.Lock()
+	for w := range e.watchers.watchers {
+		if w.match(n.FQN, keys) {
+			matched = append(matched, w)
+		}
+	}
+	e.watchers.mu.Unlock()
+
+	for _, w := range matched {
+		for _, selector := range w.selectors[n.FQN] {
+			val, fd, err := e.watchedValue(selector, keys)
+			if err != nil {
+				e.logger.Error(err, "failed to get the watched value", "selector", selector)
+				continue
+			}
+			e.sendWatchEvent(w, api.WatchEvent{Selector: selector, Keys: keys, Value: val, FeatureDescriptor: fd})
+		}
+	}
+}
+
+// watchedValue reads the updated value of the selector from the state with the watch pipeline
+func (e *engine) watchedValue(selector string, keys api.Keys) (api.Value, api.FeatureDescriptor, error) {
+	f, ctx, cancel, err := e.featureForRequest(context.Background(), selector)
+	if err != nil {
+		return api.Value{}, api.FeatureDescriptor{}, err
+	}
+	defer cancel()
+
+	val, err := e.watchPipeline(f).Apply(ctx, keys, api.Value{})
+	return val, f.FeatureDescriptor, err
+}
+
+func (e *engine) sendWatchEvent(w *watcher, ev api.WatchEvent) {
+	e.watchers.mu.Lock()
+	defer e.watchers.mu.Unlock()
+
+	// the watcher might have been removed in the meantime
+	if _, ok := e.watchers.watchers[w]; !ok {
+		return
+	}
+	select {
+	case w.c <- ev:
+	default:
+		e.logger.Info("watcher is lagging behind, dropping update", "selector", ev.Selector)
+	}
+}
+
+func (e *engine) watchMiddleware() api.Middleware {
+	return func(next api.MiddlewareHandler) api.MiddlewareHandler {
+		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
+			// Values that are not stored in the state are not committed
+			if fd.DataSource == "" {
+				return next(ctx, fd, keys, val)
+			}
+			if !fd.ValidWindow() && val.Timestamp.Before(time.Now().Add(-fd.Staleness)) {
+				return next(ctx, fd, keys, val)
+			}
+
+			encodedKeys, err := keys.Encode(fd)
+			if err != nil {
+				return val, fmt.Errorf("failed to encode keys: %v", err)
+			}
+			e.notifyWatch(fd.FQN, encodedKeys)
+
+			return next(ctx, fd, keys, val)
+		}
+	}
+}
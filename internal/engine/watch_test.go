@@ -0,0 +1,281 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"context"
+	"github.com/raptor-ml/raptor/api"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// gatedNotifier fans out the notifications to its subscribers, once the gate is open
+type gatedNotifier struct {
+	gate chan struct{}
+	mu   sync.Mutex
+	subs []chan api.WatchNotification
+}
+
+func (n *gatedNotifier) Notify(ctx context.Context, wn api.WatchNotification) error {
+	select {
+	case <-n.gate:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, c := range n.subs {
+		c <- wn
+	}
+	return nil
+}
+
+func (n *gatedNotifier) Subscribe(context.Context) (<-chan api.WatchNotification, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	c := make(chan api.WatchNotification, watchQueueSize)
+	n.subs = append(n.subs, c)
+	return c, nil
+}
+
+func watchEvent(t *testing.T, c <-chan api.WatchEvent) api.WatchEvent {
+	t.Helper()
+	select {
+	case ev, ok := <-c:
+		if !ok {
+			t.Fatalf("the watch channel was closed")
+		}
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected a watch event")
+	}
+	return api.WatchEvent{}
+}
+
+func noWatchEvent(t *testing.T, c <-chan api.WatchEvent) {
+	t.Helper()
+	select {
+	case ev := <-c:
+		t.Fatalf("unexpected watch event %+v", ev)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestWatch(t *testing.T) {
+	e := newTestEngine(t)
+	bindTestFeature(t, e, testFeature("a", api.PrimitiveTypeInteger))
+	bindTestFeature(t, e, testFeature("b", api.PrimitiveTypeInteger))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := e.Watch(ctx, nil, nil); err == nil {
+		t.Errorf("expected an error without selectors")
+	}
+	if _, err := e.Watch(ctx, []string{"missing.default"}, nil); err == nil {
+		t.Errorf("expected an error for a missing feature")
+	}
+
+	all, err := e.Watch(ctx, []string{"a.default"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filtered, err := e.Watch(ctx, []string{"a.default", "b.default"}, api.Keys{"id": "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := e.Set(ctx, "a.default", api.Keys{"id": "2"}, 2, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	ev := watchEvent(t, all)
+	if ev.Selector != "a.default" || ev.Keys["id"] != "2" || ev.Value.Value != 2 || ev.FeatureDescriptor.FQN != "a.default" {
+		t.Errorf("unexpected event %+v", ev)
+	}
+	noWatchEvent(t, filtered)
+
+	if err := e.Set(ctx, "b.default", api.Keys{"id": "1"}, 1, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	ev = watchEvent(t, filtered)
+	if ev.Selector != "b.default" || ev.Value.Value != 1 {
+		t.Errorf("unexpected event %+v", ev)
+	}
+	noWatchEvent(t, all)
+
+	cancel()
+	for _, c := range []<-chan api.WatchEvent{all, filtered} {
+		select {
+		case _, ok := <-c:
+			if ok {
+				t.Errorf("expected the channel to be closed without events")
+			}
+		case <-time.After(2 * time.Second):
+			t.Errorf("expected the channel to be closed when the context is done")
+		}
+	}
+}
+
+func TestWatchReadsTheState(t *testing.T) {
+	e := newTestEngine(t)
+	f := bindTestFeature(t, e, testFeature("a", api.PrimitiveTypeInteger))
+	var gets atomic.Int32
+	f.AddPreGetMiddleware(0, func(next api.MiddlewareHandler) api.MiddlewareHandler {
+		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
+			gets.Add(1)
+			return next(ctx, fd, keys, val)
+		}
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, err := e.Watch(ctx, []string{"a.default"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Set(ctx, "a.default", api.Keys{"id": "1"}, 1, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if ev := watchEvent(t, c); ev.Value.Value != 1 {
+		t.Errorf("unexpected event %+v", ev)
+	}
+	// the value is sent as it's stored, without running the builder's hooks
+	if n := gets.Load(); n != 0 {
+		t.Errorf("expected the get hooks not to run, got %d calls", n)
+	}
+}
+
+func TestWatchOverflow(t *testing.T) {
+	e := newTestEngine(t)
+	bindTestFeature(t, e, testFeature("a", api.PrimitiveTypeInteger))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, err := e.Watch(ctx, []string{"a.default"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the watcher doesn't read, so the updates beyond its buffer are dropped instead of blocking the writes
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 2*watchBufferSize; i++ {
+			if err := e.Set(ctx, "a.default", api.Keys{"id": "1"}, i, time.Now()); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the writes not to be blocked by a lagging watcher")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(c) < watchBufferSize && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(c) != watchBufferSize {
+		t.Errorf("expected the buffer of the watcher to be full, got %d events", len(c))
+	}
+}
+
+func TestWatchNotifier(t *testing.T) {
+	n := &gatedNotifier{gate: make(chan struct{})}
+	e := newTestEngine(t)
+	e.watchNotifier = n
+	bindTestFeature(t, e, testFeature("a", api.PrimitiveTypeInteger))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c, err := e.Watch(ctx, []string{"a.default"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the notifier doesn't accept notifications yet, so the write must not wait for it
+	start := time.Now()
+	if err := e.Set(ctx, "a.default", api.Keys{"id": "1"}, 1, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("expected the write not to wait for the notifier, took %s", d)
+	}
+	noWatchEvent(t, c)
+
+	close(n.gate)
+	ev := watchEvent(t, c)
+	if ev.Value.Value != 1 || ev.Keys["id"] != "1" {
+		t.Errorf("unexpected event %+v", ev)
+	}
+}
+
+// discardNotifier accepts all the notifications, and delivers none of them
+type discardNotifier struct{}
+
+func (discardNotifier) Notify(context.Context, api.WatchNotification) error { return nil }
+func (discardNotifier) Subscribe(context.Context) (<-chan api.WatchNotification, error) {
+	return make(chan api.WatchNotification), nil
+}
+
+// BenchmarkWatchWrite measures the cost of the watch notifications on the write path
+func BenchmarkWatchWrite(b *testing.B) {
+	benchmarks := []struct {
+		name     string
+		notifier api.Notifier[api.WatchNotification]
+		watch    bool
+	}{
+		{name: "no watchers"},
+		{name: "local watcher", watch: true},
+		{name: "notifier", notifier: discardNotifier{}},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			e := newTestEngine(b)
+			if bm.notifier != nil {
+				e.watchNotifier = bm.notifier
+			}
+			fd := testFeature("a", api.PrimitiveTypeInteger)
+			if err := e.bindFeature(&FeaturePipeliner{FeatureDescriptor: fd}); err != nil {
+				b.Fatal(err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if bm.watch {
+				c, err := e.Watch(ctx, []string{"a.default"}, nil)
+				if err != nil {
+					b.Fatal(err)
+				}
+				go func() {
+					for range c {
+					}
+				}()
+			}
+
+			keys := api.Keys{"id": "1"}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := e.Set(ctx, "a.default", keys, i, time.Now()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
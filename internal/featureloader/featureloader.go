@@ -0,0 +1,132 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package featureloader loads FeatureDescriptors for the command-line tools, either from manifest files or
+// from the Kubernetes cluster.
+package featureloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/raptor-ml/raptor/api"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultNamespace is the namespace of manifests that don't specify one.
+const DefaultNamespace = "default"
+
+// FromManifests reads the Feature manifests from the given files and directories.
+// It returns the FeatureDescriptors by their FQN.
+func FromManifests(paths []string) (map[string]api.FeatureDescriptor, error) {
+	fds := make(map[string]api.FeatureDescriptor)
+	for _, p := range paths {
+		err := filepath.WalkDir(p, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".yaml", ".yml", ".json":
+			default:
+				return nil
+			}
+
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			dec := yaml.NewYAMLOrJSONDecoder(f, 4096)
+			for {
+				ft := &manifests.Feature{}
+				if err := dec.Decode(ft); err != nil {
+					if errors.Is(err, io.EOF) {
+						return nil
+					}
+					return fmt.Errorf("failed to decode %s: %w", path, err)
+				}
+				if ft.Kind != "Feature" {
+					continue
+				}
+				if ft.Namespace == "" {
+					ft.Namespace = DefaultNamespace
+				}
+				fd, err := api.FeatureDescriptorFromManifest(ft)
+				if err != nil {
+					return fmt.Errorf("failed to parse Feature %s: %w", ft.FQN(), err)
+				}
+				fds[fd.FQN] = *fd
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return fds, nil
+}
+
+// FromCluster lists the Features of the cluster. An empty namespace lists the Features of all the namespaces.
+// It returns the FeatureDescriptors by their FQN.
+func FromCluster(ctx context.Context, k client.Reader, namespace string) (map[string]api.FeatureDescriptor, error) {
+	list := &manifests.FeatureList{}
+	if err := k.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list Features: %w", err)
+	}
+
+	fds := make(map[string]api.FeatureDescriptor, len(list.Items))
+	for i := range list.Items {
+		fd, err := api.FeatureDescriptorFromManifest(&list.Items[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Feature %s: %w", list.Items[i].FQN(), err)
+		}
+		fds[fd.FQN] = *fd
+	}
+	return fds, nil
+}
+
+// Getter returns a FeatureDescriptorGetter for the given FeatureDescriptors.
+func Getter(fds map[string]api.FeatureDescriptor) api.FeatureDescriptorGetter {
+	return func(_ context.Context, fqn string) (api.FeatureDescriptor, error) {
+		if fd, ok := fds[fqn]; ok {
+			return fd, nil
+		}
+		return api.FeatureDescriptor{}, fmt.Errorf("%w: %s", api.ErrFeatureNotFound, fqn)
+	}
+}
+
+// ClusterGetter returns a FeatureDescriptorGetter that reads the Features from the cluster.
+func ClusterGetter(k client.Reader) api.FeatureDescriptorGetter {
+	return func(ctx context.Context, fqn string) (api.FeatureDescriptor, error) {
+		ns, name, _ := strings.Cut(fqn, ".")
+		ft := &manifests.Feature{}
+		if err := k.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, ft); err != nil {
+			return api.FeatureDescriptor{}, err
+		}
+		fd, err := api.FeatureDescriptorFromManifest(ft)
+		if err != nil {
+			return api.FeatureDescriptor{}, err
+		}
+		return *fd, nil
+	}
+}
@@ -78,6 +78,8 @@ func NewServer(config ServerConfig) Server {
 	h.collectTasks = newSubscriptionQueue[api.CollectNotification](h.CollectNotifier, h.Logger.WithName("collectTasks"), h.dispatchCollect)
 	h.writeTasks = newSubscriptionQueue[api.WriteNotification](h.WriteNotifier, h.Logger.WithName("dispatchWrite"), h.dispatchWrite)
 	h.writeTasks.finalizer = h.finalizeWrite
+	// the writers may buffer the records, so the acknowledgments are deferred to the finalizer
+	h.writeTasks.queue.deferAck = true
 	return h
 }
 
@@ -30,6 +30,7 @@ func newQueue[T api.Notification](logger logr.Logger, fn HandleFn[T]) queue[T] {
 		RateLimitingInterface: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
 		logger:                logger,
 		fn:                    fn,
+		pending:               &pendingAcks[T]{},
 	}
 }
 
@@ -37,6 +38,41 @@ type queue[T api.Notification] struct {
 	workqueue.RateLimitingInterface
 	logger logr.Logger
 	fn     HandleFn[T]
+	// ack is called once the notification was handled successfully. It may be nil.
+	ack HandleFn[T]
+	// deferAck holds the acknowledgments of the handled notifications until they are durable (see takeAcks).
+	deferAck bool
+	pending  *pendingAcks[T]
+}
+
+type pendingAcks[T api.Notification] struct {
+	mu    sync.Mutex
+	items []T
+}
+
+// takeAcks returns the notifications that are waiting for an acknowledgment, and clears them.
+func (b *queue[T]) takeAcks() []T {
+	b.pending.mu.Lock()
+	defer b.pending.mu.Unlock()
+	items := b.pending.items
+	b.pending.items = nil
+	return items
+}
+
+// restoreAcks returns notifications that couldn't be made durable to the pending acknowledgments.
+func (b *queue[T]) restoreAcks(items []T) {
+	b.pending.mu.Lock()
+	defer b.pending.mu.Unlock()
+	b.pending.items = append(items, b.pending.items...)
+}
+
+// ackAll acknowledges the notifications
+func (b *queue[T]) ackAll(ctx context.Context, items []T) {
+	for _, n := range items {
+		if err := b.ack(ctx, n); err != nil {
+			b.logger.WithValues("notification", n).Error(err, "Failed to acknowledge notification")
+		}
+	}
 }
 
 func (b *queue[T]) Runnable(workers int) func(ctx context.Context) error {
@@ -90,6 +126,14 @@ func (b *queue[T]) processNextItem(ctx context.Context, stopOnDrained bool) bool
 	if err != nil {
 		b.logger.WithValues("notification", notification).Error(err, "Failed to process. Requeuing item...")
 		b.AddRateLimited(item)
+	} else if b.ack != nil && b.deferAck {
+		b.pending.mu.Lock()
+		b.pending.items = append(b.pending.items, notification)
+		b.pending.mu.Unlock()
+	} else if b.ack != nil {
+		if err := b.ack(ctx, notification); err != nil {
+			b.logger.WithValues("notification", notification).Error(err, "Failed to acknowledge notification")
+		}
 	}
 
 	b.Forget(item)
@@ -24,6 +24,13 @@ import (
 	"time"
 )
 
+const (
+	// resubscribeInterval is the initial interval between renewals of subscriptions that have ended
+	resubscribeInterval = time.Second
+	// maxResubscribeInterval is the maximal interval between renewals of subscriptions that have ended
+	maxResubscribeInterval = 30 * time.Second
+)
+
 type HandleFn[T api.Notification] func(ctx context.Context, notification T) error
 type FinalizerFunc func(ctx context.Context)
 
@@ -35,8 +42,12 @@ type subscriptionQueue[T api.Notification] struct {
 }
 
 func newSubscriptionQueue[T api.Notification](notifier api.Notifier[T], logger logr.Logger, fn HandleFn[T]) subscriptionQueue[T] {
+	q := newQueue[T](logger, fn)
+	if an, ok := notifier.(api.AckNotifier[T]); ok {
+		q.ack = an.Ack
+	}
 	return subscriptionQueue[T]{
-		queue:    newQueue[T](logger, fn),
+		queue:    q,
 		notifier: notifier,
 		logger:   logger,
 	}
@@ -58,19 +69,29 @@ func (c *subscriptionQueue[T]) Runnable(ctx context.Context) error {
 		}, SyncPeriod)
 	}()
 	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				subscription, err := c.notifier.Subscribe(ctx)
-				if err != nil {
-					c.logger.Error(err, "failed to subscribe to notifications")
-				}
+		interval := resubscribeInterval
+		for ctx.Err() == nil {
+			subscription, err := c.notifier.Subscribe(ctx)
+			if err != nil {
+				c.logger.Error(err, "failed to subscribe to notifications")
+			} else {
+				received := false
 				for notification := range subscription {
 					c.queue.Add(notification)
+					received = true
 				}
+				if received {
+					interval = resubscribeInterval
+				}
+			}
+
+			// the subscription has ended, so it's renewed with a backoff
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
 			}
+			interval = min(interval*2, maxResubscribeInterval)
 		}
 	}()
 	<-ctx.Done()
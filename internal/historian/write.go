@@ -38,11 +38,20 @@ func (h *historian) dispatchWrite(ctx context.Context, ntf api.WriteNotification
 	return err
 }
 
+// finalizeWrite flushes the buffered records to storage. The notifications are acknowledged only after they were
+// flushed, so they are redelivered if the writer crashes before that.
 func (h *historian) finalizeWrite(ctx context.Context) {
+	acks := h.writeTasks.queue.takeAcks()
 	err := h.HistoricalWriter.FlushAll(ctx)
 	if err != nil {
+		h.writeTasks.queue.restoreAcks(acks)
 		h.Logger.Error(err, "failed to flush historical logs to storage")
-	} else if h.writes > 0 {
+		return
+	}
+	if h.writeTasks.queue.ack != nil {
+		h.writeTasks.queue.ackAll(ctx, acks)
+	}
+	if h.writes > 0 {
 		h.Logger.Info("successfully flushed historical logs to storage", "writes", h.writes)
 		atomic.StoreUint32(&h.writes, 0)
 	}
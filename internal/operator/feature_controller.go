@@ -110,17 +110,17 @@ func (r *FeatureReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	for _, dep := range prog.Dependencies {
-		ns, n, _, _, _, err := api.ParseSelector(dep)
+		sel, err := api.ParseSelectorStruct(dep)
 		if err != nil {
 			logger.Error(err, "Failed to parse dependency FQN")
 			return ctrl.Result{}, err
 		}
-		if ns == "" {
-			ns = feature.Namespace
+		if sel.Namespace == "" {
+			sel.Namespace = feature.Namespace
 		}
 		feature.Status.Dependencies = append(feature.Status.Dependencies, manifests.ResourceReference{
-			Namespace: ns,
-			Name:      n,
+			Namespace: sel.Namespace,
+			Name:      sel.Name,
 		})
 	}
 
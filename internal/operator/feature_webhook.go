@@ -173,8 +173,26 @@ func (wh *webhook) Validate(ctx context.Context, f *manifests.Feature) (admissio
 			dummyEngine.DataSource = dci
 		}
 	}
-	_, err := engine.FeatureWithEngine(&dummyEngine, f)
-	return nil, err
+	// the constraints are validated against the primitive while parsing the feature
+	ft, err := engine.FeatureWithEngine(&dummyEngine, f)
+	if err != nil {
+		return nil, err
+	}
+	ft.Release()
+	return constraintsWarnings(f.Spec.Constraints), nil
+}
+
+// constraintsWarnings warns about constraints that are not enforced as the user may expect
+func constraintsWarnings(c *manifests.FeatureConstraints) admission.Warnings {
+	if c == nil || c.Mode != manifests.ConstraintModeClamp {
+		return nil
+	}
+	var warnings admission.Warnings
+	if len(c.AllowedValues) > 0 || c.Pattern != "" || c.NotNull {
+		warnings = append(warnings, "values that violate the allowedValues, pattern or notNull constraints can't be "+
+			"clamped, and are rejected")
+	}
+	return warnings
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
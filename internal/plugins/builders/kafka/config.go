@@ -0,0 +1,239 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+	"strings"
+	"time"
+)
+
+const (
+	FormatJSON     = "json"
+	FormatProtobuf = "protobuf"
+
+	StartOffsetEarliest = "earliest"
+	StartOffsetLatest   = "latest"
+
+	// DefaultMaxAttempts is the default number of attempts to process a message before it's skipped
+	DefaultMaxAttempts = 10
+)
+
+// Config is the configuration of a `kafka` DataSource.
+//
+// The credentials (`sasl_password`, `tls_ca`, `tls_cert` and `tls_key`) must be set using a `secretKeyRef`.
+type Config struct {
+	Brokers       []string `mapstructure:"brokers"`
+	Topic         string   `mapstructure:"topic"`
+	ConsumerGroup string   `mapstructure:"consumer_group"`
+	//+optional
+	StartOffset string `mapstructure:"start_offset"`
+
+	// Format of the messages. Either `json` (default) or `protobuf`.
+	//+optional
+	Format string `mapstructure:"format"`
+	// ProtoMessage is the name of the message type in the DataSource's Protobuf schema.
+	// Required when the format is `protobuf`.
+	//+optional
+	ProtoMessage string `mapstructure:"proto_message"`
+
+	// MaxAttempts is the number of attempts of a feature to process a message before it's skipped (default: 10).
+	// Errors that can't be resolved by retrying (i.e. a program error or a rejected value) are skipped immediately.
+	//+optional
+	MaxAttempts int `mapstructure:"max_attempts"`
+
+	// SASLMechanism is one of `plain`, `scram-sha-256` or `scram-sha-512`.
+	//+optional
+	SASLMechanism string `mapstructure:"sasl_mechanism"`
+	//+optional
+	SASLUsername string `mapstructure:"sasl_username"`
+	//+optional
+	SASLPassword string `mapstructure:"sasl_password"`
+
+	//+optional
+	TLS bool `mapstructure:"tls"`
+	//+optional
+	TLSCA string `mapstructure:"tls_ca"`
+	//+optional
+	TLSCert string `mapstructure:"tls_cert"`
+	//+optional
+	TLSKey string `mapstructure:"tls_key"`
+	//+optional
+	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify"`
+}
+
+// ParseConfig unmarshals and validates the DataSource config.
+// Values that are taken from secrets are decoded.
+func ParseConfig(pc manifests.ParsedConfig) (Config, error) {
+	cfg := Config{}
+	if err := pc.Unmarshal(&cfg); err != nil {
+		return cfg, fmt.Errorf("failed to unmarshal DataSource config: %w", err)
+	}
+
+	for name, v := range map[string]*string{
+		"sasl_password": &cfg.SASLPassword,
+		"tls_ca":        &cfg.TLSCA,
+		"tls_cert":      &cfg.TLSCert,
+		"tls_key":       &cfg.TLSKey,
+	} {
+		if *v == "" {
+			continue
+		}
+		b, err := base64.StdEncoding.DecodeString(*v)
+		if err != nil {
+			return cfg, fmt.Errorf("`%s` must be set using a secretKeyRef: %w", name, err)
+		}
+		*v = string(b)
+	}
+
+	if cfg.StartOffset == "" {
+		cfg.StartOffset = StartOffsetLatest
+	}
+	if cfg.Format == "" {
+		cfg.Format = FormatJSON
+	}
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = DefaultMaxAttempts
+	}
+	cfg.SASLMechanism = strings.ToLower(cfg.SASLMechanism)
+
+	return cfg, cfg.Validate()
+}
+
+// Validate checks that the config is complete and consistent.
+func (cfg Config) Validate() error {
+	if len(cfg.Brokers) == 0 {
+		return fmt.Errorf("`brokers` must be set")
+	}
+	if cfg.Topic == "" {
+		return fmt.Errorf("`topic` must be set")
+	}
+	if cfg.ConsumerGroup == "" {
+		return fmt.Errorf("`consumer_group` must be set")
+	}
+	switch cfg.StartOffset {
+	case StartOffsetEarliest, StartOffsetLatest:
+	default:
+		return fmt.Errorf("unsupported `start_offset` %q. must be `%s` or `%s`", cfg.StartOffset, StartOffsetEarliest, StartOffsetLatest)
+	}
+	switch cfg.Format {
+	case FormatJSON:
+	case FormatProtobuf:
+		if cfg.ProtoMessage == "" {
+			return fmt.Errorf("`proto_message` must be set when the format is `%s`", FormatProtobuf)
+		}
+	default:
+		return fmt.Errorf("unsupported `format` %q. must be `%s` or `%s`", cfg.Format, FormatJSON, FormatProtobuf)
+	}
+	if cfg.MaxAttempts < 0 {
+		return fmt.Errorf("`max_attempts` must be positive, got %d", cfg.MaxAttempts)
+	}
+	if _, err := cfg.saslMechanism(); err != nil {
+		return err
+	}
+	if _, err := cfg.tlsConfig(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (cfg Config) saslMechanism() (sasl.Mechanism, error) {
+	if cfg.SASLMechanism == "" {
+		return nil, nil
+	}
+	if cfg.SASLUsername == "" || cfg.SASLPassword == "" {
+		return nil, fmt.Errorf("`sasl_username` and `sasl_password` must be set when using SASL")
+	}
+
+	switch cfg.SASLMechanism {
+	case "plain":
+		return plain.Mechanism{Username: cfg.SASLUsername, Password: cfg.SASLPassword}, nil
+	case "scram-sha-256":
+		return scram.Mechanism(scram.SHA256, cfg.SASLUsername, cfg.SASLPassword)
+	case "scram-sha-512":
+		return scram.Mechanism(scram.SHA512, cfg.SASLUsername, cfg.SASLPassword)
+	default:
+		return nil, fmt.Errorf("unsupported `sasl_mechanism` %q", cfg.SASLMechanism)
+	}
+}
+
+func (cfg Config) tlsConfig() (*tls.Config, error) {
+	if !cfg.TLS {
+		if cfg.TLSCA != "" || cfg.TLSCert != "" || cfg.TLSKey != "" {
+			return nil, fmt.Errorf("`tls` must be enabled when TLS certificates are set")
+		}
+		return nil, nil
+	}
+
+	tc := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // explicitly requested by the user
+	}
+	if cfg.TLSCA != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(cfg.TLSCA)) {
+			return nil, fmt.Errorf("failed to parse `tls_ca`")
+		}
+		tc.RootCAs = pool
+	}
+	if cfg.TLSCert != "" || cfg.TLSKey != "" {
+		cert, err := tls.X509KeyPair([]byte(cfg.TLSCert), []byte(cfg.TLSKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the TLS client certificate: %w", err)
+		}
+		tc.Certificates = []tls.Certificate{cert}
+	}
+	return tc, nil
+}
+
+// ReaderConfig returns the configuration for a consumer group reader.
+func (cfg Config) ReaderConfig() (kafka.ReaderConfig, error) {
+	mechanism, err := cfg.saslMechanism()
+	if err != nil {
+		return kafka.ReaderConfig{}, err
+	}
+	tc, err := cfg.tlsConfig()
+	if err != nil {
+		return kafka.ReaderConfig{}, err
+	}
+
+	startOffset := kafka.LastOffset
+	if cfg.StartOffset == StartOffsetEarliest {
+		startOffset = kafka.FirstOffset
+	}
+
+	return kafka.ReaderConfig{
+		Brokers:     cfg.Brokers,
+		Topic:       cfg.Topic,
+		GroupID:     cfg.ConsumerGroup,
+		StartOffset: startOffset,
+		Dialer: &kafka.Dialer{
+			Timeout:       10 * time.Second,
+			DualStack:     true,
+			SASLMechanism: mechanism,
+			TLS:           tc,
+		},
+	}, nil
+}
@@ -0,0 +1,442 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-logr/logr"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/internal/stats"
+	"github.com/raptor-ml/raptor/pkg/protoregistry"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// minWriteBackoff is the delay before retrying the features that failed to process a message
+	minWriteBackoff = 100 * time.Millisecond
+	// maxWriteBackoff caps the exponential delay between the retries of a message
+	maxWriteBackoff = 30 * time.Second
+)
+
+// Decoder decodes a message into a row
+type Decoder func(msg []byte) (map[string]any, error)
+
+// NewDecoder returns a Decoder for the configured format.
+// For the `protobuf` format, the schema is the DataSource's Protobuf schema (or a URL of it).
+func NewDecoder(cfg Config, schema json.RawMessage) (Decoder, error) {
+	switch cfg.Format {
+	case FormatJSON, "":
+		return decodeJSON, nil
+	case FormatProtobuf:
+		var s string
+		if err := json.Unmarshal(schema, &s); err != nil || s == "" {
+			return nil, fmt.Errorf("the DataSource schema must be a Protobuf schema or a URL of it")
+		}
+		pack, err := protoregistry.Register(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to register the Protobuf schema: %w", err)
+		}
+
+		msgName := cfg.ProtoMessage
+		if !strings.Contains(msgName, ".") && pack != "" {
+			msgName = fmt.Sprintf("%s.%s", pack, msgName)
+		}
+		md, err := protoregistry.GetDescriptor(msgName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the Protobuf message `%s`: %w", msgName, err)
+		}
+		if md == nil {
+			return nil, fmt.Errorf("the Protobuf message `%s` was not found in the schema", msgName)
+		}
+
+		return func(b []byte) (map[string]any, error) {
+			msg := dynamicpb.NewMessage(md)
+			if err := proto.Unmarshal(b, msg); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal Protobuf message: %w", err)
+			}
+			row := make(map[string]any)
+			if err := flattenMessage(row, "", msg); err != nil {
+				return nil, err
+			}
+			return row, nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %q", cfg.Format)
+	}
+}
+
+func decodeJSON(b []byte) (map[string]any, error) {
+	var payload map[string]any
+	if err := json.Unmarshal(b, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse message as JSON: %w", err)
+	}
+	row := make(map[string]any)
+	if err := flattenJSON(row, "", payload); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// flattenJSON flattens nested objects into dot-separated fields, since the runtime only supports primitives.
+func flattenJSON(row map[string]any, prefix string, obj map[string]any) error {
+	for k, v := range obj {
+		k = prefix + k
+		switch v := v.(type) {
+		case nil:
+		case map[string]any:
+			if err := flattenJSON(row, k+".", v); err != nil {
+				return err
+			}
+		case []any:
+			if len(v) == 0 {
+				continue
+			}
+			if api.TypeDetect(v) == api.PrimitiveTypeUnknown {
+				return fmt.Errorf("field `%s` is not a list of primitives", k)
+			}
+			row[k] = v
+		default:
+			row[k] = v
+		}
+	}
+	return nil
+}
+
+// flattenMessage flattens a Protobuf message into dot-separated fields, since the runtime only supports primitives.
+func flattenMessage(row map[string]any, prefix string, msg protoreflect.Message) error {
+	var err error
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		k := prefix + string(fd.Name())
+		switch {
+		case fd.IsMap():
+			err = fmt.Errorf("field `%s` is a map, which is not supported", k)
+		case fd.IsList():
+			l := v.List()
+			vals := make([]any, 0, l.Len())
+			for i := 0; i < l.Len(); i++ {
+				var pv any
+				pv, err = protoPrimitive(fd, l.Get(i))
+				if err != nil {
+					return false
+				}
+				vals = append(vals, pv)
+			}
+			if len(vals) > 0 {
+				row[k] = vals
+			}
+		case fd.Kind() == protoreflect.MessageKind && fd.Message().FullName() != "google.protobuf.Timestamp":
+			err = flattenMessage(row, k+".", v.Message())
+		default:
+			row[k], err = protoPrimitive(fd, v)
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to decode field `%s`: %w", k, err)
+		}
+		return err == nil
+	})
+	return err
+}
+
+func protoPrimitive(fd protoreflect.FieldDescriptor, v protoreflect.Value) (any, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool(), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return int(v.Int()), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return int(v.Uint()), nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float(), nil
+	case protoreflect.StringKind:
+		return v.String(), nil
+	case protoreflect.BytesKind:
+		return string(v.Bytes()), nil
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name()), nil
+		}
+		return int(v.Enum()), nil
+	case protoreflect.MessageKind:
+		if fd.Message().FullName() == "google.protobuf.Timestamp" {
+			m := v.Message()
+			fields := m.Descriptor().Fields()
+			sec := m.Get(fields.ByName("seconds")).Int()
+			nsec := m.Get(fields.ByName("nanos")).Int()
+			return time.Unix(sec, nsec).UTC(), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported type %s", fd.Kind())
+}
+
+// Runner consumes the DataSource's topic, and writes the results of the Features' programs to the Core.
+// The Features that are using the DataSource are set with SetFeatures, and can be replaced while running.
+type Runner struct {
+	Config         Config
+	KeyFields      []string
+	TimestampField string
+	Decoder        Decoder
+	Runtime        api.RuntimeManager
+	Engine         api.Engine
+	Logger         logr.Logger
+
+	mu       sync.Mutex
+	features []api.FeatureDescriptor
+	// featuresSet is closed when the features are replaced
+	featuresSet chan struct{}
+}
+
+// SetFeatures replaces the Features that are using the DataSource. Their programs must be loaded to the Runtime.
+func (r *Runner) SetFeatures(fds []api.FeatureDescriptor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.features = fds
+	if r.featuresSet != nil {
+		close(r.featuresSet)
+		r.featuresSet = nil
+	}
+}
+
+// waitFeatures returns the current Features, and blocks while there are none, so messages are never consumed (and
+// committed) before there's a Feature to process them.
+func (r *Runner) waitFeatures(ctx context.Context) ([]api.FeatureDescriptor, error) {
+	logged := false
+	for {
+		r.mu.Lock()
+		fds := r.features
+		if r.featuresSet == nil {
+			r.featuresSet = make(chan struct{})
+		}
+		set := r.featuresSet
+		r.mu.Unlock()
+		if len(fds) > 0 {
+			return fds, nil
+		}
+
+		if !logged {
+			r.Logger.Info("no features are using the DataSource. waiting for features before consuming")
+			logged = true
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-set:
+		}
+	}
+}
+
+// messageReader is the part of kafka.Reader that is used by the Runner
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// Run consumes messages until the context is canceled.
+// The offset of a message is committed only after the results of all the Features were written, or skipped (see
+// handle).
+func (r *Runner) Run(ctx context.Context) error {
+	rc, err := r.Config.ReaderConfig()
+	if err != nil {
+		return err
+	}
+	reader := kafka.NewReader(rc)
+	defer reader.Close()
+
+	return r.consume(ctx, reader)
+}
+
+func (r *Runner) consume(ctx context.Context, reader messageReader) error {
+	for {
+		fds, err := r.waitFeatures(ctx)
+		if err != nil {
+			return nil
+		}
+
+		msg, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to fetch message: %w", err)
+		}
+
+		if err := r.handle(ctx, msg, fds); err != nil {
+			// the context was canceled before the message was processed, so it is not committed
+			return nil
+		}
+
+		if err := reader.CommitMessages(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to commit offset: %w", err)
+		}
+	}
+}
+
+// handle processes a single message by the Features. Messages that can't be decoded are skipped.
+// Features that failed to process the message are retried with a capped exponential backoff, up to the configured
+// number of attempts. Features that failed with an error that retrying can't resolve (i.e. a program error or a
+// rejected value), or that exhausted their attempts, skip the message, so a single bad message can't block the
+// partition. It returns an error only if the context was canceled.
+func (r *Runner) handle(ctx context.Context, msg kafka.Message, fds []api.FeatureDescriptor) error {
+	logger := r.Logger.WithValues("partition", msg.Partition, "offset", msg.Offset)
+
+	row, err := r.Decoder(msg.Value)
+	if err != nil {
+		logger.Error(err, "skipping a message that can't be decoded")
+		return nil
+	}
+	keys := r.keys(row)
+	ts, err := r.timestamp(row, msg.Time)
+	if err != nil {
+		logger.Error(err, "skipping a message with an invalid timestamp")
+		return nil
+	}
+
+	maxAttempts := r.Config.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+
+	// Retry only the features that failed, so values are never written twice (i.e. aggregations)
+	pending := fds
+	backoff := minWriteBackoff
+	for attempt := 1; ; attempt++ {
+		var failed []api.FeatureDescriptor
+		for _, fd := range pending {
+			err := r.write(ctx, fd, keys, row, ts)
+			if err == nil {
+				continue
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			switch {
+			case !retriable(err):
+				logger.Error(err, "skipping a message that the feature can't process", "fqn", fd.FQN)
+				stats.IncrSkippedMessage(fd.FQN, stats.SkipPermanent)
+			case attempt >= maxAttempts:
+				logger.Error(err, "skipping a message after exhausting the retries", "fqn", fd.FQN, "attempts", attempt)
+				stats.IncrSkippedMessage(fd.FQN, stats.SkipRetriesExhausted)
+			default:
+				logger.Error(err, "failed to process message", "fqn", fd.FQN, "attempt", attempt, "retry_in", backoff)
+				failed = append(failed, fd)
+			}
+		}
+		if len(failed) == 0 {
+			return nil
+		}
+		pending = failed
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+			backoff *= 2
+			if backoff > maxWriteBackoff {
+				backoff = maxWriteBackoff
+			}
+		}
+	}
+}
+
+// retriable returns false for errors that fail the same way on every attempt, i.e. a program error, a value that
+// doesn't match the feature's primitive or violates its constraints, or a feature that doesn't exist. Other errors
+// (i.e. an unavailable Core or runtime) are retried.
+func retriable(err error) bool {
+	switch {
+	case errors.Is(err, api.ErrConstraintViolation),
+		errors.Is(err, api.ErrFeatureNotFound),
+		errors.Is(err, api.ErrUnsupportedPrimitiveError),
+		errors.Is(err, api.ErrUnsupportedAggrError):
+		return false
+	}
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.FailedPrecondition, codes.OutOfRange,
+			codes.Unimplemented, codes.PermissionDenied, codes.Unauthenticated:
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Runner) write(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, row map[string]any, ts time.Time) error {
+	val, keys, err := r.Runtime.ExecuteProgram(ctx, fd.RuntimeEnv, fd.FQN, keys, row, ts, true)
+	if err != nil {
+		return err
+	}
+	if val.Value == nil {
+		return nil
+	}
+	return r.Engine.Update(ctx, fd.FQN, keys, val.Value, val.Timestamp)
+}
+
+func (r *Runner) keys(row map[string]any) api.Keys {
+	keys := api.Keys{}
+	for _, k := range r.KeyFields {
+		if v, ok := row[k]; ok && v != nil {
+			keys[k] = api.ScalarString(v)
+		}
+	}
+	return keys
+}
+
+// timestamp returns the event time of the row. If TimestampField is not set, the message time is used.
+func (r *Runner) timestamp(row map[string]any, fallback time.Time) (time.Time, error) {
+	if r.TimestampField == "" {
+		return fallback, nil
+	}
+	switch v := row[r.TimestampField].(type) {
+	case nil:
+		return fallback, nil
+	case time.Time:
+		return v, nil
+	case string:
+		if ts, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return ts, nil
+		}
+		sec, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse `%s` as a timestamp: %w", r.TimestampField, err)
+		}
+		return unixTime(sec), nil
+	case float64:
+		return unixTime(v), nil
+	case int:
+		return time.Unix(int64(v), 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("unsupported timestamp type %T", v)
+	}
+}
+
+func unixTime(sec float64) time.Time {
+	return time.Unix(0, int64(sec*float64(time.Second)))
+}
@@ -0,0 +1,259 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-logr/logr"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/segmentio/kafka-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeReader serves the messages, and blocks once they were all fetched
+type fakeReader struct {
+	mu        sync.Mutex
+	messages  []kafka.Message
+	committed []int64
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+	if len(r.messages) > 0 {
+		msg := r.messages[0]
+		r.messages = r.messages[1:]
+		r.mu.Unlock()
+		return msg, nil
+	}
+	r.mu.Unlock()
+	<-ctx.Done()
+	return kafka.Message{}, ctx.Err()
+}
+
+func (r *fakeReader) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, msg := range msgs {
+		r.committed = append(r.committed, msg.Offset)
+	}
+	return nil
+}
+
+// fakeRuntime returns the `v` field of the row as the value of the features
+type fakeRuntime struct {
+	api.RuntimeManager
+}
+
+func (fakeRuntime) ExecuteProgram(_ context.Context, _ string, _ string, keys api.Keys, row map[string]any, ts time.Time, _ bool) (api.Value, api.Keys, error) {
+	return api.Value{Value: row["v"], Timestamp: ts}, keys, nil
+}
+
+// failingEngine fails the first `failures` writes of each feature. Negative failures fail all the writes.
+// The writes of the features in `rejected` fail with a permanent error.
+type failingEngine struct {
+	api.Engine
+	failures map[string]int
+	rejected map[string]bool
+
+	mu     sync.Mutex
+	writes map[string]int
+}
+
+func (e *failingEngine) Update(_ context.Context, fqn string, _ api.Keys, _ any, _ time.Time) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.writes[fqn]++
+	if e.rejected[fqn] {
+		return status.Errorf(codes.InvalidArgument, "failed to update value: %s", api.ErrConstraintViolation)
+	}
+	if f, ok := e.failures[fqn]; ok && (f < 0 || e.writes[fqn] <= f) {
+		return fmt.Errorf("write %d of %s failed", e.writes[fqn], fqn)
+	}
+	return nil
+}
+
+func TestRunnerCommit(t *testing.T) {
+	features := []api.FeatureDescriptor{{FQN: "ok.default"}, {FQN: "flaky.default"}}
+	tests := []struct {
+		name     string
+		value    string
+		failures map[string]int
+		rejected map[string]bool
+		timeout  time.Duration
+		// wantCommitted is the offsets that should be committed
+		wantCommitted []int64
+		wantWrites    map[string]int
+	}{
+		{
+			name:          "success",
+			value:         `{"v": 1}`,
+			timeout:       200 * time.Millisecond,
+			wantCommitted: []int64{1},
+			wantWrites:    map[string]int{"ok.default": 1, "flaky.default": 1},
+		},
+		{
+			name:          "recovers after retries",
+			value:         `{"v": 1}`,
+			failures:      map[string]int{"flaky.default": 2},
+			timeout:       time.Second,
+			wantCommitted: []int64{1},
+			// the features that succeeded are not written again
+			wantWrites: map[string]int{"ok.default": 1, "flaky.default": 3},
+		},
+		{
+			name:     "never recovers",
+			value:    `{"v": 1}`,
+			failures: map[string]int{"flaky.default": -1},
+			timeout:  2 * time.Second,
+			// the message is skipped once the retries were exhausted
+			wantCommitted: []int64{1},
+			wantWrites:    map[string]int{"ok.default": 1, "flaky.default": 3},
+		},
+		{
+			name:     "rejected",
+			value:    `{"v": 1}`,
+			rejected: map[string]bool{"flaky.default": true},
+			timeout:  200 * time.Millisecond,
+			// the message is skipped without retries
+			wantCommitted: []int64{1},
+			wantWrites:    map[string]int{"ok.default": 1, "flaky.default": 1},
+		},
+		{
+			name:          "undecodable",
+			value:         `{`,
+			timeout:       200 * time.Millisecond,
+			wantCommitted: []int64{1},
+			wantWrites:    map[string]int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := &fakeReader{messages: []kafka.Message{{Offset: 1, Value: []byte(tt.value), Time: time.Now()}}}
+			eng := &failingEngine{failures: tt.failures, rejected: tt.rejected, writes: make(map[string]int)}
+			r := &Runner{
+				Config:  Config{MaxAttempts: 3},
+				Decoder: decodeJSON,
+				Runtime: fakeRuntime{},
+				Engine:  eng,
+				Logger:  logr.Discard(),
+			}
+			r.SetFeatures(features)
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			if err := r.consume(ctx, reader); err != nil {
+				t.Fatalf("consume() error = %v", err)
+			}
+
+			reader.mu.Lock()
+			committed := reader.committed
+			reader.mu.Unlock()
+			if fmt.Sprint(committed) != fmt.Sprint(tt.wantCommitted) {
+				t.Errorf("expected the committed offsets %v, got %v", tt.wantCommitted, committed)
+			}
+
+			eng.mu.Lock()
+			defer eng.mu.Unlock()
+			for fqn, want := range tt.wantWrites {
+				if eng.writes[fqn] != want {
+					t.Errorf("expected %d writes of %s, got %d", want, fqn, eng.writes[fqn])
+				}
+			}
+		})
+	}
+}
+
+func TestRunnerWaitsForFeatures(t *testing.T) {
+	reader := &fakeReader{messages: []kafka.Message{{Offset: 1, Value: []byte(`{"v": 1}`), Time: time.Now()}}}
+	eng := &failingEngine{writes: make(map[string]int)}
+	r := &Runner{
+		Decoder: decodeJSON,
+		Runtime: fakeRuntime{},
+		Engine:  eng,
+		Logger:  logr.Discard(),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- r.consume(ctx, reader) }()
+
+	committed := func() []int64 {
+		reader.mu.Lock()
+		defer reader.mu.Unlock()
+		return reader.committed
+	}
+
+	// without features, the messages are neither consumed nor committed
+	time.Sleep(100 * time.Millisecond)
+	if c := committed(); len(c) != 0 {
+		t.Fatalf("expected no committed offsets, got %v", c)
+	}
+	reader.mu.Lock()
+	pending := len(reader.messages)
+	reader.mu.Unlock()
+	if pending != 1 {
+		t.Fatal("expected the message not to be fetched")
+	}
+
+	// the message is processed by the features that were set while waiting
+	r.SetFeatures([]api.FeatureDescriptor{{FQN: "a.default"}})
+	deadline := time.Now().Add(time.Second)
+	for len(committed()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("consume() error = %v", err)
+	}
+	if c := committed(); fmt.Sprint(c) != "[1]" {
+		t.Errorf("expected the offset 1 to be committed, got %v", c)
+	}
+	eng.mu.Lock()
+	defer eng.mu.Unlock()
+	if eng.writes["a.default"] != 1 {
+		t.Errorf("expected 1 write of a.default, got %d", eng.writes["a.default"])
+	}
+}
+
+func TestRetriable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "constraint violation", err: fmt.Errorf("%w: value is too big", api.ErrConstraintViolation), want: false},
+		{name: "feature not found", err: api.ErrFeatureNotFound, want: false},
+		{name: "unsupported primitive", err: api.ErrUnsupportedPrimitiveError, want: false},
+		{name: "program error", err: fmt.Errorf("failed to execute program: %w", status.Error(codes.InvalidArgument, "division by zero")), want: false},
+		{name: "unavailable", err: status.Error(codes.Unavailable, "connection refused"), want: true},
+		{name: "deadline exceeded", err: status.Error(codes.DeadlineExceeded, "timeout"), want: true},
+		{name: "internal", err: status.Error(codes.Internal, "failed to update value"), want: true},
+		{name: "unknown", err: fmt.Errorf("connection reset"), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retriable(tt.err); got != tt.want {
+				t.Errorf("retriable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
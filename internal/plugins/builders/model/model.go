@@ -23,6 +23,8 @@ import (
 	"github.com/raptor-ml/raptor/api"
 	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
 	"github.com/raptor-ml/raptor/pkg/plugins"
+	"golang.org/x/sync/errgroup"
+	"sort"
 	"sync"
 )
 
@@ -42,10 +44,11 @@ func FeatureApply(fd api.FeatureDescriptor, builder manifests.FeatureBuilder, pl
 		return fmt.Errorf("model must have at least 2 features")
 	}
 
-	ns, _, _, _, _, err := api.ParseSelector(fd.FQN)
+	sel, err := api.ParseSelectorStruct(fd.FQN)
 	if err != nil {
 		return err
 	}
+	ns := sel.Namespace
 
 	//normalize features
 	for i, f := range md.Features {
@@ -55,15 +58,21 @@ func FeatureApply(fd api.FeatureDescriptor, builder manifests.FeatureBuilder, pl
 		}
 	}
 
-	fs := &model{engine: engine, md: md}
+	policies, err := parsePolicies(md, ns, engine.HasStatistics())
+	if err != nil {
+		return fmt.Errorf("invalid feature policies in model %s: %w", fd.FQN, err)
+	}
+
+	fs := &model{engine: engine, md: md, policies: policies}
 	pl.AddPostGetMiddleware(0, fs.preGetMiddleware)
 	pl.AddPreSetMiddleware(0, fs.preSetMiddleware)
 	return nil
 }
 
 type model struct {
-	md     api.ModelDescriptor
-	engine api.Engine
+	md       api.ModelDescriptor
+	engine   api.ExtendedManager
+	policies map[string]policy
 }
 
 func (m *model) preGetMiddleware(next api.MiddlewareHandler) api.MiddlewareHandler {
@@ -74,36 +83,62 @@ func (m *model) preGetMiddleware(next api.MiddlewareHandler) api.MiddlewareHandl
 		}
 
 		logger := api.LoggerFromContext(ctx)
-		wg := &sync.WaitGroup{}
-		wg.Add(len(m.md.Features))
 
+		mu := sync.Mutex{}
 		ret := api.Value{}
-		results := make(map[string]api.Value)
+		results := make(map[string]api.Value, len(m.md.Features))
+
+		var g errgroup.Group
 		for _, fqn := range m.md.Features {
-			go func(fqn string, wg *sync.WaitGroup) {
-				defer wg.Done()
+			fqn := fqn
+			g.Go(func() error {
 				val, _, err := m.engine.Get(ctx, fqn, keys)
+				// features without a policy are passed to the model server even when they have no value
+				_, declared := m.policies[fqn]
+				if err == nil && (val.Value != nil || !declared) {
+					mu.Lock()
+					defer mu.Unlock()
+					results[fqn] = val
+					if ret.Timestamp.IsZero() || ret.Timestamp.Before(val.Timestamp) {
+						ret.Timestamp = val.Timestamp
+					}
+					if val.Fresh {
+						ret.Fresh = true
+					}
+					return nil
+				}
 				if err != nil {
-					logger.Error(err, "failed to get feature %s", fqn)
-					return
+					logger.Error(err, "failed to get feature", "feature", fqn)
 				}
-				results[fqn] = val
-				if ret.Timestamp.IsZero() || ret.Timestamp.Before(val.Timestamp) {
-					ret.Timestamp = val.Timestamp
+
+				// the feature is missing, so it's handled by its policy
+				v, degraded, err := m.missing(ctx, fqn, err)
+				if err != nil {
+					return err
 				}
-				if val.Fresh {
-					ret.Fresh = true
+				mu.Lock()
+				defer mu.Unlock()
+				ret.Degraded = append(ret.Degraded, degraded)
+				if v != nil {
+					results[fqn] = *v
 				}
-			}(fqn, wg)
+				return nil
+			})
+		}
+		if err := g.Wait(); err != nil {
+			return val, err
 		}
-		wg.Wait()
+		sort.Slice(ret.Degraded, func(i, j int) bool {
+			return ret.Degraded[i].Selector < ret.Degraded[j].Selector
+		})
 		ret.Value = results
 
 		if ms := plugins.ModelServer.Get(m.md.ModelServer); ms != nil {
-			val, err := ms.Serve(ctx, fd, m.md, val)
+			val, err := ms.Serve(ctx, fd, m.md, ret)
 			if err != nil {
 				return val, err
 			}
+			val.Degraded = ret.Degraded
 			return next(ctx, fd, keys, val)
 		}
 
@@ -0,0 +1,226 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"context"
+	"errors"
+	"github.com/raptor-ml/raptor/api"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeEngine serves the values of the features, and the statistics if they are configured
+type fakeEngine struct {
+	api.ExtendedManager
+	values     map[string]any
+	errs       map[string]error
+	primitives map[string]api.PrimitiveType
+	statistics map[api.AggrFn]float64
+}
+
+func (e *fakeEngine) Get(_ context.Context, selector string, _ api.Keys) (api.Value, api.FeatureDescriptor, error) {
+	if err := e.errs[selector]; err != nil {
+		return api.Value{}, api.FeatureDescriptor{}, err
+	}
+	return api.Value{Value: e.values[selector], Timestamp: time.Now()}, api.FeatureDescriptor{FQN: selector}, nil
+}
+
+func (e *fakeEngine) FeatureDescriptor(_ context.Context, selector string) (api.FeatureDescriptor, error) {
+	return api.FeatureDescriptor{FQN: selector, Primitive: e.primitives[selector]}, nil
+}
+
+func (e *fakeEngine) Statistic(_ context.Context, _ api.FeatureDescriptor, fn api.AggrFn) (float64, error) {
+	if e.statistics == nil {
+		return 0, errors.New("historical statistics are not configured")
+	}
+	return e.statistics[fn], nil
+}
+
+func (e *fakeEngine) HasStatistics() bool {
+	return e.statistics != nil
+}
+
+// echoServer returns the feature-set it was served with
+type echoServer struct {
+	api.ModelServer
+}
+
+func (echoServer) Serve(_ context.Context, _ api.FeatureDescriptor, _ api.ModelDescriptor, val api.Value) (api.Value, error) {
+	return val, nil
+}
+
+func init() {
+	plugins.ModelServer.Register("echo", echoServer{})
+}
+
+func TestPreGetMiddleware(t *testing.T) {
+	features := []string{"default.a", "default.b"}
+	fp := func(onMissing manifests.MissingFeaturePolicy, def string, statistic string) []manifests.ModelFeaturePolicy {
+		p := manifests.ModelFeaturePolicy{Feature: "b", OnMissing: onMissing, Statistic: statistic}
+		if def != "" {
+			p.Default = []byte(def)
+		}
+		return []manifests.ModelFeaturePolicy{p}
+	}
+	failed := errors.New("failed")
+
+	tests := []struct {
+		name       string
+		policies   []manifests.ModelFeaturePolicy
+		b          any
+		err        error
+		primitive  api.PrimitiveType
+		statistics map[api.AggrFn]float64
+		want       map[string]any
+		degraded   []api.DegradedInput
+		wantErr    bool
+	}{
+		{
+			name: "all the features have values",
+			b:    2,
+			want: map[string]any{"default.a": 1, "default.b": 2},
+		},
+		{
+			name: "features without a policy are passed without a value",
+			want: map[string]any{"default.a": 1, "default.b": nil},
+		},
+		{
+			name:     "features without a policy are omitted when they fail",
+			err:      failed,
+			want:     map[string]any{"default.a": 1},
+			degraded: []api.DegradedInput{{Selector: "default.b", Policy: "omit", Error: "failed"}},
+		},
+		{
+			name:     "omit",
+			policies: fp(manifests.MissingFeaturePolicyOmit, "", ""),
+			want:     map[string]any{"default.a": 1},
+			degraded: []api.DegradedInput{{Selector: "default.b", Policy: "omit"}},
+		},
+		{
+			name:     "required",
+			policies: fp(manifests.MissingFeaturePolicyRequired, "", ""),
+			wantErr:  true,
+		},
+		{
+			name:     "required failed",
+			policies: fp(manifests.MissingFeaturePolicyRequired, "", ""),
+			err:      failed,
+			wantErr:  true,
+		},
+		{
+			name:     "required with a value",
+			policies: fp(manifests.MissingFeaturePolicyRequired, "", ""),
+			b:        2,
+			want:     map[string]any{"default.a": 1, "default.b": 2},
+		},
+		{
+			name:      "default",
+			policies:  fp(manifests.MissingFeaturePolicyDefault, "3", ""),
+			err:       failed,
+			primitive: api.PrimitiveTypeInteger,
+			want:      map[string]any{"default.a": 1, "default.b": 3},
+			degraded:  []api.DegradedInput{{Selector: "default.b", Policy: "default", Error: "failed"}},
+		},
+		{
+			name:       "impute",
+			policies:   fp(manifests.MissingFeaturePolicyImpute, "", "avg"),
+			primitive:  api.PrimitiveTypeFloat,
+			statistics: map[api.AggrFn]float64{api.AggrFnAvg: 2.6},
+			want:       map[string]any{"default.a": 1, "default.b": 2.6},
+			degraded:   []api.DegradedInput{{Selector: "default.b", Policy: "impute"}},
+		},
+		{
+			name:       "impute an integer",
+			policies:   fp(manifests.MissingFeaturePolicyImpute, "", "avg"),
+			primitive:  api.PrimitiveTypeInteger,
+			statistics: map[api.AggrFn]float64{api.AggrFnAvg: 2.6},
+			want:       map[string]any{"default.a": 1, "default.b": 3},
+			degraded:   []api.DegradedInput{{Selector: "default.b", Policy: "impute"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &fakeEngine{
+				values:     map[string]any{"default.a": 1, "default.b": tt.b},
+				errs:       map[string]error{"default.b": tt.err},
+				primitives: map[string]api.PrimitiveType{"default.b": tt.primitive},
+				statistics: tt.statistics,
+			}
+			md := api.ModelDescriptor{Features: features, FeaturePolicies: tt.policies, ModelServer: "echo"}
+			policies, err := parsePolicies(md, "default", e.HasStatistics())
+			if err != nil {
+				t.Fatal(err)
+			}
+			m := &model{engine: e, md: md, policies: policies}
+
+			next := func(_ context.Context, _ api.FeatureDescriptor, _ api.Keys, val api.Value) (api.Value, error) {
+				return val, nil
+			}
+			got, err := m.preGetMiddleware(next)(context.Background(), api.FeatureDescriptor{FQN: "default.model"}, api.Keys{"id": "1"}, api.Value{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("preGetMiddleware() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			values := make(map[string]any)
+			for fqn, v := range got.Value.(map[string]api.Value) {
+				values[fqn] = v.Value
+			}
+			if !reflect.DeepEqual(values, tt.want) {
+				t.Errorf("expected the feature-set %v, got %v", tt.want, values)
+			}
+			if !reflect.DeepEqual(got.Degraded, tt.degraded) {
+				t.Errorf("expected the degraded inputs %+v, got %+v", tt.degraded, got.Degraded)
+			}
+		})
+	}
+}
+
+// TestImputeWithoutStatistics checks that a model that was applied with the impute policy fails to impute when the
+// historical statistics are not available
+func TestImputeWithoutStatistics(t *testing.T) {
+	e := &fakeEngine{values: map[string]any{"default.a": 1}}
+	md := api.ModelDescriptor{
+		Features:        []string{"default.a", "default.b"},
+		FeaturePolicies: []manifests.ModelFeaturePolicy{{Feature: "b", OnMissing: manifests.MissingFeaturePolicyImpute, Statistic: "avg"}},
+		ModelServer:     "echo",
+	}
+	if _, err := parsePolicies(md, "default", e.HasStatistics()); err == nil {
+		t.Error("expected the impute policy to be rejected without historical statistics")
+	}
+
+	m := &model{engine: e, md: md, policies: map[string]policy{
+		"default.b": {onMissing: manifests.MissingFeaturePolicyImpute, statistic: api.AggrFnAvg},
+	}}
+	next := func(_ context.Context, _ api.FeatureDescriptor, _ api.Keys, val api.Value) (api.Value, error) {
+		return val, nil
+	}
+	_, err := m.preGetMiddleware(next)(context.Background(), api.FeatureDescriptor{FQN: "default.model"}, api.Keys{"id": "1"}, api.Value{})
+	if err == nil {
+		t.Fatal("expected the request to fail")
+	}
+	if !strings.HasPrefix(err.Error(), "failed to impute feature default.b") {
+		t.Errorf("expected an imputation error, got %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"math"
+	"time"
+)
+
+// policy is a parsed manifests.ModelFeaturePolicy
+type policy struct {
+	onMissing manifests.MissingFeaturePolicy
+	value     any
+	statistic api.AggrFn
+}
+
+// parsePolicies parses the policies of the model's features by their normalized selector.
+// The impute policy is allowed only when the historical statistics are configured.
+func parsePolicies(md api.ModelDescriptor, ns string, statistics bool) (map[string]policy, error) {
+	features := make(map[string]struct{}, len(md.Features))
+	for _, f := range md.Features {
+		features[f] = struct{}{}
+	}
+
+	ret := make(map[string]policy, len(md.FeaturePolicies))
+	for _, fp := range md.FeaturePolicies {
+		f, err := api.NormalizeSelector(fp.Feature, ns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize feature %s: %w", fp.Feature, err)
+		}
+		if _, ok := features[f]; !ok {
+			return nil, fmt.Errorf("feature %s is not one of the model's features", fp.Feature)
+		}
+		if _, ok := ret[f]; ok {
+			return nil, fmt.Errorf("feature %s has more than one policy", fp.Feature)
+		}
+
+		p := policy{onMissing: fp.OnMissing}
+		switch fp.OnMissing {
+		case manifests.MissingFeaturePolicyRequired, manifests.MissingFeaturePolicyOmit:
+		case manifests.MissingFeaturePolicyDefault:
+			if len(fp.Default) == 0 {
+				return nil, fmt.Errorf("feature %s must specify a default value", fp.Feature)
+			}
+			var v any
+			if err := json.Unmarshal(fp.Default, &v); err != nil {
+				return nil, fmt.Errorf("invalid default value of feature %s: %w", fp.Feature, err)
+			}
+			if p.value, err = api.NormalizeAny(v); err != nil || p.value == nil {
+				return nil, fmt.Errorf("invalid default value of feature %s", fp.Feature)
+			}
+		case manifests.MissingFeaturePolicyImpute:
+			if !statistics {
+				return nil, fmt.Errorf("feature %s can't be imputed, since the historical statistics are not configured "+
+					"(see --historical-reader-provider)", fp.Feature)
+			}
+			p.statistic = api.StringToAggrFn(fp.Statistic)
+			if p.statistic == api.AggrFnUnknown {
+				return nil, fmt.Errorf("feature %s must specify a statistic to impute with", fp.Feature)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported policy `%s` for feature %s", fp.OnMissing, fp.Feature)
+		}
+		ret[f] = p
+	}
+	return ret, nil
+}
+
+// missing handles a missing feature by its policy. Features without a policy are omitted.
+// It returns the value that replaces the feature, which is nil if the feature should be omitted.
+func (m *model) missing(ctx context.Context, selector string, cause error) (*api.Value, api.DegradedInput, error) {
+	p, ok := m.policies[selector]
+	if !ok {
+		p.onMissing = manifests.MissingFeaturePolicyOmit
+	}
+
+	degraded := api.DegradedInput{Selector: selector, Policy: string(p.onMissing)}
+	if cause != nil {
+		degraded.Error = cause.Error()
+	}
+
+	var v any
+	switch p.onMissing {
+	case manifests.MissingFeaturePolicyOmit:
+		return nil, degraded, nil
+	case manifests.MissingFeaturePolicyDefault:
+		v = p.value
+		// the default value is conformed to the feature's primitive when possible
+		if fd, err := m.engine.FeatureDescriptor(ctx, selector); err == nil && fd.Primitive != api.PrimitiveTypeUnknown {
+			if c, err := api.CastPrimitive(v, fd.Primitive); err == nil && c != nil {
+				v = c
+			}
+		}
+	case manifests.MissingFeaturePolicyImpute:
+		fd, err := m.engine.FeatureDescriptor(ctx, selector)
+		if err != nil {
+			return nil, degraded, fmt.Errorf("failed to impute feature %s: %w", selector, err)
+		}
+		stat, err := m.engine.Statistic(ctx, fd, p.statistic)
+		if err != nil {
+			return nil, degraded, fmt.Errorf("failed to impute feature %s: %w", selector, err)
+		}
+		v = stat
+		if fd.Primitive == api.PrimitiveTypeInteger {
+			v = int(math.Round(stat))
+		}
+	default:
+		if cause != nil {
+			return nil, degraded, fmt.Errorf("failed to get required feature %s: %w", selector, cause)
+		}
+		return nil, degraded, fmt.Errorf("required feature %s has no value", selector)
+	}
+	return &api.Value{Value: v, Timestamp: time.Now()}, degraded, nil
+}
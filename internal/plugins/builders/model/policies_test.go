@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"github.com/raptor-ml/raptor/api"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"reflect"
+	"testing"
+)
+
+func TestParsePolicies(t *testing.T) {
+	md := api.ModelDescriptor{Features: []string{"default.a", "default.b", "other.c"}}
+	fp := func(feature string, onMissing manifests.MissingFeaturePolicy, def string, statistic string) manifests.ModelFeaturePolicy {
+		p := manifests.ModelFeaturePolicy{Feature: feature, OnMissing: onMissing, Statistic: statistic}
+		if def != "" {
+			p.Default = json.RawMessage(def)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name       string
+		policies   []manifests.ModelFeaturePolicy
+		statistics bool
+		want       map[string]policy
+		wantErr    bool
+	}{
+		{
+			name:     "no policies",
+			policies: nil,
+			want:     map[string]policy{},
+		},
+		{
+			name: "normalized selectors",
+			policies: []manifests.ModelFeaturePolicy{
+				fp("a", manifests.MissingFeaturePolicyRequired, "", ""),
+				fp("default.b", manifests.MissingFeaturePolicyOmit, "", ""),
+				fp("other.c", manifests.MissingFeaturePolicyDefault, `"x"`, ""),
+			},
+			want: map[string]policy{
+				"default.a": {onMissing: manifests.MissingFeaturePolicyRequired},
+				"default.b": {onMissing: manifests.MissingFeaturePolicyOmit},
+				"other.c":   {onMissing: manifests.MissingFeaturePolicyDefault, value: "x"},
+			},
+		},
+		{
+			name:       "impute",
+			policies:   []manifests.ModelFeaturePolicy{fp("a", manifests.MissingFeaturePolicyImpute, "", "median")},
+			statistics: true,
+			want:       map[string]policy{"default.a": {onMissing: manifests.MissingFeaturePolicyImpute, statistic: api.AggrFnP50}},
+		},
+		{
+			name:     "impute without historical statistics",
+			policies: []manifests.ModelFeaturePolicy{fp("a", manifests.MissingFeaturePolicyImpute, "", "median")},
+			wantErr:  true,
+		},
+		{
+			name:       "impute without a statistic",
+			policies:   []manifests.ModelFeaturePolicy{fp("a", manifests.MissingFeaturePolicyImpute, "", "")},
+			statistics: true,
+			wantErr:    true,
+		},
+		{
+			name:     "default without a value",
+			policies: []manifests.ModelFeaturePolicy{fp("a", manifests.MissingFeaturePolicyDefault, "", "")},
+			wantErr:  true,
+		},
+		{
+			name:     "null default",
+			policies: []manifests.ModelFeaturePolicy{fp("a", manifests.MissingFeaturePolicyDefault, "null", "")},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid default",
+			policies: []manifests.ModelFeaturePolicy{fp("a", manifests.MissingFeaturePolicyDefault, "{", "")},
+			wantErr:  true,
+		},
+		{
+			name:     "not a feature of the model",
+			policies: []manifests.ModelFeaturePolicy{fp("default.z", manifests.MissingFeaturePolicyOmit, "", "")},
+			wantErr:  true,
+		},
+		{
+			name: "more than one policy",
+			policies: []manifests.ModelFeaturePolicy{
+				fp("a", manifests.MissingFeaturePolicyOmit, "", ""),
+				fp("default.a", manifests.MissingFeaturePolicyRequired, "", ""),
+			},
+			wantErr: true,
+		},
+		{
+			name:     "unsupported policy",
+			policies: []manifests.ModelFeaturePolicy{fp("a", "ignore", "", "")},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := md
+			md.FeaturePolicies = tt.policies
+			got, err := parsePolicies(md, "default", tt.statistics)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePolicies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePolicies() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
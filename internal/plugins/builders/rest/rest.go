@@ -27,6 +27,7 @@ import (
 	"github.com/raptor-ml/raptor/api"
 	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
 	"github.com/raptor-ml/raptor/pkg/plugins"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"io"
 	"net/http"
 	"strings"
@@ -88,7 +89,7 @@ func FeatureApply(fd api.FeatureDescriptor, builder manifests.FeatureBuilder, pl
 
 	cfg.runtime = engine
 	cfg.client = http.Client{
-		Transport: httpcache.NewTransport(httpMemoryCache),
+		Transport: otelhttp.NewTransport(httpcache.NewTransport(httpMemoryCache)),
 		Timeout:   timeout,
 	}
 
@@ -122,7 +123,11 @@ func (rest *config) getMiddleware(next api.MiddlewareHandler) api.MiddlewareHand
 			return val, err
 		}
 		req = req.WithContext(ctx)
-		req.Header = rest.Headers
+		// the headers are copied, since the trace context is propagated by injecting headers to the request
+		req.Header = rest.Headers.Clone()
+		if req.Header == nil {
+			req.Header = make(http.Header)
+		}
 
 		resp, err := rest.client.Do(req)
 		if err != nil {
@@ -0,0 +1,320 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sql implements the `sql` builder, which fetches the Feature's data from a database on demand.
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"encoding/base64"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+	"regexp"
+	"sync"
+	"time"
+
+	// register the database drivers
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+const name = "sql"
+
+func init() {
+	plugins.FeatureAppliers.Register(name, FeatureApply)
+}
+
+type config struct {
+	// Driver is the database driver. Either `postgres` or `mysql`.
+	Driver string `mapstructure:"driver"`
+	// DSN is the connection string of the database. Must be set using a `secretKeyRef`.
+	DSN string `mapstructure:"dsn"`
+	// Query is the query template. Use `{key:<name>}` placeholders to bind the Feature's keys.
+	Query string `mapstructure:"query"`
+	//+optional
+	MaxOpenConns int `mapstructure:"max_open_conns"`
+	//+optional
+	MaxIdleConns int `mapstructure:"max_idle_conns"`
+	//+optional
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+
+	query   string
+	args    []string
+	timeout time.Duration
+	runtime api.RuntimeManager
+	db      *dbsql.DB
+}
+
+var keyPlaceholder = regexp.MustCompile(`{key:([^}]+)}`)
+
+// parseQuery replaces the `{key:<name>}` placeholders with the driver's bind parameters,
+// so the keys are never interpolated into the query.
+func parseQuery(driver, tpl string) (string, []string) {
+	var args []string
+	q := keyPlaceholder.ReplaceAllStringFunc(tpl, func(s string) string {
+		args = append(args, keyPlaceholder.FindStringSubmatch(s)[1])
+		if driver == "pgx" {
+			return fmt.Sprintf("$%d", len(args))
+		}
+		return "?"
+	})
+	return q, args
+}
+
+func driverName(driver string) (string, error) {
+	switch driver {
+	case "postgres", "postgresql", "pgx":
+		return "pgx", nil
+	case "mysql":
+		return "mysql", nil
+	default:
+		return "", fmt.Errorf("unsupported driver `%s`", driver)
+	}
+}
+
+// pools holds a connection pool per database, shared by the Features that query it
+var pools = struct {
+	sync.Mutex
+	dbs map[string]*pool
+}{dbs: make(map[string]*pool)}
+
+type pool struct {
+	db *dbsql.DB
+	// refs is the number of bound Features that use the pool
+	refs int
+}
+
+// acquirePool returns the connection pool of the database, and a function that releases it once the Feature is
+// unbound. The pool is closed when the last Feature that uses it releases it.
+// Pools are keyed by their DSN, so the pool options of the DataSource that opened the pool are used.
+func acquirePool(driver string, cfg config) (*dbsql.DB, func(), error) {
+	pools.Lock()
+	defer pools.Unlock()
+
+	key := driver + cfg.DSN
+	p, ok := pools.dbs[key]
+	if !ok {
+		db, err := dbsql.Open(driver, cfg.DSN)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open database connection: %w", err)
+		}
+		if cfg.MaxOpenConns > 0 {
+			db.SetMaxOpenConns(cfg.MaxOpenConns)
+		}
+		if cfg.MaxIdleConns > 0 {
+			db.SetMaxIdleConns(cfg.MaxIdleConns)
+		}
+		if cfg.ConnMaxLifetime > 0 {
+			db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+		}
+		p = &pool{db: db}
+		pools.dbs[key] = p
+	}
+	p.refs++
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			pools.Lock()
+			defer pools.Unlock()
+
+			p.refs--
+			if p.refs == 0 {
+				_ = p.db.Close()
+				delete(pools.dbs, key)
+			}
+		})
+	}
+	return p.db, release, nil
+}
+
+func FeatureApply(fd api.FeatureDescriptor, builder manifests.FeatureBuilder, pl api.Pipeliner, engine api.ExtendedManager) error {
+	if fd.DataSource == "" {
+		return fmt.Errorf("DataSource must be set for `%s` builder", name)
+	}
+	if len(fd.Aggr) > 0 {
+		return fmt.Errorf("aggregation is not supported for `%s` builder", name)
+	}
+
+	src, err := engine.GetDataSource(fd.DataSource)
+	if err != nil {
+		return fmt.Errorf("failed to get DataSource: %v", err)
+	}
+
+	if src.Kind != name {
+		return fmt.Errorf("DataSource must be of type `%s`. got `%s`", name, src.Kind)
+	}
+
+	cfg := config{}
+	err = src.Config.Unmarshal(&cfg)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal DataSource config: %v", err)
+	}
+	if cfg.Query == "" {
+		return fmt.Errorf("`query` must be set for `%s` DataSource", name)
+	}
+	if cfg.DSN == "" {
+		return fmt.Errorf("`dsn` must be set for `%s` DataSource", name)
+	}
+	dsn, err := base64.StdEncoding.DecodeString(cfg.DSN)
+	if err != nil {
+		return fmt.Errorf("`dsn` must be set using a secretKeyRef: %w", err)
+	}
+	cfg.DSN = string(dsn)
+
+	driver, err := driverName(cfg.Driver)
+	if err != nil {
+		return err
+	}
+	cfg.query, cfg.args = parseQuery(driver, cfg.Query)
+	for _, k := range cfg.args {
+		if !containsKey(fd.Keys, k) {
+			return fmt.Errorf("the query uses the key `%s` which is not one of the Feature's keys", k)
+		}
+	}
+
+	cfg.timeout = time.Duration(float32(fd.Timeout) * 0.8)
+	if cfg.timeout == 0 {
+		cfg.timeout = 5 * time.Second
+	}
+
+	db, release, err := acquirePool(driver, cfg)
+	if err != nil {
+		return err
+	}
+	cfg.db = db
+	cfg.runtime = engine
+	pl.AddUnbindHook(release)
+
+	if fd.Freshness <= 0 {
+		pl.AddPreGetMiddleware(0, cfg.getMiddleware)
+	} else {
+		pl.AddPostGetMiddleware(0, cfg.getMiddleware)
+	}
+	return nil
+}
+
+func containsKey(keys []string, k string) bool {
+	for _, v := range keys {
+		if v == k {
+			return true
+		}
+	}
+	return false
+}
+
+func (cfg *config) getMiddleware(next api.MiddlewareHandler) api.MiddlewareHandler {
+	return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
+		cache, cacheOk := ctx.Value(api.ContextKeyFromCache).(bool)
+		if cacheOk && cache && val.Fresh && !fd.ValidWindow() {
+			return next(ctx, fd, keys, val)
+		}
+
+		args := make([]any, len(cfg.args))
+		for i, k := range cfg.args {
+			v, ok := keys[k]
+			if !ok {
+				return val, fmt.Errorf("missing key `%s`", k)
+			}
+			args[i] = v
+		}
+
+		row, err := cfg.queryRow(ctx, args)
+		if err != nil {
+			return val, err
+		}
+		if row == nil {
+			// nothing was found
+			return next(ctx, fd, keys, val)
+		}
+
+		val, keys, err = cfg.runtime.ExecuteProgram(ctx, fd.RuntimeEnv, fd.FQN, keys, row, val.Timestamp, true)
+		if err != nil {
+			return val, err
+		}
+
+		return next(ctx, fd, keys, val)
+	}
+}
+
+// queryRow returns the first row of the query's result as a map of column names to values.
+// It returns nil if the query has no results.
+func (cfg *config) queryRow(ctx context.Context, args []any) (map[string]any, error) {
+	ctx, cancel := context.WithTimeout(ctx, cfg.timeout)
+	defer cancel()
+
+	rows, err := cfg.db.QueryContext(ctx, cfg.query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the database: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+	vals := make([]any, len(cols))
+	ptrs := make([]any, len(cols))
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+	if err := rows.Scan(ptrs...); err != nil {
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	row := make(map[string]any, len(cols))
+	for i, c := range cols {
+		if v := normalize(vals[i]); v != nil {
+			row[c] = v
+		}
+	}
+	return row, nil
+}
+
+// normalize converts the database values to the primitives supported by the runtime
+func normalize(v any) any {
+	switch v := v.(type) {
+	case []byte:
+		return string(v)
+	case int64:
+		return int(v)
+	case int32:
+		return int(v)
+	case int16:
+		return int(v)
+	case int8:
+		return int(v)
+	case uint64:
+		return int(v)
+	case uint32:
+		return int(v)
+	case float32:
+		return float64(v)
+	case int, float64, string, bool, time.Time:
+		return v
+	case nil:
+		return nil
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
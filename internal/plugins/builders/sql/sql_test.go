@@ -0,0 +1,183 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name      string
+		driver    string
+		tpl       string
+		wantQuery string
+		wantArgs  []string
+	}{
+		{
+			name:      "postgres",
+			driver:    "pgx",
+			tpl:       "SELECT * FROM users WHERE id = {key:id} AND org = {key:org}",
+			wantQuery: "SELECT * FROM users WHERE id = $1 AND org = $2",
+			wantArgs:  []string{"id", "org"},
+		},
+		{
+			name:      "mysql",
+			driver:    "mysql",
+			tpl:       "SELECT * FROM users WHERE id = {key:id} AND org = {key:org}",
+			wantQuery: "SELECT * FROM users WHERE id = ? AND org = ?",
+			wantArgs:  []string{"id", "org"},
+		},
+		{
+			name:      "repeated key",
+			driver:    "pgx",
+			tpl:       "SELECT * FROM users WHERE id = {key:id} OR parent = {key:id}",
+			wantQuery: "SELECT * FROM users WHERE id = $1 OR parent = $2",
+			wantArgs:  []string{"id", "id"},
+		},
+		{
+			name:      "no placeholders",
+			driver:    "pgx",
+			tpl:       "SELECT count(*) FROM users",
+			wantQuery: "SELECT count(*) FROM users",
+		},
+		{
+			name:      "injection shaped key",
+			driver:    "pgx",
+			tpl:       "SELECT * FROM users WHERE id = {key:id' OR '1'='1}",
+			wantQuery: "SELECT * FROM users WHERE id = $1",
+			wantArgs:  []string{"id' OR '1'='1"},
+		},
+		{
+			name:      "unterminated placeholder",
+			driver:    "pgx",
+			tpl:       "SELECT * FROM users WHERE id = {key:id",
+			wantQuery: "SELECT * FROM users WHERE id = {key:id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, args := parseQuery(tt.driver, tt.tpl)
+			if q != tt.wantQuery {
+				t.Errorf("parseQuery() query = %q, want %q", q, tt.wantQuery)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("parseQuery() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+// TestQueryRowBindsKeys checks that the values of the keys are sent as bind parameters, and never as a part of the query
+func TestQueryRowBindsKeys(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	query, args := parseQuery("pgx", "SELECT name, age FROM users WHERE id = {key:id}")
+	cfg := config{query: query, args: args, timeout: time.Second, db: db}
+
+	injection := "1' OR '1'='1"
+	mock.ExpectQuery("SELECT name, age FROM users WHERE id = $1").
+		WithArgs(injection).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "age"}).AddRow([]byte("alice"), int64(30)))
+	mock.ExpectQuery("SELECT name, age FROM users WHERE id = $1").
+		WithArgs("2").
+		WillReturnRows(sqlmock.NewRows([]string{"name", "age"}))
+
+	row, err := cfg.queryRow(context.Background(), []any{injection})
+	if err != nil {
+		t.Fatalf("queryRow() error = %v", err)
+	}
+	if want := map[string]any{"name": "alice", "age": 30}; !reflect.DeepEqual(row, want) {
+		t.Errorf("queryRow() = %v, want %v", row, want)
+	}
+
+	row, err = cfg.queryRow(context.Background(), []any{"2"})
+	if err != nil {
+		t.Fatalf("queryRow() error = %v", err)
+	}
+	if row != nil {
+		t.Errorf("expected no row, got %v", row)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAcquirePool(t *testing.T) {
+	a := config{DSN: "postgres://localhost/a"}
+	b := config{DSN: "postgres://localhost/b"}
+
+	dbA1, releaseA1, err := acquirePool("pgx", a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbA2, releaseA2, err := acquirePool("pgx", a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbB, releaseB, err := acquirePool("pgx", b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer releaseB()
+	if dbA1 != dbA2 {
+		t.Error("expected the Features of the same DSN to share the pool")
+	}
+	if dbA1 == dbB {
+		t.Error("expected a pool per DSN")
+	}
+
+	// the pool is kept open until all the Features that use it released it
+	releaseA1()
+	releaseA1()
+	pools.Lock()
+	p, ok := pools.dbs["pgx"+a.DSN]
+	pools.Unlock()
+	if !ok || p.refs != 1 {
+		t.Fatal("expected the pool to stay open while a Feature uses it")
+	}
+	releaseA2()
+	if err := dbA2.Ping(); err == nil || err.Error() != "sql: database is closed" {
+		t.Errorf("expected the pool to be closed, got %v", err)
+	}
+
+	pools.Lock()
+	_, ok = pools.dbs["pgx"+a.DSN]
+	pools.Unlock()
+	if ok {
+		t.Error("expected the released pool to be removed")
+	}
+
+	// a released DSN gets a new pool
+	db, release, err := acquirePool("pgx", a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	if db == dbA1 {
+		t.Error("expected a new pool")
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encoders
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/raptor-ml/raptor/api"
+)
+
+type bucketizeConfig struct {
+	// Boundaries are the sorted boundaries of the buckets
+	Boundaries []float64 `json:"boundaries"`
+}
+
+// Bucketize encodes a number as the index of the bucket it falls in.
+// A value that is lower than the first boundary falls in the bucket 0, and a value that is equal or greater than
+// the last boundary falls in the bucket `len(boundaries)`.
+func Bucketize(_ api.FeatureDescriptor, spec json.RawMessage) (api.Encoder, error) {
+	cfg := bucketizeConfig{}
+	if err := parseSpec(spec, &cfg); err != nil {
+		return nil, err
+	}
+	if len(cfg.Boundaries) == 0 {
+		return nil, fmt.Errorf("`bucketize` requires boundaries")
+	}
+	for i := 1; i < len(cfg.Boundaries); i++ {
+		if cfg.Boundaries[i] <= cfg.Boundaries[i-1] {
+			return nil, fmt.Errorf("boundaries must be sorted in an increasing order")
+		}
+	}
+
+	return func(val any) (any, error) {
+		return numeric(val, func(v float64) (int, error) {
+			return sort.Search(len(cfg.Boundaries), func(i int) bool {
+				return cfg.Boundaries[i] > v
+			}), nil
+		})
+	}, nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package encoders implements the encodings that can be requested by the selector's `[encoding]` suffix.
+package encoders
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/raptor-ml/raptor/pkg/plugins"
+)
+
+func init() {
+	plugins.Encoders.Register("onehot", OneHot)
+	plugins.Encoders.Register("bucketize", Bucketize)
+	plugins.Encoders.Register("standard", Standard)
+	plugins.Encoders.Register("minmax", MinMax)
+	plugins.Encoders.Register("hash", Hash)
+	plugins.Encoders.Register("log", Log)
+}
+
+// parseSpec parses the configuration of the encoding. A nil spec leaves the config untouched.
+func parseSpec(spec json.RawMessage, cfg any) error {
+	if len(spec) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(spec, cfg); err != nil {
+		return fmt.Errorf("failed to parse the encoding configuration: %w", err)
+	}
+	return nil
+}
+
+// numeric applies fn on a number, or on each of the items of a list of numbers.
+func numeric[T any](val any, fn func(float64) (T, error)) (any, error) {
+	switch v := val.(type) {
+	case int:
+		return fn(float64(v))
+	case float64:
+		return fn(v)
+	case []int:
+		ret := make([]T, len(v))
+		for i, n := range v {
+			var err error
+			if ret[i], err = fn(float64(n)); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	case []float64:
+		ret := make([]T, len(v))
+		for i, n := range v {
+			var err error
+			if ret[i], err = fn(n); err != nil {
+				return nil, err
+			}
+		}
+		return ret, nil
+	default:
+		return nil, fmt.Errorf("expected a number or a list of numbers, got %T", val)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encoders
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+)
+
+func fnvBucket(s string, buckets uint32) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(s))
+	return int(h.Sum32() % buckets)
+}
+
+func TestEncoders(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoder string
+		spec    string
+		val     any
+		want    any
+		wantErr bool
+		specErr bool
+	}{
+		{name: "onehot", encoder: "onehot", spec: `{"vocabulary": ["a", "b", "c"]}`, val: "b", want: []float64{0, 1, 0}},
+		{name: "onehot unknown value", encoder: "onehot", spec: `{"vocabulary": ["a", "b"]}`, val: "z", want: []float64{0, 0}},
+		{name: "onehot oov", encoder: "onehot", spec: `{"vocabulary": ["a", "b"], "oov": true}`, val: "z", want: []float64{0, 0, 1}},
+		{name: "onehot multi-hot", encoder: "onehot", spec: `{"vocabulary": ["a", "b", "c"]}`, val: []string{"a", "c"}, want: []float64{1, 0, 1}},
+		{name: "onehot number", encoder: "onehot", spec: `{"vocabulary": ["a"]}`, val: 1, wantErr: true},
+		{name: "onehot without vocabulary", encoder: "onehot", specErr: true},
+		{name: "onehot duplicates", encoder: "onehot", spec: `{"vocabulary": ["a", "a"]}`, specErr: true},
+		{name: "onehot invalid spec", encoder: "onehot", spec: `{"vocabulary": "a"}`, specErr: true},
+
+		{name: "bucketize", encoder: "bucketize", spec: `{"boundaries": [0, 10, 100]}`, val: 5, want: 1},
+		{name: "bucketize below", encoder: "bucketize", spec: `{"boundaries": [0, 10, 100]}`, val: -1.5, want: 0},
+		{name: "bucketize boundary", encoder: "bucketize", spec: `{"boundaries": [0, 10, 100]}`, val: 10.0, want: 2},
+		{name: "bucketize above", encoder: "bucketize", spec: `{"boundaries": [0, 10, 100]}`, val: 1000, want: 3},
+		{name: "bucketize list", encoder: "bucketize", spec: `{"boundaries": [0, 10]}`, val: []float64{-1, 5, 20}, want: []int{0, 1, 2}},
+		{name: "bucketize string", encoder: "bucketize", spec: `{"boundaries": [0]}`, val: "a", wantErr: true},
+		{name: "bucketize without boundaries", encoder: "bucketize", specErr: true},
+		{name: "bucketize unsorted", encoder: "bucketize", spec: `{"boundaries": [10, 0]}`, specErr: true},
+		{name: "bucketize repeated boundary", encoder: "bucketize", spec: `{"boundaries": [0, 0]}`, specErr: true},
+
+		{name: "standard", encoder: "standard", spec: `{"mean": 10, "stddev": 2}`, val: 14, want: 2.0},
+		{name: "standard list", encoder: "standard", spec: `{"mean": 10, "stddev": 2}`, val: []int{8, 10}, want: []float64{-1, 0}},
+		{name: "standard string", encoder: "standard", spec: `{"mean": 10, "stddev": 2}`, val: "a", wantErr: true},
+		{name: "standard without stddev", encoder: "standard", spec: `{"mean": 10}`, specErr: true},
+		{name: "standard negative stddev", encoder: "standard", spec: `{"stddev": -1}`, specErr: true},
+
+		{name: "minmax", encoder: "minmax", spec: `{"min": 10, "max": 20}`, val: 15.0, want: 0.5},
+		{name: "minmax out of range", encoder: "minmax", spec: `{"min": 10, "max": 20}`, val: 30, want: 2.0},
+		{name: "minmax list", encoder: "minmax", spec: `{"min": 0, "max": 4}`, val: []float64{0, 1, 4}, want: []float64{0, 0.25, 1}},
+		{name: "minmax bool", encoder: "minmax", spec: `{"min": 0, "max": 1}`, val: true, wantErr: true},
+		{name: "minmax zero min", encoder: "minmax", spec: `{"min": 0, "max": 1}`, val: 1, want: 1.0},
+		{name: "minmax without max", encoder: "minmax", spec: `{"min": 0}`, specErr: true},
+		{name: "minmax empty range", encoder: "minmax", spec: `{"min": 1, "max": 1}`, specErr: true},
+
+		{name: "hash", encoder: "hash", val: "a", want: fnvBucket("a", defaultHashBuckets)},
+		{name: "hash buckets", encoder: "hash", spec: `{"buckets": 7}`, val: 42, want: fnvBucket("42", 7)},
+		{name: "hash list", encoder: "hash", spec: `{"buckets": 7}`, val: []string{"a", "b"}, want: []int{fnvBucket("a", 7), fnvBucket("b", 7)}},
+		{name: "hash map", encoder: "hash", val: map[string]any{}, wantErr: true},
+		{name: "hash zero buckets", encoder: "hash", spec: `{"buckets": 0}`, specErr: true},
+
+		{name: "log", encoder: "log", val: 0, want: 0.0},
+		{name: "log positive", encoder: "log", val: math.E - 1, want: 1.0},
+		{name: "log list", encoder: "log", val: []int{0, 0}, want: []float64{0, 0}},
+		{name: "log out of domain", encoder: "log", val: -1, wantErr: true},
+		{name: "log string", encoder: "log", val: "a", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := plugins.Encoders.Get(tt.encoder)
+			if factory == nil {
+				t.Fatalf("the encoder %s is not registered", tt.encoder)
+			}
+			var spec json.RawMessage
+			if tt.spec != "" {
+				spec = json.RawMessage(tt.spec)
+			}
+			enc, err := factory(api.FeatureDescriptor{FQN: "default.feature"}, spec)
+			if (err != nil) != tt.specErr {
+				t.Fatalf("factory error = %v, specErr %v", err, tt.specErr)
+			}
+			if tt.specErr {
+				return
+			}
+
+			got, err := enc(tt.val)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("encoder error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) && !tt.wantErr {
+				t.Errorf("encoder = %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnknownEncoder(t *testing.T) {
+	for _, name := range []string{"", "zscore", "OneHot"} {
+		if plugins.Encoders.Get(name) != nil {
+			t.Errorf("expected the encoder `%s` to not be registered", name)
+		}
+	}
+}
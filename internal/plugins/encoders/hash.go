@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encoders
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/raptor-ml/raptor/api"
+)
+
+// defaultHashBuckets is the number of buckets of the hashing trick, unless configured otherwise
+const defaultHashBuckets = 1024
+
+type hashConfig struct {
+	Buckets int `json:"buckets"`
+}
+
+// Hash encodes a value as its bucket in the hashing trick, i.e. `fnv32a(value) % buckets`.
+// A list is encoded as the list of the buckets of its items.
+func Hash(_ api.FeatureDescriptor, spec json.RawMessage) (api.Encoder, error) {
+	cfg := hashConfig{Buckets: defaultHashBuckets}
+	if err := parseSpec(spec, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Buckets <= 0 {
+		return nil, fmt.Errorf("`hash` requires a positive number of buckets")
+	}
+
+	bucket := func(v any) (int, error) {
+		switch v.(type) {
+		case string, int, float64, bool, time.Time:
+		default:
+			return 0, fmt.Errorf("expected a scalar or a list of scalars, got %T", v)
+		}
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(api.ScalarString(v)))
+		return int(h.Sum32() % uint32(cfg.Buckets)), nil
+	}
+	return func(val any) (any, error) {
+		switch v := val.(type) {
+		case []string:
+			return hashList(v, bucket)
+		case []int:
+			return hashList(v, bucket)
+		case []float64:
+			return hashList(v, bucket)
+		case []bool:
+			return hashList(v, bucket)
+		case []time.Time:
+			return hashList(v, bucket)
+		default:
+			return bucket(val)
+		}
+	}, nil
+}
+
+func hashList[T any](l []T, bucket func(any) (int, error)) ([]int, error) {
+	ret := make([]int, len(l))
+	for i, v := range l {
+		var err error
+		if ret[i], err = bucket(v); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encoders
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/raptor-ml/raptor/api"
+)
+
+type oneHotConfig struct {
+	// Vocabulary is the list of the known values. Each value is encoded as its own dimension.
+	Vocabulary []string `json:"vocabulary"`
+	// OOV adds a dimension for the values that are not part of the vocabulary.
+	OOV bool `json:"oov"`
+}
+
+// OneHot encodes a string as a one-hot vector over the vocabulary.
+// A list of strings is encoded as a multi-hot vector.
+func OneHot(_ api.FeatureDescriptor, spec json.RawMessage) (api.Encoder, error) {
+	cfg := oneHotConfig{}
+	if err := parseSpec(spec, &cfg); err != nil {
+		return nil, err
+	}
+	if len(cfg.Vocabulary) == 0 {
+		return nil, fmt.Errorf("`onehot` requires a vocabulary")
+	}
+	index := make(map[string]int, len(cfg.Vocabulary))
+	for i, v := range cfg.Vocabulary {
+		if _, ok := index[v]; ok {
+			return nil, fmt.Errorf("`%s` is defined more than once in the vocabulary", v)
+		}
+		index[v] = i
+	}
+
+	dim := len(cfg.Vocabulary)
+	if cfg.OOV {
+		dim++
+	}
+	hot := func(vec []float64, v string) {
+		if i, ok := index[v]; ok {
+			vec[i] = 1
+		} else if cfg.OOV {
+			vec[dim-1] = 1
+		}
+	}
+	return func(val any) (any, error) {
+		vec := make([]float64, dim)
+		switch v := val.(type) {
+		case string:
+			hot(vec, v)
+		case []string:
+			for _, s := range v {
+				hot(vec, s)
+			}
+		default:
+			return nil, fmt.Errorf("expected a string or a list of strings, got %T", val)
+		}
+		return vec, nil
+	}, nil
+}
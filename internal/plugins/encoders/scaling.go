@@ -0,0 +1,86 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encoders
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/raptor-ml/raptor/api"
+)
+
+type standardConfig struct {
+	Mean   float64 `json:"mean"`
+	Stddev float64 `json:"stddev"`
+}
+
+// Standard scales a number to its standard score, using the mean and the standard deviation from the spec.
+func Standard(_ api.FeatureDescriptor, spec json.RawMessage) (api.Encoder, error) {
+	cfg := standardConfig{}
+	if err := parseSpec(spec, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Stddev <= 0 {
+		return nil, fmt.Errorf("`standard` requires a positive stddev")
+	}
+
+	return func(val any) (any, error) {
+		return numeric(val, func(v float64) (float64, error) {
+			return (v - cfg.Mean) / cfg.Stddev, nil
+		})
+	}, nil
+}
+
+type minMaxConfig struct {
+	Min *float64 `json:"min"`
+	Max *float64 `json:"max"`
+}
+
+// MinMax scales a number to the [0, 1] range, using the min and the max from the spec.
+// Values that are out of the range are not clipped.
+func MinMax(_ api.FeatureDescriptor, spec json.RawMessage) (api.Encoder, error) {
+	cfg := minMaxConfig{}
+	if err := parseSpec(spec, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Min == nil || cfg.Max == nil {
+		return nil, fmt.Errorf("`minmax` requires a min and a max")
+	}
+	lo, hi := *cfg.Min, *cfg.Max
+	if hi <= lo {
+		return nil, fmt.Errorf("max must be greater than min")
+	}
+
+	return func(val any) (any, error) {
+		return numeric(val, func(v float64) (float64, error) {
+			return (v - lo) / (hi - lo), nil
+		})
+	}, nil
+}
+
+// Log encodes a number as its natural logarithm of `1 + value`, so zeros are kept as zeros.
+func Log(_ api.FeatureDescriptor, _ json.RawMessage) (api.Encoder, error) {
+	return func(val any) (any, error) {
+		return numeric(val, func(v float64) (float64, error) {
+			if v <= -1 {
+				return 0, fmt.Errorf("cannot apply `log` on %v", v)
+			}
+			return math.Log1p(v), nil
+		})
+	}, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kserve_v2
+
+import (
+	"fmt"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"strings"
+	"time"
+)
+
+const (
+	protocolREST = "rest"
+	protocolGRPC = "grpc"
+)
+
+type config struct {
+	// Endpoint is the address of the inference server.
+	// For REST, this is the base URL of the server, i.e. `http://triton.default:8000`.
+	// For gRPC, this is the host and port of the server, i.e. `triton.default:8001`.
+	// +required
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Protocol is the protocol used to communicate with the inference server: `rest` or `grpc`.
+	// Default is `rest`.
+	// +optional
+	Protocol string `mapstructure:"protocol"`
+
+	// ModelName is the name of the model in the inference server.
+	// if not set, the name of the Raptor model will be used.
+	// +optional
+	ModelName string `mapstructure:"modelName"`
+
+	// ModelVersion is the version of the model in the inference server.
+	// If not set, the inference server will choose the version.
+	// +optional
+	ModelVersion string `mapstructure:"modelVersion"`
+
+	// InputName is the name of a single input tensor that holds all the features, ordered as the model's features.
+	// This requires all the features to be numeric scalars.
+	// If not set, each feature is sent as its own input tensor, named after the feature's FQN.
+	// +optional
+	InputName string `mapstructure:"inputName"`
+
+	// OutputName is the name of the output tensor that holds the prediction.
+	// If not set, the first output tensor will be used.
+	// +optional
+	OutputName string `mapstructure:"outputName"`
+
+	// Timeout is the maximum duration of an inference request.
+	// Default is 5s.
+	// +optional
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// TLS enables TLS for the gRPC protocol.
+	// +optional
+	TLS bool `mapstructure:"tls"`
+}
+
+func (cfg *config) Parse(pc manifests.ParsedConfig) error {
+	// unset optional values are left empty, and can't be parsed as a duration
+	set := make(manifests.ParsedConfig, len(pc))
+	for k, v := range pc {
+		if v != "" {
+			set[k] = v
+		}
+	}
+	err := set.Unmarshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to parse KServe V2 Inference config: %v", err)
+	}
+
+	// Check for required fields
+	if cfg.Endpoint == "" {
+		return fmt.Errorf("endpoint must be set")
+	}
+
+	// Set defaults
+	if cfg.Protocol == "" {
+		cfg.Protocol = protocolREST
+	}
+	cfg.Protocol = strings.ToLower(cfg.Protocol)
+	if cfg.Protocol != protocolREST && cfg.Protocol != protocolGRPC {
+		return fmt.Errorf("protocol must be one of `%s` or `%s`", protocolREST, protocolGRPC)
+	}
+	if cfg.Protocol == protocolREST && !strings.HasPrefix(cfg.Endpoint, "http://") && !strings.HasPrefix(cfg.Endpoint, "https://") {
+		cfg.Endpoint = "http://" + cfg.Endpoint
+	}
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kserve_v2
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"github.com/raptor-ml/raptor/internal/plugins/modelservers/kserve-v2/inference"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"math"
+	"sync"
+)
+
+// grpcClients holds the clients of the inference servers by their endpoint
+var grpcClients sync.Map
+
+func grpcClient(cfg config) (inference.GRPCInferenceServiceClient, error) {
+	key := fmt.Sprintf("%s/%t", cfg.Endpoint, cfg.TLS)
+	if c, ok := grpcClients.Load(key); ok {
+		return c.(inference.GRPCInferenceServiceClient), nil
+	}
+
+	creds := insecure.NewCredentials()
+	if cfg.TLS {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	cc, err := grpc.Dial(cfg.Endpoint,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial inference server: %w", err)
+	}
+
+	c, loaded := grpcClients.LoadOrStore(key, inference.NewGRPCInferenceServiceClient(cc))
+	if loaded {
+		_ = cc.Close()
+	}
+	return c.(inference.GRPCInferenceServiceClient), nil
+}
+
+func inferGRPC(ctx context.Context, cfg config, id string, inputs []tensor) ([]tensor, error) {
+	c, err := grpcClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &inference.ModelInferRequest{
+		ModelName:    cfg.ModelName,
+		ModelVersion: cfg.ModelVersion,
+		Id:           id,
+	}
+	for _, t := range inputs {
+		in := &inference.ModelInferRequest_InferInputTensor{
+			Name:     t.Name,
+			Datatype: t.Datatype,
+			Shape:    t.Shape,
+			Contents: &inference.InferTensorContents{},
+		}
+		for _, d := range t.Data {
+			switch v := d.(type) {
+			case bool:
+				in.Contents.BoolContents = append(in.Contents.BoolContents, v)
+			case int64:
+				in.Contents.Int64Contents = append(in.Contents.Int64Contents, v)
+			case float32:
+				in.Contents.Fp32Contents = append(in.Contents.Fp32Contents, v)
+			case float64:
+				in.Contents.Fp64Contents = append(in.Contents.Fp64Contents, v)
+			case string:
+				in.Contents.BytesContents = append(in.Contents.BytesContents, []byte(v))
+			default:
+				return nil, fmt.Errorf("unsupported input element %T", d)
+			}
+		}
+		req.Inputs = append(req.Inputs, in)
+	}
+	if cfg.OutputName != "" {
+		req.Outputs = []*inference.ModelInferRequest_InferRequestedOutputTensor{{Name: cfg.OutputName}}
+	}
+
+	resp, err := c.ModelInfer(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send inference request: %w", err)
+	}
+
+	ret := make([]tensor, len(resp.Outputs))
+	for i, o := range resp.Outputs {
+		ret[i] = tensor{Name: o.Name, Datatype: o.Datatype, Shape: o.Shape}
+		// servers (i.e. Triton) might return the outputs in the raw form
+		if len(resp.RawOutputContents) > i {
+			ret[i].Data, err = rawData(o.Datatype, resp.RawOutputContents[i])
+			if err != nil {
+				return nil, fmt.Errorf("output `%s`: %w", o.Name, err)
+			}
+			continue
+		}
+		ret[i].Data = contentsData(o.Contents)
+	}
+	return ret, nil
+}
+
+func contentsData(c *inference.InferTensorContents) []any {
+	var ret []any
+	for _, v := range c.GetBoolContents() {
+		ret = append(ret, v)
+	}
+	for _, v := range c.GetIntContents() {
+		ret = append(ret, int64(v))
+	}
+	for _, v := range c.GetInt64Contents() {
+		ret = append(ret, v)
+	}
+	for _, v := range c.GetUintContents() {
+		ret = append(ret, uint64(v))
+	}
+	for _, v := range c.GetUint64Contents() {
+		ret = append(ret, v)
+	}
+	for _, v := range c.GetFp32Contents() {
+		ret = append(ret, v)
+	}
+	for _, v := range c.GetFp64Contents() {
+		ret = append(ret, v)
+	}
+	for _, v := range c.GetBytesContents() {
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+// rawData decodes the raw (little-endian) contents of a tensor.
+// BYTES elements are prefixed by their 4-bytes length.
+func rawData(datatype string, b []byte) ([]any, error) {
+	if datatype == datatypeBytes {
+		var ret []any
+		for len(b) > 0 {
+			if len(b) < 4 {
+				return nil, fmt.Errorf("malformed BYTES contents")
+			}
+			l := binary.LittleEndian.Uint32(b)
+			b = b[4:]
+			if uint32(len(b)) < l {
+				return nil, fmt.Errorf("malformed BYTES contents")
+			}
+			ret = append(ret, b[:l])
+			b = b[l:]
+		}
+		return ret, nil
+	}
+
+	size := map[string]int{
+		datatypeBool: 1, "INT8": 1, "UINT8": 1, "INT16": 2, "UINT16": 2, "INT32": 4, "UINT32": 4,
+		datatypeInt64: 8, "UINT64": 8, datatypeFP32: 4, datatypeFP64: 8,
+	}[datatype]
+	if size == 0 {
+		return nil, fmt.Errorf("unsupported datatype %s", datatype)
+	}
+	if len(b)%size != 0 {
+		return nil, fmt.Errorf("raw contents of %d bytes don't match the datatype %s", len(b), datatype)
+	}
+
+	ret := make([]any, 0, len(b)/size)
+	for i := 0; i < len(b); i += size {
+		e := b[i : i+size]
+		switch datatype {
+		case datatypeBool:
+			ret = append(ret, e[0] != 0)
+		case "INT8":
+			ret = append(ret, int64(int8(e[0])))
+		case "UINT8":
+			ret = append(ret, uint64(e[0]))
+		case "INT16":
+			ret = append(ret, int64(int16(binary.LittleEndian.Uint16(e))))
+		case "UINT16":
+			ret = append(ret, uint64(binary.LittleEndian.Uint16(e)))
+		case "INT32":
+			ret = append(ret, int64(int32(binary.LittleEndian.Uint32(e))))
+		case "UINT32":
+			ret = append(ret, uint64(binary.LittleEndian.Uint32(e)))
+		case datatypeInt64:
+			ret = append(ret, int64(binary.LittleEndian.Uint64(e)))
+		case "UINT64":
+			ret = append(ret, binary.LittleEndian.Uint64(e))
+		case datatypeFP32:
+			ret = append(ret, math.Float32frombits(binary.LittleEndian.Uint32(e)))
+		case datatypeFP64:
+			ret = append(ret, math.Float64frombits(binary.LittleEndian.Uint64(e)))
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kserve_v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"github.com/raptor-ml/raptor/internal/plugins/modelservers/kserve-v2/inference"
+	"google.golang.org/grpc"
+	"math"
+	"net"
+	"reflect"
+	"testing"
+)
+
+// le encodes the values in little-endian
+func le(vals ...any) []byte {
+	var buf bytes.Buffer
+	for _, v := range vals {
+		_ = binary.Write(&buf, binary.LittleEndian, v)
+	}
+	return buf.Bytes()
+}
+
+func TestRawData(t *testing.T) {
+	tests := []struct {
+		name     string
+		datatype string
+		b        []byte
+		want     []any
+		wantErr  bool
+	}{
+		{
+			name:     "bytes",
+			datatype: datatypeBytes,
+			b:        append(append(le(uint32(3)), "abc"...), append(le(uint32(0)), append(le(uint32(2)), "de"...)...)...),
+			want:     []any{[]byte("abc"), []byte{}, []byte("de")},
+		},
+		{name: "bytes with a truncated length", datatype: datatypeBytes, b: []byte{1, 0}, wantErr: true},
+		{name: "bytes with a truncated element", datatype: datatypeBytes, b: append(le(uint32(4)), "abc"...), wantErr: true},
+		{name: "int8", datatype: "INT8", b: le(int8(-3), int8(7)), want: []any{int64(-3), int64(7)}},
+		{name: "uint16", datatype: "UINT16", b: le(uint16(65535)), want: []any{uint64(65535)}},
+		{name: "int32", datatype: "INT32", b: le(int32(-100000)), want: []any{int64(-100000)}},
+		{name: "int64", datatype: datatypeInt64, b: le(int64(math.MinInt64)), want: []any{int64(math.MinInt64)}},
+		{name: "fp32", datatype: datatypeFP32, b: le(float32(0.5), float32(-2)), want: []any{float32(0.5), float32(-2)}},
+		{name: "fp64", datatype: datatypeFP64, b: le(0.1), want: []any{0.1}},
+		{name: "bool", datatype: datatypeBool, b: []byte{1, 0}, want: []any{true, false}},
+		{name: "size mismatch", datatype: datatypeFP32, b: []byte{1, 2, 3}, wantErr: true},
+		{name: "unsupported datatype", datatype: "FP16", b: []byte{1, 2}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rawData(tt.datatype, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rawData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rawData() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+type inferenceServer struct {
+	inference.UnimplementedGRPCInferenceServiceServer
+	t    *testing.T
+	resp *inference.ModelInferResponse
+}
+
+func (s *inferenceServer) ModelInfer(_ context.Context, req *inference.ModelInferRequest) (*inference.ModelInferResponse, error) {
+	if req.ModelName != "churn" {
+		s.t.Errorf("expected model churn, got %s", req.ModelName)
+	}
+	if len(req.Inputs) != 2 {
+		s.t.Fatalf("expected 2 inputs, got %d", len(req.Inputs))
+	}
+	if c := req.Inputs[0].Contents.Int64Contents; !reflect.DeepEqual(c, []int64{1}) {
+		s.t.Errorf("unexpected contents of %s: %v", req.Inputs[0].Name, c)
+	}
+	if c := req.Inputs[1].Contents.Fp64Contents; !reflect.DeepEqual(c, []float64{2.5}) {
+		s.t.Errorf("unexpected contents of %s: %v", req.Inputs[1].Name, c)
+	}
+	return s.resp, nil
+}
+
+func TestServeGRPC(t *testing.T) {
+	tests := []struct {
+		name   string
+		config manifests.ParsedConfig
+		resp   *inference.ModelInferResponse
+		want   any
+	}{
+		{
+			name: "contents",
+			resp: &inference.ModelInferResponse{Outputs: []*inference.ModelInferResponse_InferOutputTensor{{
+				Name: "out", Datatype: datatypeFP32, Shape: []int64{1, 2},
+				Contents: &inference.InferTensorContents{Fp32Contents: []float32{0.25, 0.75}},
+			}}},
+			want: []float64{0.25, 0.75},
+		},
+		{
+			name:   "raw contents",
+			config: manifests.ParsedConfig{"outputName": "label"},
+			resp: &inference.ModelInferResponse{
+				Outputs: []*inference.ModelInferResponse_InferOutputTensor{
+					{Name: "probs", Datatype: datatypeFP32, Shape: []int64{1}},
+					{Name: "label", Datatype: datatypeBytes, Shape: []int64{1}},
+				},
+				RawOutputContents: [][]byte{le(float32(0.75)), append(le(uint32(3)), "yes"...)},
+			},
+			want: "yes",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lis, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Fatal(err)
+			}
+			srv := grpc.NewServer()
+			inference.RegisterGRPCInferenceServiceServer(srv, &inferenceServer{t: t, resp: tt.resp})
+			go func() {
+				_ = srv.Serve(lis)
+			}()
+			defer srv.Stop()
+
+			cfg := manifests.ParsedConfig{"endpoint": lis.Addr().String(), "protocol": protocolGRPC, "modelName": "churn"}
+			for k, v := range tt.config {
+				cfg[k] = v
+			}
+			got, err := serve(cfg)
+			if err != nil {
+				t.Fatalf("Serve() error = %v", err)
+			}
+			if !reflect.DeepEqual(got.Value, tt.want) {
+				t.Errorf("Serve() = %#v, want %#v", got.Value, tt.want)
+			}
+		})
+	}
+}
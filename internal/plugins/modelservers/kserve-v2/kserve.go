@@ -0,0 +1,51 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kserve_v2
+
+import (
+	"context"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const name = "kserve-v2"
+
+func init() {
+	// Register the plugin
+	plugins.ModelServer.Register(name, &kserve{})
+}
+
+// kserve is a model server that sends the feature set to an existing inference server that implements the
+// Open Inference Protocol (KServe V2), such as Triton, MLServer or KServe.
+// The inference server is managed externally, so there are no resources to reconcile.
+type kserve struct{}
+
+func (*kserve) Owns() []client.Object {
+	return nil
+}
+
+func (*kserve) Reconcile(ctx context.Context, req api.ModelReconcileRequest) (bool, error) {
+	pc, err := req.Model.ParseInferenceConfig(ctx, req.Client)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse inference config: %v", err)
+	}
+
+	cfg := config{}
+	return false, cfg.Parse(pc)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kserve_v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+var httpClient = &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+
+type restRequest struct {
+	ID      string          `json:"id,omitempty"`
+	Inputs  []tensor        `json:"inputs"`
+	Outputs []restOutputReq `json:"outputs,omitempty"`
+}
+
+type restOutputReq struct {
+	Name string `json:"name"`
+}
+
+type restResponse struct {
+	Outputs []tensor `json:"outputs"`
+	Error   string   `json:"error,omitempty"`
+}
+
+func inferURL(cfg config) string {
+	u := fmt.Sprintf("%s/v2/models/%s", cfg.Endpoint, url.PathEscape(cfg.ModelName))
+	if cfg.ModelVersion != "" {
+		u += "/versions/" + url.PathEscape(cfg.ModelVersion)
+	}
+	return u + "/infer"
+}
+
+func inferREST(ctx context.Context, cfg config, id string, inputs []tensor) ([]tensor, error) {
+	r := restRequest{ID: id, Inputs: inputs}
+	if cfg.OutputName != "" {
+		r.Outputs = []restOutputReq{{Name: cfg.OutputName}}
+	}
+	body, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal inference request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, inferURL(cfg), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send inference request: %w", err)
+	}
+	defer resp.Body.Close()
+	buf, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inference response: %w", err)
+	}
+
+	var res restResponse
+	if err := json.Unmarshal(buf, &res); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("inference request failed with status %d: %s", resp.StatusCode, buf)
+		}
+		return nil, fmt.Errorf("failed to unmarshal inference response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || res.Error != "" {
+		return nil, fmt.Errorf("inference request failed with status %d: %s", resp.StatusCode, res.Error)
+	}
+
+	// some servers return the data as nested arrays by the shape
+	for i, o := range res.Outputs {
+		res.Outputs[i].Data = flatten(o.Data)
+	}
+	return res.Outputs, nil
+}
+
+func flatten(data []any) []any {
+	var ret []any
+	for _, d := range data {
+		if l, ok := d.([]any); ok {
+			ret = append(ret, flatten(l)...)
+			continue
+		}
+		ret = append(ret, d)
+	}
+	return ret
+}
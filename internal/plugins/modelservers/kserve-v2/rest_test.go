@@ -0,0 +1,153 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kserve_v2
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/raptor-ml/raptor/api"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	tests := []struct {
+		name string
+		data []any
+		want []any
+	}{
+		{name: "flat", data: []any{1.0, 2.0}, want: []any{1.0, 2.0}},
+		{name: "nested", data: []any{[]any{1.0, 2.0}, []any{3.0, 4.0}}, want: []any{1.0, 2.0, 3.0, 4.0}},
+		{name: "deeply nested", data: []any{[]any{[]any{1.0}, []any{2.0}}, 3.0}, want: []any{1.0, 2.0, 3.0}},
+		{name: "empty", data: []any{}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := flatten(tt.data); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flatten() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServeREST(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     manifests.ParsedConfig
+		wantPath   string
+		status     int
+		response   string
+		wantOutput []string
+		want       any
+		wantErr    bool
+	}{
+		{
+			name:     "first output",
+			config:   manifests.ParsedConfig{"modelName": "churn"},
+			wantPath: "/v2/models/churn/infer",
+			status:   http.StatusOK,
+			response: `{"outputs":[{"name":"out","shape":[1,1],"datatype":"FP32","data":[0.25]}]}`,
+			want:     0.25,
+		},
+		{
+			name:       "named output with nested data",
+			config:     manifests.ParsedConfig{"modelName": "churn", "modelVersion": "2", "outputName": "probs"},
+			wantPath:   "/v2/models/churn/versions/2/infer",
+			wantOutput: []string{"probs"},
+			status:     http.StatusOK,
+			response: `{"outputs":[{"name":"label","shape":[1],"datatype":"BYTES","data":["yes"]},` +
+				`{"name":"probs","shape":[1,2],"datatype":"FP64","data":[[0.25,0.75]]}]}`,
+			want: []float64{0.25, 0.75},
+		},
+		{
+			name:     "model name from the fqn",
+			config:   manifests.ParsedConfig{},
+			wantPath: "/v2/models/default-model/infer",
+			status:   http.StatusOK,
+			response: `{"outputs":[{"name":"out","shape":[1],"datatype":"INT64","data":[1]}]}`,
+			want:     1,
+		},
+		{
+			name:     "error response",
+			config:   manifests.ParsedConfig{"modelName": "churn"},
+			wantPath: "/v2/models/churn/infer",
+			status:   http.StatusBadRequest,
+			response: `{"error":"invalid input"}`,
+			wantErr:  true,
+		},
+		{
+			name:     "non-json error response",
+			config:   manifests.ParsedConfig{"modelName": "churn"},
+			wantPath: "/v2/models/churn/infer",
+			status:   http.StatusInternalServerError,
+			response: `internal error`,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("expected path %s, got %s", tt.wantPath, r.URL.Path)
+				}
+				var req restRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Errorf("failed to decode request: %v", err)
+				}
+				if len(req.Inputs) != 2 || req.Inputs[0].Name != "default.a" || req.Inputs[1].Datatype != datatypeFP64 {
+					t.Errorf("unexpected inputs: %+v", req.Inputs)
+				}
+				var outputs []string
+				for _, o := range req.Outputs {
+					outputs = append(outputs, o.Name)
+				}
+				if !reflect.DeepEqual(outputs, tt.wantOutput) {
+					t.Errorf("expected requested outputs %v, got %v", tt.wantOutput, outputs)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer srv.Close()
+
+			cfg := manifests.ParsedConfig{"endpoint": srv.URL}
+			for k, v := range tt.config {
+				cfg[k] = v
+			}
+			got, err := serve(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Serve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got.Value, tt.want) {
+				t.Errorf("Serve() = %#v, want %#v", got.Value, tt.want)
+			}
+		})
+	}
+}
+
+// serve sends a feature set of two features to the model server with the given inference config
+func serve(cfg manifests.ParsedConfig) (api.Value, error) {
+	fd := api.FeatureDescriptor{FQN: "default.model"}
+	md := api.ModelDescriptor{Features: []string{"default.a", "default.b"}, InferenceConfig: cfg}
+	fs := map[string]api.Value{
+		"default.a": {Value: 1},
+		"default.b": {Value: 2.5},
+	}
+	return (&kserve{}).Serve(context.Background(), fd, md, api.Value{Value: fs})
+}
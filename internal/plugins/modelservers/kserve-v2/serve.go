@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kserve_v2
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/raptor-ml/raptor/api"
+	"strings"
+	"time"
+)
+
+func (*kserve) Serve(ctx context.Context, fd api.FeatureDescriptor, md api.ModelDescriptor, val api.Value) (api.Value, error) {
+	ret := api.Value{}
+
+	cfg := config{}
+	err := cfg.Parse(md.InferenceConfig)
+	if err != nil {
+		return ret, fmt.Errorf("failed to parse inference config: %w", err)
+	}
+	if cfg.ModelName == "" {
+		cfg.ModelName = strings.ReplaceAll(fd.FQN, ".", "-")
+	}
+
+	fs, ok := val.Value.(map[string]api.Value)
+	if !ok {
+		return ret, fmt.Errorf("cannot cast feature set to map[string]api.Value")
+	}
+	inputs, err := inputTensors(cfg, md, fs)
+	if err != nil {
+		return ret, fmt.Errorf("failed to build the input tensors: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	var outputs []tensor
+	id := uuid.NewString()
+	switch cfg.Protocol {
+	case protocolGRPC:
+		outputs, err = inferGRPC(ctx, cfg, id, inputs)
+	default:
+		outputs, err = inferREST(ctx, cfg, id, inputs)
+	}
+	if err != nil {
+		return ret, err
+	}
+
+	out, err := outputTensor(cfg, outputs)
+	if err != nil {
+		return ret, err
+	}
+
+	ret.Timestamp = time.Now()
+	ret.Value, err = out.value()
+	if err != nil {
+		return ret, fmt.Errorf("failed to normalize result: %w", err)
+	}
+	return ret, nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kserve_v2
+
+import (
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"reflect"
+	"time"
+)
+
+// Tensor data types of the Open Inference Protocol
+const (
+	datatypeBool  = "BOOL"
+	datatypeInt64 = "INT64"
+	datatypeFP32  = "FP32"
+	datatypeFP64  = "FP64"
+	datatypeBytes = "BYTES"
+)
+
+// tensor is the protocol-agnostic representation of an input or an output tensor.
+// Data holds the flattened elements of the tensor in row-major order.
+type tensor struct {
+	Name     string  `json:"name"`
+	Shape    []int64 `json:"shape"`
+	Datatype string  `json:"datatype"`
+	Data     []any   `json:"data"`
+}
+
+func datatypeOf(t reflect.Type) (string, error) {
+	if t == reflect.TypeOf(time.Time{}) {
+		return datatypeBytes, nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return datatypeBool, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return datatypeInt64, nil
+	case reflect.Float32:
+		return datatypeFP32, nil
+	case reflect.Float64:
+		return datatypeFP64, nil
+	case reflect.String:
+		return datatypeBytes, nil
+	default:
+		return "", fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+func element(v reflect.Value) any {
+	if ts, ok := v.Interface().(time.Time); ok {
+		return ts.Format(time.RFC3339Nano)
+	}
+	if v.CanInt() {
+		return v.Int()
+	}
+	return v.Interface()
+}
+
+// featureTensor converts the value of a feature to an input tensor with a batch of one.
+// Scalars are sent with the shape [1] and lists with the shape [1, len].
+func featureTensor(name string, v any) (tensor, error) {
+	if v == nil {
+		return tensor{}, fmt.Errorf("feature %s has no value", name)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		dt, err := datatypeOf(rv.Type())
+		if err != nil {
+			return tensor{}, fmt.Errorf("feature %s: %w", name, err)
+		}
+		return tensor{Name: name, Shape: []int64{1}, Datatype: dt, Data: []any{element(rv)}}, nil
+	}
+
+	dt, err := datatypeOf(rv.Type().Elem())
+	if err != nil {
+		return tensor{}, fmt.Errorf("feature %s: %w", name, err)
+	}
+	data := make([]any, rv.Len())
+	for i := range data {
+		data[i] = element(rv.Index(i))
+	}
+	return tensor{Name: name, Shape: []int64{1, int64(len(data))}, Datatype: dt, Data: data}, nil
+}
+
+// inputTensors converts the feature set to the input tensors of the model
+func inputTensors(cfg config, md api.ModelDescriptor, fs map[string]api.Value) ([]tensor, error) {
+	if cfg.InputName == "" {
+		ret := make([]tensor, 0, len(md.Features))
+		for _, f := range md.Features {
+			t, err := featureTensor(f, fs[f].Value)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, t)
+		}
+		return ret, nil
+	}
+
+	// all the features are packed in a single tensor
+	data := make([]any, len(md.Features))
+	for i, f := range md.Features {
+		switch v := fs[f].Value.(type) {
+		case int:
+			data[i] = float64(v)
+		case float64:
+			data[i] = v
+		case nil:
+			return nil, fmt.Errorf("feature %s has no value", f)
+		default:
+			return nil, fmt.Errorf("feature %s must be a numeric scalar to be packed in the `%s` input, got %T",
+				f, cfg.InputName, v)
+		}
+	}
+	return []tensor{{
+		Name:     cfg.InputName,
+		Shape:    []int64{1, int64(len(data))},
+		Datatype: datatypeFP64,
+		Data:     data,
+	}}, nil
+}
+
+// outputTensor returns the output tensor with the configured name, or the first output if no name was configured
+func outputTensor(cfg config, outputs []tensor) (tensor, error) {
+	if len(outputs) == 0 {
+		return tensor{}, fmt.Errorf("the inference server returned no outputs")
+	}
+	if cfg.OutputName == "" {
+		return outputs[0], nil
+	}
+	for _, o := range outputs {
+		if o.Name == cfg.OutputName {
+			return o, nil
+		}
+	}
+	return tensor{}, fmt.Errorf("output `%s` was not returned by the inference server", cfg.OutputName)
+}
+
+// value converts the output tensor to a feature value.
+// A tensor with a single element is converted to a scalar, otherwise it's flattened to a list.
+func (t tensor) value() (any, error) {
+	data := make([]any, len(t.Data))
+	for i, d := range t.Data {
+		v, err := outputElement(t.Datatype, d)
+		if err != nil {
+			return nil, fmt.Errorf("output `%s`: %w", t.Name, err)
+		}
+		data[i] = v
+	}
+	if len(data) == 1 {
+		return api.NormalizeAny(data[0])
+	}
+	return api.NormalizeAny(data)
+}
+
+func outputElement(datatype string, d any) (any, error) {
+	switch datatype {
+	case datatypeBool:
+		if v, ok := d.(bool); ok {
+			return v, nil
+		}
+	case "INT8", "INT16", "INT32", datatypeInt64, "UINT8", "UINT16", "UINT32", "UINT64":
+		switch v := d.(type) {
+		case int:
+			return v, nil
+		case int64:
+			return int(v), nil
+		case uint64:
+			return int(v), nil
+		case float64:
+			return int(v), nil
+		}
+	case "FP16", datatypeFP32, datatypeFP64:
+		switch v := d.(type) {
+		case float32:
+			return float64(v), nil
+		case float64:
+			return v, nil
+		}
+	case datatypeBytes:
+		switch v := d.(type) {
+		case string:
+			return v, nil
+		case []byte:
+			return string(v), nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported datatype %s", datatype)
+	}
+	return nil, fmt.Errorf("unexpected %T element for datatype %s", d, datatype)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kserve_v2
+
+import (
+	"github.com/raptor-ml/raptor/api"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInputTensors(t *testing.T) {
+	ts := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	md := api.ModelDescriptor{Features: []string{"default.a", "default.b"}}
+	tests := []struct {
+		name    string
+		cfg     config
+		fs      map[string]api.Value
+		want    []tensor
+		wantErr bool
+	}{
+		{
+			name: "scalars",
+			fs: map[string]api.Value{
+				"default.a": {Value: 3},
+				"default.b": {Value: 1.5},
+			},
+			want: []tensor{
+				{Name: "default.a", Shape: []int64{1}, Datatype: datatypeInt64, Data: []any{int64(3)}},
+				{Name: "default.b", Shape: []int64{1}, Datatype: datatypeFP64, Data: []any{1.5}},
+			},
+		},
+		{
+			name: "strings and timestamps",
+			fs: map[string]api.Value{
+				"default.a": {Value: "hello"},
+				"default.b": {Value: ts},
+			},
+			want: []tensor{
+				{Name: "default.a", Shape: []int64{1}, Datatype: datatypeBytes, Data: []any{"hello"}},
+				{Name: "default.b", Shape: []int64{1}, Datatype: datatypeBytes, Data: []any{"2022-01-02T03:04:05Z"}},
+			},
+		},
+		{
+			name: "lists",
+			fs: map[string]api.Value{
+				"default.a": {Value: []int{1, 2, 3}},
+				"default.b": {Value: []bool{true, false}},
+			},
+			want: []tensor{
+				{Name: "default.a", Shape: []int64{1, 3}, Datatype: datatypeInt64, Data: []any{int64(1), int64(2), int64(3)}},
+				{Name: "default.b", Shape: []int64{1, 2}, Datatype: datatypeBool, Data: []any{true, false}},
+			},
+		},
+		{
+			name:    "missing value",
+			fs:      map[string]api.Value{"default.a": {Value: 1}},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported type",
+			fs:      map[string]api.Value{"default.a": {Value: 1}, "default.b": {Value: map[string]any{}}},
+			wantErr: true,
+		},
+		{
+			name: "packed",
+			cfg:  config{InputName: "input"},
+			fs: map[string]api.Value{
+				"default.a": {Value: 3},
+				"default.b": {Value: 1.5},
+			},
+			want: []tensor{
+				{Name: "input", Shape: []int64{1, 2}, Datatype: datatypeFP64, Data: []any{3.0, 1.5}},
+			},
+		},
+		{
+			name: "packed non-numeric",
+			cfg:  config{InputName: "input"},
+			fs: map[string]api.Value{
+				"default.a": {Value: 3},
+				"default.b": {Value: "hello"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "packed missing value",
+			cfg:     config{InputName: "input"},
+			fs:      map[string]api.Value{"default.a": {Value: 3}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := inputTensors(tt.cfg, md, tt.fs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("inputTensors() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("inputTensors() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTensorValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		t       tensor
+		want    any
+		wantErr bool
+	}{
+		{name: "scalar", t: tensor{Datatype: datatypeFP32, Data: []any{float32(0.5)}}, want: 0.5},
+		{name: "integer from json", t: tensor{Datatype: "INT32", Data: []any{2.0}}, want: 2},
+		{name: "list", t: tensor{Datatype: datatypeInt64, Data: []any{int64(1), int64(2)}}, want: []int{1, 2}},
+		{name: "bytes", t: tensor{Datatype: datatypeBytes, Data: []any{[]byte("yes")}}, want: "yes"},
+		{name: "unexpected element", t: tensor{Datatype: datatypeBool, Data: []any{"true"}}, wantErr: true},
+		{name: "unsupported datatype", t: tensor{Datatype: "COMPLEX", Data: []any{1.0}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.t.value()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("value() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("value() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package local implements the historical parquet provider on top of the local filesystem.
+//
+// Files are partitioned by FQN, date and hour (UTC) of the records: `<dir>/fqn=<fqn>/timestamp=<date>/hour=<hour>/`,
+// and active window buckets are written to separate `data-alive-*` files.
+//
+// The records are written to a hidden temporary file of the partition, which is finalized on each flush of the writer,
+// since the historian acknowledges the notifications once they were flushed. Hence, a new file is created for every
+// partition on each sync of the historian, and the file size is bounded by the records that are committed in between.
+package local
+
+import (
+	"context"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/internal/plugins/providers/historical/parquet"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	localSource "github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/source"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const pluginName = "local-parquet"
+
+// datePartitionLayout is the layout of the `timestamp=` partition directories
+const datePartitionLayout = "2006-01-02"
+
+func init() {
+	plugins.Configurers.Register(pluginName, BindConfig)
+	plugins.HistoricalWriterFactories.Register(pluginName, HistoricalWriterFactory)
+	plugins.HistoricalReaderFactories.Register(pluginName, HistoricalReaderFactory)
+}
+
+func BindConfig(set *pflag.FlagSet) error {
+	set.String("local-parquet-dir", "raptor/features/", "Local directory for storing features - for historical data")
+	return nil
+}
+
+func HistoricalReaderFactory(viper *viper.Viper) (api.HistoricalReader, error) {
+	dir := viper.GetString("local-parquet-dir")
+	if dir == "" {
+		return nil, fmt.Errorf("local-parquet-dir is required")
+	}
+	if st, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("failed to check the historical data directory: %w", err)
+	} else if !st.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	return parquet.BaseReader(4, fileLister(dir)), nil
+}
+
+// fileLister lists the parquet files of the feature under `<dir>/fqn=<fqn>/`.
+// Date partitions (`timestamp=<date>`) that are out of the time range are skipped.
+func fileLister(dir string) parquet.FileLister {
+	return func(ctx context.Context, fqn string, since, until time.Time) ([]source.ParquetFile, error) {
+		base := filepath.Join(dir, fmt.Sprintf("fqn=%s", fqn))
+		if _, err := os.Stat(base); os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		var paths []string
+		err := filepath.WalkDir(base, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if !inRange(d.Name(), since, until) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			// skip files that are still being written
+			if strings.HasPrefix(d.Name(), ".") || !strings.HasSuffix(d.Name(), ".parquet") {
+				return nil
+			}
+			paths = append(paths, path)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var files []source.ParquetFile
+		for _, p := range paths {
+			pf, err := localSource.NewLocalFileReader(p)
+			if err != nil {
+				for _, f := range files {
+					_ = f.Close()
+				}
+				return nil, fmt.Errorf("cannot open %s: %w", p, err)
+			}
+			files = append(files, pf)
+		}
+		return files, nil
+	}
+}
+
+// inRange checks if a partition directory might contain records within the time range.
+// Partitions are the UTC dates of the records (see partitionOf), and a day of margin is kept on both sides.
+func inRange(partition string, since, until time.Time) bool {
+	d, ok := strings.CutPrefix(partition, "timestamp=")
+	if !ok {
+		return true
+	}
+	t, err := time.Parse(datePartitionLayout, d)
+	if err != nil {
+		return true
+	}
+	return !t.After(until.Add(24*time.Hour)) && !t.Add(48*time.Hour).Before(since)
+}
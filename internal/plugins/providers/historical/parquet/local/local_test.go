@@ -0,0 +1,239 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/internal/plugins/providers/historical/parquet"
+	"github.com/spf13/viper"
+	"github.com/xitongsys/parquet-go/reader"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newWriter(t *testing.T) *localWriter {
+	t.Helper()
+	v := viper.New()
+	v.Set("local-parquet-dir", t.TempDir())
+	w, err := HistoricalWriterFactory(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w.(*localWriter)
+}
+
+func commit(t *testing.T, w *localWriter, wn api.WriteNotification) {
+	t.Helper()
+	if err := w.Commit(context.Background(), wn); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func notification(fqn string, ts time.Time) api.WriteNotification {
+	return api.WriteNotification{
+		FQN:         fqn,
+		EncodedKeys: "1",
+		Value:       &api.Value{Value: 1.5, Timestamp: ts},
+	}
+}
+
+// files returns the paths of the files under the writer's directory, relative to it
+func files(t *testing.T, w *localWriter) []string {
+	t.Helper()
+	var paths []string
+	err := filepath.WalkDir(w.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(w.dir, path)
+		paths = append(paths, filepath.ToSlash(rel))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// countRows lists the files of the feature with the fileLister, and returns the number of files and their rows
+func countRows(t *testing.T, w *localWriter, fqn string, since, until time.Time) (int, int64) {
+	t.Helper()
+	pfs, err := fileLister(w.dir)(context.Background(), fqn, since, until)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows int64
+	for _, pf := range pfs {
+		pr, err := reader.NewParquetReader(pf, new(parquet.HistoricalRecord), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows += pr.GetNumRows()
+		pr.ReadStop()
+		_ = pf.Close()
+	}
+	return len(pfs), rows
+}
+
+func TestRotateOnFlush(t *testing.T) {
+	ctx := context.Background()
+	w := newWriter(t)
+	now := time.Now()
+	since, until := now.Add(-time.Hour), now.Add(time.Hour)
+
+	// the records of a partition are written to the same file until it's flushed
+	commit(t, w, notification("a.default", now))
+	commit(t, w, notification("a.default", now))
+	if len(w.files) != 1 {
+		t.Errorf("expected 1 open file, got %d", len(w.files))
+	}
+	if err := w.FlushAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	commit(t, w, notification("a.default", now))
+	if err := w.FlushAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n, rows := countRows(t, w, "a.default", since, until); n != 2 || rows != 3 {
+		t.Errorf("expected 2 files with 3 rows, got %d files with %d rows", n, rows)
+	}
+}
+
+func TestPartitionLayout(t *testing.T) {
+	w := newWriter(t)
+	ts := time.Date(2022, 12, 1, 5, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+
+	for _, alive := range []bool{true, false} {
+		wn := notification("w.default+sum", ts)
+		wn.Value.Value = api.BucketData{"sum": 3, "count": 2}
+		wn.Bucket = "b1"
+		wn.ActiveBucket = alive
+		commit(t, w, wn)
+	}
+	commit(t, w, notification("a.default", ts))
+	if err := w.FlushAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the partitions are in UTC, and the alive and dead buckets are written to separate files
+	want := []*regexp.Regexp{
+		regexp.MustCompile(`^fqn=a\.default/timestamp=2022-12-01/hour=03/data-\d+\.snappy\.parquet$`),
+		regexp.MustCompile(`^fqn=w\.default\+sum/timestamp=2022-12-01/hour=03/data-\d+\.snappy\.parquet$`),
+		regexp.MustCompile(`^fqn=w\.default\+sum/timestamp=2022-12-01/hour=03/data-alive-\d+\.snappy\.parquet$`),
+	}
+	got := files(t, w)
+	if len(got) != len(want) {
+		t.Fatalf("expected %d files, got %v", len(want), got)
+	}
+	for i, re := range want {
+		if !re.MatchString(got[i]) {
+			t.Errorf("expected the file %q to match %s", got[i], re)
+		}
+	}
+}
+
+func TestTmpFilesAreHidden(t *testing.T) {
+	w := newWriter(t)
+	now := time.Now()
+	since, until := now.Add(-time.Hour), now.Add(time.Hour)
+
+	commit(t, w, notification("a.default", now))
+	got := files(t, w)
+	if len(got) != 1 || !strings.HasPrefix(filepath.Base(got[0]), ".") || !strings.HasSuffix(got[0], ".tmp") {
+		t.Fatalf("expected a single tmp file, got %v", got)
+	}
+	if n, _ := countRows(t, w, "a.default", since, until); n != 0 {
+		t.Errorf("expected the tmp file not to be listed, got %d files", n)
+	}
+
+	if err := w.FlushAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n, rows := countRows(t, w, "a.default", since, until); n != 1 || rows != 1 {
+		t.Errorf("expected 1 file with 1 row after the flush, got %d files with %d rows", n, rows)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	ctx := context.Background()
+	w := newWriter(t)
+	now := time.Now()
+	for _, fqn := range []string{"a.default", "b.default", "c.default"} {
+		commit(t, w, notification(fqn, now))
+	}
+
+	// flushing a feature finalizes only its files
+	if err := w.Flush(ctx, "a.default"); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.files) != 2 {
+		t.Errorf("expected 2 open files, got %d", len(w.files))
+	}
+	if n, _ := countRows(t, w, "a.default", now.Add(-time.Hour), now.Add(time.Hour)); n != 1 {
+		t.Errorf("expected the flushed file to be listed, got %d files", n)
+	}
+
+	// closing the writer finalizes all the open files
+	if err := w.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.files) != 0 {
+		t.Errorf("expected no open files, got %d", len(w.files))
+	}
+	for _, f := range files(t, w) {
+		if strings.HasSuffix(f, ".tmp") {
+			t.Errorf("expected all the tmp files to be renamed, got %s", f)
+		}
+	}
+	for _, fqn := range []string{"b.default", "c.default"} {
+		if n, rows := countRows(t, w, fqn, now.Add(-time.Hour), now.Add(time.Hour)); n != 1 || rows != 1 {
+			t.Errorf("expected 1 file with 1 row of %s, got %d files with %d rows", fqn, n, rows)
+		}
+	}
+}
+
+func TestInRange(t *testing.T) {
+	since := time.Date(2022, 12, 10, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2022, 12, 12, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		partition string
+		want      bool
+	}{
+		{partition: "timestamp=2022-12-11", want: true},
+		{partition: "timestamp=2022-12-09", want: true},
+		{partition: "timestamp=2022-12-13", want: true},
+		{partition: "timestamp=2022-12-07", want: false},
+		{partition: "timestamp=2022-12-14", want: false},
+		{partition: "hour=05", want: true},
+		{partition: "timestamp=invalid", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.partition, func(t *testing.T) {
+			if got := inRange(tt.partition, since, until); got != tt.want {
+				t.Errorf("inRange(%q) = %v, want %v", tt.partition, got, tt.want)
+			}
+		})
+	}
+}
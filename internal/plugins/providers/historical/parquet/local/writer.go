@@ -0,0 +1,210 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"github.com/raptor-ml/raptor/internal/plugins/providers/historical/parquet"
+	"github.com/raptor-ml/raptor/internal/version"
+	"github.com/spf13/viper"
+	localSource "github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/writer"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// partition identifies the file that a record is written to
+type partition struct {
+	fqn   string
+	date  string
+	hour  int
+	alive bool
+}
+
+func partitionOf(wn api.WriteNotification) partition {
+	ts := wn.Value.Timestamp.UTC()
+	return partition{
+		fqn:   wn.FQN,
+		date:  ts.Format(datePartitionLayout),
+		hour:  ts.Hour(),
+		alive: wn.ActiveBucket,
+	}
+}
+
+// dir returns the directory of the partition: <fqn>/<date>/<hour>
+func (p partition) dir(base string) string {
+	return filepath.Join(base, fmt.Sprintf("fqn=%s", p.fqn), fmt.Sprintf("timestamp=%s", p.date), fmt.Sprintf("hour=%02d", p.hour))
+}
+
+// file is an open parquet file. It's written to a hidden temporary path, and renamed to its final path
+// once it's finalized, so readers never see partially written files.
+type file struct {
+	pw      *writer.ParquetWriter
+	tmpPath string
+	path    string
+}
+
+type localWriter struct {
+	dir string
+	np  int64
+
+	mu    sync.Mutex
+	files map[partition]*file
+}
+
+func HistoricalWriterFactory(viper *viper.Viper) (api.HistoricalWriter, error) {
+	dir := viper.GetString("local-parquet-dir")
+	if dir == "" {
+		return nil, fmt.Errorf("local-parquet-dir is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create the historical data directory: %w", err)
+	}
+
+	return &localWriter{
+		dir:   dir,
+		np:    4,
+		files: make(map[partition]*file),
+	}, nil
+}
+
+func (lw *localWriter) Commit(_ context.Context, wn api.WriteNotification) error {
+	if wn.Value == nil {
+		return fmt.Errorf("cannot commit a notification without a value")
+	}
+
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	f, err := lw.getFile(partitionOf(wn))
+	if err != nil {
+		return err
+	}
+	if err := f.pw.Write(parquet.NewHistoricalRecord(wn)); err != nil {
+		return fmt.Errorf("cannot write record: %w", err)
+	}
+	return nil
+}
+
+// getFile returns the open file of the partition, or creates a new one. It must be called while holding the lock.
+func (lw *localWriter) getFile(p partition) (*file, error) {
+	if f, ok := lw.files[p]; ok {
+		return f, nil
+	}
+
+	dir := p.dir(lw.dir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("cannot create partition directory: %w", err)
+	}
+
+	name := "data"
+	if p.alive {
+		name = "data-alive"
+	}
+	name = fmt.Sprintf("%s-%d.snappy.parquet", name, time.Now().UnixNano())
+
+	f := &file{
+		tmpPath: filepath.Join(dir, "."+name+".tmp"),
+		path:    filepath.Join(dir, name),
+	}
+	pf, err := localSource.NewLocalFileWriter(f.tmpPath)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create parquet file: %w", err)
+	}
+	f.pw, err = writer.NewParquetWriter(pf, new(parquet.HistoricalRecord), lw.np)
+	if err != nil {
+		_ = pf.Close()
+		_ = os.Remove(f.tmpPath)
+		return nil, fmt.Errorf("cannot create parquet writer: %w", err)
+	}
+	f.pw.PageSize = 1 * 1024 * 1024       // 1M
+	f.pw.RowGroupSize = 128 * 1024 * 1024 // 128M
+	createdBy := fmt.Sprintf("raptor-historian version %s", version.Version)
+	f.pw.Footer.CreatedBy = &createdBy
+
+	lw.files[p] = f
+	return f, nil
+}
+
+// finalize closes the file of the partition and moves it to its final path. It must be called while holding the lock.
+func (lw *localWriter) finalize(p partition) error {
+	f, ok := lw.files[p]
+	if !ok {
+		return nil
+	}
+	delete(lw.files, p)
+
+	if err := f.pw.WriteStop(); err != nil {
+		_ = f.pw.PFile.Close()
+		return fmt.Errorf("cannot write stop: %w", err)
+	}
+	if err := f.pw.PFile.Close(); err != nil {
+		return fmt.Errorf("cannot close parquet file: %w", err)
+	}
+	if err := os.Rename(f.tmpPath, f.path); err != nil {
+		return fmt.Errorf("cannot move parquet file to its final path: %w", err)
+	}
+	return nil
+}
+
+// Flush finalizes all the open files of the feature, so its committed records are persisted.
+func (lw *localWriter) Flush(_ context.Context, fqn string) error {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	return lw.flush(func(p partition) bool {
+		return p.fqn == fqn
+	})
+}
+
+// FlushAll finalizes all the open files, so all the committed records are persisted.
+// The notifications are acknowledged once it returns, so records must not be left in temporary files.
+func (lw *localWriter) FlushAll(context.Context) error {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	return lw.flush(func(partition) bool {
+		return true
+	})
+}
+
+// Close finalizes all the open files.
+func (lw *localWriter) Close(ctx context.Context) error {
+	return lw.FlushAll(ctx)
+}
+
+func (lw *localWriter) flush(filter func(p partition) bool) error {
+	var errs []error
+	for p := range lw.files {
+		if filter(p) {
+			if err := lw.finalize(p); err != nil {
+				errs = append(errs, fmt.Errorf("cannot flush parquet file of %s: %w", p.fqn, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (lw *localWriter) BindFeature(*api.FeatureDescriptor, *manifests.ModelSpec, api.FeatureDescriptorGetter) error {
+	return nil
+}
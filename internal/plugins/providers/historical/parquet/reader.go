@@ -0,0 +1,162 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parquet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/types"
+	"sort"
+	"strings"
+	"time"
+)
+
+// readBatchSize is the number of rows that are read from a file at once
+const readBatchSize = 10_000
+
+// FileLister lists the parquet files that may contain records of the feature within the time range.
+type FileLister func(ctx context.Context, fqn string, since, until time.Time) ([]source.ParquetFile, error)
+
+type baseReader struct {
+	listFiles FileLister
+	np        int64
+}
+
+// BaseReader creates an api.HistoricalReader for files that were written by the BaseParquet writer.
+func BaseReader(np int64, listFiles FileLister) api.HistoricalReader {
+	return &baseReader{
+		listFiles: listFiles,
+		np:        np,
+	}
+}
+
+func (br *baseReader) Read(ctx context.Context, fqn string, since, until time.Time) ([]api.HistoricalRecord, error) {
+	files, err := br.listFiles(ctx, fqn, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list parquet files: %w", err)
+	}
+
+	var ret []api.HistoricalRecord
+	for _, pf := range files {
+		recs, err := br.readFile(ctx, pf, fqn, since, until)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, recs...)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Timestamp.Before(ret[j].Timestamp)
+	})
+	return ret, nil
+}
+
+func (br *baseReader) readFile(ctx context.Context, pf source.ParquetFile, fqn string, since, until time.Time) ([]api.HistoricalRecord, error) {
+	defer pf.Close()
+
+	pr, err := reader.NewParquetReader(pf, new(HistoricalRecord), br.np)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create parquet reader: %w", err)
+	}
+	defer pr.ReadStop()
+
+	var ret []api.HistoricalRecord
+	for left := int(pr.GetNumRows()); left > 0; left -= readBatchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		rows := make([]HistoricalRecord, min(left, readBatchSize))
+		if err := pr.Read(&rows); err != nil {
+			return nil, fmt.Errorf("cannot read parquet rows: %w", err)
+		}
+		for _, hr := range rows {
+			if hr.FQN != fqn {
+				continue
+			}
+			rec := hr.ToAPI()
+			if rec.Timestamp.Before(since) || rec.Timestamp.After(until) {
+				continue
+			}
+			ret = append(ret, rec)
+		}
+	}
+	return ret, nil
+}
+
+func (br *baseReader) Close(context.Context) error {
+	return nil
+}
+
+// ToAPI converts the record back to an api.HistoricalRecord
+func (hr HistoricalRecord) ToAPI() api.HistoricalRecord {
+	rec := api.HistoricalRecord{
+		FQN:         hr.FQN,
+		EncodedKeys: hr.Keys,
+		Timestamp:   types.TIMESTAMP_MICROSToTime(hr.Timestamp, false),
+	}
+
+	if b := hr.Bucket; b != nil {
+		rec.Bucket = b.BucketName
+		rec.ActiveBucket = b.Alive != nil && *b.Alive
+		rec.Value = b.Data()
+		return rec
+	}
+
+	v := hr.Value
+	switch {
+	case v == nil:
+	case v.String != nil:
+		rec.Value = *v.String
+	case v.Int != nil:
+		rec.Value = int(*v.Int)
+	case v.Double != nil:
+		rec.Value = *v.Double
+	case v.Timestamp != nil:
+		rec.Value = types.TIMESTAMP_MICROSToTime(*v.Timestamp, false)
+	case v.StringList != nil:
+		rec.Value = *v.StringList
+	case v.IntList != nil:
+		l := make([]int, len(*v.IntList))
+		for i, n := range *v.IntList {
+			l[i] = int(n)
+		}
+		rec.Value = l
+	case v.DoubleList != nil:
+		rec.Value = *v.DoubleList
+	case v.Embedding != nil:
+		rec.Value = api.Embedding(*v.Embedding)
+	case v.TimestampList != nil:
+		l := make([]time.Time, len(*v.TimestampList))
+		for i, n := range *v.TimestampList {
+			l[i] = types.TIMESTAMP_MICROSToTime(n, false)
+		}
+		rec.Value = l
+	case v.Struct != nil:
+		// numbers are kept as json.Number, so they can be cast to the field's primitive without losing precision
+		d := json.NewDecoder(strings.NewReader(*v.Struct))
+		d.UseNumber()
+		var st api.Struct
+		if err := d.Decode(&st); err == nil {
+			rec.Value = st
+		}
+	}
+	return rec
+}
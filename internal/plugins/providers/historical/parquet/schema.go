@@ -17,6 +17,7 @@ limitations under the License.
 package parquet
 
 import (
+	"encoding/json"
 	"github.com/raptor-ml/raptor/api"
 	"github.com/xitongsys/parquet-go/types"
 	"time"
@@ -39,15 +40,109 @@ type Value struct {
 	IntList       *[]int64   `parquet:"name=int_list, type=MAP, convertedtype=LIST, valuetype=INT64"`
 	DoubleList    *[]float64 `parquet:"name=double_list, type=MAP, convertedtype=LIST, valuetype=DOUBLE"`
 	TimestampList *[]int64   `parquet:"name=timestamp_list, type=MAP, convertedtype=LIST, valuetype=INT64, valuelogicaltype=TIMESTAMP, valuelogicaltype.isadjustedtoutc=false, valuelogicaltype.unit=MICROS"`
+
+	Embedding *[]float32 `parquet:"name=embedding, type=MAP, convertedtype=LIST, valuetype=FLOAT"`
+
+	// Struct holds the fields of a struct value as a JSON object
+	Struct *string `parquet:"name=struct, type=BYTE_ARRAY, convertedtype=JSON"`
 }
 type Bucket struct {
 	BucketName string `parquet:"name=bucket_name, type=BYTE_ARRAY, convertedtype=UTF8, encoding=PLAIN"`
 	Alive      *bool  `parquet:"name=alive, type=BOOLEAN"`
 
-	Count *int64   `parquet:"name=count, type=INT64"`
-	Sum   *float64 `parquet:"name=sum, type=DOUBLE"`
-	Min   *float64 `parquet:"name=min, type=DOUBLE"`
-	Max   *float64 `parquet:"name=max, type=DOUBLE"`
+	Count   *int64   `parquet:"name=count, type=INT64"`
+	Sum     *float64 `parquet:"name=sum, type=DOUBLE"`
+	Min     *float64 `parquet:"name=min, type=DOUBLE"`
+	Max     *float64 `parquet:"name=max, type=DOUBLE"`
+	M2      *float64 `parquet:"name=m2, type=DOUBLE"`
+	M2Count *int64   `parquet:"name=m2_count, type=INT64"`
+	M2Mean  *float64 `parquet:"name=m2_mean, type=DOUBLE"`
+	First   *float64 `parquet:"name=first, type=DOUBLE"`
+	FirstTs *int64   `parquet:"name=first_ts, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=false, logicaltype.unit=MICROS"`
+	Last    *float64 `parquet:"name=last, type=DOUBLE"`
+	LastTs  *int64   `parquet:"name=last_ts, type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=false, logicaltype.unit=MICROS"`
+
+	// Sketch holds the rest of the bucket's fields, i.e. the HyperLogLog registers and the DDSketch bins.
+	Sketch map[string]float64 `parquet:"name=sketch, type=MAP, convertedtype=MAP, keytype=BYTE_ARRAY, keyconvertedtype=UTF8, valuetype=DOUBLE"`
+}
+
+// bucketColumns are the fields of the BucketData that have their own column
+var bucketColumns = map[string]bool{
+	"count": true, "sum": true, "min": true, "max": true, "m2": true, "m2_count": true, "m2_mean": true,
+	"first": true, "first_ts": true, "last": true, "last_ts": true,
+}
+
+// NewBucket converts the raw data of a window bucket to its parquet representation.
+func NewBucket(name string, alive bool, data api.BucketData) *Bucket {
+	double := func(field string) *float64 {
+		if v, ok := data[field]; ok {
+			return &v
+		}
+		return nil
+	}
+	integer := func(field string) *int64 {
+		if v, ok := data[field]; ok {
+			n := int64(v)
+			return &n
+		}
+		return nil
+	}
+
+	b := &Bucket{
+		BucketName: name,
+		Alive:      &alive,
+		Count:      integer("count"),
+		Sum:        double("sum"),
+		Min:        double("min"),
+		Max:        double("max"),
+		M2:         double("m2"),
+		M2Count:    integer("m2_count"),
+		M2Mean:     double("m2_mean"),
+		First:      double("first"),
+		FirstTs:    integer("first_ts"),
+		Last:       double("last"),
+		LastTs:     integer("last_ts"),
+	}
+	for k, v := range data {
+		if bucketColumns[k] {
+			continue
+		}
+		if b.Sketch == nil {
+			b.Sketch = make(map[string]float64)
+		}
+		b.Sketch[k] = v
+	}
+	return b
+}
+
+// Data returns the raw data of the window bucket.
+func (b *Bucket) Data() api.BucketData {
+	data := make(api.BucketData, len(b.Sketch)+len(bucketColumns))
+	for k, v := range b.Sketch {
+		data[k] = v
+	}
+	double := func(field string, v *float64) {
+		if v != nil {
+			data[field] = *v
+		}
+	}
+	integer := func(field string, v *int64) {
+		if v != nil {
+			data[field] = float64(*v)
+		}
+	}
+	integer("count", b.Count)
+	double("sum", b.Sum)
+	double("min", b.Min)
+	double("max", b.Max)
+	double("m2", b.M2)
+	integer("m2_count", b.M2Count)
+	double("m2_mean", b.M2Mean)
+	double("first", b.First)
+	integer("first_ts", b.FirstTs)
+	double("last", b.Last)
+	integer("last_ts", b.LastTs)
+	return data
 }
 
 func NewHistoricalRecord(wn api.WriteNotification) HistoricalRecord {
@@ -57,20 +152,7 @@ func NewHistoricalRecord(wn api.WriteNotification) HistoricalRecord {
 		Timestamp: types.TimeToTIMESTAMP_MICROS(wn.Value.Timestamp, false),
 	}
 	if wn.Bucket != "" {
-		wrm := api.ToLowLevelValue[api.WindowResultMap](wn.Value.Value)
-
-		count := int64(wrm[api.AggrFnCount])
-		sum := wrm[api.AggrFnSum]
-		min := wrm[api.AggrFnMin]
-		max := wrm[api.AggrFnMax]
-		hr.Bucket = &Bucket{
-			BucketName: wn.Bucket,
-			Alive:      &wn.ActiveBucket,
-			Count:      &count,
-			Sum:        &sum,
-			Min:        &min,
-			Max:        &max,
-		}
+		hr.Bucket = NewBucket(wn.Bucket, wn.ActiveBucket, api.ToLowLevelValue[api.BucketData](wn.Value.Value))
 		return hr
 	}
 	switch api.TypeDetect(wn.Value.Value) {
@@ -113,6 +195,11 @@ func NewHistoricalRecord(wn api.WriteNotification) HistoricalRecord {
 		hr.Value = &Value{
 			DoubleList: &v,
 		}
+	case api.PrimitiveTypeEmbedding:
+		v := []float32(api.ToLowLevelValue[api.Embedding](wn.Value.Value))
+		hr.Value = &Value{
+			Embedding: &v,
+		}
 	case api.PrimitiveTypeTimestampList:
 		v := api.ToLowLevelValue[[]time.Time](wn.Value.Value)
 		var l []int64
@@ -122,6 +209,13 @@ func NewHistoricalRecord(wn api.WriteNotification) HistoricalRecord {
 		hr.Value = &Value{
 			TimestampList: &l,
 		}
+	case api.PrimitiveTypeStruct:
+		// the value was already validated against the schema, so it's always serializable
+		b, _ := json.Marshal(wn.Value.Value)
+		v := string(b)
+		hr.Value = &Value{
+			Struct: &v,
+		}
 	}
 	return hr
 }
@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parquet
+
+import (
+	"github.com/raptor-ml/raptor/api"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/writer"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEmbeddingRecord(t *testing.T) {
+	ts := time.Date(2022, 12, 1, 3, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		val  any
+		want any
+	}{
+		{name: "embedding", val: api.Embedding{1, -2.5, 0.125}, want: api.Embedding{1, -2.5, 0.125}},
+		{name: "single dimension", val: api.Embedding{3}, want: api.Embedding{3}},
+		{name: "float list", val: []float64{1, 2}, want: []float64{1, 2}},
+	}
+
+	var records []HistoricalRecord
+	for _, tt := range tests {
+		hr := NewHistoricalRecord(api.WriteNotification{
+			FQN:         "emb.default",
+			EncodedKeys: "1",
+			Value:       &api.Value{Value: tt.val, Timestamp: ts},
+		})
+		if _, ok := tt.val.(api.Embedding); ok != (hr.Value.Embedding != nil) {
+			t.Errorf("%s: expected the embedding column to be set only for embeddings, got %v", tt.name, hr.Value.Embedding)
+		}
+		records = append(records, hr)
+	}
+
+	// the records are written to a parquet file and read back
+	buf := buffer.NewBufferFile()
+	pw, err := writer.NewParquetWriter(buf, new(HistoricalRecord), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, hr := range records {
+		if err := pw.Write(hr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pw.WriteStop(); err != nil {
+		t.Fatal(err)
+	}
+
+	pr, err := reader.NewParquetReader(buffer.NewBufferFileFromBytes(buf.Bytes()), new(HistoricalRecord), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.ReadStop()
+	got := make([]HistoricalRecord, pr.GetNumRows())
+	if err := pr.Read(&got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(tests) {
+		t.Fatalf("expected %d records, got %d", len(tests), len(got))
+	}
+	for i, tt := range tests {
+		rec := got[i].ToAPI()
+		if !reflect.DeepEqual(rec.Value, tt.want) {
+			t.Errorf("%s: expected %#v, got %#v", tt.name, tt.want, rec.Value)
+		}
+		if !rec.Timestamp.Equal(ts) {
+			t.Errorf("%s: expected the timestamp %s, got %s", tt.name, ts, rec.Timestamp)
+		}
+	}
+}
@@ -74,12 +74,12 @@ func (bw *baseParquet) getWriter(ctx context.Context, fqn string, alive bool) (*
 		pw.RowGroupSize = 256 * 1024 * 1024 // 256M
 		createdBy := "raptor-historian version latest"
 		pw.Footer.CreatedBy = &createdBy
-		bw.writers[fqn] = &parquetWriter{
+		bw.writers[idx] = &parquetWriter{
 			ParquetWriter: pw,
 			Mutex:         &sync.Mutex{},
 		}
 	}
-	return bw.writers[fqn], nil
+	return bw.writers[idx], nil
 }
 func (bw *baseParquet) Flush(_ context.Context, fqn string) error {
 	err := bw.flush(fqn)
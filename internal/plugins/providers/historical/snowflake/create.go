@@ -40,13 +40,13 @@ func (sw *snowflakeWriter) createTask() error {
     WAREHOUSE = '%s'
 	COMMENT = 'Remove active buckets that were finalized'
     AS
-        MERGE INTO historical AS target USING %s AS source
+        MERGE INTO %s AS target USING %s AS source
             ON target.fqn = source.fqn
                 AND target.keys = source.keys
                 AND target.bucket = source.bucket
             WHEN MATCHED AND target.bucket IS NOT NULL AND target.bucket_active = TRUE AND source.bucket_active = FALSE
                 THEN DELETE;`
-	_, err := sw.db.Exec(fmt.Sprintf(cleanupTask, featuresTable, sw.config.Get("warehouse"), featuresTable))
+	_, err := sw.db.Exec(fmt.Sprintf(cleanupTask, featuresTable, sw.config.Get("warehouse"), featuresTable, featuresTable))
 	if err != nil {
 		return fmt.Errorf("failed to create snowflake task: %w", err)
 	}
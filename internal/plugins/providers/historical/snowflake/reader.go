@@ -0,0 +1,144 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	sf "github.com/snowflakedb/gosnowflake"
+	"github.com/spf13/viper"
+	"strings"
+	"time"
+)
+
+func HistoricalReaderFactory(viper *viper.Viper) (api.HistoricalReader, error) {
+	dsn, _, err := parseURI(viper.GetString("snowflake-uri"))
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("snowflake", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snowflake connection: %w", err)
+	}
+	return &snowflakeReader{db: db}, nil
+}
+
+type snowflakeReader struct {
+	db *sql.DB
+}
+
+func (sr *snowflakeReader) Read(ctx context.Context, fqn string, since, until time.Time) ([]api.HistoricalRecord, error) {
+	const q = `SELECT fqn, keys, value, timestamp, bucket, bucket_active FROM %s
+	WHERE fqn = ? AND timestamp >= ? AND timestamp <= ?
+	ORDER BY timestamp`
+	rows, err := sr.db.QueryContext(ctx, fmt.Sprintf(q, featuresTable), fqn, sf.DataTypeTimestampLtz, since, sf.DataTypeTimestampLtz, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query snowflake: %w", err)
+	}
+	defer rows.Close()
+
+	var ret []api.HistoricalRecord
+	for rows.Next() {
+		var rec api.HistoricalRecord
+		var val string
+		var bucket sql.NullString
+		var alive sql.NullBool
+		if err := rows.Scan(&rec.FQN, &rec.EncodedKeys, &val, &rec.Timestamp, &bucket, &alive); err != nil {
+			return nil, fmt.Errorf("failed to scan snowflake row: %w", err)
+		}
+		rec.Bucket = bucket.String
+		rec.ActiveBucket = alive.Bool
+		rec.Value, err = parseValue(val, rec.Bucket != "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the value of %s: %w", rec.FQN, err)
+		}
+		ret = append(ret, rec)
+	}
+	return ret, rows.Err()
+}
+
+func (sr *snowflakeReader) Close(context.Context) error {
+	return sr.db.Close()
+}
+
+// parseValue parses the variant value as it was written by the snowflakeWriter.
+// Numbers are returned as int when possible, and float64 otherwise.
+func parseValue(raw string, bucket bool) (any, error) {
+	dec := json.NewDecoder(strings.NewReader(raw))
+	dec.UseNumber()
+
+	if bucket {
+		var data api.BucketData
+		if err := json.Unmarshal([]byte(raw), &data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return normalizeJSON(v)
+}
+
+func normalizeJSON(v any) (any, error) {
+	switch v := v.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return int(n), nil
+		}
+		return v.Float64()
+	case []any:
+		l := make([]any, len(v))
+		floats := false
+		for i, item := range v {
+			n, err := normalizeJSON(item)
+			if err != nil {
+				return nil, err
+			}
+			_, isFloat := n.(float64)
+			floats = floats || isFloat
+			l[i] = n
+		}
+		// a list of floats may contain whole numbers
+		if floats {
+			for i, item := range l {
+				if n, ok := item.(int); ok {
+					l[i] = float64(n)
+				}
+			}
+		}
+		return api.NormalizeAny(l)
+	case map[string]any:
+		st := make(api.Struct, len(v))
+		for k, item := range v {
+			n, err := normalizeJSON(item)
+			if err != nil {
+				return nil, err
+			}
+			st[k] = n
+		}
+		return st, nil
+	default:
+		return v, nil
+	}
+}
@@ -37,6 +37,7 @@ const featuresTable = "HISTORICAL_FEATURES"
 func init() {
 	plugins.Configurers.Register(pluginName, BindConfig)
 	plugins.HistoricalWriterFactories.Register(pluginName, HistoricalWriterFactory)
+	plugins.HistoricalReaderFactories.Register(pluginName, HistoricalReaderFactory)
 }
 
 func BindConfig(set *pflag.FlagSet) error {
@@ -44,22 +45,29 @@ func BindConfig(set *pflag.FlagSet) error {
 	return nil
 }
 
-func HistoricalWriterFactory(viper *viper.Viper) (api.HistoricalWriter, error) {
-	uri := viper.GetString("snowflake-uri")
+// parseURI returns the DSN of the snowflake driver, and the URI's parameters
+func parseURI(uri string) (string, url.Values, error) {
 	if !strings.HasPrefix(uri, "snowflake://") {
 		uri = "snowflake://" + uri
 	}
 	u, err := url.Parse(uri)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse snowflake uri: %w", err)
+		return "", nil, fmt.Errorf("failed to parse snowflake uri: %w", err)
 	}
 	if u.Query().Get("warehouse") == "" {
-		return nil, fmt.Errorf("warehouse is required")
+		return "", nil, fmt.Errorf("warehouse is required")
 	}
 	if u.Scheme != "snowflake" {
-		return nil, fmt.Errorf("scheme must be snowflake")
+		return "", nil, fmt.Errorf("scheme must be snowflake")
+	}
+	return strings.TrimPrefix(u.String(), "snowflake://"), u.Query(), nil
+}
+
+func HistoricalWriterFactory(viper *viper.Viper) (api.HistoricalWriter, error) {
+	dsn, config, err := parseURI(viper.GetString("snowflake-uri"))
+	if err != nil {
+		return nil, err
 	}
-	dsn := strings.TrimPrefix(u.String(), "snowflake://")
 
 	db, err := sql.Open("snowflake", dsn)
 	if err != nil {
@@ -68,7 +76,7 @@ func HistoricalWriterFactory(viper *viper.Viper) (api.HistoricalWriter, error) {
 
 	sw := &snowflakeWriter{
 		db:     db,
-		config: u.Query(),
+		config: config,
 		queryBuilder: querybuilder.New(querybuilder.Config{
 			FeaturesTable:    featuresTable,
 			SubtractDuration: subtractDuration,
@@ -89,7 +97,7 @@ type snowflakeWriter struct {
 }
 
 func (sw *snowflakeWriter) Commit(ctx context.Context, wn api.WriteNotification) error {
-	q := `INSERT INTO historical (fqn, keys, value, timestamp, bucket, bucket_active) SELECT ?, ?, to_variant(%s), ?, ?, ?`
+	q := `INSERT INTO ` + featuresTable + ` (fqn, keys, value, timestamp, bucket, bucket_active) SELECT ?, ?, to_variant(%s), ?, ?, ?`
 	var val any
 	var bucket *string
 	var alive *bool
@@ -97,12 +105,7 @@ func (sw *snowflakeWriter) Commit(ctx context.Context, wn api.WriteNotification)
 		bucket = &wn.Bucket
 		alive = &wn.ActiveBucket
 
-		wrm := api.ToLowLevelValue[api.WindowResultMap](wn.Value.Value)
-		v := make(map[string]float64)
-		for k, vv := range wrm {
-			v[k.String()] = vv
-		}
-		rawJSON, err := json.Marshal(v)
+		rawJSON, err := json.Marshal(api.ToLowLevelValue[api.BucketData](wn.Value.Value))
 		if err != nil {
 			return fmt.Errorf("failed to marshal snowflake value: %w", err)
 		}
@@ -90,7 +90,7 @@ func subtractDuration(d time.Duration, field string) string {
 	return fmt.Sprintf("DATEADD('%s', %d, %s)", unit, v, field)
 }
 func castFeature(ft api.FeatureDescriptor) string {
-	if ft.ValidWindow() {
+	if ft.ValidWindow() || ft.Primitive == api.PrimitiveTypeStruct {
 		return "OBJECT"
 	}
 	if !ft.Primitive.Scalar() {
@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/pkg/querybuilder"
+	"time"
+
+	// register the clickhouse driver
+	_ "github.com/ClickHouse/clickhouse-go/v2"
+)
+
+// clickhouse stores the values as JSON strings. The views are parameterized, and read the time range from the
+// `since` and `until` parameters, i.e. `SELECT * FROM view(since='2022-12-01 00:00:00', until='2022-12-31 00:00:00')`.
+type clickhouse struct{}
+
+func (clickhouse) driver() string { return "clickhouse" }
+
+func (clickhouse) queryBuilder(table string) querybuilder.Config {
+	return querybuilder.Config{
+		FeaturesTable:    table,
+		Dialect:          querybuilder.DialectClickHouse,
+		Since:            "{since:DateTime64(6)}",
+		Until:            "{until:DateTime64(6)}",
+		EscapeName:       func(s string) string { return fmt.Sprintf("`%s`", s) },
+		SubtractDuration: clickhouse{}.subtractDuration,
+		CastFeature:      clickhouse{}.castFeature,
+	}
+}
+
+func (clickhouse) createTable(table string) []string {
+	return []string{fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	fqn LowCardinality(String),
+	keys String,
+	value String,
+	timestamp DateTime64(6, 'UTC'),
+	bucket Nullable(String),
+	bucket_active Nullable(Bool)
+) ENGINE = MergeTree
+ORDER BY (fqn, timestamp)`, table)}
+}
+
+func (clickhouse) createView(name, _, query string) []string {
+	return []string{fmt.Sprintf("CREATE OR REPLACE VIEW %s AS %s", name, query)}
+}
+
+// insert sends the rows as a single block. The driver batches the statements of a transaction until it's committed.
+func (clickhouse) insert(ctx context.Context, db *dbsql.DB, table string, rows []row) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin batch: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, "INSERT INTO "+table+" (fqn, keys, value, timestamp, bucket, bucket_active)")
+	if err != nil {
+		return fmt.Errorf("failed to prepare batch: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, r := range rows {
+		if _, err := stmt.ExecContext(ctx, r.fqn, r.keys, r.value, r.timestamp, r.bucket, r.active); err != nil {
+			return fmt.Errorf("failed to append to batch: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+func (clickhouse) subtractDuration(d time.Duration, field string) string {
+	unit, v := durationUnit(d)
+	return fmt.Sprintf("(%s - INTERVAL %d %s)", field, v, unit)
+}
+
+func (clickhouse) castFeature(ft api.FeatureDescriptor) string {
+	if ft.ValidWindow() || !ft.Primitive.Scalar() {
+		return "JSON"
+	}
+	switch ft.Primitive {
+	case api.PrimitiveTypeString:
+		return "String"
+	case api.PrimitiveTypeInteger:
+		return "Int64"
+	case api.PrimitiveTypeFloat:
+		return "Float64"
+	case api.PrimitiveTypeBoolean:
+		return "Bool"
+	case api.PrimitiveTypeTimestamp:
+		return "DateTime64(6)"
+	}
+	return "JSON"
+}
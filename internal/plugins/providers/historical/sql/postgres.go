@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/pkg/querybuilder"
+	"strings"
+	"time"
+
+	// register the postgres driver
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// pgMaxRows is the max number of rows of a single INSERT statement. Each row has 6 parameters, and postgres is
+// limited to 65535 parameters per statement.
+const pgMaxRows = 10_000
+
+// postgres stores the values as jsonb. The views read the time range from the `raptor.since` and `raptor.until`
+// settings, i.e. `SET raptor.since = '2022-12-01'`.
+type postgres struct{}
+
+func (postgres) driver() string { return "pgx" }
+
+func (postgres) queryBuilder(table string) querybuilder.Config {
+	return querybuilder.Config{
+		FeaturesTable:    table,
+		Dialect:          querybuilder.DialectPostgres,
+		Since:            "current_setting('raptor.since')::timestamptz",
+		Until:            "current_setting('raptor.until')::timestamptz",
+		EscapeName:       querybuilder.EscapeName,
+		SubtractDuration: postgres{}.subtractDuration,
+		CastFeature:      postgres{}.castFeature,
+	}
+}
+
+func (postgres) createTable(table string) []string {
+	return []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	fqn text NOT NULL,
+	keys text NOT NULL,
+	value jsonb,
+	"timestamp" timestamptz NOT NULL,
+	bucket text,
+	bucket_active boolean
+)`, table),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_fqn_timestamp_idx ON %s (fqn, "timestamp")`,
+			strings.ReplaceAll(table, ".", "_"), table),
+	}
+}
+
+func (postgres) createView(name, comment, query string) []string {
+	// CREATE OR REPLACE VIEW can't change the columns of an existing view
+	return []string{
+		fmt.Sprintf("DROP VIEW IF EXISTS %s", name),
+		fmt.Sprintf("CREATE VIEW %s AS %s", name, query),
+		fmt.Sprintf("COMMENT ON VIEW %s IS %s", name,
+			quoteLiteral(comment+". Requires the raptor.since and raptor.until settings.")),
+	}
+}
+
+// quoteLiteral quotes a string as a SQL literal. COMMENT doesn't accept parameters, so the literal is inlined.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func (postgres) insert(ctx context.Context, db *dbsql.DB, table string, rows []row) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for len(rows) > 0 {
+		chunk := rows
+		if len(chunk) > pgMaxRows {
+			chunk = chunk[:pgMaxRows]
+		}
+		rows = rows[len(chunk):]
+
+		var q strings.Builder
+		q.WriteString(`INSERT INTO ` + table + ` (fqn, keys, value, "timestamp", bucket, bucket_active) VALUES `)
+		args := make([]any, 0, len(chunk)*6)
+		for i, r := range chunk {
+			if i > 0 {
+				q.WriteString(", ")
+			}
+			n := len(args)
+			_, _ = fmt.Fprintf(&q, "($%d, $%d, $%d::jsonb, $%d, $%d, $%d)", n+1, n+2, n+3, n+4, n+5, n+6)
+			args = append(args, r.fqn, r.keys, r.value, r.timestamp, r.bucket, r.active)
+		}
+		if _, err := tx.ExecContext(ctx, q.String(), args...); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (postgres) subtractDuration(d time.Duration, field string) string {
+	unit, v := durationUnit(d)
+	if unit == "nanosecond" {
+		// postgres intervals are limited to microseconds
+		unit, v = "microsecond", int64(d/time.Microsecond)
+	}
+	return fmt.Sprintf("(%s - INTERVAL '%d %s')", field, v, unit)
+}
+
+func (postgres) castFeature(ft api.FeatureDescriptor) string {
+	if ft.ValidWindow() || !ft.Primitive.Scalar() {
+		return "jsonb"
+	}
+	switch ft.Primitive {
+	case api.PrimitiveTypeString:
+		return "text"
+	case api.PrimitiveTypeInteger:
+		return "bigint"
+	case api.PrimitiveTypeFloat:
+		return "double precision"
+	case api.PrimitiveTypeBoolean:
+		return "boolean"
+	case api.PrimitiveTypeTimestamp:
+		return "timestamptz"
+	}
+	return "jsonb"
+}
@@ -0,0 +1,226 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sql implements a historical writer for SQL databases over database/sql.
+// Each supported database is a dialect that creates the features table, batches the inserts and flavors the
+// queries of the Features' views.
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+	"github.com/raptor-ml/raptor/pkg/querybuilder"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"sync"
+	"time"
+)
+
+const pluginName = "sql"
+
+func init() {
+	plugins.Configurers.Register(pluginName, BindConfig)
+	plugins.HistoricalWriterFactories.Register(pluginName, HistoricalWriterFactory)
+}
+
+func BindConfig(set *pflag.FlagSet) error {
+	set.String("sql-historical-dialect", "postgres", "SQL dialect of the historical database. Either `postgres` or `clickhouse`")
+	set.String("sql-historical-dsn", "", "DSN of the historical database")
+	set.String("sql-historical-table", "raptor_historical_features", "Table of the historical features")
+	set.Int("sql-historical-batch-size", 1000, "Max number of rows that are buffered per Feature before they are written")
+	return nil
+}
+
+// dialect is the flavor of a supported database
+type dialect interface {
+	// driver is the name of the database/sql driver
+	driver() string
+	// queryBuilder returns the querybuilder configuration of the features table. EscapeName must be set, as it's used
+	// for the names of the views as well.
+	queryBuilder(table string) querybuilder.Config
+	// createTable returns the statements that create the features table if it doesn't exist
+	createTable(table string) []string
+	// createView returns the statements that create or replace a view
+	createView(name, comment, query string) []string
+	// insert writes the rows to the features table as a single batch
+	insert(ctx context.Context, db *dbsql.DB, table string, rows []row) error
+}
+
+var dialects = map[string]dialect{
+	"postgres":   postgres{},
+	"clickhouse": clickhouse{},
+}
+
+// row is a record of the features table. The value is JSON encoded.
+type row struct {
+	fqn       string
+	keys      string
+	value     string
+	timestamp time.Time
+	bucket    *string
+	active    *bool
+}
+
+func newRow(wn api.WriteNotification) (row, error) {
+	r := row{
+		fqn:       wn.FQN,
+		keys:      wn.EncodedKeys,
+		timestamp: wn.Value.Timestamp,
+	}
+
+	var val any = wn.Value.Value
+	if wn.Bucket != "" {
+		bucket, active := wn.Bucket, wn.ActiveBucket
+		r.bucket = &bucket
+		r.active = &active
+		val = api.ToLowLevelValue[api.BucketData](wn.Value.Value)
+	}
+
+	rawJSON, err := json.Marshal(val)
+	if err != nil {
+		return r, fmt.Errorf("failed to marshal value: %w", err)
+	}
+	r.value = string(rawJSON)
+	return r, nil
+}
+
+func HistoricalWriterFactory(viper *viper.Viper) (api.HistoricalWriter, error) {
+	name := viper.GetString("sql-historical-dialect")
+	d, ok := dialects[name]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sql dialect `%s`", name)
+	}
+	dsn := viper.GetString("sql-historical-dsn")
+	if dsn == "" {
+		return nil, fmt.Errorf("sql-historical-dsn is required")
+	}
+	table := viper.GetString("sql-historical-table")
+	if table == "" {
+		return nil, fmt.Errorf("sql-historical-table is required")
+	}
+	batchSize := viper.GetInt("sql-historical-batch-size")
+	if batchSize < 1 {
+		return nil, fmt.Errorf("sql-historical-batch-size must be positive")
+	}
+
+	db, err := dbsql.Open(d.driver(), dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s connection: %w", name, err)
+	}
+
+	qbc := d.queryBuilder(table)
+	sw := &sqlWriter{
+		db:           db,
+		dialect:      d,
+		table:        table,
+		batchSize:    batchSize,
+		queryBuilder: querybuilder.New(qbc),
+		escapeName:   qbc.EscapeName,
+		pending:      make(map[string][]row),
+	}
+	if err := sw.createTable(context.TODO()); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize %s writer: %w", name, err)
+	}
+	return sw, nil
+}
+
+type sqlWriter struct {
+	db           *dbsql.DB
+	dialect      dialect
+	table        string
+	batchSize    int
+	queryBuilder querybuilder.QueryBuilder
+	escapeName   func(string) string
+
+	mu sync.Mutex
+	// pending holds the rows that weren't written yet per FQN
+	pending map[string][]row
+}
+
+func (sw *sqlWriter) createTable(ctx context.Context) error {
+	for _, q := range sw.dialect.createTable(sw.table) {
+		if _, err := sw.db.ExecContext(ctx, q); err != nil {
+			return fmt.Errorf("failed to create table %s: %w", sw.table, err)
+		}
+	}
+	return nil
+}
+
+// Commit buffers the notification, and writes the Feature's rows once the batch is full.
+func (sw *sqlWriter) Commit(ctx context.Context, wn api.WriteNotification) error {
+	r, err := newRow(wn)
+	if err != nil {
+		return err
+	}
+
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	batch := append(sw.pending[wn.FQN], r)
+	sw.pending[wn.FQN] = batch
+	if len(batch) < sw.batchSize {
+		return nil
+	}
+	if err := sw.flush(ctx, wn.FQN); err != nil {
+		// the failed notification is retried, so it shouldn't be written with the rest of the batch
+		sw.pending[wn.FQN] = batch[:len(batch)-1]
+		return err
+	}
+	return nil
+}
+
+func (sw *sqlWriter) Flush(ctx context.Context, fqn string) error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+	return sw.flush(ctx, fqn)
+}
+
+func (sw *sqlWriter) FlushAll(ctx context.Context) error {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	var errs []error
+	for fqn := range sw.pending {
+		if err := sw.flush(ctx, fqn); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// flush writes the pending rows of the Feature. On failure, the rows are kept to be retried by the next flush.
+func (sw *sqlWriter) flush(ctx context.Context, fqn string) error {
+	rows := sw.pending[fqn]
+	if len(rows) == 0 {
+		return nil
+	}
+	if err := sw.dialect.insert(ctx, sw.db, sw.table, rows); err != nil {
+		return fmt.Errorf("failed to write %d rows of %s: %w", len(rows), fqn, err)
+	}
+	delete(sw.pending, fqn)
+	return nil
+}
+
+func (sw *sqlWriter) Close(ctx context.Context) error {
+	err := sw.FlushAll(ctx)
+	return errors.Join(err, sw.db.Close())
+}
@@ -0,0 +1,251 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/spf13/viper"
+	"regexp"
+	"testing"
+	"time"
+)
+
+const testTable = "raptor_historical_features"
+
+var insertQuery = regexp.QuoteMeta(`INSERT INTO ` + testTable + ` (fqn, keys, value, "timestamp", bucket, bucket_active) VALUES `)
+
+func testWriter(t *testing.T, batchSize int) (*sqlWriter, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+	return &sqlWriter{
+		db:         db,
+		dialect:    postgres{},
+		table:      testTable,
+		batchSize:  batchSize,
+		escapeName: postgres{}.queryBuilder(testTable).EscapeName,
+		pending:    make(map[string][]row),
+	}, mock
+}
+
+func notification(fqn string, v any) api.WriteNotification {
+	return api.WriteNotification{
+		FQN:         fqn,
+		EncodedKeys: "1",
+		Value:       &api.Value{Value: v, Timestamp: time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func TestDurationUnit(t *testing.T) {
+	tests := []struct {
+		d        time.Duration
+		wantUnit string
+		wantV    int64
+	}{
+		{d: 2 * time.Hour, wantUnit: "hour", wantV: 2},
+		{d: 90 * time.Minute, wantUnit: "minute", wantV: 90},
+		{d: 90 * time.Second, wantUnit: "second", wantV: 90},
+		{d: 1500 * time.Millisecond, wantUnit: "millisecond", wantV: 1500},
+		{d: 1500 * time.Microsecond, wantUnit: "microsecond", wantV: 1500},
+		{d: 1500 * time.Nanosecond, wantUnit: "nanosecond", wantV: 1500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			unit, v := durationUnit(tt.d)
+			if unit != tt.wantUnit || v != tt.wantV {
+				t.Errorf("durationUnit(%s) = %d %s, want %d %s", tt.d, v, unit, tt.wantV, tt.wantUnit)
+			}
+		})
+	}
+}
+
+func TestSubtractDuration(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "postgres", got: postgres{}.subtractDuration(time.Hour, "ts"), want: "(ts - INTERVAL '1 hour')"},
+		{name: "postgres nanoseconds", got: postgres{}.subtractDuration(2000*time.Nanosecond+1, "ts"), want: "(ts - INTERVAL '2 microsecond')"},
+		{name: "clickhouse", got: clickhouse{}.subtractDuration(time.Hour, "ts"), want: "(ts - INTERVAL 1 hour)"},
+		{name: "clickhouse nanoseconds", got: clickhouse{}.subtractDuration(1500*time.Nanosecond, "ts"), want: "(ts - INTERVAL 1500 nanosecond)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("subtractDuration() = %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostgresCreateView(t *testing.T) {
+	stmts := postgres{}.createView(`"o'brien.default"`, "o'brien.default Feature", "SELECT 1")
+	want := []string{
+		`DROP VIEW IF EXISTS "o'brien.default"`,
+		`CREATE VIEW "o'brien.default" AS SELECT 1`,
+		`COMMENT ON VIEW "o'brien.default" IS 'o''brien.default Feature. Requires the raptor.since and raptor.until settings.'`,
+	}
+	if len(stmts) != len(want) {
+		t.Fatalf("createView() = %q, want %q", stmts, want)
+	}
+	for i := range want {
+		if stmts[i] != want[i] {
+			t.Errorf("createView()[%d] = %q, want %q", i, stmts[i], want[i])
+		}
+	}
+}
+
+func TestNewRow(t *testing.T) {
+	r, err := newRow(notification("a.default", 1.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.fqn != "a.default" || r.keys != "1" || r.value != "1.5" || r.bucket != nil || r.active != nil {
+		t.Errorf("unexpected row %+v", r)
+	}
+
+	wn := notification("w.default+sum", api.BucketData{"sum": 3})
+	wn.Bucket = "b1"
+	wn.ActiveBucket = true
+	r, err = newRow(wn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.value != `{"sum":3}` || r.bucket == nil || *r.bucket != "b1" || r.active == nil || !*r.active {
+		t.Errorf("unexpected bucket row %+v", r)
+	}
+
+	if _, err := newRow(notification("a.default", func() {})); err == nil {
+		t.Error("expected an error for a value that can't be marshaled")
+	}
+}
+
+func TestCommit(t *testing.T) {
+	ctx := context.Background()
+	sw, mock := testWriter(t, 2)
+
+	// the rows are buffered until the batch is full
+	if err := sw.Commit(ctx, notification("a.default", 1)); err != nil {
+		t.Fatal(err)
+	}
+	mock.ExpectBegin()
+	mock.ExpectExec(insertQuery).WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+	if err := sw.Commit(ctx, notification("a.default", 2)); err == nil {
+		t.Fatal("expected an error")
+	}
+	// the failed notification is retried by the caller, so only the previous row is kept
+	if n := len(sw.pending["a.default"]); n != 1 {
+		t.Fatalf("expected 1 pending row, got %d", n)
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(insertQuery).
+		WithArgs("a.default", "1", "1", sqlmock.AnyArg(), nil, nil, "a.default", "1", "2", sqlmock.AnyArg(), nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	if err := sw.Commit(ctx, notification("a.default", 2)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := sw.pending["a.default"]; ok {
+		t.Error("expected the written rows to be removed")
+	}
+}
+
+func TestFlushAll(t *testing.T) {
+	ctx := context.Background()
+	sw, mock := testWriter(t, 10)
+	for _, fqn := range []string{"a.default", "b.default"} {
+		if err := sw.Commit(ctx, notification(fqn, 1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// flushing a Feature without pending rows is a no-op
+	if err := sw.Flush(ctx, "c.default"); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.MatchExpectationsInOrder(false)
+	for i := 0; i < 2; i++ {
+		mock.ExpectBegin()
+		mock.ExpectExec(insertQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+	mock.ExpectClose()
+	if err := sw.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(sw.pending) != 0 {
+		t.Errorf("expected no pending rows, got %d Features", len(sw.pending))
+	}
+}
+
+func TestPostgresInsertChunks(t *testing.T) {
+	sw, mock := testWriter(t, 1)
+	rows := make([]row, pgMaxRows+1)
+	for i := range rows {
+		rows[i] = row{fqn: "a.default", keys: "1", value: "1"}
+	}
+
+	// postgres limits the parameters of a statement, so the rows are inserted in chunks of a single transaction
+	mock.ExpectBegin()
+	mock.ExpectExec(insertQuery).WillReturnResult(sqlmock.NewResult(0, pgMaxRows))
+	mock.ExpectExec(insertQuery).WithArgs("a.default", "1", "1", sqlmock.AnyArg(), nil, nil).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	if err := sw.dialect.insert(context.Background(), sw.db, sw.table, rows); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHistoricalWriterFactoryConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		dialect   string
+		dsn       string
+		table     string
+		batchSize int
+	}{
+		{name: "unsupported dialect", dialect: "oracle", dsn: "oracle://localhost", table: testTable, batchSize: 1},
+		{name: "no DSN", dialect: "postgres", table: testTable, batchSize: 1},
+		{name: "no table", dialect: "postgres", dsn: "postgres://localhost/raptor", batchSize: 1},
+		{name: "no batch size", dialect: "clickhouse", dsn: "clickhouse://localhost", table: testTable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := viper.New()
+			v.Set("sql-historical-dialect", tt.dialect)
+			v.Set("sql-historical-dsn", tt.dsn)
+			v.Set("sql-historical-table", tt.table)
+			v.Set("sql-historical-batch-size", tt.batchSize)
+			if _, err := HistoricalWriterFactory(v); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
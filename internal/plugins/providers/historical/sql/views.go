@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	manifests "github.com/raptor-ml/raptor/api/v1alpha1"
+	"time"
+)
+
+func (sw *sqlWriter) BindFeature(fd *api.FeatureDescriptor, model *manifests.ModelSpec, getter api.FeatureDescriptorGetter) error {
+	var query string
+	var typ string
+	if fd.Builder == api.ModelBuilder {
+		typ = "Model"
+		if model == nil {
+			return fmt.Errorf("model is nil")
+		}
+		q, err := sw.queryBuilder.FeatureSet(context.TODO(), *model, getter)
+		if err != nil {
+			return fmt.Errorf("failed to build Model query: %w", err)
+		}
+		query = q
+	} else {
+		typ = "Feature"
+		q, err := sw.queryBuilder.Feature(*fd)
+		if err != nil {
+			return fmt.Errorf("failed to build Feature query: %w", err)
+		}
+		query = q
+	}
+
+	name := sw.escapeName(fd.FQN)
+	comment := fmt.Sprintf("%s %s", fd.FQN, typ)
+	for _, q := range sw.dialect.createView(name, comment, query) {
+		if _, err := sw.db.ExecContext(context.TODO(), q); err != nil {
+			return fmt.Errorf("failed to create %s view for %s: %w", typ, fd.FQN, err)
+		}
+	}
+	return nil
+}
+
+// durationUnit returns the biggest unit that represents the duration without a remainder
+func durationUnit(d time.Duration) (string, int64) {
+	switch {
+	case d%time.Hour == 0:
+		return "hour", int64(d / time.Hour)
+	case d%time.Minute == 0:
+		return "minute", int64(d / time.Minute)
+	case d%time.Second == 0:
+		return "second", int64(d / time.Second)
+	case d%time.Millisecond == 0:
+		return "millisecond", int64(d / time.Millisecond)
+	case d%time.Microsecond == 0:
+		return "microsecond", int64(d / time.Microsecond)
+	default:
+		return "nanosecond", int64(d / time.Nanosecond)
+	}
+}
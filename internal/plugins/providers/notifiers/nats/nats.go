@@ -0,0 +1,412 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package nats implements an api.Notifier on top of NATS JetStream.
+//
+// Collect and write notifications are consumed by durable consumers with explicit acks, so notifications that were
+// sent while the historian is down (or that it failed to handle) are redelivered (see api.AckNotifier).
+// Notifications that were delivered too many times are moved to a dead-letter subject.
+// Watch notifications are fanned out to all the subscribers with ephemeral ordered consumers.
+package nats
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"sync"
+	"time"
+)
+
+const pluginName = "nats"
+
+func init() {
+	plugins.Configurers.Register(pluginName, BindConfig)
+	plugins.CollectNotifierFactories.Register(pluginName, NotifierFactory[api.CollectNotification])
+	plugins.WriteNotifierFactories.Register(pluginName, NotifierFactory[api.WriteNotification])
+	plugins.WatchNotifierFactories.Register(pluginName, NotifierFactory[api.WatchNotification])
+}
+
+const (
+	// retryInterval is the initial interval between retries of failed subscriptions
+	retryInterval = time.Second
+	// maxRetryInterval is the maximal interval between retries of failed subscriptions
+	maxRetryInterval = 30 * time.Second
+)
+
+// Headers of the dead-letter messages
+const (
+	headerSubject = "Raptor-Subject"
+	headerError   = "Raptor-Error"
+)
+
+func connect(viper *viper.Viper) (*nats.Conn, error) {
+	opts := []nats.Option{
+		nats.Name("raptor"),
+		nats.MaxReconnects(-1),
+	}
+
+	if creds := viper.GetString("nats-creds"); creds != "" {
+		opts = append(opts, nats.UserCredentials(creds))
+	}
+	if seed := viper.GetString("nats-nkey"); seed != "" {
+		opt, err := nats.NkeyOptionFromSeed(seed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load nkey seed: %w", err)
+		}
+		opts = append(opts, opt)
+	}
+	if user := viper.GetString("nats-user"); user != "" {
+		opts = append(opts, nats.UserInfo(user, viper.GetString("nats-pass")))
+	}
+	if token := viper.GetString("nats-token"); token != "" {
+		opts = append(opts, nats.Token(token))
+	}
+
+	if viper.GetBool("nats-tls") {
+		opts = append(opts, nats.Secure(&tls.Config{
+			MinVersion: tls.VersionTLS12,
+		}))
+	}
+	if ca := viper.GetString("nats-tls-ca"); ca != "" {
+		opts = append(opts, nats.RootCAs(ca))
+	}
+	if cert := viper.GetString("nats-tls-cert"); cert != "" {
+		opts = append(opts, nats.ClientCert(cert, viper.GetString("nats-tls-key")))
+	}
+
+	return nats.Connect(viper.GetString("nats-url"), opts...)
+}
+
+func NotifierFactory[T api.Notification](viper *viper.Viper) (api.Notifier[T], error) {
+	nc, err := connect(viper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+	js, err := jetstream.New(nc)
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("failed to create jetstream context: %w", err)
+	}
+
+	n := &notifier[T]{
+		nc:                nc,
+		js:                js,
+		stream:            viper.GetString("nats-stream"),
+		durable:           viper.GetString("nats-durable"),
+		deadLetterSubject: viper.GetString("nats-dead-letter-subject"),
+		ackWait:           viper.GetDuration("nats-ack-wait"),
+		maxDeliver:        viper.GetInt("nats-max-deliver"),
+		pending:           make(map[T][]jetstream.Msg),
+		inFlight:          make(map[uint64]struct{}),
+	}
+	if n.ackWait <= 0 {
+		nc.Close()
+		return nil, fmt.Errorf("nats: ack wait must be positive, got %s", n.ackWait)
+	}
+	if n.maxDeliver < 1 {
+		nc.Close()
+		return nil, fmt.Errorf("nats: max deliver must be at least 1, got %d", n.maxDeliver)
+	}
+	var t T
+	switch any(t).(type) {
+	case api.CollectNotification:
+		n.subject = viper.GetString("nats-collect-subject")
+	case api.WriteNotification:
+		n.subject = viper.GetString("nats-write-subject")
+	case api.WatchNotification:
+		n.subject = viper.GetString("nats-watch-subject")
+	}
+
+	// all the notifications are kept in the same stream, and the subscribers filter them by their subject
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name: n.stream,
+		Subjects: []string{
+			viper.GetString("nats-collect-subject"),
+			viper.GetString("nats-write-subject"),
+			viper.GetString("nats-watch-subject"),
+			viper.GetString("nats-dead-letter-subject"),
+		},
+		MaxAge: viper.GetDuration("nats-max-age"),
+	})
+	if err != nil {
+		nc.Close()
+		return nil, fmt.Errorf("failed to create jetstream stream %s: %w", n.stream, err)
+	}
+	return n, nil
+}
+
+type notifier[T api.Notification] struct {
+	nc                *nats.Conn
+	js                jetstream.JetStream
+	stream            string
+	subject           string
+	durable           string
+	deadLetterSubject string
+	ackWait           time.Duration
+	maxDeliver        int
+
+	mu sync.Mutex
+	// pending are the delivered messages of each notification, that weren't acknowledged yet.
+	// Identical notifications may be handled only once by the subscriber, so they are acknowledged together.
+	pending map[T][]jetstream.Msg
+	// inFlight are the stream sequences of the delivered messages that weren't acknowledged yet
+	inFlight map[uint64]struct{}
+}
+
+// Close flushes the pending messages and closes the connection to NATS.
+func (n *notifier[T]) Close() error {
+	n.nc.Close()
+	return nil
+}
+
+// broadcast returns true if every subscriber should get all the notifications
+func (n *notifier[T]) broadcast() bool {
+	var t T
+	_, ok := any(t).(api.WatchNotification)
+	return ok
+}
+
+func (n *notifier[T]) Notify(ctx context.Context, notification T) error {
+	msg, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("cannot marshal notification: %w", err)
+	}
+	if _, err := n.js.Publish(ctx, n.subject, msg); err != nil {
+		return fmt.Errorf("cannot publish notification: %w", err)
+	}
+	return nil
+}
+
+func (n *notifier[T]) Ack(_ context.Context, notification T) error {
+	n.mu.Lock()
+	msgs := n.pending[notification]
+	delete(n.pending, notification)
+	n.mu.Unlock()
+
+	var errs []error
+	for _, msg := range msgs {
+		errs = append(errs, msg.Ack())
+		if md, err := msg.Metadata(); err == nil {
+			n.mu.Lock()
+			delete(n.inFlight, md.Sequence.Stream)
+			n.mu.Unlock()
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("cannot acknowledge notification: %w", err)
+	}
+	return nil
+}
+
+// consumer returns the consumer of the subscription: a durable one with explicit acks, or an ephemeral ordered one
+// for broadcasts.
+func (n *notifier[T]) consumer(ctx context.Context) (jetstream.Consumer, error) {
+	if n.broadcast() {
+		return n.js.OrderedConsumer(ctx, n.stream, jetstream.OrderedConsumerConfig{
+			FilterSubjects: []string{n.subject},
+			DeliverPolicy:  jetstream.DeliverNewPolicy,
+		})
+	}
+	return n.js.CreateOrUpdateConsumer(ctx, n.stream, jetstream.ConsumerConfig{
+		Durable:       fmt.Sprintf("%s-%s", n.durable, subjectToken(n.subject)),
+		FilterSubject: n.subject,
+		DeliverPolicy: jetstream.DeliverAllPolicy,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       n.ackWait,
+		// the deliveries are limited by the subscriber, that moves the notification to the dead-letter subject
+		// (see receive)
+		MaxDeliver: -1,
+	})
+}
+
+// Subscribe delivers the notifications until the context is done. Failed subscriptions are retried with a backoff.
+func (n *notifier[T]) Subscribe(ctx context.Context) (<-chan T, error) {
+	c := make(chan T)
+	go func() {
+		defer close(c)
+		if !n.broadcast() {
+			go n.keepAlive(ctx)
+		}
+
+		interval := retryInterval
+		for {
+			if n.consume(ctx, c) {
+				interval = retryInterval
+			}
+			if n.nc.IsClosed() {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(interval):
+			}
+			interval = min(interval*2, maxRetryInterval)
+		}
+	}()
+	return c, nil
+}
+
+// consume delivers the messages of the consumer to the subscriber, until the context is done or the consumer failed.
+// It returns true if any notification was delivered.
+func (n *notifier[T]) consume(ctx context.Context, c chan<- T) bool {
+	cons, err := n.consumer(ctx)
+	if err != nil {
+		return false
+	}
+	it, err := cons.Messages()
+	if err != nil {
+		return false
+	}
+	defer it.Stop()
+	stop := context.AfterFunc(ctx, it.Stop)
+	defer stop()
+
+	delivered := false
+	for {
+		msg, err := it.Next()
+		if err != nil {
+			return delivered
+		}
+		notification, ok := n.receive(ctx, msg)
+		if !ok {
+			continue
+		}
+		select {
+		case c <- notification:
+			delivered = true
+		case <-ctx.Done():
+			return delivered
+		}
+	}
+}
+
+// keepAlive periodically resets the ack wait of the messages that are still handled by the subscriber (i.e. retried),
+// so they are redelivered only if the subscriber stopped handling them (i.e. crashed).
+func (n *notifier[T]) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(n.ackWait / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		n.mu.Lock()
+		var msgs []jetstream.Msg
+		for _, m := range n.pending {
+			msgs = append(msgs, m...)
+		}
+		n.mu.Unlock()
+		for _, msg := range msgs {
+			_ = msg.InProgress()
+		}
+	}
+}
+
+// receive decodes the message, and keeps it until it's acknowledged.
+// It returns false if the message shouldn't be delivered to the subscriber.
+func (n *notifier[T]) receive(ctx context.Context, msg jetstream.Msg) (T, bool) {
+	var notification T
+	if err := json.Unmarshal(msg.Data(), &notification); err != nil {
+		// malformed notifications are never redelivered
+		_ = msg.Term()
+		return notification, false
+	}
+	if n.broadcast() {
+		return notification, true
+	}
+
+	md, err := msg.Metadata()
+	if err != nil {
+		_ = msg.Term()
+		return notification, false
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if _, ok := n.inFlight[md.Sequence.Stream]; ok {
+		// the subscriber is still handling it
+		_ = msg.InProgress()
+		return notification, false
+	}
+	if md.NumDelivered > uint64(n.maxDeliver) {
+		n.deadLetter(ctx, msg, fmt.Errorf("reached the maximal number of deliveries (%d)", n.maxDeliver))
+		return notification, false
+	}
+	n.inFlight[md.Sequence.Stream] = struct{}{}
+	n.pending[notification] = append(n.pending[notification], msg)
+	return notification, true
+}
+
+// deadLetter moves the message to the dead-letter subject, along with the reason.
+// If it fails to be published, the message is redelivered after the ack wait, so it can be retried.
+func (n *notifier[T]) deadLetter(ctx context.Context, msg jetstream.Msg, reason error) {
+	dl := nats.NewMsg(n.deadLetterSubject)
+	dl.Data = msg.Data()
+	dl.Header.Set(headerSubject, msg.Subject())
+	dl.Header.Set(headerError, reason.Error())
+	if _, err := n.js.PublishMsg(ctx, dl); err != nil {
+		_ = msg.NakWithDelay(n.ackWait)
+		return
+	}
+	_ = msg.Term()
+}
+
+// subjectToken converts the subject to a token that can be used in a consumer name
+func subjectToken(subject string) string {
+	b := []byte(subject)
+	for i, c := range b {
+		switch c {
+		case '.', '*', '>', ' ':
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+func BindConfig(set *pflag.FlagSet) error {
+	set.String("nats-url", nats.DefaultURL, "NATS server URLs (comma separated)")
+	set.String("nats-creds", "", "NATS user credentials file")
+	set.String("nats-nkey", "", "NATS NKey seed file")
+	set.String("nats-user", "", "NATS username")
+	set.String("nats-pass", "", "NATS password")
+	set.String("nats-token", "", "NATS authentication token")
+	set.Bool("nats-tls", false, "Enable TLS for NATS")
+	set.String("nats-tls-ca", "", "NATS TLS root CA file")
+	set.String("nats-tls-cert", "", "NATS TLS client certificate file")
+	set.String("nats-tls-key", "", "NATS TLS client key file")
+	set.String("nats-stream", "RAPTOR_NOTIFICATIONS", "NATS JetStream stream of the notifications")
+	set.String("nats-collect-subject", "raptor.notifications.collect", "NATS subject of the collect notifications")
+	set.String("nats-write-subject", "raptor.notifications.write", "NATS subject of the write notifications")
+	set.String("nats-watch-subject", "raptor.notifications.watch", "NATS subject of the watch notifications")
+	set.String("nats-dead-letter-subject", "raptor.notifications.dead", "NATS subject of the notifications that were delivered too many times")
+	set.String("nats-durable", "raptor-historian", "Prefix of the NATS JetStream durable consumers")
+	set.Duration("nats-ack-wait", time.Minute, "Duration after which unacknowledged notifications are redelivered")
+	set.Int("nats-max-deliver", 5, "Number of deliveries after which a notification is moved to the dead-letter subject")
+	set.Duration("nats-max-age", 24*time.Hour, "Maximal age of the notifications in the NATS JetStream stream")
+	return nil
+}
@@ -0,0 +1,346 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nats
+
+import (
+	"context"
+	"fmt"
+	"github.com/nats-io/nats.go"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"os"
+	"testing"
+	"time"
+)
+
+// urlEnv is the environment variable of the URL of the JetStream enabled server that the tests run against.
+// The tests that require a server are skipped when it's not set.
+const urlEnv = "NATS_URL"
+
+const testTimeout = 5 * time.Second
+
+func requireURL(t *testing.T) string {
+	t.Helper()
+	url := os.Getenv(urlEnv)
+	if url == "" {
+		t.Skipf("%s is not set", urlEnv)
+	}
+	return url
+}
+
+// testConfig returns the configuration of a new stream, that is deleted when the test is done.
+// The stream and its subjects are unique, so tests can't interfere with each other.
+func testConfig(t *testing.T) *viper.Viper {
+	t.Helper()
+	url := requireURL(t)
+
+	set := pflag.NewFlagSet(t.Name(), pflag.ContinueOnError)
+	if err := BindConfig(set); err != nil {
+		t.Fatal(err)
+	}
+	v := viper.New()
+	if err := v.BindPFlags(set); err != nil {
+		t.Fatal(err)
+	}
+
+	id := time.Now().UnixNano()
+	v.Set("nats-url", url)
+	v.Set("nats-stream", fmt.Sprintf("RAPTOR_TEST_%d", id))
+	v.Set("nats-collect-subject", fmt.Sprintf("raptor_test_%d.collect", id))
+	v.Set("nats-write-subject", fmt.Sprintf("raptor_test_%d.write", id))
+	v.Set("nats-watch-subject", fmt.Sprintf("raptor_test_%d.watch", id))
+	v.Set("nats-dead-letter-subject", fmt.Sprintf("raptor_test_%d.dead", id))
+	v.Set("nats-ack-wait", 500*time.Millisecond)
+
+	t.Cleanup(func() {
+		nc, err := nats.Connect(url)
+		if err != nil {
+			return
+		}
+		defer nc.Close()
+		if js, err := nc.JetStream(); err == nil {
+			_ = js.DeleteStream(v.GetString("nats-stream"))
+		}
+	})
+	return v
+}
+
+func newNotifier[T api.Notification](t *testing.T, v *viper.Viper) *notifier[T] {
+	t.Helper()
+	n, err := NotifierFactory[T](v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = n.(*notifier[T]).Close() })
+	return n.(*notifier[T])
+}
+
+func subscribe[T api.Notification](t *testing.T, n *notifier[T]) (<-chan T, context.CancelFunc) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	c, err := n.Subscribe(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, cancel
+}
+
+func receive[T api.Notification](t *testing.T, c <-chan T) T {
+	t.Helper()
+	select {
+	case n, ok := <-c:
+		if !ok {
+			t.Fatal("the subscription was closed")
+		}
+		return n
+	case <-time.After(testTimeout):
+		t.Fatal("expected a notification")
+	}
+	panic("unreachable")
+}
+
+func expectNone[T api.Notification](t *testing.T, c <-chan T, d time.Duration) {
+	t.Helper()
+	select {
+	case n := <-c:
+		t.Fatalf("expected no notification, got %+v", n)
+	case <-time.After(d):
+	}
+}
+
+func notify[T api.Notification](t *testing.T, n *notifier[T], notifications ...T) {
+	t.Helper()
+	for _, notification := range notifications {
+		if err := n.Notify(context.Background(), notification); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSubjectToken(t *testing.T) {
+	tests := []struct {
+		subject string
+		want    string
+	}{
+		{subject: "raptor.notifications.write", want: "raptor_notifications_write"},
+		{subject: "raptor.*.collect", want: "raptor___collect"},
+		{subject: "raptor.>", want: "raptor__"},
+		{subject: "raptor", want: "raptor"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.subject, func(t *testing.T) {
+			if got := subjectToken(tt.subject); got != tt.want {
+				t.Errorf("subjectToken(%q) = %q, want %q", tt.subject, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotifierFactoryConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		ackWait    time.Duration
+		maxDeliver int
+	}{
+		{name: "no ack wait", maxDeliver: 5},
+		{name: "negative ack wait", ackWait: -time.Second, maxDeliver: 5},
+		{name: "no max deliver", ackWait: time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := testConfig(t)
+			v.Set("nats-ack-wait", tt.ackWait)
+			v.Set("nats-max-deliver", tt.maxDeliver)
+			if _, err := NotifierFactory[api.CollectNotification](v); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestRedeliverAfterRestart(t *testing.T) {
+	v := testConfig(t)
+	n := newNotifier[api.WriteNotification](t, v)
+	c, cancel := subscribe(t, n)
+
+	notification := api.WriteNotification{FQN: "a.default", EncodedKeys: "1"}
+	notify(t, n, notification)
+	if got := receive(t, c); got != notification {
+		t.Fatalf("expected %+v, got %+v", notification, got)
+	}
+	// the subscriber restarts before it acknowledged the notification
+	cancel()
+
+	restarted := newNotifier[api.WriteNotification](t, v)
+	c, _ = subscribe(t, restarted)
+	if got := receive(t, c); got != notification {
+		t.Errorf("expected the unacknowledged notification to be redelivered, got %+v", got)
+	}
+	if err := restarted.Ack(context.Background(), notification); err != nil {
+		t.Fatal(err)
+	}
+	// the acknowledged notification isn't redelivered after the ack wait
+	expectNone(t, c, 2*v.GetDuration("nats-ack-wait"))
+}
+
+func TestKeepAlive(t *testing.T) {
+	v := testConfig(t)
+	a := newNotifier[api.CollectNotification](t, v)
+	b := newNotifier[api.CollectNotification](t, v)
+	ca, _ := subscribe(t, a)
+	cb, _ := subscribe(t, b)
+
+	// identical notifications are acknowledged together
+	notification := api.CollectNotification{FQN: "a.default", EncodedKeys: "1"}
+	notify(t, a, notification, notification)
+	got := map[*notifier[api.CollectNotification]]int{}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ca:
+			got[a]++
+		case <-cb:
+			got[b]++
+		case <-time.After(testTimeout):
+			t.Fatalf("expected 2 notifications, got %d", i)
+		}
+	}
+
+	// the notifications are still handled, so they're kept alive instead of being redelivered to the other subscriber
+	expectNone(t, ca, 3*v.GetDuration("nats-ack-wait"))
+	expectNone(t, cb, 0)
+	for n := range got {
+		if err := n.Ack(context.Background(), notification); err != nil {
+			t.Fatal(err)
+		}
+		n.mu.Lock()
+		if len(n.pending) != 0 || len(n.inFlight) != 0 {
+			t.Errorf("expected no pending notifications, got %d pending and %d in flight", len(n.pending), len(n.inFlight))
+		}
+		n.mu.Unlock()
+	}
+	expectNone(t, ca, 2*v.GetDuration("nats-ack-wait"))
+	expectNone(t, cb, 0)
+}
+
+func TestDeadLetter(t *testing.T) {
+	v := testConfig(t)
+	v.Set("nats-max-deliver", 1)
+
+	nc, err := nats.Connect(v.GetString("nats-url"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(nc.Close)
+	dead, err := nc.SubscribeSync(v.GetString("nats-dead-letter-subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crashed := newNotifier[api.CollectNotification](t, v)
+	c, cancel := subscribe(t, crashed)
+
+	// malformed notifications are skipped
+	if _, err := crashed.js.Publish(context.Background(), crashed.subject, []byte("{")); err != nil {
+		t.Fatal(err)
+	}
+	notification := api.CollectNotification{FQN: "a.default", EncodedKeys: "1"}
+	notify(t, crashed, notification)
+	if got := receive(t, c); got != notification {
+		t.Fatalf("expected the valid notification, got %+v", got)
+	}
+	cancel()
+
+	// the notification was already delivered as many times as allowed, so it's dead-lettered instead of redelivered
+	n := newNotifier[api.CollectNotification](t, v)
+	c, _ = subscribe(t, n)
+	msg, err := dead.NextMsg(testTimeout)
+	if err != nil {
+		t.Fatalf("expected the notification to be dead-lettered: %v", err)
+	}
+	if msg.Header.Get(headerSubject) != n.subject || msg.Header.Get(headerError) == "" {
+		t.Errorf("expected the dead-letter headers, got %v", msg.Header)
+	}
+	if _, err := dead.NextMsg(100 * time.Millisecond); err == nil {
+		t.Error("expected only the notification that was delivered too many times to be dead-lettered")
+	}
+	expectNone(t, c, 2*v.GetDuration("nats-ack-wait"))
+}
+
+func TestWatchFanOut(t *testing.T) {
+	v := testConfig(t)
+	n := newNotifier[api.WatchNotification](t, v)
+	subscribers := []<-chan api.WatchNotification{}
+	for i := 0; i < 2; i++ {
+		c, _ := subscribe(t, newNotifier[api.WatchNotification](t, v))
+		subscribers = append(subscribers, c)
+	}
+
+	// the subscribers only get the notifications that were sent after they started consuming, so a warmup
+	// notification is sent until all of them got it
+	warmup := api.WatchNotification{FQN: "warmup.default", EncodedKeys: "1"}
+	for _, c := range subscribers {
+		deadline := time.Now().Add(testTimeout)
+	warm:
+		for {
+			notify(t, n, warmup)
+			select {
+			case <-c:
+				break warm
+			case <-time.After(50 * time.Millisecond):
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("expected the subscriber to start consuming")
+			}
+		}
+	}
+
+	// every subscriber gets every notification
+	want := api.WatchNotification{FQN: "a.default", EncodedKeys: "1"}
+	notify(t, n, want)
+	for i, c := range subscribers {
+		for {
+			if got := receive(t, c); got == want {
+				break
+			} else if got != warmup {
+				t.Fatalf("subscriber %d: unexpected notification %+v", i, got)
+			}
+		}
+	}
+
+	// broadcasts aren't acknowledged
+	if err := n.Ack(context.Background(), want); err != nil {
+		t.Errorf("Ack() error = %v", err)
+	}
+}
+
+func TestSubscribeClosedConnection(t *testing.T) {
+	n := newNotifier[api.CollectNotification](t, testConfig(t))
+	c, _ := subscribe(t, n)
+	_ = n.Close()
+
+	// the subscription isn't retried once the connection is closed
+	select {
+	case _, ok := <-c:
+		if ok {
+			t.Error("expected no notification")
+		}
+	case <-time.After(testTimeout):
+		t.Error("expected the subscription to be closed")
+	}
+}
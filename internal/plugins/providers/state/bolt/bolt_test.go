@@ -0,0 +1,214 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bolt
+
+import (
+	"context"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/internal/plugins/providers/state/statetest"
+	"github.com/spf13/viper"
+	"go.etcd.io/bbolt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func openState(t *testing.T, path string) *state {
+	t.Helper()
+	s, err := newState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// count returns the number of records in the bucket
+func count(t *testing.T, s *state, bucket []byte) int {
+	t.Helper()
+	n := 0
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		n = tx.Bucket(bucket).Stats().KeyN
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestState(t *testing.T) {
+	statetest.Run(t, func(t *testing.T) api.State {
+		s := openState(t, filepath.Join(t.TempDir(), "state.db"))
+		t.Cleanup(func() { _ = s.Close() })
+		return s
+	})
+}
+
+func TestReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "nested", "state.db")
+	scalar := api.FeatureDescriptor{
+		FQN:          "score.default",
+		Primitive:    api.PrimitiveTypeInteger,
+		Freshness:    time.Minute,
+		Staleness:    time.Hour,
+		KeepPrevious: &api.KeepPrevious{Versions: 1, Over: time.Hour},
+		Keys:         []string{"id"},
+	}
+	list := api.FeatureDescriptor{
+		FQN:       "tags.default",
+		Primitive: api.PrimitiveTypeStringList,
+		Freshness: time.Minute,
+		Staleness: time.Hour,
+		Keys:      []string{"id"},
+	}
+	win := api.FeatureDescriptor{
+		FQN:       "clicks.default",
+		Primitive: api.PrimitiveTypeFloat,
+		Aggr:      []api.AggrFn{api.AggrFnSum, api.AggrFnCount},
+		Freshness: time.Minute,
+		Staleness: time.Hour,
+		Keys:      []string{"id"},
+	}
+	keys := api.Keys{"id": "1"}
+
+	s := openState(t, path)
+	for _, v := range []int{1, 2} {
+		if err := s.Set(ctx, scalar, keys, v, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Append(ctx, list, keys, []string{"a", "b"}, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	// the bucket is completed, so it's a part of the window
+	if err := s.WindowAdd(ctx, win, keys, 5.0, time.Now().Add(-2*win.Freshness)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// the values survive reopening the file
+	s = openState(t, path)
+	defer s.Close()
+	if err := s.Ping(ctx); err != nil {
+		t.Fatalf("Ping() error = %v", err)
+	}
+	for _, tt := range []struct {
+		name    string
+		fd      api.FeatureDescriptor
+		version uint
+		want    any
+	}{
+		{name: "scalar", fd: scalar, want: 2},
+		{name: "previous version", fd: scalar, version: 1, want: 1},
+		{name: "list", fd: list, want: []string{"a", "b"}},
+		{name: "window", fd: win, want: api.WindowResultMap{api.AggrFnSum: 5, api.AggrFnCount: 1}},
+	} {
+		v, err := s.Get(ctx, tt.fd, keys, tt.version)
+		if err != nil {
+			t.Fatalf("%s: Get() error = %v", tt.name, err)
+		}
+		if v == nil || !reflect.DeepEqual(v.Value, tt.want) {
+			t.Errorf("%s: expected %v, got %+v", tt.name, tt.want, v)
+		}
+	}
+}
+
+func TestCompaction(t *testing.T) {
+	ctx := context.Background()
+	s := openState(t, filepath.Join(t.TempDir(), "state.db"))
+	short := api.FeatureDescriptor{
+		FQN:       "short.default",
+		Primitive: api.PrimitiveTypeInteger,
+		Freshness: time.Millisecond,
+		Staleness: 50 * time.Millisecond,
+		Keys:      []string{"id"},
+	}
+	long := short
+	long.FQN = "long.default"
+	long.Staleness = time.Hour
+
+	for _, fd := range []api.FeatureDescriptor{short, long} {
+		if err := s.Set(ctx, fd, api.Keys{"id": "1"}, 1, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// window buckets are kept for a grace period after they're dead, so an expired bucket is stored directly
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		return put(tx.Bucket(windowsBucket), "expired", &record{
+			Value:    []byte("{}"),
+			TS:       time.Now().Add(-time.Hour).UnixMicro(),
+			ExpireAt: time.Now().Add(-time.Minute).UnixMicro(),
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := count(t, s, entriesBucket); n != 2 {
+		t.Fatalf("expected 2 entries, got %d", n)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.compact(10 * time.Millisecond)
+		close(done)
+	}()
+
+	// the expired entry and bucket are evicted, and the rest are kept
+	deadline := time.Now().Add(time.Second)
+	for count(t, s, entriesBucket) != 1 || count(t, s, windowsBucket) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the expired records to be evicted, got %d entries and %d buckets",
+				count(t, s, entriesBucket), count(t, s, windowsBucket))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	v, err := s.Get(ctx, long, api.Keys{"id": "1"}, 0)
+	if err != nil || v == nil || v.Value != 1 {
+		t.Errorf("expected the value that didn't expire to be kept, got %+v, %v", v, err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the compaction to stop when the state is closed")
+	}
+}
+
+func TestStateFactory(t *testing.T) {
+	v := viper.New()
+	v.Set("bolt-path", filepath.Join(t.TempDir(), "state.db"))
+	v.Set("bolt-compaction-interval", time.Duration(0))
+	if _, err := StateFactory(v); err == nil {
+		t.Errorf("expected an error for a non-positive compaction interval")
+	}
+
+	v.Set("bolt-compaction-interval", time.Minute)
+	s, err := StateFactory(v)
+	if err != nil {
+		t.Fatalf("StateFactory() error = %v", err)
+	}
+	if err := s.(*state).Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package bolt implements an api.State that is embedded in the process, and persisted to a local file with bbolt.
+// It is meant for single-node deployments, where running an external state provider is not desired.
+// The state survives restarts, but it can't be shared between processes, as the file is locked by a single process.
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"go.etcd.io/bbolt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const pluginName = "bolt"
+
+func init() {
+	plugins.Configurers.Register(pluginName, BindConfig)
+	plugins.StateFactories.Register(pluginName, StateFactory)
+}
+
+var (
+	entriesBucket = []byte("entries")
+	windowsBucket = []byte("windows")
+)
+
+// record is a stored value, or window bucket, along with its timestamp and expiration
+type record struct {
+	Value json.RawMessage `json:"v"`
+	// TS is the timestamp of the value in unix microseconds
+	TS int64 `json:"ts"`
+	// ExpireAt is the expiration time in unix microseconds. Zero means no expiration.
+	ExpireAt int64 `json:"xat,omitempty"`
+}
+
+func (r *record) timestamp() time.Time {
+	return time.UnixMicro(r.TS)
+}
+
+func (r *record) expired(now time.Time) bool {
+	return r.ExpireAt > 0 && now.UnixMicro() >= r.ExpireAt
+}
+
+// expireAt returns the expiration time for the given TTL. A zero TTL means no expiration.
+func expireAt(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		return 0
+	}
+	return time.Now().Add(ttl).UnixMicro()
+}
+
+// get returns the record of the key, or nil if it doesn't exist or expired
+func get(b *bbolt.Bucket, key string, now time.Time) (*record, error) {
+	v := b.Get([]byte(key))
+	if v == nil {
+		return nil, nil
+	}
+	r := &record{}
+	if err := json.Unmarshal(v, r); err != nil {
+		return nil, fmt.Errorf("failed to decode record %s: %w", key, err)
+	}
+	if r.expired(now) {
+		return nil, nil
+	}
+	return r, nil
+}
+
+func put(b *bbolt.Bucket, key string, r *record) error {
+	v, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode record %s: %w", key, err)
+	}
+	return b.Put([]byte(key), v)
+}
+
+type state struct {
+	db *bbolt.DB
+
+	// stop stops the compaction
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// New opens (or creates) a State that is persisted to the file at the given path.
+// Expired values are evicted lazily; use StateFactory to also get a periodic compaction.
+func New(path string) (api.State, error) {
+	return newState(path)
+}
+
+func newState(path string) (*state, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create the state directory: %w", err)
+	}
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the state file %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range [][]byte{entriesBucket, windowsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize the state file %s: %w", path, err)
+	}
+	return &state{db: db, stop: make(chan struct{})}, nil
+}
+
+func (s *state) Ping(context.Context) error {
+	return s.db.View(func(tx *bbolt.Tx) error {
+		if tx.Bucket(entriesBucket) == nil || tx.Bucket(windowsBucket) == nil {
+			return fmt.Errorf("bolt: the state file is not initialized")
+		}
+		return nil
+	})
+}
+
+// Close stops the compaction and closes the state file
+func (s *state) Close() error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	return s.db.Close()
+}
+
+// compact periodically evicts the expired entries and buckets, so their pages can be reused, until the state is
+// closed
+func (s *state) compact(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		var now time.Time
+		select {
+		case <-s.stop:
+			return
+		case now = <-ticker.C:
+		}
+
+		err := s.db.Update(func(tx *bbolt.Tx) error {
+			for _, name := range [][]byte{entriesBucket, windowsBucket} {
+				if err := evict(tx.Bucket(name), now); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err == bbolt.ErrDatabaseNotOpen {
+			return
+		}
+	}
+}
+
+// evict deletes the expired records of the bucket
+func evict(b *bbolt.Bucket, now time.Time) error {
+	var expired [][]byte
+	err := b.ForEach(func(k, v []byte) error {
+		r := record{}
+		if err := json.Unmarshal(v, &r); err != nil || r.expired(now) {
+			expired = append(expired, append([]byte(nil), k...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, k := range expired {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StateFactory opens the State with a periodic compaction, that stops when the state is closed.
+func StateFactory(viper *viper.Viper) (api.State, error) {
+	interval := viper.GetDuration("bolt-compaction-interval")
+	if interval <= 0 {
+		return nil, fmt.Errorf("bolt: compaction interval must be positive, got %s", interval)
+	}
+
+	s, err := newState(viper.GetString("bolt-path"))
+	if err != nil {
+		return nil, fmt.Errorf("bolt: %w", err)
+	}
+	go s.compact(interval)
+	return s, nil
+}
+func BindConfig(set *pflag.FlagSet) error {
+	set.String("bolt-path", "/var/lib/raptor/state.db", "Path of the file that persists the embedded bolt state")
+	set.Duration("bolt-compaction-interval", time.Minute, "Interval for evicting expired values from the embedded bolt state")
+	return nil
+}
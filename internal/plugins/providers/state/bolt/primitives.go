@@ -0,0 +1,374 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bolt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"go.etcd.io/bbolt"
+	"reflect"
+	"time"
+)
+
+func primitiveKey(fd api.FeatureDescriptor, keys api.Keys, version uint) (string, error) {
+	e, err := keys.Encode(fd)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode keys: %w", err)
+	}
+	ver := ""
+	if version > 0 {
+		ver = fmt.Sprintf("/%d", version)
+	}
+	return fmt.Sprintf("%s:%s%s", fd.FQN, e, ver), nil
+}
+
+// scalar converts the value to the feature's scalar type, the same way it would have been read back from Redis
+func scalar(val any, pt api.PrimitiveType) (v any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unsupported value type %T", val)
+		}
+	}()
+	return api.ScalarFromString(api.ScalarString(val), pt)
+}
+
+// encodeValue encodes a value that was converted to the feature's primitive.
+// Scalars are encoded as strings, so their type is restored by the feature's primitive, and not by the encoding.
+func encodeValue(fd api.FeatureDescriptor, val any) (json.RawMessage, error) {
+	switch {
+	case fd.Primitive == api.PrimitiveTypeEmbedding, fd.Primitive == api.PrimitiveTypeStruct:
+		return json.Marshal(val)
+	case fd.Primitive.Scalar():
+		return json.Marshal(api.ScalarString(val))
+	default:
+		l := val.([]any)
+		ss := make([]string, len(l))
+		for i, v := range l {
+			ss[i] = api.ScalarString(v)
+		}
+		return json.Marshal(ss)
+	}
+}
+
+// decodeValue decodes a value that was encoded with encodeValue. Lists are decoded as []any.
+func decodeValue(fd api.FeatureDescriptor, raw json.RawMessage) (any, error) {
+	switch {
+	case fd.Primitive == api.PrimitiveTypeEmbedding:
+		var emb api.Embedding
+		err := json.Unmarshal(raw, &emb)
+		return emb, err
+	case fd.Primitive == api.PrimitiveTypeStruct:
+		var m map[string]any
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return nil, err
+		}
+		return fd.StructValue(m)
+	case fd.Primitive.Scalar():
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return scalar(s, fd.Primitive)
+	default:
+		var ss []string
+		if err := json.Unmarshal(raw, &ss); err != nil {
+			return nil, err
+		}
+		l := make([]any, len(ss))
+		for i, s := range ss {
+			v, err := scalar(s, fd.Primitive.Singular())
+			if err != nil {
+				return nil, err
+			}
+			l[i] = v
+		}
+		return l, nil
+	}
+}
+
+// latest returns the later timestamp between the stored record and the new one
+func latest(r *record, ts time.Time) int64 {
+	if r != nil && r.TS > ts.UnixMicro() {
+		return r.TS
+	}
+	return ts.UnixMicro()
+}
+
+func (s *state) Get(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, version uint) (*api.Value, error) {
+	if fd.ValidWindow() {
+		if version != 0 {
+			return nil, fmt.Errorf("version is not supported for windowed features")
+		}
+		return s.getWindow(ctx, fd, keys)
+	}
+	return s.getPrimitive(ctx, fd, keys, version)
+}
+
+func (s *state) getPrimitive(_ context.Context, fd api.FeatureDescriptor, keys api.Keys, version uint) (*api.Value, error) {
+	key, err := primitiveKey(fd, keys, version)
+	if err != nil {
+		return nil, err
+	}
+
+	var r *record
+	err = s.db.View(func(tx *bbolt.Tx) error {
+		r, err = get(tx.Bucket(entriesBucket), key, time.Now())
+		return err
+	})
+	if err != nil || r == nil {
+		return nil, err
+	}
+
+	val, err := decodeValue(fd, r.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the value of %s: %w", key, err)
+	}
+	if l, ok := val.([]any); ok {
+		if val, err = api.NormalizeAny(l); err != nil {
+			return nil, err
+		}
+	}
+
+	return &api.Value{
+		Value:     val,
+		Timestamp: r.timestamp(),
+		Fresh:     time.Since(r.timestamp()) < fd.Freshness,
+	}, nil
+}
+func (s *state) Update(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	if fd.ValidWindow() {
+		return s.WindowAdd(ctx, fd, keys, value, ts)
+	}
+	if fd.Primitive.Scalar() || fd.Primitive == api.PrimitiveTypeEmbedding || fd.Primitive == api.PrimitiveTypeStruct {
+		return s.Set(ctx, fd, keys, value, ts)
+	}
+	return s.Append(ctx, fd, keys, value, ts)
+}
+
+// keepVersions shifts the previous versions of the value within the write transaction
+func keepVersions(b *bbolt.Bucket, fd api.FeatureDescriptor, keys api.Keys) error {
+	if fd.KeepPrevious == nil {
+		return nil
+	}
+
+	now := time.Now()
+	for i := int(fd.KeepPrevious.Versions) - 1; i >= 0; i-- {
+		oldK, err := primitiveKey(fd, keys, uint(i))
+		if err != nil {
+			return err
+		}
+		newK, err := primitiveKey(fd, keys, uint(i)+1)
+		if err != nil {
+			return err
+		}
+
+		old, err := get(b, oldK, now)
+		if err != nil {
+			return err
+		}
+		if old == nil {
+			if err := b.Delete([]byte(newK)); err != nil {
+				return err
+			}
+			continue
+		}
+		r := *old
+		if fd.KeepPrevious.Over == 0 {
+			r.ExpireAt = 0
+		} else {
+			r.ExpireAt = now.Add(time.Duration(i+1) * fd.KeepPrevious.Over).UnixMicro()
+		}
+		if err := put(b, newK, &r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// write stores the value that is calculated by fn out of the current value within a single write transaction.
+// fn receives a nil value if the key has no value.
+func (s *state) write(fd api.FeatureDescriptor, keys api.Keys, ts time.Time, fn func(current any) (any, error)) error {
+	key, err := primitiveKey(fd, keys, 0)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(entriesBucket)
+		r, err := get(b, key, time.Now())
+		if err != nil {
+			return err
+		}
+
+		var current any
+		if r != nil {
+			current, err = decodeValue(fd, r.Value)
+			if err != nil {
+				return fmt.Errorf("failed to decode the value of %s: %w", key, err)
+			}
+		}
+		val, err := fn(current)
+		if err != nil {
+			return err
+		}
+		raw, err := encodeValue(fd, val)
+		if err != nil {
+			return err
+		}
+
+		if err := keepVersions(b, fd, keys); err != nil {
+			return fmt.Errorf("failed to keep versions while updating value: %w", err)
+		}
+		return put(b, key, &record{
+			Value:    raw,
+			TS:       latest(r, ts),
+			ExpireAt: expireAt(fd.Staleness),
+		})
+	})
+}
+
+func (s *state) Set(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	if fd.ValidWindow() {
+		return s.WindowAdd(ctx, fd, keys, value, ts)
+	}
+	if time.Since(ts) > fd.Staleness {
+		return fmt.Errorf("timestamp %s is too old", ts)
+	}
+
+	var val any
+	var err error
+	if fd.Primitive == api.PrimitiveTypeEmbedding {
+		val, err = fd.EmbeddingValue(value)
+		if err != nil {
+			return err
+		}
+	} else if fd.Primitive == api.PrimitiveTypeStruct {
+		val, err = fd.StructValue(value)
+		if err != nil {
+			return err
+		}
+	} else if fd.Primitive.Scalar() {
+		val, err = scalar(value, fd.Primitive)
+		if err != nil {
+			return err
+		}
+	} else {
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return fmt.Errorf("`Set` of a list feature only supports slices and arrays")
+		}
+		var l []any
+		for i := 0; i < rv.Len(); i++ {
+			v, err := scalar(rv.Index(i).Interface(), fd.Primitive.Singular())
+			if err != nil {
+				return err
+			}
+			l = append(l, v)
+		}
+		val = l
+	}
+
+	return s.write(fd, keys, ts, func(any) (any, error) {
+		return val, nil
+	})
+}
+func (s *state) Append(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	if fd.ValidWindow() {
+		return fmt.Errorf("cannot append a windowed feature")
+	}
+	if time.Since(ts) > fd.Staleness {
+		return fmt.Errorf("timestamp %s is too old", ts)
+	}
+	if fd.Primitive == api.PrimitiveTypeEmbedding {
+		return fmt.Errorf("cannot append to an embedding feature")
+	}
+	if fd.Primitive == api.PrimitiveTypeStruct {
+		return fmt.Errorf("cannot append to a struct feature")
+	}
+	if fd.Primitive.Scalar() {
+		return fmt.Errorf("`Append` only supports slices and arrays")
+	}
+
+	var vals []any
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		for i := 0; i < rv.Len(); i++ {
+			vals = append(vals, rv.Index(i).Interface())
+		}
+	} else {
+		vals = append(vals, value)
+	}
+	var err error
+	for i, v := range vals {
+		if vals[i], err = scalar(v, fd.Primitive.Singular()); err != nil {
+			return err
+		}
+	}
+
+	return s.write(fd, keys, ts, func(current any) (any, error) {
+		var l []any
+		if current != nil {
+			l = current.([]any)
+		}
+		return append(l, vals...), nil
+	})
+}
+
+func (s *state) Incr(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	if fd.ValidWindow() {
+		return fmt.Errorf("cannot increment to a windowed feature")
+	}
+	if time.Since(ts) > fd.Staleness {
+		return fmt.Errorf("timestamp %s is too old", ts)
+	}
+	if !fd.Primitive.Scalar() {
+		return fmt.Errorf("`Ince` only supports sclars")
+	}
+
+	return s.write(fd, keys, ts, func(current any) (any, error) {
+		if current == nil {
+			current = 0
+		}
+
+		var val any
+		switch v := value.(type) {
+		case int:
+			switch c := current.(type) {
+			case int:
+				val = c + v
+			case float64:
+				val = c + float64(v)
+			default:
+				return nil, fmt.Errorf("value is not an integer or out of range")
+			}
+		case float64:
+			switch c := current.(type) {
+			case int:
+				val = float64(c) + v
+			case float64:
+				val = c + v
+			default:
+				return nil, fmt.Errorf("value is not a valid float")
+			}
+		default:
+			return nil, fmt.Errorf("`Incr` only supports scalar numberic values")
+		}
+		return scalar(val, fd.Primitive)
+	})
+}
@@ -0,0 +1,203 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"go.etcd.io/bbolt"
+	"strings"
+	"time"
+)
+
+func windowKey(FQN string, bucketName string, encodedKeys string) string {
+	return fmt.Sprintf("%s/%s:%s", FQN, bucketName, encodedKeys)
+}
+func fromWindowKey(k string) (fqn string, bucketName string, encodedKeys string) {
+	firstSep := strings.Index(k, "/")
+	lastColon := strings.LastIndex(k, ":")
+	return k[:firstSep], k[firstSep+1 : lastColon], k[lastColon+1:]
+}
+
+func decodeBucket(r *record) (api.BucketData, error) {
+	data := make(api.BucketData)
+	if err := json.Unmarshal(r.Value, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode bucket: %w", err)
+	}
+	return data, nil
+}
+
+func (s *state) DeadWindowBuckets(ctx context.Context, fd api.FeatureDescriptor, ignore api.RawBuckets) (api.RawBuckets, error) {
+	dead := make(map[string]struct{})
+	for _, b := range api.DeadWindowBuckets(fd.Staleness, fd.Freshness) {
+		dead[b] = struct{}{}
+	}
+	ignored := make(map[string]struct{})
+	for _, b := range ignore {
+		ignored[windowKey(b.FQN, b.Bucket, b.EncodedKeys)] = struct{}{}
+	}
+
+	var buckets api.RawBuckets
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		now := time.Now()
+		prefix := []byte(fd.FQN + "/")
+		c := tx.Bucket(windowsBucket).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if _, ok := ignored[string(k)]; ok {
+				continue
+			}
+			_, bucketName, encodedKeys := fromWindowKey(string(k))
+			if _, ok := dead[bucketName]; !ok {
+				continue
+			}
+
+			r := &record{}
+			if err := json.Unmarshal(v, r); err != nil {
+				return fmt.Errorf("failed to decode record %s: %w", k, err)
+			}
+			if r.expired(now) {
+				continue
+			}
+			data, err := decodeBucket(r)
+			if err != nil {
+				return err
+			}
+			buckets = append(buckets, api.RawBucket{
+				FQN:         fd.FQN,
+				Bucket:      bucketName,
+				EncodedKeys: encodedKeys,
+				Data:        data,
+			})
+		}
+		return nil
+	})
+	return buckets, err
+}
+
+func (s *state) WindowBuckets(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, bucketNames []string) (api.RawBuckets, error) {
+	encodedKeys, err := keys.Encode(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	var buckets api.RawBuckets
+	err = s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(windowsBucket)
+		now := time.Now()
+		for _, name := range bucketNames {
+			r, err := get(b, windowKey(fd.FQN, name, encodedKeys), now)
+			if err != nil {
+				return err
+			}
+			if r == nil {
+				continue
+			}
+			data, err := decodeBucket(r)
+			if err != nil {
+				return err
+			}
+			if len(data) == 0 {
+				continue
+			}
+			buckets = append(buckets, api.RawBucket{
+				FQN:         fd.FQN,
+				Bucket:      name,
+				EncodedKeys: encodedKeys,
+				Data:        data,
+			})
+		}
+		return nil
+	})
+	return buckets, err
+}
+
+func (s *state) getWindow(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys) (*api.Value, error) {
+	buckets, err := s.WindowBuckets(ctx, fd, keys, api.AliveWindowBuckets(fd.Staleness, fd.Freshness))
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]api.BucketData, len(buckets))
+	for i, b := range buckets {
+		data[i] = b.Data
+	}
+	ret := api.WindowResult(fd.Aggr, data...)
+	if ret == nil {
+		return nil, nil
+	}
+
+	return &api.Value{
+		Value:     ret,
+		Timestamp: time.Now(),
+		Fresh:     true,
+	}, nil
+}
+
+func (s *state) WindowAdd(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	updates, err := api.BucketUpdates(fd.Aggr, value, ts)
+	if err != nil {
+		return err
+	}
+	return s.windowApply(ctx, fd, keys, updates, ts)
+}
+
+func (s *state) WindowMerge(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, data api.BucketData, ts time.Time) error {
+	return s.windowApply(ctx, fd, keys, data.Updates(), ts)
+}
+
+// windowApply applies the updates to the bucket of the window that contains the timestamp.
+func (s *state) windowApply(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, updates []api.BucketUpdate, ts time.Time) error {
+	bucketName := api.BucketName(ts, fd.Freshness)
+	encodedKeys, err := keys.Encode(fd)
+	if err != nil {
+		return fmt.Errorf("failed to encode keys: %w", err)
+	}
+
+	exp := api.BucketDeadTime(bucketName, fd.Freshness, fd.Staleness)
+	if !time.Now().Before(exp) {
+		// the bucket is already dead, so there's nothing to keep
+		return nil
+	}
+
+	key := windowKey(fd.FQN, bucketName, encodedKeys)
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(windowsBucket)
+		r, err := get(b, key, time.Now())
+		if err != nil {
+			return err
+		}
+
+		data := make(api.BucketData)
+		if r != nil {
+			if data, err = decodeBucket(r); err != nil {
+				return err
+			}
+		} else {
+			r = &record{}
+		}
+		data.Apply(updates)
+		if r.Value, err = json.Marshal(data); err != nil {
+			return fmt.Errorf("failed to encode bucket: %w", err)
+		}
+		r.TS = latest(r, ts)
+		r.ExpireAt = exp.UnixMicro()
+		return put(b, key, r)
+	})
+}
@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package memory implements an in-process api.State.
+// It is meant for local development and tests, where running an external state provider is not desired.
+// The state is not shared between processes, and it is lost when the process exits.
+//
+// It also provides an in-process notifier, that is the only notifier provider usable with the in-process state.
+// The notifications are delivered only within the process, so the historian only works with it in a single-process
+// setup, where it runs in the same process as the core.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"sync"
+	"time"
+)
+
+const pluginName = "memory"
+
+func init() {
+	plugins.Configurers.Register(pluginName, BindConfig)
+	plugins.StateFactories.Register(pluginName, StateFactory)
+}
+
+// entry is a stored primitive value
+type entry struct {
+	value    any
+	ts       time.Time
+	expireAt time.Time
+}
+
+// bucket is a stored window bucket
+type bucket struct {
+	data     api.BucketData
+	ts       time.Time
+	expireAt time.Time
+}
+
+func expired(expireAt, now time.Time) bool {
+	return !expireAt.IsZero() && !now.Before(expireAt)
+}
+
+type state struct {
+	mu      sync.RWMutex
+	entries map[string]*entry
+	buckets map[string]*bucket
+
+	// stop stops the garbage collection
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// New creates a new in-memory State.
+// Expired values are evicted lazily; use StateFactory to also get a periodic garbage collection.
+func New() api.State {
+	return newState()
+}
+
+func newState() *state {
+	return &state{
+		entries: make(map[string]*entry),
+		buckets: make(map[string]*bucket),
+		stop:    make(chan struct{}),
+	}
+}
+
+func (s *state) Ping(context.Context) error {
+	return nil
+}
+
+// Close stops the garbage collection.
+func (s *state) Close() error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	return nil
+}
+
+// gc periodically evicts the expired entries and buckets, until the state is closed
+func (s *state) gc(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		var now time.Time
+		select {
+		case <-s.stop:
+			return
+		case now = <-ticker.C:
+		}
+
+		s.mu.Lock()
+		for k, e := range s.entries {
+			if expired(e.expireAt, now) {
+				delete(s.entries, k)
+			}
+		}
+		for k, b := range s.buckets {
+			if expired(b.expireAt, now) {
+				delete(s.buckets, k)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// StateFactory creates a new in-memory State with a periodic garbage collection, that stops when the state is closed.
+func StateFactory(viper *viper.Viper) (api.State, error) {
+	interval := viper.GetDuration("memory-gc-interval")
+	if interval <= 0 {
+		return nil, fmt.Errorf("memory: gc interval must be positive, got %s", interval)
+	}
+
+	s := newState()
+	go s.gc(interval)
+	return s, nil
+}
+func BindConfig(set *pflag.FlagSet) error {
+	set.Duration("memory-gc-interval", time.Minute, "Interval for evicting expired values from the in-memory state")
+	return nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memory
+
+import (
+	"context"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/internal/plugins/providers/state/statetest"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"testing"
+	"time"
+)
+
+func TestState(t *testing.T) {
+	statetest.Run(t, func(t *testing.T) api.State {
+		s := newState()
+		t.Cleanup(func() { _ = s.Close() })
+		return s
+	})
+}
+
+func TestGC(t *testing.T) {
+	s := newState()
+	fd := api.FeatureDescriptor{
+		FQN:       "gc.default",
+		Primitive: api.PrimitiveTypeInteger,
+		Freshness: time.Millisecond,
+		Staleness: 50 * time.Millisecond,
+		Keys:      []string{"id"},
+	}
+	if err := s.Set(context.Background(), fd, api.Keys{"id": "1"}, 1, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.gc(10 * time.Millisecond)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.mu.RLock()
+		n := len(s.entries)
+		s.mu.RUnlock()
+		if n == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the expired entry to be evicted")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// closing twice is a no-op
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the gc to stop when the state is closed")
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	ctx := context.Background()
+	n, err := plugins.NewWatchNotifier(pluginName, viper.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	notification := api.WatchNotification{FQN: "a.default", EncodedKeys: "1"}
+	if err := n.Notify(ctx, notification); err == nil {
+		t.Error("expected an error when there are no subscribers")
+	}
+
+	// every subscriber of the process gets every notification, even when it subscribed with another notifier
+	other, err := NotifierFactory[api.WatchNotification](nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var subscribers []<-chan api.WatchNotification
+	for _, s := range []api.Notifier[api.WatchNotification]{n, other} {
+		c, err := s.Subscribe(subCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		subscribers = append(subscribers, c)
+	}
+	if err := n.Notify(ctx, notification); err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range subscribers {
+		select {
+		case got := <-c:
+			if got != notification {
+				t.Errorf("subscriber %d: expected %+v, got %+v", i, notification, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("subscriber %d: expected a notification", i)
+		}
+	}
+
+	// notifications of other types aren't delivered
+	wn, err := NotifierFactory[api.WriteNotification](nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wn.Notify(ctx, api.WriteNotification{FQN: "a.default"}); err == nil {
+		t.Error("expected an error when there are no subscribers of the type")
+	}
+
+	// the subscriptions are closed and removed once their context is done
+	cancel()
+	for i, c := range subscribers {
+		select {
+		case _, ok := <-c:
+			if ok {
+				t.Errorf("subscriber %d: expected no notification", i)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("subscriber %d: expected the subscription to be closed", i)
+		}
+	}
+	if err := n.Notify(ctx, notification); err == nil {
+		t.Error("expected an error once the subscribers are gone")
+	}
+}
+
+func TestResolveNotifierProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		state    string
+		notifier string
+		redis    string
+		want     string
+		wantErr  bool
+	}{
+		{name: "defaults to the in-process notifier", state: pluginName, want: pluginName},
+		{name: "in-process notifier", state: pluginName, notifier: pluginName, want: pluginName},
+		{name: "mismatch", state: pluginName, notifier: "redis", wantErr: true},
+		{name: "embedded state provider", state: "bolt", want: pluginName},
+		{name: "embedded state provider with redis", state: "bolt", notifier: "redis", redis: "localhost:6379", want: "redis"},
+		{name: "embedded state provider without redis", state: "bolt", notifier: "redis", wantErr: true},
+		{name: "other state provider", state: "redis", redis: "localhost:6379", want: "redis"},
+		{name: "other state provider without redis", state: "postgres", wantErr: true},
+		{name: "other state provider without redis streams", state: "postgres", notifier: "redis-streams", wantErr: true},
+		{name: "other state provider with a notifier", state: "postgres", notifier: "nats", want: "nats"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set := pflag.NewFlagSet(tt.name, pflag.ContinueOnError)
+			set.String("state-provider", "redis", "")
+			set.String("notifier-provider", "redis", "")
+			set.StringArray("redis", []string{}, "")
+			args := []string{"--state-provider=" + tt.state}
+			if tt.notifier != "" {
+				args = append(args, "--notifier-provider="+tt.notifier)
+			}
+			if tt.redis != "" {
+				args = append(args, "--redis="+tt.redis)
+			}
+			if err := set.Parse(args); err != nil {
+				t.Fatal(err)
+			}
+			v := viper.New()
+			if err := v.BindPFlags(set); err != nil {
+				t.Fatal(err)
+			}
+
+			err := plugins.ResolveNotifierProvider(v)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveNotifierProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := v.GetString("notifier-provider"); !tt.wantErr && got != tt.want {
+				t.Errorf("expected the %q notifier provider, got %q", tt.want, got)
+			}
+		})
+	}
+}
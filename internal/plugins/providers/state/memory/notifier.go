@@ -0,0 +1,103 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+	"github.com/spf13/viper"
+	"sync"
+)
+
+func init() {
+	plugins.CollectNotifierFactories.Register(pluginName, NotifierFactory[api.CollectNotification])
+	plugins.WriteNotifierFactories.Register(pluginName, NotifierFactory[api.WriteNotification])
+	plugins.WatchNotifierFactories.Register(pluginName, NotifierFactory[api.WatchNotification])
+}
+
+// subscriptionBuffer is the number of notifications that are queued for a subscriber before Notify blocks
+const subscriptionBuffer = 1024
+
+// buses holds the bus of each notification type. The buses are shared by all the notifiers of the process, so
+// the notifications are delivered only to subscribers of the same process.
+var buses sync.Map
+
+type subscription[T api.Notification] struct {
+	c    chan T
+	done <-chan struct{}
+}
+
+type bus[T api.Notification] struct {
+	mu          sync.RWMutex
+	subscribers map[*subscription[T]]struct{}
+}
+
+func busOf[T api.Notification]() *bus[T] {
+	var t T
+	b, _ := buses.LoadOrStore(fmt.Sprintf("%T", t), &bus[T]{subscribers: make(map[*subscription[T]]struct{})})
+	return b.(*bus[T])
+}
+
+// NotifierFactory creates an in-process Notifier.
+// Like the redis notifier, every notification is delivered to all the current subscribers, so it can only be used
+// when the historian runs in the same process as the core (i.e. a single-process setup).
+func NotifierFactory[T api.Notification](*viper.Viper) (api.Notifier[T], error) {
+	return &notifier[T]{bus: busOf[T]()}, nil
+}
+
+type notifier[T api.Notification] struct {
+	bus *bus[T]
+}
+
+func (n *notifier[T]) Notify(ctx context.Context, notification T) error {
+	n.bus.mu.RLock()
+	defer n.bus.mu.RUnlock()
+
+	if len(n.bus.subscribers) == 0 {
+		return fmt.Errorf("no subscriber available")
+	}
+	for s := range n.bus.subscribers {
+		select {
+		case s.c <- notification:
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (n *notifier[T]) Subscribe(ctx context.Context) (<-chan T, error) {
+	s := &subscription[T]{
+		c:    make(chan T, subscriptionBuffer),
+		done: ctx.Done(),
+	}
+	n.bus.mu.Lock()
+	n.bus.subscribers[s] = struct{}{}
+	n.bus.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		n.bus.mu.Lock()
+		delete(n.bus.subscribers, s)
+		n.bus.mu.Unlock()
+		close(s.c)
+	}()
+	return s.c, nil
+}
@@ -0,0 +1,342 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"reflect"
+	"time"
+)
+
+func primitiveKey(fd api.FeatureDescriptor, keys api.Keys, version uint) (string, error) {
+	e, err := keys.Encode(fd)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode keys: %w", err)
+	}
+	ver := ""
+	if version > 0 {
+		ver = fmt.Sprintf("/%d", version)
+	}
+	return fmt.Sprintf("%s:%s%s", fd.FQN, e, ver), nil
+}
+
+// scalar converts the value to the feature's scalar type, the same way it would have been read back from Redis
+func scalar(val any, pt api.PrimitiveType) (v any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unsupported value type %T", val)
+		}
+	}()
+	return api.ScalarFromString(api.ScalarString(val), pt)
+}
+
+// copyStruct copies the struct, so the stored value can't be modified by the caller
+func copyStruct(st api.Struct) api.Struct {
+	ret := make(api.Struct, len(st))
+	for k, v := range st {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+			c := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+			reflect.Copy(c, rv)
+			v = c.Interface()
+		}
+		ret[k] = v
+	}
+	return ret
+}
+
+// expireAt returns the expiration time for the given TTL. A zero TTL means no expiration.
+func expireAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+// latest returns the later timestamp between the stored entry and the new one
+func latest(e *entry, ts time.Time) time.Time {
+	if e != nil && !expired(e.expireAt, time.Now()) && e.ts.After(ts) {
+		return e.ts
+	}
+	return ts
+}
+
+func (s *state) Get(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, version uint) (*api.Value, error) {
+	if fd.ValidWindow() {
+		if version != 0 {
+			return nil, fmt.Errorf("version is not supported for windowed features")
+		}
+		return s.getWindow(ctx, fd, keys)
+	}
+	return s.getPrimitive(ctx, fd, keys, version)
+}
+
+func (s *state) getPrimitive(_ context.Context, fd api.FeatureDescriptor, keys api.Keys, version uint) (*api.Value, error) {
+	key, err := primitiveKey(fd, keys, version)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	e, ok := s.entries[key]
+	if !ok || expired(e.expireAt, time.Now()) {
+		return nil, nil
+	}
+
+	val := e.value
+	if emb, ok := val.(api.Embedding); ok {
+		val = append(api.Embedding(nil), emb...)
+	} else if st, ok := val.(api.Struct); ok {
+		val = copyStruct(st)
+	} else if !fd.Primitive.Scalar() {
+		val, err = api.NormalizeAny(append([]any(nil), e.value.([]any)...))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &api.Value{
+		Value:     val,
+		Timestamp: e.ts,
+		Fresh:     time.Since(e.ts) < fd.Freshness,
+	}, nil
+}
+func (s *state) Update(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	if fd.ValidWindow() {
+		return s.WindowAdd(ctx, fd, keys, value, ts)
+	}
+	if fd.Primitive.Scalar() || fd.Primitive == api.PrimitiveTypeEmbedding || fd.Primitive == api.PrimitiveTypeStruct {
+		return s.Set(ctx, fd, keys, value, ts)
+	}
+	return s.Append(ctx, fd, keys, value, ts)
+}
+
+// keepVersions shifts the existing previous versions of the value. It must be called while holding the write lock.
+func (s *state) keepVersions(fd api.FeatureDescriptor, keys api.Keys) error {
+	if fd.KeepPrevious == nil {
+		return nil
+	}
+
+	now := time.Now()
+	for i := int(fd.KeepPrevious.Versions) - 1; i >= 0; i-- {
+		oldK, err := primitiveKey(fd, keys, uint(i))
+		if err != nil {
+			return err
+		}
+		newK, err := primitiveKey(fd, keys, uint(i)+1)
+		if err != nil {
+			return err
+		}
+
+		old, ok := s.entries[oldK]
+		if !ok || expired(old.expireAt, now) {
+			continue
+		}
+		e := *old
+		if fd.KeepPrevious.Over == 0 {
+			e.expireAt = time.Time{}
+		} else {
+			e.expireAt = now.Add(time.Duration(i+1) * fd.KeepPrevious.Over)
+		}
+		s.entries[newK] = &e
+	}
+
+	return nil
+}
+
+func (s *state) Set(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	if fd.ValidWindow() {
+		return s.WindowAdd(ctx, fd, keys, value, ts)
+	}
+	if time.Since(ts) > fd.Staleness {
+		return fmt.Errorf("timestamp %s is too old", ts)
+	}
+
+	key, err := primitiveKey(fd, keys, 0)
+	if err != nil {
+		return err
+	}
+
+	var val any
+	if fd.Primitive == api.PrimitiveTypeEmbedding {
+		emb, err := fd.EmbeddingValue(value)
+		if err != nil {
+			return err
+		}
+		val = append(api.Embedding(nil), emb...)
+	} else if fd.Primitive == api.PrimitiveTypeStruct {
+		st, err := fd.StructValue(value)
+		if err != nil {
+			return err
+		}
+		val = copyStruct(st)
+	} else if fd.Primitive.Scalar() {
+		val, err = scalar(value, fd.Primitive)
+		if err != nil {
+			return err
+		}
+	} else {
+		rv := reflect.ValueOf(value)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return fmt.Errorf("`Set` of a list feature only supports slices and arrays")
+		}
+		var l []any
+		for i := 0; i < rv.Len(); i++ {
+			v, err := scalar(rv.Index(i).Interface(), fd.Primitive.Singular())
+			if err != nil {
+				return err
+			}
+			l = append(l, v)
+		}
+		val = l
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.keepVersions(fd, keys); err != nil {
+		return fmt.Errorf("failed to keep versions while updating value: %w", err)
+	}
+	s.entries[key] = &entry{
+		value:    val,
+		ts:       latest(s.entries[key], ts),
+		expireAt: expireAt(fd.Staleness),
+	}
+	return nil
+}
+func (s *state) Append(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	if fd.ValidWindow() {
+		return fmt.Errorf("cannot append a windowed feature")
+	}
+	if time.Since(ts) > fd.Staleness {
+		return fmt.Errorf("timestamp %s is too old", ts)
+	}
+	if fd.Primitive == api.PrimitiveTypeEmbedding {
+		return fmt.Errorf("cannot append to an embedding feature")
+	}
+	if fd.Primitive == api.PrimitiveTypeStruct {
+		return fmt.Errorf("cannot append to a struct feature")
+	}
+	if fd.Primitive.Scalar() {
+		return fmt.Errorf("`Append` only supports slices and arrays")
+	}
+
+	key, err := primitiveKey(fd, keys, 0)
+	if err != nil {
+		return err
+	}
+
+	var vals []any
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		for i := 0; i < rv.Len(); i++ {
+			vals = append(vals, rv.Index(i).Interface())
+		}
+	} else {
+		vals = append(vals, value)
+	}
+	for i, v := range vals {
+		if vals[i], err = scalar(v, fd.Primitive.Singular()); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.keepVersions(fd, keys); err != nil {
+		return fmt.Errorf("failed to keep versions while updating value: %w", err)
+	}
+
+	var l []any
+	e, ok := s.entries[key]
+	if ok && !expired(e.expireAt, time.Now()) {
+		l = append(l, e.value.([]any)...)
+	}
+	s.entries[key] = &entry{
+		value:    append(l, vals...),
+		ts:       latest(e, ts),
+		expireAt: expireAt(fd.Staleness),
+	}
+	return nil
+}
+
+func (s *state) Incr(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	if fd.ValidWindow() {
+		return fmt.Errorf("cannot increment to a windowed feature")
+	}
+	if time.Since(ts) > fd.Staleness {
+		return fmt.Errorf("timestamp %s is too old", ts)
+	}
+	if !fd.Primitive.Scalar() {
+		return fmt.Errorf("`Ince` only supports sclars")
+	}
+
+	key, err := primitiveKey(fd, keys, 0)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var current any = 0
+	e, ok := s.entries[key]
+	if ok && !expired(e.expireAt, time.Now()) {
+		current = e.value
+	}
+
+	var val any
+	switch v := value.(type) {
+	case int:
+		switch c := current.(type) {
+		case int:
+			val = c + v
+		case float64:
+			val = c + float64(v)
+		default:
+			return fmt.Errorf("value is not an integer or out of range")
+		}
+	case float64:
+		switch c := current.(type) {
+		case int:
+			val = float64(c) + v
+		case float64:
+			val = c + v
+		default:
+			return fmt.Errorf("value is not a valid float")
+		}
+	default:
+		return fmt.Errorf("`Incr` only supports scalar numberic values")
+	}
+	val, err = scalar(val, fd.Primitive)
+	if err != nil {
+		return err
+	}
+
+	if err := s.keepVersions(fd, keys); err != nil {
+		return fmt.Errorf("failed to keep versions while updating value: %w", err)
+	}
+	s.entries[key] = &entry{
+		value:    val,
+		ts:       latest(e, ts),
+		expireAt: expireAt(fd.Staleness),
+	}
+	return nil
+}
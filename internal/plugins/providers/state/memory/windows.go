@@ -0,0 +1,165 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package memory
+
+import (
+	"context"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"strings"
+	"time"
+)
+
+func windowKey(FQN string, bucketName string, encodedKeys string) string {
+	return fmt.Sprintf("%s/%s:%s", FQN, bucketName, encodedKeys)
+}
+func fromWindowKey(k string) (fqn string, bucketName string, encodedKeys string) {
+	firstSep := strings.Index(k, "/")
+	lastColon := strings.LastIndex(k, ":")
+	return k[:firstSep], k[firstSep+1 : lastColon], k[lastColon+1:]
+}
+
+func (s *state) DeadWindowBuckets(ctx context.Context, fd api.FeatureDescriptor, ignore api.RawBuckets) (api.RawBuckets, error) {
+	dead := make(map[string]struct{})
+	for _, b := range api.DeadWindowBuckets(fd.Staleness, fd.Freshness) {
+		dead[b] = struct{}{}
+	}
+	ignored := make(map[string]struct{})
+	for _, b := range ignore {
+		ignored[windowKey(b.FQN, b.Bucket, b.EncodedKeys)] = struct{}{}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var buckets api.RawBuckets
+	now := time.Now()
+	for k, b := range s.buckets {
+		if expired(b.expireAt, now) {
+			continue
+		}
+		if _, ok := ignored[k]; ok {
+			continue
+		}
+		fqn, bucketName, encodedKeys := fromWindowKey(k)
+		if fqn != fd.FQN {
+			continue
+		}
+		if _, ok := dead[bucketName]; !ok {
+			continue
+		}
+		buckets = append(buckets, api.RawBucket{
+			FQN:         fqn,
+			Bucket:      bucketName,
+			EncodedKeys: encodedKeys,
+			Data:        b.data.Copy(),
+		})
+	}
+	return buckets, nil
+}
+
+func (s *state) WindowBuckets(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, bucketNames []string) (api.RawBuckets, error) {
+	encodedKeys, err := keys.Encode(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var buckets api.RawBuckets
+	now := time.Now()
+	for _, name := range bucketNames {
+		b, ok := s.buckets[windowKey(fd.FQN, name, encodedKeys)]
+		if !ok || expired(b.expireAt, now) || len(b.data) == 0 {
+			continue
+		}
+		buckets = append(buckets, api.RawBucket{
+			FQN:         fd.FQN,
+			Bucket:      name,
+			EncodedKeys: encodedKeys,
+			Data:        b.data.Copy(),
+		})
+	}
+	return buckets, nil
+}
+
+func (s *state) getWindow(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys) (*api.Value, error) {
+	buckets, err := s.WindowBuckets(ctx, fd, keys, api.AliveWindowBuckets(fd.Staleness, fd.Freshness))
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]api.BucketData, len(buckets))
+	for i, b := range buckets {
+		data[i] = b.Data
+	}
+	ret := api.WindowResult(fd.Aggr, data...)
+	if ret == nil {
+		return nil, nil
+	}
+
+	return &api.Value{
+		Value:     ret,
+		Timestamp: time.Now(),
+		Fresh:     true,
+	}, nil
+}
+
+func (s *state) WindowAdd(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	updates, err := api.BucketUpdates(fd.Aggr, value, ts)
+	if err != nil {
+		return err
+	}
+	return s.windowApply(ctx, fd, keys, updates, ts)
+}
+
+func (s *state) WindowMerge(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, data api.BucketData, ts time.Time) error {
+	return s.windowApply(ctx, fd, keys, data.Updates(), ts)
+}
+
+// windowApply applies the updates to the bucket of the window that contains the timestamp.
+func (s *state) windowApply(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, updates []api.BucketUpdate, ts time.Time) error {
+	bucketName := api.BucketName(ts, fd.Freshness)
+	encodedKeys, err := keys.Encode(fd)
+	if err != nil {
+		return fmt.Errorf("failed to encode keys: %w", err)
+	}
+
+	exp := api.BucketDeadTime(bucketName, fd.Freshness, fd.Staleness)
+	if expired(exp, time.Now()) {
+		// the bucket is already dead, so there's nothing to keep
+		return nil
+	}
+
+	key := windowKey(fd.FQN, bucketName, encodedKeys)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok || expired(b.expireAt, time.Now()) {
+		b = &bucket{data: make(api.BucketData)}
+		s.buckets[key] = b
+	}
+	b.data.Apply(updates)
+	if ts.After(b.ts) {
+		b.ts = ts
+	}
+	b.expireAt = exp
+	return nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrationsLock is the key of the advisory lock that serializes the migrations of concurrent instances
+const migrationsLock = 0x7261_7074_6f72 // "raptor"
+
+// migrations are the schema changes of the state, by their order. Applied migrations must never change.
+var migrations = []string{
+	// 1: versioned values, window buckets and the merge function of the sketch fields of the buckets
+	`CREATE TABLE raptor_state_values (
+		fqn       text        NOT NULL,
+		keys      text        NOT NULL,
+		version   integer     NOT NULL,
+		value     jsonb       NOT NULL,
+		ts        timestamptz NOT NULL,
+		expire_at timestamptz,
+		PRIMARY KEY (fqn, keys, version)
+	);
+	CREATE INDEX raptor_state_values_expire_at ON raptor_state_values (expire_at) WHERE expire_at IS NOT NULL;
+
+	CREATE TABLE raptor_state_buckets (
+		fqn       text             NOT NULL,
+		bucket    text             NOT NULL,
+		keys      text             NOT NULL,
+		"sum"     double precision,
+		"count"   double precision,
+		"m2"      double precision,
+		"m2_count" double precision,
+		"m2_mean" double precision,
+		"min"     double precision,
+		"max"     double precision,
+		"first"   double precision,
+		"first_ts" double precision,
+		"last"    double precision,
+		"last_ts" double precision,
+		sketches  jsonb            NOT NULL DEFAULT '{}',
+		ts        timestamptz      NOT NULL,
+		expire_at timestamptz      NOT NULL,
+		PRIMARY KEY (fqn, bucket, keys)
+	);
+	CREATE INDEX raptor_state_buckets_expire_at ON raptor_state_buckets (expire_at);
+
+	-- HyperLogLog registers keep the max, and the DDSketch bins are added up (see api.BucketData)
+	CREATE FUNCTION raptor_merge_sketches(a jsonb, b jsonb) RETURNS jsonb AS $$
+		SELECT coalesce(jsonb_object_agg(k, CASE
+			WHEN k LIKE 'hll:%' THEN greatest((a->>k)::double precision, (b->>k)::double precision)
+			ELSE coalesce((a->>k)::double precision, 0) + coalesce((b->>k)::double precision, 0)
+		END), '{}'::jsonb)
+		FROM (SELECT jsonb_object_keys(a) AS k UNION SELECT jsonb_object_keys(b)) AS fields
+	$$ LANGUAGE sql IMMUTABLE;`,
+}
+
+// migrate applies the migrations that weren't applied yet
+func migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, migrationsLock); err != nil {
+			return fmt.Errorf("failed to acquire the migrations lock: %w", err)
+		}
+		_, err := tx.Exec(ctx, `CREATE TABLE IF NOT EXISTS raptor_state_migrations (
+			version    integer     PRIMARY KEY,
+			applied_at timestamptz NOT NULL DEFAULT now()
+		)`)
+		if err != nil {
+			return fmt.Errorf("failed to create the migrations table: %w", err)
+		}
+
+		var applied int
+		if err := tx.QueryRow(ctx, `SELECT coalesce(max(version), 0) FROM raptor_state_migrations`).Scan(&applied); err != nil {
+			return fmt.Errorf("failed to get the schema version: %w", err)
+		}
+		if applied > len(migrations) {
+			return fmt.Errorf("the schema version %d is newer than the supported version %d", applied, len(migrations))
+		}
+
+		for i := applied; i < len(migrations); i++ {
+			if _, err := tx.Exec(ctx, migrations[i]); err != nil {
+				return fmt.Errorf("failed to apply migration %d: %w", i+1, err)
+			}
+			if _, err := tx.Exec(ctx, `INSERT INTO raptor_state_migrations (version) VALUES ($1)`, i+1); err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", i+1, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,217 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/internal/plugins/providers/state/statetest"
+	"github.com/spf13/viper"
+	"os"
+	"testing"
+	"time"
+)
+
+// dsnEnv is the environment variable of the DSN of the database that the tests run against.
+// The tests that require a database are skipped when it's not set.
+const dsnEnv = "POSTGRES_STATE_DSN"
+
+func requireDSN(t *testing.T) string {
+	t.Helper()
+	dsn := os.Getenv(dsnEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", dsnEnv)
+	}
+	return dsn
+}
+
+// testPool returns a connection pool to a new schema, that is dropped when the test is done.
+// The schema isolates the tables, so tests can't interfere with each other.
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	dsn := requireDSN(t)
+	ctx := context.Background()
+
+	admin, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := fmt.Sprintf("raptor_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec(ctx, `CREATE SCHEMA `+schema); err != nil {
+		admin.Close()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_, _ = admin.Exec(context.Background(), `DROP SCHEMA `+schema+` CASCADE`)
+		admin.Close()
+	})
+
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func testState(t *testing.T) *state {
+	t.Helper()
+	pool := testPool(t)
+	if err := migrate(context.Background(), pool); err != nil {
+		t.Fatalf("migrate() error = %v", err)
+	}
+	return &state{pool: pool}
+}
+
+func count(t *testing.T, pool *pgxpool.Pool, query string) int {
+	t.Helper()
+	n := 0
+	if err := pool.QueryRow(context.Background(), query).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestState(t *testing.T) {
+	requireDSN(t)
+	statetest.Run(t, func(t *testing.T) api.State {
+		return testState(t)
+	})
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	pool := testPool(t)
+
+	if err := migrate(ctx, pool); err != nil {
+		t.Fatalf("migrate() error = %v", err)
+	}
+	// migrating an up-to-date schema is a no-op
+	if err := migrate(ctx, pool); err != nil {
+		t.Fatalf("migrate() error = %v", err)
+	}
+	if n := count(t, pool, `SELECT max(version) FROM raptor_state_migrations`); n != len(migrations) {
+		t.Errorf("expected the schema version %d, got %d", len(migrations), n)
+	}
+	for _, table := range []string{"raptor_state_values", "raptor_state_buckets"} {
+		if n := count(t, pool, `SELECT count(*) FROM `+table); n != 0 {
+			t.Errorf("expected the table %s to be empty, got %d rows", table, n)
+		}
+	}
+	for _, col := range bucketColumns {
+		q := fmt.Sprintf(`SELECT count(*) FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = 'raptor_state_buckets' AND column_name = '%s'`, col)
+		if n := count(t, pool, q); n != 1 {
+			t.Errorf("expected the bucket column %s to be created", col)
+		}
+	}
+
+	// a schema that was migrated by a newer version is not supported
+	if _, err := pool.Exec(ctx, `INSERT INTO raptor_state_migrations (version) VALUES ($1)`, len(migrations)+1); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrate(ctx, pool); err == nil {
+		t.Error("expected an error for a newer schema version")
+	}
+}
+
+func TestSweep(t *testing.T) {
+	ctx := context.Background()
+	s := testState(t)
+	fd := api.FeatureDescriptor{
+		FQN:       "sweep.default",
+		Primitive: api.PrimitiveTypeInteger,
+		Freshness: time.Millisecond,
+		Staleness: 50 * time.Millisecond,
+		Keys:      []string{"id"},
+	}
+	long := fd
+	long.FQN = "kept.default"
+	long.Staleness = time.Hour
+	for _, fd := range []api.FeatureDescriptor{fd, long} {
+		if err := s.Set(ctx, fd, api.Keys{"id": "1"}, 1, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// window buckets are kept for a grace period after they're dead, so an expired bucket is inserted directly
+	_, err := s.pool.Exec(ctx, `INSERT INTO raptor_state_buckets (fqn, bucket, keys, ts, expire_at)
+		VALUES ('sweep.default', 'expired', '1', now() - interval '1 hour', now() - interval '1 minute')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sweepCtx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		s.sweep(sweepCtx, 10*time.Millisecond, time.Second)
+		close(done)
+	}()
+
+	// the expired value and bucket are deleted, and the rest are kept
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		values := count(t, s.pool, `SELECT count(*) FROM raptor_state_values`)
+		buckets := count(t, s.pool, `SELECT count(*) FROM raptor_state_buckets`)
+		if values == 1 && buckets == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the expired rows to be swept, got %d values and %d buckets", values, buckets)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	v, err := s.Get(ctx, long, api.Keys{"id": "1"}, 0)
+	if err != nil || v == nil || v.Value != 1 {
+		t.Errorf("expected the value that didn't expire to be kept, got %+v, %v", v, err)
+	}
+
+	stop()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the sweeper to stop when its context is done")
+	}
+}
+
+func TestStateFactoryConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		dsn      string
+		interval time.Duration
+	}{
+		{name: "no DSN", interval: time.Minute},
+		{name: "no interval", dsn: "postgres://localhost/raptor"},
+		{name: "negative interval", dsn: "postgres://localhost/raptor", interval: -time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := viper.New()
+			v.Set("postgres-state-dsn", tt.dsn)
+			v.Set("postgres-state-sweep-interval", tt.interval)
+			if _, err := StateFactory(v); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
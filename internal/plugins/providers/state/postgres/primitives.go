@@ -0,0 +1,335 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/raptor-ml/raptor/api"
+	"reflect"
+	"time"
+)
+
+const (
+	sqlGetValue = `SELECT value, ts FROM raptor_state_values
+		WHERE fqn = $1 AND keys = $2 AND version = $3 AND (expire_at IS NULL OR expire_at > $4)`
+
+	// sqlCopyVersion copies the alive value of a version to the next version
+	sqlCopyVersion = `INSERT INTO raptor_state_values (fqn, keys, version, value, ts, expire_at)
+		SELECT fqn, keys, $4::integer, value, ts, $5::timestamptz FROM raptor_state_values
+		WHERE fqn = $1 AND keys = $2 AND version = $3 AND (expire_at IS NULL OR expire_at > $6)
+		ON CONFLICT (fqn, keys, version) DO UPDATE SET value = excluded.value, ts = excluded.ts, expire_at = excluded.expire_at`
+	sqlDeleteVersion = `DELETE FROM raptor_state_values WHERE fqn = $1 AND keys = $2 AND version = $3`
+
+	// sqlUpsertValue upserts the latest version of a value. The new value is calculated out of the stored value by
+	// the expression of the write method (see upsertValue), unless the stored value has expired.
+	sqlUpsertValue = `INSERT INTO raptor_state_values AS v (fqn, keys, version, value, ts, expire_at)
+		VALUES ($1, $2, 0, $3, $4, $5)
+		ON CONFLICT (fqn, keys, version) DO UPDATE SET
+			value = CASE WHEN v.expire_at <= $6 THEN excluded.value ELSE %s END,
+			ts = CASE WHEN v.expire_at <= $6 THEN excluded.ts ELSE greatest(v.ts, excluded.ts) END,
+			expire_at = excluded.expire_at`
+
+	sqlSweepValues = `DELETE FROM raptor_state_values WHERE expire_at <= $1`
+)
+
+var (
+	sqlSetValue    = fmt.Sprintf(sqlUpsertValue, `excluded.value`)
+	sqlAppendValue = fmt.Sprintf(sqlUpsertValue, `v.value || excluded.value`)
+	// scalars are stored as JSON strings (see encodeValue), so they are added up as numeric, and stored as a string again
+	sqlIncrValue = fmt.Sprintf(sqlUpsertValue, `to_jsonb(((v.value #>> '{}')::numeric + (excluded.value #>> '{}')::numeric)::text)`)
+)
+
+// scalar converts the value to the feature's scalar type, the same way it would have been read back from the state
+func scalar(val any, pt api.PrimitiveType) (v any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unsupported value type %T", val)
+		}
+	}()
+	return api.ScalarFromString(api.ScalarString(val), pt)
+}
+
+// encodeValue encodes a value that was converted to the feature's primitive.
+// Scalars are encoded as strings, so their type is restored by the feature's primitive, and not by the encoding.
+func encodeValue(fd api.FeatureDescriptor, val any) (string, error) {
+	var raw []byte
+	var err error
+	switch {
+	case fd.Primitive == api.PrimitiveTypeEmbedding, fd.Primitive == api.PrimitiveTypeStruct:
+		raw, err = json.Marshal(val)
+	case fd.Primitive.Scalar():
+		raw, err = json.Marshal(api.ScalarString(val))
+	default:
+		l := val.([]any)
+		ss := make([]string, len(l))
+		for i, v := range l {
+			ss[i] = api.ScalarString(v)
+		}
+		raw, err = json.Marshal(ss)
+	}
+	return string(raw), err
+}
+
+// decodeValue decodes a value that was encoded with encodeValue.
+func decodeValue(fd api.FeatureDescriptor, raw []byte) (any, error) {
+	switch {
+	case fd.Primitive == api.PrimitiveTypeEmbedding:
+		var emb api.Embedding
+		err := json.Unmarshal(raw, &emb)
+		return emb, err
+	case fd.Primitive == api.PrimitiveTypeStruct:
+		var m map[string]any
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return nil, err
+		}
+		return fd.StructValue(m)
+	case fd.Primitive.Scalar():
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return scalar(s, fd.Primitive)
+	default:
+		var ss []string
+		if err := json.Unmarshal(raw, &ss); err != nil {
+			return nil, err
+		}
+		l := make([]any, len(ss))
+		for i, s := range ss {
+			v, err := scalar(s, fd.Primitive.Singular())
+			if err != nil {
+				return nil, err
+			}
+			l[i] = v
+		}
+		return api.NormalizeAny(l)
+	}
+}
+
+// expireAt returns the expiration time for the given TTL, or nil if the TTL is zero (no expiration).
+func expireAt(now time.Time, ttl time.Duration) *time.Time {
+	if ttl <= 0 {
+		return nil
+	}
+	t := now.Add(ttl)
+	return &t
+}
+
+func (s *state) Get(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, version uint) (*api.Value, error) {
+	if fd.ValidWindow() {
+		if version != 0 {
+			return nil, fmt.Errorf("version is not supported for windowed features")
+		}
+		return s.getWindow(ctx, fd, keys)
+	}
+	return s.getPrimitive(ctx, fd, keys, version)
+}
+
+func (s *state) getPrimitive(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, version uint) (*api.Value, error) {
+	encodedKeys, err := keys.Encode(fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode keys: %w", err)
+	}
+
+	var raw []byte
+	var ts time.Time
+	err = s.pool.QueryRow(ctx, sqlGetValue, fd.FQN, encodedKeys, int(version), time.Now()).Scan(&raw, &ts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get value: %w", err)
+	}
+
+	val, err := decodeValue(fd, raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the value of %s:%s: %w", fd.FQN, encodedKeys, err)
+	}
+	return &api.Value{
+		Value:     val,
+		Timestamp: ts,
+		Fresh:     time.Since(ts) < fd.Freshness,
+	}, nil
+}
+func (s *state) Update(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	if fd.ValidWindow() {
+		return s.WindowAdd(ctx, fd, keys, value, ts)
+	}
+	if fd.Primitive.Scalar() || fd.Primitive == api.PrimitiveTypeEmbedding || fd.Primitive == api.PrimitiveTypeStruct {
+		return s.Set(ctx, fd, keys, value, ts)
+	}
+	return s.Append(ctx, fd, keys, value, ts)
+}
+
+// keepVersions copies the previous versions of the value to their next version within the write transaction
+func keepVersions(ctx context.Context, tx pgx.Tx, fd api.FeatureDescriptor, encodedKeys string, now time.Time) error {
+	// serializes the writers of the key, as the versions are shifted across multiple statements
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended($1, 0))`, fd.FQN+":"+encodedKeys); err != nil {
+		return fmt.Errorf("failed to lock the key: %w", err)
+	}
+
+	for i := int(fd.KeepPrevious.Versions) - 1; i >= 0; i-- {
+		var xat *time.Time
+		if fd.KeepPrevious.Over > 0 {
+			xat = expireAt(now, time.Duration(i+1)*fd.KeepPrevious.Over)
+		}
+		tag, err := tx.Exec(ctx, sqlCopyVersion, fd.FQN, encodedKeys, i, i+1, xat, now)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() > 0 {
+			continue
+		}
+		if _, err := tx.Exec(ctx, sqlDeleteVersion, fd.FQN, encodedKeys, i+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upsertValue upserts the latest version of the value with the statement of the write method.
+// If the feature keeps previous versions, they are shifted within the same transaction.
+func (s *state) upsertValue(ctx context.Context, query string, fd api.FeatureDescriptor, keys api.Keys, val any, ts time.Time) error {
+	encodedKeys, err := keys.Encode(fd)
+	if err != nil {
+		return fmt.Errorf("failed to encode keys: %w", err)
+	}
+	raw, err := encodeValue(fd, val)
+	if err != nil {
+		return fmt.Errorf("failed to encode value: %w", err)
+	}
+
+	now := time.Now()
+	args := []any{fd.FQN, encodedKeys, raw, ts, expireAt(now, fd.Staleness), now}
+	if fd.KeepPrevious == nil {
+		_, err = s.pool.Exec(ctx, query, args...)
+		return err
+	}
+	return pgx.BeginFunc(ctx, s.pool, func(tx pgx.Tx) error {
+		if err := keepVersions(ctx, tx, fd, encodedKeys, now); err != nil {
+			return fmt.Errorf("failed to keep versions while updating value: %w", err)
+		}
+		_, err := tx.Exec(ctx, query, args...)
+		return err
+	})
+}
+
+func (s *state) Set(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	if fd.ValidWindow() {
+		return s.WindowAdd(ctx, fd, keys, value, ts)
+	}
+	if time.Since(ts) > fd.Staleness {
+		return fmt.Errorf("timestamp %s is too old", ts)
+	}
+
+	var val any
+	var err error
+	if fd.Primitive == api.PrimitiveTypeEmbedding {
+		val, err = fd.EmbeddingValue(value)
+		if err != nil {
+			return err
+		}
+	} else if fd.Primitive == api.PrimitiveTypeStruct {
+		val, err = fd.StructValue(value)
+		if err != nil {
+			return err
+		}
+	} else if fd.Primitive.Scalar() {
+		val, err = scalar(value, fd.Primitive)
+		if err != nil {
+			return err
+		}
+	} else {
+		val, err = listValue(value, fd.Primitive.Singular())
+		if err != nil {
+			return fmt.Errorf("`Set` of a list feature only supports slices and arrays")
+		}
+	}
+
+	return s.upsertValue(ctx, sqlSetValue, fd, keys, val, ts)
+}
+
+// listValue converts the items of a slice (or an array) to the given scalar type
+func listValue(value any, pt api.PrimitiveType) ([]any, error) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("value is not a slice or an array")
+	}
+	l := make([]any, rv.Len())
+	for i := range l {
+		v, err := scalar(rv.Index(i).Interface(), pt)
+		if err != nil {
+			return nil, err
+		}
+		l[i] = v
+	}
+	return l, nil
+}
+
+func (s *state) Append(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	if fd.ValidWindow() {
+		return fmt.Errorf("cannot append a windowed feature")
+	}
+	if time.Since(ts) > fd.Staleness {
+		return fmt.Errorf("timestamp %s is too old", ts)
+	}
+	if fd.Primitive == api.PrimitiveTypeEmbedding {
+		return fmt.Errorf("cannot append to an embedding feature")
+	}
+	if fd.Primitive == api.PrimitiveTypeStruct {
+		return fmt.Errorf("cannot append to a struct feature")
+	}
+	if fd.Primitive.Scalar() {
+		return fmt.Errorf("`Append` only supports slices and arrays")
+	}
+
+	if rv := reflect.ValueOf(value); rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		value = []any{value}
+	}
+	vals, err := listValue(value, fd.Primitive.Singular())
+	if err != nil {
+		return err
+	}
+	return s.upsertValue(ctx, sqlAppendValue, fd, keys, vals, ts)
+}
+
+func (s *state) Incr(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	if fd.ValidWindow() {
+		return fmt.Errorf("cannot increment to a windowed feature")
+	}
+	if time.Since(ts) > fd.Staleness {
+		return fmt.Errorf("timestamp %s is too old", ts)
+	}
+	if !fd.Primitive.Scalar() {
+		return fmt.Errorf("`Ince` only supports sclars")
+	}
+	switch value.(type) {
+	case int, float64:
+	default:
+		return fmt.Errorf("`Incr` only supports scalar numberic values")
+	}
+
+	val, err := scalar(value, fd.Primitive)
+	if err != nil {
+		return err
+	}
+	return s.upsertValue(ctx, sqlIncrValue, fd, keys, val, ts)
+}
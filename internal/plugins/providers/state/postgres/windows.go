@@ -0,0 +1,211 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/raptor-ml/raptor/api"
+	"strings"
+	"time"
+)
+
+// bucketColumns are the fields of api.BucketData that have a column of their own. The other fields (the sketches of
+// the distinct count and the percentiles) are kept in the `sketches` column.
+var bucketColumns = []string{"sum", "count", "m2", "m2_count", "m2_mean", "min", "max", "first", "first_ts", "last", "last_ts"}
+
+// bucketMerges are the expressions that merge the columns of a stored bucket (b) with an added one (excluded).
+// The expressions of the upsert read the stored values before the update, so m2 and m2_mean are merged along with
+// m2_count (see api.BucketOpMoments).
+var bucketMerges = map[string]string{
+	"sum":      `CASE WHEN excluded."sum" IS NULL THEN b."sum" ELSE coalesce(b."sum", 0) + excluded."sum" END`,
+	"count":    `CASE WHEN excluded."count" IS NULL THEN b."count" ELSE coalesce(b."count", 0) + excluded."count" END`,
+	"m2":       `CASE WHEN excluded."m2_count" IS NULL THEN b."m2" WHEN b."m2_count" IS NULL THEN excluded."m2" ELSE b."m2" + excluded."m2" + (excluded."m2_mean" - b."m2_mean") ^ 2 * b."m2_count" * excluded."m2_count" / (b."m2_count" + excluded."m2_count") END`,
+	"m2_count": `CASE WHEN excluded."m2_count" IS NULL THEN b."m2_count" ELSE coalesce(b."m2_count", 0) + excluded."m2_count" END`,
+	"m2_mean":  `CASE WHEN excluded."m2_count" IS NULL THEN b."m2_mean" WHEN b."m2_count" IS NULL THEN excluded."m2_mean" ELSE b."m2_mean" + (excluded."m2_mean" - b."m2_mean") * excluded."m2_count" / (b."m2_count" + excluded."m2_count") END`,
+	"min":      `least(b."min", excluded."min")`,
+	"max":      `greatest(b."max", excluded."max")`,
+	"first":    `CASE WHEN excluded."first_ts" < b."first_ts" OR b."first_ts" IS NULL THEN excluded."first" ELSE b."first" END`,
+	"first_ts": `CASE WHEN excluded."first_ts" < b."first_ts" OR b."first_ts" IS NULL THEN excluded."first_ts" ELSE b."first_ts" END`,
+	"last":     `CASE WHEN excluded."last_ts" >= b."last_ts" OR b."last_ts" IS NULL THEN excluded."last" ELSE b."last" END`,
+	"last_ts":  `CASE WHEN excluded."last_ts" >= b."last_ts" OR b."last_ts" IS NULL THEN excluded."last_ts" ELSE b."last_ts" END`,
+}
+
+var (
+	sqlUpsertBucket string
+	sqlGetBuckets   string
+	// sqlDeadBuckets is served by the primary key, as it's prefixed by the fqn and the bucket
+	sqlDeadBuckets  string
+	sqlSweepBuckets = `DELETE FROM raptor_state_buckets WHERE expire_at <= $1`
+)
+
+func init() {
+	cols := make([]string, len(bucketColumns))
+	params := make([]string, len(bucketColumns))
+	sets := make([]string, len(bucketColumns))
+	for i, c := range bucketColumns {
+		cols[i] = fmt.Sprintf(`"%s"`, c)
+		params[i] = fmt.Sprintf("$%d", i+7)
+		sets[i] = fmt.Sprintf(`"%s" = %s`, c, bucketMerges[c])
+	}
+
+	sqlUpsertBucket = fmt.Sprintf(`INSERT INTO raptor_state_buckets AS b (fqn, bucket, keys, ts, expire_at, sketches, %s)
+		VALUES ($1, $2, $3, $4, $5, $6, %s)
+		ON CONFLICT (fqn, bucket, keys) DO UPDATE SET
+			%s,
+			sketches = raptor_merge_sketches(b.sketches, excluded.sketches),
+			ts = greatest(b.ts, excluded.ts)`,
+		strings.Join(cols, ", "), strings.Join(params, ", "), strings.Join(sets, ",\n\t\t\t"))
+	sqlGetBuckets = fmt.Sprintf(`SELECT bucket, keys, sketches, %s FROM raptor_state_buckets
+		WHERE fqn = $1 AND keys = $2 AND bucket = ANY($3) AND expire_at > $4`, strings.Join(cols, ", "))
+	sqlDeadBuckets = fmt.Sprintf(`SELECT bucket, keys, sketches, %s FROM raptor_state_buckets
+		WHERE fqn = $1 AND bucket = ANY($2) AND expire_at > $3`, strings.Join(cols, ", "))
+}
+
+// scanBuckets scans the rows of sqlGetBuckets or sqlDeadBuckets
+func scanBuckets(fqn string, rows pgx.Rows, ignore map[string]struct{}) (api.RawBuckets, error) {
+	var buckets api.RawBuckets
+	for rows.Next() {
+		var bucket, keys string
+		sketches := make(map[string]float64)
+		cols := make([]*float64, len(bucketColumns))
+		dest := []any{&bucket, &keys, &sketches}
+		for i := range cols {
+			dest = append(dest, &cols[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan bucket: %w", err)
+		}
+		if _, ok := ignore[bucket+":"+keys]; ok {
+			continue
+		}
+
+		data := api.BucketData(sketches)
+		for i, v := range cols {
+			if v != nil {
+				data[bucketColumns[i]] = *v
+			}
+		}
+		if len(data) == 0 {
+			continue
+		}
+		buckets = append(buckets, api.RawBucket{
+			FQN:         fqn,
+			Bucket:      bucket,
+			EncodedKeys: keys,
+			Data:        data,
+		})
+	}
+	return buckets, rows.Err()
+}
+
+func (s *state) DeadWindowBuckets(ctx context.Context, fd api.FeatureDescriptor, ignore api.RawBuckets) (api.RawBuckets, error) {
+	ignored := make(map[string]struct{})
+	for _, b := range ignore {
+		ignored[b.Bucket+":"+b.EncodedKeys] = struct{}{}
+	}
+
+	rows, err := s.pool.Query(ctx, sqlDeadBuckets, fd.FQN, api.DeadWindowBuckets(fd.Staleness, fd.Freshness), time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dead buckets: %w", err)
+	}
+	defer rows.Close()
+	return scanBuckets(fd.FQN, rows, ignored)
+}
+
+func (s *state) WindowBuckets(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, bucketNames []string) (api.RawBuckets, error) {
+	encodedKeys, err := keys.Encode(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.pool.Query(ctx, sqlGetBuckets, fd.FQN, encodedKeys, bucketNames, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get buckets: %w", err)
+	}
+	defer rows.Close()
+	return scanBuckets(fd.FQN, rows, nil)
+}
+
+func (s *state) getWindow(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys) (*api.Value, error) {
+	buckets, err := s.WindowBuckets(ctx, fd, keys, api.AliveWindowBuckets(fd.Staleness, fd.Freshness))
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]api.BucketData, len(buckets))
+	for i, b := range buckets {
+		data[i] = b.Data
+	}
+	ret := api.WindowResult(fd.Aggr, data...)
+	if ret == nil {
+		return nil, nil
+	}
+
+	return &api.Value{
+		Value:     ret,
+		Timestamp: time.Now(),
+		Fresh:     true,
+	}, nil
+}
+
+func (s *state) WindowAdd(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
+	updates, err := api.BucketUpdates(fd.Aggr, value, ts)
+	if err != nil {
+		return err
+	}
+	return s.windowApply(ctx, fd, keys, updates, ts)
+}
+
+func (s *state) WindowMerge(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, data api.BucketData, ts time.Time) error {
+	return s.windowApply(ctx, fd, keys, data.Updates(), ts)
+}
+
+// windowApply applies the updates to the bucket of the window that contains the timestamp.
+func (s *state) windowApply(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, updates []api.BucketUpdate, ts time.Time) error {
+	bucketName := api.BucketName(ts, fd.Freshness)
+	encodedKeys, err := keys.Encode(fd)
+	if err != nil {
+		return fmt.Errorf("failed to encode keys: %w", err)
+	}
+
+	exp := api.BucketDeadTime(bucketName, fd.Freshness, fd.Staleness)
+	if !time.Now().Before(exp) {
+		// the bucket is already dead, so there's nothing to keep
+		return nil
+	}
+
+	// the updates are applied to an empty bucket, and merged with the stored one by the upsert
+	data := make(api.BucketData)
+	data.Apply(updates)
+	args := []any{fd.FQN, bucketName, encodedKeys, ts, exp, nil}
+	for _, c := range bucketColumns {
+		if v, ok := data[c]; ok {
+			args = append(args, v)
+		} else {
+			args = append(args, nil)
+		}
+		delete(data, c)
+	}
+	args[5] = map[string]float64(data)
+
+	if _, err := s.pool.Exec(ctx, sqlUpsertBucket, args...); err != nil {
+		return fmt.Errorf("failed to add to bucket: %w", err)
+	}
+	return nil
+}
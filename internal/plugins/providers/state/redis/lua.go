@@ -32,7 +32,7 @@ func (rs redisScripts) Load(scripter redis.Scripter) error {
 	return nil
 }
 
-var scripts = redisScripts{luaHMax, luaHMin, luaMax, luaMaxExpAt}
+var scripts = redisScripts{luaHMax, luaHMin, luaHFirst, luaHLast, luaHMoments, luaMax, luaMaxExpAt}
 
 // luaHMin doing an atomic MIN operation on a given Hash's Field
 // Arguments:
@@ -74,6 +74,92 @@ end
 return 0
 `)
 
+// luaHFirst doing an atomic operation that keeps the earliest value on a given Hash's Field
+// Arguments:
+//   - KEYS[1] - Hash Key
+//   - KEYS[2] - Field key
+//   - KEYS[3] - Timestamp Field key
+//   - ARGV[1] - Numeric Value
+//   - ARGV[2] - Numeric Timestamp
+//
+// Returns 1 if there was a change or 0 if not
+var luaHFirst = redis.NewScript(`
+local key = KEYS[1]
+local field = KEYS[2]
+local tsField = KEYS[3]
+local ts = tonumber(ARGV[2])
+
+local current = redis.call('HGET', key, tsField)
+if not current or ts < tonumber(current) then
+  redis.call('HSET', key, field, ARGV[1], tsField, ARGV[2])
+  return 1
+end
+
+return 0
+`)
+
+// luaHLast doing an atomic operation that keeps the latest value on a given Hash's Field
+// Arguments:
+//   - KEYS[1] - Hash Key
+//   - KEYS[2] - Field key
+//   - KEYS[3] - Timestamp Field key
+//   - ARGV[1] - Numeric Value
+//   - ARGV[2] - Numeric Timestamp
+//
+// Returns 1 if there was a change or 0 if not
+var luaHLast = redis.NewScript(`
+local key = KEYS[1]
+local field = KEYS[2]
+local tsField = KEYS[3]
+local ts = tonumber(ARGV[2])
+
+local current = redis.call('HGET', key, tsField)
+if not current or ts >= tonumber(current) then
+  redis.call('HSET', key, field, ARGV[1], tsField, ARGV[2])
+  return 1
+end
+
+return 0
+`)
+
+// luaHMoments doing an atomic merge of the moments of values (see api.BucketOpMoments) on a given Hash's Fields
+// Arguments:
+//   - KEYS[1] - Hash Key
+//   - KEYS[2] - Field key of the sum of squared differences from the mean (M2)
+//   - KEYS[3] - Count Field key
+//   - KEYS[4] - Mean Field key
+//   - ARGV[1] - Numeric M2
+//   - ARGV[2] - Numeric Count
+//   - ARGV[3] - Numeric Mean
+//
+// Returns 1 if there was a change or 0 if not
+var luaHMoments = redis.NewScript(`
+local key = KEYS[1]
+local field = KEYS[2]
+local countField = KEYS[3]
+local meanField = KEYS[4]
+local m2 = tonumber(ARGV[1])
+local count = tonumber(ARGV[2])
+local mean = tonumber(ARGV[3])
+
+local current = redis.call('HMGET', key, field, countField, meanField)
+local curM2 = tonumber(current[1]) or 0
+local curCount = tonumber(current[2]) or 0
+local curMean = tonumber(current[3]) or 0
+
+local total = curCount + count
+if total == 0 then
+  return 0
+end
+
+local delta = mean - curMean
+redis.call('HSET', key,
+  field, curM2 + m2 + delta * delta * curCount * count / total,
+  countField, total,
+  meanField, curMean + delta * count / total)
+return 1
+`)
+
 // luaMax doing an atomic MAX operation on a regular key
 // Arguments:
 //   - KEYS[1] - Key
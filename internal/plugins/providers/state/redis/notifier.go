@@ -29,6 +29,7 @@ import (
 func init() {
 	plugins.CollectNotifierFactories.Register(pluginName, NotifierFactory[api.CollectNotification])
 	plugins.WriteNotifierFactories.Register(pluginName, NotifierFactory[api.WriteNotification])
+	plugins.WatchNotifierFactories.Register(pluginName, NotifierFactory[api.WatchNotification])
 }
 func NotifierFactory[T api.Notification](viper *viper.Viper) (api.Notifier[T], error) {
 	rc, err := redisClient(viper, viper.GetInt("redis-db"))
@@ -51,6 +52,8 @@ func (n *notifier[T]) NotificationChannel() string {
 		return "_raptor:notification:write"
 	case api.CollectNotification:
 		return "_raptor:notification:collect"
+	case api.WatchNotification:
+		return "_raptor:notification:watch"
 	}
 	panic("not implemented")
 }
@@ -82,7 +85,8 @@ func (n *notifier[T]) Subscribe(ctx context.Context) (<-chan T, error) {
 			var notification T
 			err := json.Unmarshal([]byte(msg.Payload), &notification)
 			if err != nil {
-				panic(fmt.Errorf("couldn't unmarshal notification: %w", err))
+				// malformed notifications are dropped, as there's no one to redeliver them
+				continue
 			}
 			c <- notification
 		}
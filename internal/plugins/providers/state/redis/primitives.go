@@ -18,10 +18,13 @@ package redis
 
 import (
 	"context"
+	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/go-redis/redis/v8"
 	"github.com/raptor-ml/raptor/api"
+	"math"
 	"reflect"
 	"time"
 )
@@ -65,7 +68,25 @@ func (s *state) getPrimitive(ctx context.Context, fd api.FeatureDescriptor, keys
 	}
 
 	var val any
-	if fd.Primitive.Scalar() {
+	if fd.Primitive == api.PrimitiveTypeEmbedding {
+		res, err := s.client.Get(ctx, key).Result()
+		if err != nil {
+			return nil, err
+		}
+		val, err = decodeEmbedding(res)
+		if err != nil {
+			return nil, err
+		}
+	} else if fd.Primitive == api.PrimitiveTypeStruct {
+		res, err := s.client.HGetAll(ctx, key).Result()
+		if err != nil {
+			return nil, err
+		}
+		val, err = decodeStruct(fd, res)
+		if err != nil {
+			return nil, err
+		}
+	} else if fd.Primitive.Scalar() {
 		res, err := s.client.Get(ctx, key).Result()
 		if err != nil {
 			return nil, err
@@ -103,7 +124,7 @@ func (s *state) Update(ctx context.Context, fd api.FeatureDescriptor, keys api.K
 	if fd.ValidWindow() {
 		return s.WindowAdd(ctx, fd, keys, value, ts)
 	}
-	if fd.Primitive.Scalar() {
+	if fd.Primitive.Scalar() || fd.Primitive == api.PrimitiveTypeEmbedding || fd.Primitive == api.PrimitiveTypeStruct {
 		return s.Set(ctx, fd, keys, value, ts)
 	}
 	return s.Append(ctx, fd, keys, value, ts)
@@ -147,12 +168,37 @@ func (s *state) Set(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys
 		return err
 	}
 
+	var emb api.Embedding
+	if fd.Primitive == api.PrimitiveTypeEmbedding {
+		emb, err = fd.EmbeddingValue(value)
+		if err != nil {
+			return err
+		}
+	}
+	var fields map[string]any
+	if fd.Primitive == api.PrimitiveTypeStruct {
+		fields, err = encodeStruct(fd, value)
+		if err != nil {
+			return err
+		}
+	}
+
 	tx := s.client.TxPipeline()
 	if err := s.keepVersions(ctx, tx, fd, keys, ts); err != nil {
 		return fmt.Errorf("failed to keep versions while updating value: %w", err)
 	}
 
-	if fd.Primitive.Scalar() {
+	if fd.Primitive == api.PrimitiveTypeEmbedding {
+		tx.Set(ctx, key, encodeEmbedding(emb), fd.Staleness)
+	} else if fd.Primitive == api.PrimitiveTypeStruct {
+		tx.Del(ctx, key)
+		if len(fields) > 0 {
+			tx.HSet(ctx, key, fields)
+		}
+		if fd.Staleness > 0 {
+			tx.PExpire(ctx, key, fd.Staleness)
+		}
+	} else if fd.Primitive.Scalar() {
 		tx.Set(ctx, key, api.ScalarString(value), fd.Staleness)
 	} else {
 		tx.Del(ctx, key)
@@ -177,6 +223,12 @@ func (s *state) Append(ctx context.Context, fd api.FeatureDescriptor, keys api.K
 	if time.Since(ts) > fd.Staleness {
 		return fmt.Errorf("timestamp %s is too old", ts)
 	}
+	if fd.Primitive == api.PrimitiveTypeEmbedding {
+		return fmt.Errorf("cannot append to an embedding feature")
+	}
+	if fd.Primitive == api.PrimitiveTypeStruct {
+		return fmt.Errorf("cannot append to a struct feature")
+	}
 	if fd.Primitive.Scalar() {
 		return fmt.Errorf("`Append` only supports slices and arrays")
 	}
@@ -241,3 +293,89 @@ func (s *state) Incr(ctx context.Context, fd api.FeatureDescriptor, keys api.Key
 	_, err = tx.Exec(ctx)
 	return err
 }
+
+// encodeEmbedding encodes the embedding as packed little-endian float32s
+func encodeEmbedding(emb api.Embedding) []byte {
+	b := make([]byte, 4*len(emb))
+	for i, f := range emb {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(f))
+	}
+	return b
+}
+
+func decodeEmbedding(s string) (api.Embedding, error) {
+	if len(s)%4 != 0 {
+		return nil, fmt.Errorf("invalid embedding encoding of %d bytes", len(s))
+	}
+	b := []byte(s)
+	emb := make(api.Embedding, len(b)/4)
+	for i := range emb {
+		emb[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return emb, nil
+}
+
+// encodeStruct encodes the struct as the fields of a Redis hash.
+// Scalars are encoded as their ScalarString, and lists as a JSON array of ScalarStrings.
+func encodeStruct(fd api.FeatureDescriptor, value any) (map[string]any, error) {
+	st, err := fd.StructValue(value)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]any, len(st))
+	for k, v := range st {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice {
+			ret[k] = api.ScalarString(v)
+			continue
+		}
+		l := make([]string, rv.Len())
+		for i := range l {
+			l[i] = api.ScalarString(rv.Index(i).Interface())
+		}
+		b, err := json.Marshal(l)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode field `%s`: %w", k, err)
+		}
+		ret[k] = string(b)
+	}
+	return ret, nil
+}
+
+func decodeStruct(fd api.FeatureDescriptor, res map[string]string) (api.Struct, error) {
+	ret := make(api.Struct, len(res))
+	for k, v := range res {
+		f, ok := fd.Field(k)
+		if !ok {
+			// the field was removed from the schema
+			continue
+		}
+		if f.Primitive.Scalar() {
+			val, err := api.ScalarFromString(v, f.Primitive)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode field `%s`: %w", k, err)
+			}
+			ret[k] = val
+			continue
+		}
+
+		var l []string
+		if err := json.Unmarshal([]byte(v), &l); err != nil {
+			return nil, fmt.Errorf("failed to decode field `%s`: %w", k, err)
+		}
+		items := make([]any, len(l))
+		for i, item := range l {
+			val, err := api.ScalarFromString(item, f.Primitive.Singular())
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode field `%s`: %w", k, err)
+			}
+			items[i] = val
+		}
+		val, err := api.NormalizeAny(items)
+		if err != nil {
+			return nil, err
+		}
+		ret[k] = val
+	}
+	return ret, nil
+}
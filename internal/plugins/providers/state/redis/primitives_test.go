@@ -0,0 +1,65 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"github.com/raptor-ml/raptor/api"
+	"math"
+	"testing"
+)
+
+func TestEmbeddingEncoding(t *testing.T) {
+	tests := []struct {
+		name string
+		emb  api.Embedding
+	}{
+		{name: "values", emb: api.Embedding{1, -2.5, 0.125}},
+		{name: "empty", emb: api.Embedding{}},
+		{name: "NaN", emb: api.Embedding{float32(math.NaN()), 1}},
+		{name: "infinities", emb: api.Embedding{float32(math.Inf(1)), float32(math.Inf(-1))}},
+		{name: "extremes", emb: api.Embedding{math.MaxFloat32, math.SmallestNonzeroFloat32, float32(math.Copysign(0, -1))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := encodeEmbedding(tt.emb)
+			if len(b) != 4*len(tt.emb) {
+				t.Fatalf("expected %d bytes, got %d", 4*len(tt.emb), len(b))
+			}
+			got, err := decodeEmbedding(string(b))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.emb) {
+				t.Fatalf("decodeEmbedding() = %v, want %v", got, tt.emb)
+			}
+			// the values are compared by their bits, so NaNs and negative zeros are compared too
+			for i := range tt.emb {
+				if math.Float32bits(got[i]) != math.Float32bits(tt.emb[i]) {
+					t.Errorf("decodeEmbedding()[%d] = %v, want %v", i, got[i], tt.emb[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeInvalidEmbedding(t *testing.T) {
+	for _, s := range []string{"a", "abcde", "abcdefg"} {
+		if _, err := decodeEmbedding(s); err == nil {
+			t.Errorf("expected an error for an encoding of %d bytes", len(s))
+		}
+	}
+}
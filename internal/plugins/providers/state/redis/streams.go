@@ -0,0 +1,427 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/pkg/plugins"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const streamsPluginName = "redis-streams"
+
+func init() {
+	plugins.Configurers.Register(streamsPluginName, BindStreamsConfig)
+	plugins.CollectNotifierFactories.Register(streamsPluginName, StreamsNotifierFactory[api.CollectNotification])
+	plugins.WriteNotifierFactories.Register(streamsPluginName, StreamsNotifierFactory[api.WriteNotification])
+	plugins.WatchNotifierFactories.Register(streamsPluginName, StreamsNotifierFactory[api.WatchNotification])
+}
+
+const (
+	streamPayloadField = "payload"
+	// streamReadBlock is the maximal duration of a blocking read, so the subscription can notice it was canceled
+	streamReadBlock = 2 * time.Second
+	// streamRetryInterval is the interval between retries of failed reads
+	streamRetryInterval = time.Second
+	streamBatchSize     = 100
+)
+
+// StreamsNotifierFactory creates a Notifier on top of Redis Streams.
+//
+// Collect and write notifications are consumed by a consumer group, and must be acknowledged once they were handled
+// (see api.AckNotifier). Pending notifications of consumers that crashed are claimed by the other consumers, and
+// notifications that can't be decoded, or were delivered too many times, are moved to a dead-letter stream.
+// Watch notifications are fanned out to all the subscribers, so they are read without a consumer group.
+func StreamsNotifierFactory[T api.Notification](viper *viper.Viper) (api.Notifier[T], error) {
+	rc, err := redisClient(viper, viper.GetInt("redis-db"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create redis client: %w", err)
+	}
+
+	n := &streamsNotifier[T]{
+		client:        rc,
+		group:         viper.GetString("redis-streams-group"),
+		consumer:      viper.GetString("redis-streams-consumer"),
+		maxLen:        viper.GetInt64("redis-streams-max-len"),
+		claimIdle:     viper.GetDuration("redis-streams-claim-idle"),
+		maxDeliveries: viper.GetInt64("redis-streams-max-deliveries"),
+		pending:       make(map[T][]string),
+		inFlight:      make(map[string]struct{}),
+	}
+	if n.group == "" {
+		return nil, fmt.Errorf("redis-streams: consumer group is required")
+	}
+	if n.claimIdle <= 0 {
+		return nil, fmt.Errorf("redis-streams: claim idle time must be positive, got %s", n.claimIdle)
+	}
+	if n.maxDeliveries < 1 {
+		return nil, fmt.Errorf("redis-streams: max deliveries must be at least 1, got %d", n.maxDeliveries)
+	}
+	if n.consumer == "" {
+		// the hostname is the pod name, and the suffix distinguishes between restarts of a pod with a stable name
+		host, _ := os.Hostname()
+		n.consumer = fmt.Sprintf("%s-%s", host, uuid.NewString()[:8])
+	}
+	return n, nil
+}
+
+type streamsNotifier[T api.Notification] struct {
+	client        redis.UniversalClient
+	group         string
+	consumer      string
+	maxLen        int64
+	claimIdle     time.Duration
+	maxDeliveries int64
+
+	mu sync.Mutex
+	// pending are the IDs of the delivered messages of each notification, that weren't acknowledged yet.
+	// Identical notifications may be handled only once by the subscriber, so they are acknowledged together.
+	pending map[T][]string
+	// inFlight are the IDs of the delivered messages that weren't acknowledged yet
+	inFlight map[string]struct{}
+}
+
+func (n *streamsNotifier[T]) Stream() string {
+	var t T
+	switch any(t).(type) {
+	case api.WriteNotification:
+		return "_raptor:stream:write"
+	case api.CollectNotification:
+		return "_raptor:stream:collect"
+	case api.WatchNotification:
+		return "_raptor:stream:watch"
+	}
+	panic("not implemented")
+}
+
+// DeadLetterStream is the stream of the messages that couldn't be handled
+func (n *streamsNotifier[T]) DeadLetterStream() string {
+	return n.Stream() + ":dead"
+}
+
+// broadcast returns true if every subscriber should get all the notifications
+func (n *streamsNotifier[T]) broadcast() bool {
+	var t T
+	_, ok := any(t).(api.WatchNotification)
+	return ok
+}
+
+func (n *streamsNotifier[T]) Notify(ctx context.Context, notification T) error {
+	msg, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("cannot marshal notification: %w", err)
+	}
+	err = n.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: n.Stream(),
+		MaxLen: n.maxLen,
+		Approx: true,
+		Values: map[string]any{streamPayloadField: msg},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("cannot add notification to stream: %w", err)
+	}
+	return nil
+}
+
+func (n *streamsNotifier[T]) Ack(ctx context.Context, notification T) error {
+	n.mu.Lock()
+	ids := n.pending[notification]
+	delete(n.pending, notification)
+	n.mu.Unlock()
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := n.client.XAck(ctx, n.Stream(), n.group, ids...).Err()
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, id := range ids {
+		delete(n.inFlight, id)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot acknowledge notification: %w", err)
+	}
+	return nil
+}
+
+func (n *streamsNotifier[T]) Subscribe(ctx context.Context) (<-chan T, error) {
+	c := make(chan T)
+	if n.broadcast() {
+		lastID, err := n.lastID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the last message of the stream: %w", err)
+		}
+		go func() {
+			defer close(c)
+			n.fanOut(ctx, c, lastID)
+		}()
+		return c, nil
+	}
+	go func() {
+		defer close(c)
+		n.consume(ctx, c)
+	}()
+	return c, nil
+}
+
+// lastID returns the ID of the last message of the stream, or the minimal ID if the stream is empty
+func (n *streamsNotifier[T]) lastID(ctx context.Context) (string, error) {
+	msgs, err := n.client.XRevRangeN(ctx, n.Stream(), "+", "-", 1).Result()
+	if err != nil {
+		return "", err
+	}
+	if len(msgs) == 0 {
+		return "0-0", nil
+	}
+	return msgs[0].ID, nil
+}
+
+// fanOut delivers the messages of the stream that follow lastID, without a consumer group.
+// Every read resumes from the last delivered message, so the messages that are added between reads aren't skipped.
+func (n *streamsNotifier[T]) fanOut(ctx context.Context, c chan<- T, lastID string) {
+	for ctx.Err() == nil {
+		streams, err := n.client.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{n.Stream(), lastID},
+			Count:   streamBatchSize,
+			Block:   streamReadBlock,
+		}).Result()
+		if err != nil {
+			if !errors.Is(err, redis.Nil) {
+				sleep(ctx, streamRetryInterval)
+			}
+			continue
+		}
+		for _, s := range streams {
+			for _, msg := range s.Messages {
+				lastID = msg.ID
+				notification, err := decodeMessage[T](msg)
+				if err != nil {
+					continue
+				}
+				select {
+				case c <- notification:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}
+}
+
+// consume delivers the messages of the stream with the consumer group.
+// It starts with the messages that are pending for this consumer (i.e. before a restart), and then reads new ones.
+func (n *streamsNotifier[T]) consume(ctx context.Context, c chan<- T) {
+	for ctx.Err() == nil {
+		err := n.client.XGroupCreateMkStream(ctx, n.Stream(), n.group, "0").Err()
+		if err == nil || strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			break
+		}
+		sleep(ctx, streamRetryInterval)
+	}
+
+	go n.claimLoop(ctx, c)
+
+	lastID := "0"
+	for ctx.Err() == nil {
+		streams, err := n.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    n.group,
+			Consumer: n.consumer,
+			Streams:  []string{n.Stream(), lastID},
+			Count:    streamBatchSize,
+			Block:    streamReadBlock,
+		}).Result()
+		if err != nil {
+			if !errors.Is(err, redis.Nil) {
+				sleep(ctx, streamRetryInterval)
+			}
+			continue
+		}
+
+		var msgs []redis.XMessage
+		for _, s := range streams {
+			msgs = append(msgs, s.Messages...)
+		}
+		if lastID != ">" && len(msgs) == 0 {
+			// all the pending messages were delivered
+			lastID = ">"
+			continue
+		}
+		if lastID != ">" {
+			lastID = msgs[len(msgs)-1].ID
+		}
+		if !n.deliver(ctx, c, msgs) {
+			return
+		}
+	}
+}
+
+// claimLoop periodically claims the messages that are pending for too long, i.e. ones of consumers that crashed, or
+// ones that this consumer failed to acknowledge. Messages that were delivered too many times are moved to the
+// dead-letter stream.
+func (n *streamsNotifier[T]) claimLoop(ctx context.Context, c chan<- T) {
+	ticker := time.NewTicker(n.claimIdle / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pending, err := n.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: n.Stream(),
+			Group:  n.group,
+			Idle:   n.claimIdle,
+			Start:  "-",
+			End:    "+",
+			Count:  streamBatchSize,
+		}).Result()
+		if err != nil {
+			continue
+		}
+
+		var claim, dead []string
+		for _, p := range pending {
+			if n.isInFlight(p.ID) {
+				// still handled by this consumer
+				continue
+			}
+			if p.RetryCount >= n.maxDeliveries {
+				dead = append(dead, p.ID)
+			} else {
+				claim = append(claim, p.ID)
+			}
+		}
+
+		if msgs, err := n.claim(ctx, dead); err == nil {
+			for _, msg := range msgs {
+				_ = n.deadLetter(ctx, msg, fmt.Errorf("reached the maximal number of deliveries (%d)", n.maxDeliveries))
+			}
+		}
+		if msgs, err := n.claim(ctx, claim); err == nil && !n.deliver(ctx, c, msgs) {
+			return
+		}
+	}
+}
+
+// claim transfers the ownership of the pending messages to this consumer
+func (n *streamsNotifier[T]) claim(ctx context.Context, ids []string) ([]redis.XMessage, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return n.client.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   n.Stream(),
+		Group:    n.group,
+		Consumer: n.consumer,
+		MinIdle:  n.claimIdle,
+		Messages: ids,
+	}).Result()
+}
+
+func (n *streamsNotifier[T]) isInFlight(id string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, ok := n.inFlight[id]
+	return ok
+}
+
+// deliver sends the messages to the subscriber, and returns false if the subscription was canceled
+func (n *streamsNotifier[T]) deliver(ctx context.Context, c chan<- T, msgs []redis.XMessage) bool {
+	for _, msg := range msgs {
+		if n.isInFlight(msg.ID) {
+			continue
+		}
+
+		notification, err := decodeMessage[T](msg)
+		if err != nil {
+			_ = n.deadLetter(ctx, msg, err)
+			continue
+		}
+
+		n.mu.Lock()
+		n.inFlight[msg.ID] = struct{}{}
+		n.pending[notification] = append(n.pending[notification], msg.ID)
+		n.mu.Unlock()
+
+		select {
+		case c <- notification:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// deadLetter moves the message to the dead-letter stream, along with the reason
+func (n *streamsNotifier[T]) deadLetter(ctx context.Context, msg redis.XMessage, reason error) error {
+	payload, _ := msg.Values[streamPayloadField].(string)
+	_, err := n.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: n.DeadLetterStream(),
+			MaxLen: n.maxLen,
+			Approx: true,
+			Values: map[string]any{
+				streamPayloadField: payload,
+				"id":               msg.ID,
+				"error":            reason.Error(),
+			},
+		})
+		pipe.XAck(ctx, n.Stream(), n.group, msg.ID)
+		return nil
+	})
+	return err
+}
+
+func decodeMessage[T api.Notification](msg redis.XMessage) (T, error) {
+	var notification T
+	payload, ok := msg.Values[streamPayloadField].(string)
+	if !ok {
+		return notification, fmt.Errorf("message %s has no payload", msg.ID)
+	}
+	if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+		return notification, fmt.Errorf("couldn't unmarshal notification: %w", err)
+	}
+	return notification, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
+
+func BindStreamsConfig(set *pflag.FlagSet) error {
+	set.String("redis-streams-group", "raptor-historian", "Redis Streams consumer group of the notifications")
+	set.String("redis-streams-consumer", "", "Redis Streams consumer name (default: the hostname with a random suffix)")
+	set.Int64("redis-streams-max-len", 1_000_000, "Approximate maximal length of the Redis Streams of the notifications")
+	set.Duration("redis-streams-claim-idle", time.Minute, "Idle time after which pending notifications of other consumers are claimed")
+	set.Int64("redis-streams-max-deliveries", 5, "Number of deliveries after which a notification is moved to the dead-letter stream")
+	return nil
+}
@@ -0,0 +1,318 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redis
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/raptor-ml/raptor/api"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testTimeout = 5 * time.Second
+
+func newStreamsNotifier[T api.Notification](t *testing.T, mr *miniredis.Miniredis, consumer string, claimIdle time.Duration, maxDeliveries int64) *streamsNotifier[T] {
+	t.Helper()
+	rc := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rc.Close() })
+	return &streamsNotifier[T]{
+		client:        rc,
+		group:         "test",
+		consumer:      consumer,
+		maxLen:        1000,
+		claimIdle:     claimIdle,
+		maxDeliveries: maxDeliveries,
+		pending:       make(map[T][]string),
+		inFlight:      make(map[string]struct{}),
+	}
+}
+
+func subscribe[T api.Notification](t *testing.T, n *streamsNotifier[T]) (<-chan T, context.CancelFunc) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	c, err := n.Subscribe(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, cancel
+}
+
+func receive[T api.Notification](t *testing.T, c <-chan T) T {
+	t.Helper()
+	select {
+	case n, ok := <-c:
+		if !ok {
+			t.Fatal("the subscription was closed")
+		}
+		return n
+	case <-time.After(testTimeout):
+		t.Fatal("expected a notification")
+	}
+	panic("unreachable")
+}
+
+func expectNone[T api.Notification](t *testing.T, c <-chan T, d time.Duration) {
+	t.Helper()
+	select {
+	case n := <-c:
+		t.Fatalf("expected no notification, got %+v", n)
+	case <-time.After(d):
+	}
+}
+
+func pendingCount[T api.Notification](t *testing.T, n *streamsNotifier[T]) int64 {
+	t.Helper()
+	p, err := n.client.XPending(context.Background(), n.Stream(), n.group).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p.Count
+}
+
+func notify[T api.Notification](t *testing.T, n *streamsNotifier[T], notifications ...T) {
+	t.Helper()
+	for _, notification := range notifications {
+		if err := n.Notify(context.Background(), notification); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestStreamsConsumerGroup(t *testing.T) {
+	mr := miniredis.RunT(t)
+	ctx := context.Background()
+	a := newStreamsNotifier[api.CollectNotification](t, mr, "a", time.Minute, 5)
+	b := newStreamsNotifier[api.CollectNotification](t, mr, "b", time.Minute, 5)
+	ca, _ := subscribe(t, a)
+	cb, _ := subscribe(t, b)
+
+	want := map[string]bool{"a.default": true, "b.default": true, "c.default": true, "d.default": true}
+	for fqn := range want {
+		notify(t, a, api.CollectNotification{FQN: fqn, EncodedKeys: "1"})
+	}
+
+	// each notification is delivered to a single consumer of the group
+	got := make(map[string]int)
+	received := map[*streamsNotifier[api.CollectNotification]][]api.CollectNotification{}
+	for i := 0; i < len(want); i++ {
+		select {
+		case n := <-ca:
+			got[n.FQN]++
+			received[a] = append(received[a], n)
+		case n := <-cb:
+			got[n.FQN]++
+			received[b] = append(received[b], n)
+		case <-time.After(testTimeout):
+			t.Fatalf("expected %d notifications, got %v", len(want), got)
+		}
+	}
+	for fqn := range want {
+		if got[fqn] != 1 {
+			t.Errorf("expected %s to be delivered once, got %d", fqn, got[fqn])
+		}
+	}
+	expectNone(t, ca, 100*time.Millisecond)
+	expectNone(t, cb, 0)
+
+	if n := pendingCount(t, a); n != int64(len(want)) {
+		t.Errorf("expected %d pending notifications before the acknowledgements, got %d", len(want), n)
+	}
+	for n, notifications := range received {
+		for _, notification := range notifications {
+			if err := n.Ack(ctx, notification); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if n := pendingCount(t, a); n != 0 {
+		t.Errorf("expected no pending notifications, got %d", n)
+	}
+
+	// identical notifications are acknowledged together
+	dup := api.CollectNotification{FQN: "dup.default", EncodedKeys: "1"}
+	notify(t, a, dup, dup)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ca:
+		case <-cb:
+		case <-time.After(testTimeout):
+			t.Fatal("expected the duplicate notifications to be delivered")
+		}
+	}
+	// both are delivered to the same consumer only if it read them together, so each consumer acknowledges its own
+	_ = a.Ack(ctx, dup)
+	_ = b.Ack(ctx, dup)
+	if n := pendingCount(t, a); n != 0 {
+		t.Errorf("expected the duplicates to be acknowledged, got %d pending", n)
+	}
+}
+
+func TestStreamsRedeliverAfterRestart(t *testing.T) {
+	mr := miniredis.RunT(t)
+	a := newStreamsNotifier[api.WriteNotification](t, mr, "a", time.Minute, 5)
+	c, cancel := subscribe(t, a)
+
+	notification := api.WriteNotification{FQN: "a.default", EncodedKeys: "1"}
+	notify(t, a, notification)
+	receive(t, c)
+	// the consumer restarts before it acknowledged the notification
+	cancel()
+
+	restarted := newStreamsNotifier[api.WriteNotification](t, mr, "a", time.Minute, 5)
+	c, _ = subscribe(t, restarted)
+	if got := receive(t, c); got != notification {
+		t.Errorf("expected the pending notification to be redelivered, got %+v", got)
+	}
+	if err := restarted.Ack(context.Background(), notification); err != nil {
+		t.Fatal(err)
+	}
+	if n := pendingCount(t, restarted); n != 0 {
+		t.Errorf("expected no pending notifications, got %d", n)
+	}
+}
+
+func TestStreamsClaim(t *testing.T) {
+	mr := miniredis.RunT(t)
+	crashed := newStreamsNotifier[api.CollectNotification](t, mr, "crashed", time.Minute, 5)
+	c, cancel := subscribe(t, crashed)
+
+	notification := api.CollectNotification{FQN: "a.default", EncodedKeys: "1"}
+	notify(t, crashed, notification)
+	receive(t, c)
+	cancel()
+
+	// the notification is claimed by another consumer once it's idle for long enough
+	n := newStreamsNotifier[api.CollectNotification](t, mr, "other", 100*time.Millisecond, 5)
+	c, _ = subscribe(t, n)
+	if got := receive(t, c); got != notification {
+		t.Errorf("expected the claimed notification, got %+v", got)
+	}
+	// the notification is in flight, so it's not claimed again
+	expectNone(t, c, 300*time.Millisecond)
+
+	if err := n.Ack(context.Background(), notification); err != nil {
+		t.Fatal(err)
+	}
+	if p := pendingCount(t, n); p != 0 {
+		t.Errorf("expected no pending notifications, got %d", p)
+	}
+}
+
+func TestStreamsDeadLetter(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("undecodable", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		n := newStreamsNotifier[api.CollectNotification](t, mr, "a", time.Minute, 5)
+		c, _ := subscribe(t, n)
+
+		id, err := n.client.XAdd(ctx, &redis.XAddArgs{Stream: n.Stream(), Values: map[string]any{streamPayloadField: "{"}}).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		valid := api.CollectNotification{FQN: "a.default", EncodedKeys: "1"}
+		notify(t, n, valid)
+		if got := receive(t, c); got != valid {
+			t.Errorf("expected the valid notification, got %+v", got)
+		}
+
+		dead, err := n.client.XRange(ctx, n.DeadLetterStream(), "-", "+").Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(dead) != 1 || dead[0].Values["id"] != id || dead[0].Values[streamPayloadField] != "{" || dead[0].Values["error"] == "" {
+			t.Fatalf("expected the undecodable message in the dead-letter stream, got %+v", dead)
+		}
+		// the undecodable message is acknowledged, so only the valid one is pending
+		if p := pendingCount(t, n); p != 1 {
+			t.Errorf("expected 1 pending notification, got %d", p)
+		}
+	})
+
+	t.Run("max deliveries", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		crashed := newStreamsNotifier[api.CollectNotification](t, mr, "crashed", time.Minute, 1)
+		c, cancel := subscribe(t, crashed)
+		notify(t, crashed, api.CollectNotification{FQN: "a.default", EncodedKeys: "1"})
+		receive(t, c)
+		cancel()
+
+		// the notification was already delivered as many times as allowed, so it's dead-lettered instead of claimed
+		n := newStreamsNotifier[api.CollectNotification](t, mr, "other", 100*time.Millisecond, 1)
+		c, _ = subscribe(t, n)
+		deadline := time.Now().Add(testTimeout)
+		for {
+			l, err := n.client.XLen(ctx, n.DeadLetterStream()).Result()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if l == 1 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("expected the notification to be dead-lettered")
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+		expectNone(t, c, 100*time.Millisecond)
+		if p := pendingCount(t, n); p != 0 {
+			t.Errorf("expected no pending notifications, got %d", p)
+		}
+	})
+}
+
+func TestStreamsWatchFanOut(t *testing.T) {
+	mr := miniredis.RunT(t)
+	n := newStreamsNotifier[api.WatchNotification](t, mr, "a", time.Minute, 5)
+	subscribers := []<-chan api.WatchNotification{}
+
+	// the notifications that were sent before the subscription are not delivered
+	notify(t, n, api.WatchNotification{FQN: "old.default", EncodedKeys: "1"})
+	for i := 0; i < 2; i++ {
+		c, _ := subscribe(t, newStreamsNotifier[api.WatchNotification](t, mr, "", time.Minute, 5))
+		subscribers = append(subscribers, c)
+	}
+
+	// every subscriber gets every notification that was sent since it subscribed, even between its reads
+	var want []api.WatchNotification
+	for i := 0; i < 3; i++ {
+		want = append(want, api.WatchNotification{FQN: "a.default", EncodedKeys: strconv.Itoa(i)})
+		notify(t, n, want[i])
+		time.Sleep(50 * time.Millisecond)
+	}
+	for i, c := range subscribers {
+		for _, w := range want {
+			if got := receive(t, c); got != w {
+				t.Fatalf("subscriber %d: expected %+v, got %+v", i, w, got)
+			}
+		}
+		expectNone(t, c, 100*time.Millisecond)
+	}
+
+	// the notifications are not read with a consumer group, so nothing is pending
+	groups, err := n.client.XInfoGroups(context.Background(), n.Stream()).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 0 {
+		t.Errorf("expected no consumer groups, got %+v", groups)
+	}
+}
@@ -22,7 +22,6 @@ import (
 	"fmt"
 	"github.com/go-redis/redis/v8"
 	"github.com/raptor-ml/raptor/api"
-	"math"
 	"strconv"
 	"strings"
 	"sync"
@@ -123,13 +122,13 @@ func (s *state) windowBuckets(ctx context.Context, buckets []api.RawBucket) (api
 				return
 			}
 
-			rm := make(api.WindowResultMap)
+			rm := make(api.BucketData)
 			for k, v := range res {
 				vv, err := strconv.ParseFloat(v, 64)
 				if err != nil {
 					cErr <- err
 				}
-				rm[api.StringToAggrFn(k)] = vv
+				rm[k] = vv
 			}
 			c <- api.RawBucket{
 				FQN:         b.FQN,
@@ -186,36 +185,12 @@ func (s *state) getWindow(ctx context.Context, fd api.FeatureDescriptor, keys ap
 		return nil, err
 	}
 
-	var avg bool
-	ret := make(api.WindowResultMap)
-	for _, b := range buckets {
-		for _, fn := range fd.Aggr {
-			switch fn {
-			case api.AggrFnCount, api.AggrFnSum:
-				ret[fn] += b.Data[fn]
-			case api.AggrFnMin:
-				if _, ok := ret[fn]; !ok {
-					ret[fn] = b.Data[fn]
-				} else {
-					ret[fn] = math.Min(ret[fn], b.Data[fn])
-				}
-			case api.AggrFnMax:
-				if _, ok := ret[fn]; !ok {
-					ret[fn] = b.Data[fn]
-				} else {
-					ret[fn] = math.Max(ret[fn], b.Data[fn])
-				}
-			case api.AggrFnAvg:
-				avg = true
-			}
-		}
-	}
-	// Should be implicitly by the end
-	if avg {
-		ret[api.AggrFnAvg] = ret[api.AggrFnSum] / ret[api.AggrFnCount]
+	data := make([]api.BucketData, len(buckets))
+	for i, b := range buckets {
+		data[i] = b.Data
 	}
-
-	if len(ret) == 0 {
+	ret := api.WindowResult(fd.Aggr, data...)
+	if ret == nil {
 		return nil, nil
 	}
 
@@ -227,33 +202,41 @@ func (s *state) getWindow(ctx context.Context, fd api.FeatureDescriptor, keys ap
 }
 
 func (s *state) WindowAdd(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, value any, ts time.Time) error {
-	bucket := api.BucketName(ts, fd.Freshness)
-	key, err := primitiveKey(fd, keys, 0)
+	updates, err := api.BucketUpdates(fd.Aggr, value, ts)
 	if err != nil {
 		return err
 	}
+	return s.windowApply(ctx, fd, keys, updates, ts)
+}
+
+func (s *state) WindowMerge(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, data api.BucketData, ts time.Time) error {
+	return s.windowApply(ctx, fd, keys, data.Updates(), ts)
+}
 
-	var val float64
-	switch v := value.(type) {
-	case int:
-		val = float64(v)
-	case float64:
-		val = v
-	default:
-		return fmt.Errorf("unsupported value type %T", value)
+// windowApply applies the updates to the bucket of the window that contains the timestamp.
+func (s *state) windowApply(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, updates []api.BucketUpdate, ts time.Time) error {
+	bucket := api.BucketName(ts, fd.Freshness)
+	encodedKeys, err := keys.Encode(fd)
+	if err != nil {
+		return fmt.Errorf("failed to encode keys: %w", err)
 	}
+	key := windowKey(fd.FQN, bucket, encodedKeys)
 
 	tx := s.client.TxPipeline()
-	for _, fn := range fd.Aggr {
-		switch fn {
-		case api.AggrFnSum:
-			tx.HIncrByFloat(ctx, key, "sum", val)
-		case api.AggrFnCount:
-			tx.HIncrBy(ctx, key, "count", 1)
-		case api.AggrFnMin:
-			luaHMin.Run(ctx, tx, []string{key, "min"}, val)
-		case api.AggrFnMax:
-			luaHMax.Run(ctx, tx, []string{key, "max"}, val)
+	for _, u := range updates {
+		switch u.Op {
+		case api.BucketOpIncr:
+			tx.HIncrByFloat(ctx, key, u.Field, u.Value)
+		case api.BucketOpMin:
+			luaHMin.Run(ctx, tx, []string{key, u.Field}, u.Value)
+		case api.BucketOpMax:
+			luaHMax.Run(ctx, tx, []string{key, u.Field}, u.Value)
+		case api.BucketOpFirst:
+			luaHFirst.Run(ctx, tx, []string{key, u.Field, u.TimestampField()}, u.Value, u.Timestamp)
+		case api.BucketOpLast:
+			luaHLast.Run(ctx, tx, []string{key, u.Field, u.TimestampField()}, u.Value, u.Timestamp)
+		case api.BucketOpMoments:
+			luaHMoments.Run(ctx, tx, []string{key, u.Field, u.CountField(), u.MeanField()}, u.Value, u.Count, u.Mean)
 		}
 	}
 	exp := api.BucketDeadTime(bucket, fd.Freshness, fd.Staleness)
@@ -0,0 +1,307 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statetest implements a suite of tests that verifies the behavior of api.State implementations.
+// It is shared by the tests of the state providers, so they all behave the same way.
+package statetest
+
+import (
+	"context"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Factory creates a new, empty State for a single test.
+type Factory func(t *testing.T) api.State
+
+var seq atomic.Int64
+
+// fqn returns a unique FQN, so the tests don't collide when the state is shared (e.g. a database)
+func fqn(name string) string {
+	return fmt.Sprintf("%s-%d-%d.statetest", name, time.Now().UnixNano(), seq.Add(1))
+}
+
+func feature(name string, primitive api.PrimitiveType) api.FeatureDescriptor {
+	return api.FeatureDescriptor{
+		FQN:       fqn(name),
+		Primitive: primitive,
+		Freshness: time.Minute,
+		Staleness: time.Hour,
+		Keys:      []string{"id"},
+	}
+}
+
+func window(name string, aggr ...api.AggrFn) api.FeatureDescriptor {
+	fd := feature(name, api.PrimitiveTypeFloat)
+	fd.Aggr = aggr
+	fd.Freshness = time.Minute
+	fd.Staleness = 10 * time.Minute
+	return fd
+}
+
+var keys = api.Keys{"id": "1"}
+
+// now returns the current time in the precision of the state providers
+func now() time.Time {
+	return time.Now().Truncate(time.Millisecond)
+}
+
+func get(t *testing.T, s api.State, fd api.FeatureDescriptor, k api.Keys, version uint) *api.Value {
+	t.Helper()
+	v, err := s.Get(context.Background(), fd, k, version)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	return v
+}
+
+func expectValue(t *testing.T, s api.State, fd api.FeatureDescriptor, k api.Keys, version uint, want any) {
+	t.Helper()
+	v := get(t, s, fd, k, version)
+	if want == nil {
+		if v != nil {
+			t.Fatalf("expected no value for version %d, got %+v", version, v.Value)
+		}
+		return
+	}
+	if v == nil {
+		t.Fatalf("expected %v for version %d, got no value", want, version)
+	}
+	if !reflect.DeepEqual(v.Value, want) {
+		t.Fatalf("expected %#v for version %d, got %#v", want, version, v.Value)
+	}
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// Run runs the suite against the states of the factory
+func Run(t *testing.T, newState Factory) {
+	ctx := context.Background()
+
+	t.Run("get missing", func(t *testing.T) {
+		s := newState(t)
+		expectValue(t, s, feature("missing", api.PrimitiveTypeInteger), keys, 0, nil)
+	})
+
+	t.Run("set and get", func(t *testing.T) {
+		s := newState(t)
+		fd := feature("set", api.PrimitiveTypeInteger)
+		ts := now()
+		must(t, s.Set(ctx, fd, keys, 3, ts))
+
+		v := get(t, s, fd, keys, 0)
+		if v == nil || v.Value != 3 {
+			t.Fatalf("expected 3, got %+v", v)
+		}
+		if !v.Timestamp.Equal(ts) {
+			t.Errorf("expected timestamp %s, got %s", ts, v.Timestamp)
+		}
+		if !v.Fresh {
+			t.Errorf("expected the value to be fresh")
+		}
+		expectValue(t, s, fd, api.Keys{"id": "2"}, 0, nil)
+
+		must(t, s.Set(ctx, fd, keys, 4, ts))
+		expectValue(t, s, fd, keys, 0, 4)
+	})
+
+	t.Run("set a value that is too old", func(t *testing.T) {
+		s := newState(t)
+		fd := feature("old", api.PrimitiveTypeInteger)
+		if err := s.Set(ctx, fd, keys, 3, now().Add(-2*fd.Staleness)); err == nil {
+			t.Fatalf("expected an error for a stale timestamp")
+		}
+	})
+
+	t.Run("set a list", func(t *testing.T) {
+		s := newState(t)
+		fd := feature("set-list", api.PrimitiveTypeStringList)
+		must(t, s.Set(ctx, fd, keys, []string{"a", "b"}, now()))
+		expectValue(t, s, fd, keys, 0, []string{"a", "b"})
+	})
+
+	t.Run("append", func(t *testing.T) {
+		s := newState(t)
+		fd := feature("append", api.PrimitiveTypeIntegerList)
+		must(t, s.Append(ctx, fd, keys, 1, now()))
+		must(t, s.Append(ctx, fd, keys, []int{2, 3}, now()))
+		expectValue(t, s, fd, keys, 0, []int{1, 2, 3})
+
+		if err := s.Append(ctx, feature("append-scalar", api.PrimitiveTypeInteger), keys, 1, now()); err == nil {
+			t.Errorf("expected an error when appending to a scalar")
+		}
+	})
+
+	t.Run("incr", func(t *testing.T) {
+		s := newState(t)
+		fd := feature("incr", api.PrimitiveTypeInteger)
+		must(t, s.Incr(ctx, fd, keys, 2, now()))
+		must(t, s.Incr(ctx, fd, keys, 3, now()))
+		expectValue(t, s, fd, keys, 0, 5)
+
+		fd = feature("incr-float", api.PrimitiveTypeFloat)
+		must(t, s.Incr(ctx, fd, keys, 1.5, now()))
+		must(t, s.Incr(ctx, fd, keys, 1, now()))
+		expectValue(t, s, fd, keys, 0, 2.5)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		s := newState(t)
+		fd := feature("update", api.PrimitiveTypeString)
+		must(t, s.Update(ctx, fd, keys, "a", now()))
+		must(t, s.Update(ctx, fd, keys, "b", now()))
+		expectValue(t, s, fd, keys, 0, "b")
+
+		fd = feature("update-list", api.PrimitiveTypeStringList)
+		must(t, s.Update(ctx, fd, keys, "a", now()))
+		must(t, s.Update(ctx, fd, keys, "b", now()))
+		expectValue(t, s, fd, keys, 0, []string{"a", "b"})
+	})
+
+	t.Run("keep previous", func(t *testing.T) {
+		s := newState(t)
+		fd := feature("versions", api.PrimitiveTypeInteger)
+		fd.KeepPrevious = &api.KeepPrevious{Versions: 2, Over: time.Hour}
+
+		// only the versions that exist are shifted
+		must(t, s.Set(ctx, fd, keys, 1, now()))
+		expectValue(t, s, fd, keys, 0, 1)
+		expectValue(t, s, fd, keys, 1, nil)
+
+		must(t, s.Set(ctx, fd, keys, 2, now()))
+		must(t, s.Incr(ctx, fd, keys, 1, now()))
+		expectValue(t, s, fd, keys, 0, 3)
+		expectValue(t, s, fd, keys, 1, 2)
+		expectValue(t, s, fd, keys, 2, 1)
+
+		// the oldest version is dropped
+		must(t, s.Set(ctx, fd, keys, 4, now()))
+		expectValue(t, s, fd, keys, 0, 4)
+		expectValue(t, s, fd, keys, 1, 3)
+		expectValue(t, s, fd, keys, 2, 2)
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		s := newState(t)
+		fd := feature("ttl", api.PrimitiveTypeInteger)
+		fd.Staleness = 500 * time.Millisecond
+		must(t, s.Set(ctx, fd, keys, 1, now()))
+		expectValue(t, s, fd, keys, 0, 1)
+
+		time.Sleep(fd.Staleness + 100*time.Millisecond)
+		expectValue(t, s, fd, keys, 0, nil)
+	})
+
+	t.Run("windows", func(t *testing.T) {
+		s := newState(t)
+		fd := window("window", api.AggrFnSum, api.AggrFnCount, api.AggrFnMin, api.AggrFnMax, api.AggrFnAvg)
+		ts := now()
+		for _, add := range []struct {
+			val float64
+			ts  time.Time
+		}{
+			{1, ts},
+			{2, ts},
+			{5, ts.Add(-2 * fd.Freshness)},
+			// outside the window
+			{100, ts.Add(-fd.Staleness - fd.Freshness)},
+		} {
+			must(t, s.WindowAdd(ctx, fd, keys, add.val, add.ts))
+		}
+
+		v := get(t, s, fd, keys, 0)
+		if v == nil {
+			t.Fatalf("expected a window value")
+		}
+		want := api.WindowResultMap{api.AggrFnSum: 8, api.AggrFnCount: 3, api.AggrFnMin: 1, api.AggrFnMax: 5, api.AggrFnAvg: 8.0 / 3}
+		if !reflect.DeepEqual(v.Value, want) {
+			t.Errorf("expected %v, got %v", want, v.Value)
+		}
+
+		if _, err := s.Get(ctx, fd, keys, 1); err == nil {
+			t.Errorf("expected an error for a version of a windowed feature")
+		}
+
+		buckets, err := s.WindowBuckets(ctx, fd, keys, api.AliveWindowBuckets(fd.Staleness, fd.Freshness))
+		must(t, err)
+		if len(buckets) != 2 {
+			t.Fatalf("expected 2 alive buckets, got %d", len(buckets))
+		}
+		for _, b := range buckets {
+			if b.FQN != fd.FQN || b.EncodedKeys != "1" {
+				t.Errorf("unexpected bucket %+v", b)
+			}
+		}
+
+		dead, err := s.DeadWindowBuckets(ctx, fd, nil)
+		must(t, err)
+		if len(dead) != 1 || dead[0].Data["sum"] != 100 {
+			t.Fatalf("expected the bucket outside the window to be dead, got %+v", dead)
+		}
+		dead, err = s.DeadWindowBuckets(ctx, fd, dead)
+		must(t, err)
+		if len(dead) != 0 {
+			t.Errorf("expected the ignored dead buckets to be skipped, got %+v", dead)
+		}
+	})
+
+	t.Run("window merge", func(t *testing.T) {
+		s := newState(t)
+		fd := window("merge", api.AggrFnSum, api.AggrFnCount, api.AggrFnMin, api.AggrFnMax, api.AggrFnVariance,
+			api.AggrFnFirst, api.AggrFnLast)
+		ts := now()
+		for _, v := range []float64{1, 3} {
+			must(t, s.WindowAdd(ctx, fd, keys, v, ts))
+		}
+
+		// the raw data of the bucket of another instance, with the values 5 and 7
+		other, err := api.BucketUpdates(fd.Aggr, 5.0, ts.Add(-time.Second))
+		must(t, err)
+		data := api.BucketData{}
+		data.Apply(other)
+		more, err := api.BucketUpdates(fd.Aggr, 7.0, ts.Add(time.Second))
+		must(t, err)
+		data.Apply(more)
+		must(t, s.WindowMerge(ctx, fd, keys, data, ts))
+
+		name := api.BucketName(ts, fd.Freshness)
+		buckets, err := s.WindowBuckets(ctx, fd, keys, []string{name})
+		must(t, err)
+		if len(buckets) != 1 {
+			t.Fatalf("expected a single bucket, got %d", len(buckets))
+		}
+
+		got := api.WindowResult(fd.Aggr, buckets[0].Data)
+		want := api.WindowResultMap{
+			api.AggrFnSum: 16, api.AggrFnCount: 4, api.AggrFnMin: 1, api.AggrFnMax: 7,
+			api.AggrFnVariance: 20.0 / 3, api.AggrFnFirst: 5, api.AggrFnLast: 7,
+		}
+		for fn, w := range want {
+			if g, ok := got[fn]; !ok || g-w > 1e-9 || w-g > 1e-9 {
+				t.Errorf("expected %s to be %v, got %v", fn, w, got[fn])
+			}
+		}
+	})
+}
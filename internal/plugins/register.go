@@ -17,19 +17,33 @@ limitations under the License.
 package plugins
 
 import (
+	_ "github.com/raptor-ml/raptor/internal/plugins/builders/kafka"
 	_ "github.com/raptor-ml/raptor/internal/plugins/builders/model"
 	_ "github.com/raptor-ml/raptor/internal/plugins/builders/rest"
 	// register all builder plugins
 	_ "github.com/raptor-ml/raptor/internal/plugins/builders/sourceless"
+	_ "github.com/raptor-ml/raptor/internal/plugins/builders/sql"
 	_ "github.com/raptor-ml/raptor/internal/plugins/builders/streaming"
 
+	// register all encoder plugins
+	_ "github.com/raptor-ml/raptor/internal/plugins/encoders"
+
 	// register all model server plugins
+	_ "github.com/raptor-ml/raptor/internal/plugins/modelservers/kserve-v2"
 	_ "github.com/raptor-ml/raptor/internal/plugins/modelservers/sagemaker-ack"
 
 	// register all historical provider plugins
+	_ "github.com/raptor-ml/raptor/internal/plugins/providers/historical/parquet/local"
 	_ "github.com/raptor-ml/raptor/internal/plugins/providers/historical/parquet/s3"
 	_ "github.com/raptor-ml/raptor/internal/plugins/providers/historical/snowflake"
+	_ "github.com/raptor-ml/raptor/internal/plugins/providers/historical/sql"
+
+	// register all notifier plugins
+	_ "github.com/raptor-ml/raptor/internal/plugins/providers/notifiers/nats"
 
 	// register all state provider plugins
+	_ "github.com/raptor-ml/raptor/internal/plugins/providers/state/bolt"
+	_ "github.com/raptor-ml/raptor/internal/plugins/providers/state/memory"
+	_ "github.com/raptor-ml/raptor/internal/plugins/providers/state/postgres"
 	_ "github.com/raptor-ml/raptor/internal/plugins/providers/state/redis"
 )
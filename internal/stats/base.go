@@ -114,7 +114,7 @@ func Run(cfg *rest.Config, kc client.Client, usageReporting bool, logger logr.Lo
 
 		wait.UntilWithContext(ctx, func(ctx context.Context) {
 			pr := push.New(usageAPI, "core").
-				Gatherer(metrics.Registry).
+				Gatherer(usageGatherer).
 				Client(&http.Client{Timeout: 5 * time.Minute})
 
 			for k, v := range consts {
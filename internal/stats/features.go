@@ -0,0 +1,222 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"path"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OtherFeatures is the label value that is used for the features that exceeded the cardinality limits
+const OtherFeatures = "_other"
+
+// Reasons of skipped messages
+const (
+	// SkipPermanent is a message that failed with an error that can't be resolved by retrying
+	SkipPermanent = "permanent"
+	// SkipRetriesExhausted is a message that kept failing until the retries were exhausted
+	SkipRetriesExhausted = "retries_exhausted"
+)
+
+// Cache results
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheStale = "stale"
+)
+
+const featureMetricsPrefix = coreSubsystemKey + "_feature_"
+
+var (
+	pipelineDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: coreSubsystemKey,
+		Name:      "feature_pipeline_duration_seconds",
+		Help:      "Latency of the feature read and write pipelines.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"fqn", "operation"})
+	cacheResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: coreSubsystemKey,
+		Name:      "feature_cache_total",
+		Help:      "Number of feature reads from the state, by result: hit, miss or stale.",
+	}, []string{"fqn", "result"})
+	valueAge = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: coreSubsystemKey,
+		Name:      "feature_value_age_seconds",
+		Help:      "Age of the feature values at read time.",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+	}, []string{"fqn"})
+	constraintViolations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: coreSubsystemKey,
+		Name:      "feature_constraint_violations_total",
+		Help:      "Number of written feature values that violated the feature's constraints, by constraint.",
+	}, []string{"fqn", "constraint"})
+	runtimeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Subsystem: coreSubsystemKey,
+		Name:      "runtime_execution_duration_seconds",
+		Help:      "Duration of the program executions, by runtime environment.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"env"})
+	skippedMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: coreSubsystemKey,
+		Name:      "feature_skipped_messages_total",
+		Help:      "Number of DataSource messages that a feature failed to process and were skipped, by reason.",
+	}, []string{"fqn", "reason"})
+	runtimeErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: coreSubsystemKey,
+		Name:      "runtime_execution_errors_total",
+		Help:      "Number of failed program executions, by runtime environment.",
+	}, []string{"env"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		pipelineDuration,
+		cacheResults,
+		valueAge,
+		constraintViolations,
+		skippedMessages,
+		runtimeDuration,
+		runtimeErrors,
+	)
+}
+
+// fqnLabels limits the cardinality of the `fqn` label
+var fqnLabels = &labelLimiter{seen: make(map[string]struct{})}
+
+type labelLimiter struct {
+	mu        sync.RWMutex
+	allowlist []string
+	max       int
+	seen      map[string]struct{}
+}
+
+// SetFeatureLabelLimits sets the cardinality limits of the per-feature metrics.
+// The allowlist is a list of FQN glob patterns (i.e. `default.*`) of the features that are labeled by their FQN. An
+// empty allowlist allows all the features.
+// Max is the maximum number of distinct FQN labels. Zero means unlimited.
+// The metrics of the features that don't meet the limits are aggregated under the OtherFeatures label.
+func SetFeatureLabelLimits(allowlist []string, max int) {
+	fqnLabels.mu.Lock()
+	defer fqnLabels.mu.Unlock()
+
+	fqnLabels.allowlist = allowlist
+	fqnLabels.max = max
+}
+
+func (l *labelLimiter) allowed(fqn string) bool {
+	if len(l.allowlist) == 0 {
+		return true
+	}
+	for _, p := range l.allowlist {
+		if ok, _ := path.Match(p, fqn); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *labelLimiter) label(fqn string) string {
+	l.mu.RLock()
+	_, ok := l.seen[fqn]
+	l.mu.RUnlock()
+	if ok {
+		return fqn
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.seen[fqn]; ok {
+		return fqn
+	}
+	if !l.allowed(fqn) || (l.max > 0 && len(l.seen) >= l.max) {
+		return OtherFeatures
+	}
+	l.seen[fqn] = struct{}{}
+	return fqn
+}
+
+func (l *labelLimiter) forget(fqn string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, ok := l.seen[fqn]
+	delete(l.seen, fqn)
+	return ok
+}
+
+// ObservePipeline records the latency of a feature pipeline operation (i.e. `get`, `set`) that started at `start`.
+func ObservePipeline(fqn string, operation string, start time.Time) {
+	pipelineDuration.WithLabelValues(fqnLabels.label(fqn), strings.ToLower(operation)).Observe(time.Since(start).Seconds())
+}
+
+// IncrCacheResult increments the number of feature reads from the state with the given result.
+func IncrCacheResult(fqn string, result string) {
+	cacheResults.WithLabelValues(fqnLabels.label(fqn), result).Inc()
+}
+
+// ObserveValueAge records the age of a feature value at read time.
+func ObserveValueAge(fqn string, ts time.Time) {
+	valueAge.WithLabelValues(fqnLabels.label(fqn)).Observe(time.Since(ts).Seconds())
+}
+
+// IncrConstraintViolation increments the number of written values that violated the constraint.
+func IncrConstraintViolation(fqn string, constraint string) {
+	constraintViolations.WithLabelValues(fqnLabels.label(fqn), constraint).Inc()
+}
+
+// IncrSkippedMessage increments the number of DataSource messages that the feature failed to process and were skipped.
+func IncrSkippedMessage(fqn string, reason string) {
+	skippedMessages.WithLabelValues(fqnLabels.label(fqn), reason).Inc()
+}
+
+// ObserveRuntimeExecution records the duration of a program execution, and whether it failed.
+func ObserveRuntimeExecution(env string, start time.Time, err error) {
+	runtimeDuration.WithLabelValues(env).Observe(time.Since(start).Seconds())
+	if err != nil {
+		runtimeErrors.WithLabelValues(env).Inc()
+	}
+}
+
+// DeleteFeatureMetrics removes the metrics of the feature, and frees its label for other features.
+func DeleteFeatureMetrics(fqn string) {
+	if !fqnLabels.forget(fqn) {
+		return
+	}
+	l := prometheus.Labels{"fqn": fqn}
+	pipelineDuration.DeletePartialMatch(l)
+	cacheResults.DeletePartialMatch(l)
+	valueAge.DeletePartialMatch(l)
+	constraintViolations.DeletePartialMatch(l)
+	skippedMessages.DeletePartialMatch(l)
+}
+
+// usageGatherer excludes the per-feature metrics, so the feature names are never sent with the usage reports.
+var usageGatherer = prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+	mfs, err := metrics.Registry.Gather()
+	ret := mfs[:0]
+	for _, mf := range mfs {
+		if !strings.HasPrefix(mf.GetName(), featureMetricsPrefix) {
+			ret = append(ret, mf)
+		}
+	}
+	return ret, err
+})
@@ -0,0 +1,199 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"strings"
+	"testing"
+	"time"
+)
+
+// resetLabels resets the cardinality limits, and restores them when the test is done
+func resetLabels(t *testing.T, allowlist []string, max int) {
+	t.Helper()
+	reset := func() {
+		fqnLabels.mu.Lock()
+		fqnLabels.seen = make(map[string]struct{})
+		fqnLabels.mu.Unlock()
+		SetFeatureLabelLimits(nil, 0)
+	}
+	reset()
+	SetFeatureLabelLimits(allowlist, max)
+	t.Cleanup(reset)
+}
+
+func sampleCount(t *testing.T, o prometheus.Observer) uint64 {
+	t.Helper()
+	m := &dto.Metric{}
+	if err := o.(prometheus.Metric).Write(m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestFeatureLabelLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist []string
+		max       int
+		fqns      []string
+		want      []string
+	}{
+		{
+			name: "unlimited",
+			fqns: []string{"a.default", "b.default", "a.default"},
+			want: []string{"a.default", "b.default", "a.default"},
+		},
+		{
+			name:      "allowlist",
+			allowlist: []string{"*.default", "other.exact"},
+			fqns:      []string{"a.default", "a.other", "other.exact", "other.exact2"},
+			want:      []string{"a.default", OtherFeatures, "other.exact", OtherFeatures},
+		},
+		{
+			name: "max",
+			max:  2,
+			fqns: []string{"a.default", "b.default", "c.default", "a.default"},
+			want: []string{"a.default", "b.default", OtherFeatures, "a.default"},
+		},
+		{
+			name:      "allowlist and max",
+			allowlist: []string{"*.default"},
+			max:       1,
+			fqns:      []string{"a.other", "a.default", "b.default"},
+			want:      []string{OtherFeatures, "a.default", OtherFeatures},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetLabels(t, tt.allowlist, tt.max)
+			for i, fqn := range tt.fqns {
+				if got := fqnLabels.label(fqn); got != tt.want[i] {
+					t.Errorf("label(%s) = %s, want %s", fqn, got, tt.want[i])
+				}
+			}
+		})
+	}
+
+	t.Run("forgotten labels are freed", func(t *testing.T) {
+		resetLabels(t, nil, 1)
+		if got := fqnLabels.label("a.default"); got != "a.default" {
+			t.Fatalf("label() = %s", got)
+		}
+		if !fqnLabels.forget("a.default") || fqnLabels.forget("a.default") {
+			t.Error("expected the label to be forgotten once")
+		}
+		if got := fqnLabels.label("b.default"); got != "b.default" {
+			t.Errorf("expected the freed label to be reused, got %s", got)
+		}
+	})
+}
+
+func TestFeatureMetrics(t *testing.T) {
+	resetLabels(t, nil, 0)
+	const fqn = "metrics.default"
+
+	IncrCacheResult(fqn, CacheHit)
+	IncrCacheResult(fqn, CacheHit)
+	IncrCacheResult(fqn, CacheStale)
+	if got := testutil.ToFloat64(cacheResults.WithLabelValues(fqn, CacheHit)); got != 2 {
+		t.Errorf("expected 2 cache hits, got %v", got)
+	}
+	if got := testutil.ToFloat64(cacheResults.WithLabelValues(fqn, CacheStale)); got != 1 {
+		t.Errorf("expected 1 stale read, got %v", got)
+	}
+
+	// operations are labeled in lower case
+	ObservePipeline(fqn, "Get", time.Now())
+	ObservePipeline(fqn, "Set", time.Now().Add(-time.Second))
+	if got := sampleCount(t, pipelineDuration.WithLabelValues(fqn, "get")); got != 1 {
+		t.Errorf("expected 1 get observation, got %d", got)
+	}
+	if got := sampleCount(t, pipelineDuration.WithLabelValues(fqn, "set")); got != 1 {
+		t.Errorf("expected 1 set observation, got %d", got)
+	}
+
+	ObserveValueAge(fqn, time.Now().Add(-time.Minute))
+	if got := sampleCount(t, valueAge.WithLabelValues(fqn)); got != 1 {
+		t.Errorf("expected 1 value age observation, got %d", got)
+	}
+
+	IncrConstraintViolation(fqn, "min")
+	if got := testutil.ToFloat64(constraintViolations.WithLabelValues(fqn, "min")); got != 1 {
+		t.Errorf("expected 1 constraint violation, got %v", got)
+	}
+
+	IncrSkippedMessage(fqn, SkipPermanent)
+	if got := testutil.ToFloat64(skippedMessages.WithLabelValues(fqn, SkipPermanent)); got != 1 {
+		t.Errorf("expected 1 skipped message, got %v", got)
+	}
+
+	// the metrics of the feature are removed
+	DeleteFeatureMetrics(fqn)
+	for name, c := range map[string]prometheus.Collector{
+		"pipeline":    pipelineDuration,
+		"cache":       cacheResults,
+		"value age":   valueAge,
+		"constraints": constraintViolations,
+		"skipped":     skippedMessages,
+	} {
+		if n := testutil.CollectAndCount(c); n != 0 {
+			t.Errorf("expected the %s metrics to be deleted, got %d series", name, n)
+		}
+	}
+}
+
+func TestRuntimeMetrics(t *testing.T) {
+	const env = "test-env"
+	ObserveRuntimeExecution(env, time.Now(), nil)
+	ObserveRuntimeExecution(env, time.Now(), errors.New("failed"))
+
+	if got := sampleCount(t, runtimeDuration.WithLabelValues(env)); got != 2 {
+		t.Errorf("expected 2 executions, got %d", got)
+	}
+	if got := testutil.ToFloat64(runtimeErrors.WithLabelValues(env)); got != 1 {
+		t.Errorf("expected 1 error, got %v", got)
+	}
+}
+
+func TestUsageGathererExcludesFeatures(t *testing.T) {
+	resetLabels(t, nil, 0)
+	IncrCacheResult("usage.default", CacheMiss)
+	ObserveRuntimeExecution("usage-env", time.Now(), nil)
+	t.Cleanup(func() { DeleteFeatureMetrics("usage.default") })
+
+	mfs, err := usageGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	runtime := false
+	for _, mf := range mfs {
+		if strings.HasPrefix(mf.GetName(), featureMetricsPrefix) {
+			t.Errorf("expected the feature metric %s to be excluded", mf.GetName())
+		}
+		if mf.GetName() == coreSubsystemKey+"_runtime_execution_duration_seconds" {
+			runtime = true
+		}
+	}
+	if !runtime {
+		t.Error("expected the runtime metrics to be gathered")
+	}
+}
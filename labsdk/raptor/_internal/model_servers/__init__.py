@@ -14,4 +14,5 @@
 #  limitations under the License.
 
 from .protocol import *
+from .kserve import *
 from .sagemaker import *
@@ -0,0 +1,48 @@
+# -*- coding: utf-8 -*-
+#  Copyright (c) 2022 RaptorML authors.
+#
+#  Licensed under the Apache License, Version 2.0 (the "License");
+#  you may not use this file except in compliance with the License.
+#  You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+#  Unless required by applicable law or agreed to in writing, software
+#  distributed under the License is distributed on an "AS IS" BASIS,
+#  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+#  See the License for the specific language governing permissions and
+#  limitations under the License.
+
+from typing import Dict, Union
+
+from .protocol import ModelServer
+from ...types import SecretKeyRef
+
+
+class KServeV2(ModelServer):
+    """Open Inference Protocol (KServe V2) model server, such as Triton, MLServer or KServe."""
+
+    @classmethod
+    def configurable_envs(cls) -> Dict[str, str]:
+        return {
+            'KSERVE_ENDPOINT': '(REQUIRED) Address of the inference server, i.e. `http://triton.default:8000` for REST '
+                               'or `triton.default:8001` for gRPC',
+            'KSERVE_PROTOCOL': '(OPTIONAL) Protocol to communicate with the inference server: `rest` or `grpc`. '
+                               'Default is `rest`',
+            'KSERVE_MODEL_VERSION': '(OPTIONAL) Version of the model in the inference server',
+            'KSERVE_INPUT_NAME': '(OPTIONAL) Name of a single input tensor that holds all the features. '
+                                 'If not set, each feature is sent as its own input tensor',
+            'KSERVE_OUTPUT_NAME': '(OPTIONAL) Name of the output tensor that holds the prediction',
+            'KSERVE_TIMEOUT': '(OPTIONAL) Maximum duration of an inference request. Default is 5s',
+        }
+
+    @classmethod
+    def inference_config(cls, **kwargs) -> Dict[str, Union[str, SecretKeyRef]]:
+        return {
+            'endpoint': '$KSERVE_ENDPOINT',
+            'protocol': '$KSERVE_PROTOCOL',
+            'modelVersion': '$KSERVE_MODEL_VERSION',
+            'inputName': '$KSERVE_INPUT_NAME',
+            'outputName': '$KSERVE_OUTPUT_NAME',
+            'timeout': '$KSERVE_TIMEOUT',
+        }
@@ -38,15 +38,16 @@ import re
 from datetime import datetime
 from inspect import getsource, getsourcefile, getsourcelines
 from pydoc import locate
-from typing import List, Dict, Callable, Union, Tuple, Optional
+from typing import List, Dict, Callable, Union, Tuple, Optional, Any
 
 from redbaron import RedBaron, DefNode
 
 selector_regex = re.compile(
-    r'^((?P<namespace>[a-z0-9]+(?:_[a-z0-9]+)*)\.)?(?P<name>[a-z0-9]+(?:_[a-z0-9]+)*)(\+(?P<aggrFn>([a-z]+_*[a-z]+)))?(@-(?P<version>([0-9]+)))?(\[(?P<encoding>([a-z]+_*[a-z]+))])?$',
+    r'^((?P<namespace>[a-z0-9]+(?:_[a-z0-9]+)*)\.)?(?P<name>[a-z0-9]+(?:_[a-z0-9]+)*)(:(?P<field>[a-z0-9]+(?:_[a-z0-9]+)*))?(\+(?P<aggrFn>([a-z]+_*[a-z0-9]+))(\[(?P<lookback>([0-9]+[a-z0-9.]*))])?)?(@-(?P<version>([0-9]+)))?(\[(?P<encoding>([a-z]+_*[a-z]+))])?$',
     re.IGNORECASE)
 
-primitive = Union[str, int, float, bool, datetime, List[str], List[int], List[float], List[bool], List[datetime], None]
+primitive = Union[
+    str, int, float, bool, datetime, List[str], List[int], List[float], List[bool], List[datetime], Dict[str, Any], None]
 
 
 def normalize_fqn(fqn, default_namespace='default'):
@@ -69,7 +70,9 @@ def normalize_selector(selector, default_namespace='default'):
         raise Exception(f'Invalid selector: {selector}')
     namespace = matches.group('namespace')
     name = matches.group('name')
+    field = matches.group('field')
     aggr_fn = matches.group('aggrFn')
+    lookback = matches.group('lookback')
     version = matches.group('version')
     encoding = matches.group('encoding')
 
@@ -77,8 +80,12 @@ def normalize_selector(selector, default_namespace='default'):
         namespace = default_namespace
 
     extra = ''
+    if field is not None and field != '':
+        extra += f':{field}'
     if aggr_fn is not None and aggr_fn != '':
         extra += f'+{aggr_fn}'
+    if lookback is not None and lookback != '':
+        extra += f'[{lookback}]'
     if version is not None and version != '':
         extra += f'@-{version}'
     if encoding is not None and encoding != '':
@@ -223,6 +223,8 @@ def _feature_getter(owner_spec: FeatureSpec) -> Callable[
             fqn = f'{fqn}+{matches.group("aggrFn")}'
             if matches.group('version') is not None:
                 raise Exception(f'Cannot specify previous version for aggregated feature: {selector}')
+            if matches.group('lookback') is not None:
+                raise Exception(f'Custom lookbacks are not supported by the local replay yet: {selector}')
 
         version = 0
         if matches.group('version') is not None:
@@ -15,3 +15,5 @@
 
 from .streaming import StreamingConfig, StreamingKind
 from .rest import RestConfig
+from .kafka import KafkaConfig
+from .sql import SqlConfig
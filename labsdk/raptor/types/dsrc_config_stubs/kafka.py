@@ -0,0 +1,43 @@
+# -*- coding: utf-8 -*-
+#  Copyright (c) 2022 RaptorML authors.
+#
+#  Licensed under the Apache License, Version 2.0 (the "License");
+#  you may not use this file except in compliance with the License.
+#  You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+#  Unless required by applicable law or agreed to in writing, software
+#  distributed under the License is distributed on an "AS IS" BASIS,
+#  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+#  See the License for the specific language governing permissions and
+#  limitations under the License.
+
+from typing import Dict, Union
+
+from .protocol import SourceProductionConfig
+from ..common import SecretKeyRef
+
+
+class KafkaConfig(SourceProductionConfig):
+    @classmethod
+    def kind(cls) -> str:
+        return 'kafka'
+
+    def configurable_envs(self) -> Dict[str, str]:
+        return {}
+
+    def config(self) -> Dict[str, Union[str, SecretKeyRef]]:
+        return {
+            'brokers': 'localhost:9092',
+            'topic': '<topic>',
+            'consumer_group': '<consumer_group>',
+            'start_offset': 'latest',
+            'format': 'json',
+            'proto_message': '',
+            'max_attempts': '10',
+            'sasl_mechanism': '',
+            'sasl_username': '',
+            'sasl_password': SecretKeyRef('kafka-credentials', 'password'),
+            'tls': 'false',
+        }
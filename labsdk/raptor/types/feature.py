@@ -41,8 +41,16 @@ class AggregationFunction(EnumSpec):
     Max = 'max'
     Min = 'min'
     Count = 'count'
+    CountDistinct = 'count_distinct'
     DistinctCount = 'distinct_count'
     ApproxDistinctCount = 'approx_distinct_count'
+    Stddev = 'stddev'
+    Variance = 'variance'
+    First = 'first'
+    Last = 'last'
+    P50 = 'p50'
+    P95 = 'p95'
+    P99 = 'p99'
 
     @staticmethod
     def parse(a):
@@ -55,9 +63,10 @@ class AggregationFunction(EnumSpec):
     def supports(self, typ):
         if self == AggregationFunction.Unknown:
             return False
-        if self in (AggregationFunction.Sum, AggregationFunction.Avg, AggregationFunction.Max, AggregationFunction.Min):
-            return typ in (Primitive.Integer, Primitive.Float)
-        return True
+        if self in (AggregationFunction.Count, AggregationFunction.CountDistinct, AggregationFunction.DistinctCount,
+                    AggregationFunction.ApproxDistinctCount):
+            return True
+        return typ in (Primitive.Integer, Primitive.Float)
 
     def apply(self, rgb: RollingGroupby):
         if self == AggregationFunction.Sum:
@@ -70,8 +79,23 @@ class AggregationFunction(EnumSpec):
             return rgb.min()
         if self == AggregationFunction.Count:
             return rgb.count()
-        if self == AggregationFunction.DistinctCount or self == AggregationFunction.ApproxDistinctCount:
+        if self in (AggregationFunction.CountDistinct, AggregationFunction.DistinctCount,
+                    AggregationFunction.ApproxDistinctCount):
             return rgb.apply(lambda x: pd.Series(x).nunique())
+        if self == AggregationFunction.Stddev:
+            return rgb.std()
+        if self == AggregationFunction.Variance:
+            return rgb.var()
+        if self == AggregationFunction.First:
+            return rgb.apply(lambda x: x.iloc[0])
+        if self == AggregationFunction.Last:
+            return rgb.apply(lambda x: x.iloc[-1])
+        if self == AggregationFunction.P50:
+            return rgb.quantile(0.5)
+        if self == AggregationFunction.P95:
+            return rgb.quantile(0.95)
+        if self == AggregationFunction.P99:
+            return rgb.quantile(0.99)
         raise Exception(f'Unknown AggrFn {self}')
 
 
@@ -42,6 +42,7 @@ class ModelServer(EnumSpec):
     Model server to use for deployment
     """
     SageMakerACK = 'sagemaker-ack'
+    KServeV2 = 'kserve-v2'
     Seldon = 'seldon'
     KServe = 'kserve'
     VertexAI = 'vertexai'
@@ -58,7 +59,7 @@ class ModelServer(EnumSpec):
         raise Exception(f'Unknown ModelServer {m}')
 
     def __str__(self):
-        if self != ModelServer.SageMakerACK:
+        if self not in (ModelServer.SageMakerACK, ModelServer.KServeV2):
             warn(f'ModelServer {self} is not supported yet')
         return self.value
 
@@ -70,6 +71,8 @@ class ModelServer(EnumSpec):
     def config(self) -> Optional[model_servers.ModelServer]:
         if self == ModelServer.SageMakerACK:
             return model_servers.Sagemaker
+        if self == ModelServer.KServeV2:
+            return model_servers.KServeV2
 
         warn(f'ModelServer {self} is not supported yet')
         return None
@@ -0,0 +1,327 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package historical
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/raptor-ml/raptor/api"
+)
+
+// progressInterval is the number of entities between progress reports of a feature
+const progressInterval = 1000
+
+// Progress is the backfill progress of a single feature.
+type Progress struct {
+	FQN string `json:"fqn"`
+	// Entities is the number of entities (encoded keys) that were found in the historical records.
+	Entities int `json:"entities"`
+	// Processed is the number of entities that were processed so far.
+	Processed int `json:"processed"`
+	// Restored is the number of entities that were restored to the state.
+	// Entities that are already up-to-date in the state, or that have no live values, are skipped.
+	Restored  int       `json:"restored"`
+	Completed bool      `json:"completed"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Backfiller re-materializes the online state out of the historical records.
+//
+// For each feature it restores the latest values that are not stale yet, along with the previous versions that
+// are kept by the feature (as long as they aren't stale), and the buckets of windowed features that are still
+// alive. Buckets are restored by merging their raw data into the state (see api.State.WindowMerge).
+//
+// The backfill never overrides newer data: values are restored only if the state doesn't hold a value with the
+// same or a later timestamp, and buckets are restored only if they don't exist in the state. This makes it safe
+// to run while the feature is being updated, and to re-run after an interruption. A Checkpoint file can be used
+// to skip the features that were already completed by a previous run.
+type Backfiller struct {
+	Reader api.HistoricalReader
+	State  api.State
+	// Checkpoint is a path to a file that keeps the progress of the backfill. If it's empty, the progress is not kept.
+	Checkpoint string
+	Logger     logr.Logger
+
+	mu       sync.Mutex
+	progress map[string]*Progress
+}
+
+// Backfill restores the given features to the state.
+func (b *Backfiller) Backfill(ctx context.Context, fds []api.FeatureDescriptor) error {
+	if err := b.loadCheckpoint(); err != nil {
+		return err
+	}
+
+	for _, fd := range fds {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		logger := b.Logger.WithValues("fqn", fd.FQN)
+		if p := b.Progress(fd.FQN); p.Completed {
+			logger.Info("feature was already backfilled, skipping", "completed_at", p.UpdatedAt)
+			continue
+		}
+		if fd.DataSource == "" {
+			logger.Info("feature is not stored in the state, skipping")
+			continue
+		}
+		if fd.Staleness <= 0 {
+			logger.Info("feature doesn't have a staleness, skipping")
+			continue
+		}
+
+		if err := b.backfill(ctx, fd, logger); err != nil {
+			return fmt.Errorf("failed to backfill %s: %w", fd.FQN, err)
+		}
+	}
+	return nil
+}
+
+// Progress returns the progress of the feature.
+func (b *Backfiller) Progress(fqn string) Progress {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if p, ok := b.progress[fqn]; ok {
+		return *p
+	}
+	return Progress{FQN: fqn}
+}
+
+func (b *Backfiller) backfill(ctx context.Context, fd api.FeatureDescriptor, logger logr.Logger) error {
+	now := time.Now()
+	since := now.Add(-fd.Staleness)
+	if fd.ValidWindow() {
+		// bucket records are timestamped by the start of the bucket
+		since = since.Add(-fd.Freshness)
+	}
+	recs, err := b.Reader.Read(ctx, fd.FQN, since, now)
+	if err != nil {
+		return fmt.Errorf("failed to read historical records: %w", err)
+	}
+
+	byKeys := make(map[string][]api.HistoricalRecord)
+	for _, r := range recs {
+		byKeys[r.EncodedKeys] = append(byKeys[r.EncodedKeys], r)
+	}
+	entities := make([]string, 0, len(byKeys))
+	for k := range byKeys {
+		entities = append(entities, k)
+	}
+	sort.Strings(entities)
+
+	p := Progress{FQN: fd.FQN, Entities: len(entities)}
+	logger.Info("backfilling feature", "records", len(recs), "entities", p.Entities)
+	for _, encodedKeys := range entities {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		keys := api.Keys{}
+		if err := keys.Decode(encodedKeys, fd); err != nil {
+			return fmt.Errorf("failed to decode keys `%s`: %w", encodedKeys, err)
+		}
+
+		var restored bool
+		if fd.ValidWindow() {
+			restored, err = b.restoreWindow(ctx, fd, keys, byKeys[encodedKeys])
+		} else {
+			restored, err = b.restoreValue(ctx, fd, keys, byKeys[encodedKeys])
+		}
+		if err != nil {
+			return fmt.Errorf("failed to restore keys `%s`: %w", encodedKeys, err)
+		}
+
+		p.Processed++
+		if restored {
+			p.Restored++
+		}
+		if p.Processed%progressInterval == 0 {
+			logger.Info("backfill progress", "processed", p.Processed, "entities", p.Entities, "restored", p.Restored)
+			if err := b.saveProgress(p); err != nil {
+				return err
+			}
+		}
+	}
+
+	p.Completed = true
+	logger.Info("feature was backfilled", "entities", p.Entities, "restored", p.Restored)
+	return b.saveProgress(p)
+}
+
+// restoreValue replays the latest records of a non-windowed feature, so the previous versions are restored as well.
+// The records must be ordered by timestamp.
+func (b *Backfiller) restoreValue(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, recs []api.HistoricalRecord) (bool, error) {
+	cur, err := b.State.Get(ctx, fd, keys, 0)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the current value: %w", err)
+	}
+
+	versions := 1
+	if fd.KeepPrevious != nil {
+		versions += int(fd.KeepPrevious.Versions)
+	}
+
+	var replay []api.HistoricalRecord
+	for i := len(recs) - 1; i >= 0 && len(replay) < versions; i-- {
+		r := recs[i]
+		if cur != nil && !r.Timestamp.After(cur.Timestamp) {
+			break
+		}
+		if time.Since(r.Timestamp) >= fd.Staleness {
+			// the state rejects stale values
+			break
+		}
+		if r.Value == nil || r.Bucket != "" {
+			continue
+		}
+		replay = append(replay, r)
+	}
+
+	for i := len(replay) - 1; i >= 0; i-- {
+		val, err := castValue(replay[i].Value, fd)
+		if err != nil {
+			return false, fmt.Errorf("invalid historical value: %w", err)
+		}
+		if err := b.State.Set(ctx, fd, keys, val, replay[i].Timestamp); err != nil {
+			return false, fmt.Errorf("failed to set value: %w", err)
+		}
+	}
+	return len(replay) > 0, nil
+}
+
+// restoreWindow restores the alive buckets of a windowed feature that are missing in the state.
+func (b *Backfiller) restoreWindow(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, recs []api.HistoricalRecord) (bool, error) {
+	alive := make(map[string]struct{})
+	for _, name := range api.AliveWindowBuckets(fd.Staleness, fd.Freshness) {
+		alive[name] = struct{}{}
+	}
+
+	// the final record of a bucket is preferred over its alive snapshots, and a later snapshot over an earlier one
+	buckets := make(map[string]api.BucketData)
+	final := make(map[string]bool)
+	for _, r := range recs {
+		if _, ok := alive[r.Bucket]; !ok || final[r.Bucket] {
+			continue
+		}
+		data, ok := r.Value.(api.BucketData)
+		if !ok {
+			continue
+		}
+		buckets[r.Bucket] = data
+		final[r.Bucket] = !r.ActiveBucket
+	}
+	if len(buckets) == 0 {
+		return false, nil
+	}
+
+	names := make([]string, 0, len(buckets))
+	for name := range buckets {
+		names = append(names, name)
+	}
+	existing, err := b.State.WindowBuckets(ctx, fd, keys, names)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the current buckets: %w", err)
+	}
+	for _, eb := range existing {
+		delete(buckets, eb.Bucket)
+	}
+
+	for name, data := range buckets {
+		if err := b.State.WindowMerge(ctx, fd, keys, data, api.BucketTime(name, fd.Freshness)); err != nil {
+			return false, fmt.Errorf("failed to restore bucket %s: %w", name, err)
+		}
+	}
+	return len(buckets) > 0, nil
+}
+
+// castValue converts a historical value to the primitive of the feature.
+// Historical providers that don't keep the exact type of the value (e.g. JSON based) may return a different type.
+func castValue(v any, fd api.FeatureDescriptor) (any, error) {
+	if fd.Primitive == api.PrimitiveTypeStruct {
+		return fd.StructValue(v)
+	}
+	return api.CastPrimitive(v, fd.Primitive)
+}
+
+func (b *Backfiller) loadCheckpoint() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.progress = make(map[string]*Progress)
+	if b.Checkpoint == "" {
+		return nil
+	}
+	data, err := os.ReadFile(b.Checkpoint)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var progress []*Progress
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return fmt.Errorf("failed to parse checkpoint %s: %w", b.Checkpoint, err)
+	}
+	for _, p := range progress {
+		b.progress[p.FQN] = p
+	}
+	return nil
+}
+
+// saveProgress updates the progress of the feature, and writes the checkpoint atomically.
+func (b *Backfiller) saveProgress(p Progress) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	p.UpdatedAt = time.Now()
+	b.progress[p.FQN] = &p
+	if b.Checkpoint == "" {
+		return nil
+	}
+
+	progress := make([]*Progress, 0, len(b.progress))
+	for _, p := range b.progress {
+		progress = append(progress, p)
+	}
+	sort.Slice(progress, func(i, j int) bool {
+		return progress[i].FQN < progress[j].FQN
+	})
+	data, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	tmp := filepath.Join(filepath.Dir(b.Checkpoint), "."+filepath.Base(b.Checkpoint)+".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, b.Checkpoint); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package historical
+
+import (
+	"context"
+	"github.com/go-logr/logr"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/internal/plugins/providers/state/memory"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// countingReader counts the reads of each feature
+type countingReader struct {
+	fakeReader
+	reads map[string]int
+}
+
+func (r *countingReader) Read(ctx context.Context, fqn string, since, until time.Time) ([]api.HistoricalRecord, error) {
+	r.reads[fqn]++
+	return r.fakeReader.Read(ctx, fqn, since, until)
+}
+
+func record(fqn, encodedKeys string, ts time.Time, v any) api.HistoricalRecord {
+	return api.HistoricalRecord{FQN: fqn, EncodedKeys: encodedKeys, Timestamp: ts, Value: v}
+}
+
+func getValue(t *testing.T, s api.State, fd api.FeatureDescriptor, id string, version uint) any {
+	t.Helper()
+	v, err := s.Get(context.Background(), fd, api.Keys{"id": id}, version)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v == nil {
+		return nil
+	}
+	return v.Value
+}
+
+func TestBackfillValues(t *testing.T) {
+	fd := api.FeatureDescriptor{
+		FQN:          "default.score",
+		Primitive:    api.PrimitiveTypeInteger,
+		Freshness:    time.Minute,
+		Staleness:    2 * time.Hour,
+		KeepPrevious: &api.KeepPrevious{Versions: 1, Over: time.Hour},
+		Keys:         []string{"id"},
+		DataSource:   "default.source",
+	}
+	now := time.Now()
+	reader := fakeReader{records: map[string][]api.HistoricalRecord{fd.FQN: {
+		record(fd.FQN, "2", now.Add(-3*time.Hour), 20),
+		record(fd.FQN, "1", now.Add(-30*time.Minute), 1),
+		record(fd.FQN, "1", now.Add(-20*time.Minute), 2),
+		record(fd.FQN, "3", now.Add(-20*time.Minute), 30),
+		record(fd.FQN, "1", now.Add(-10*time.Minute), 3.0),
+		record(fd.FQN, "3", now.Add(-10*time.Minute), 31),
+	}}}
+
+	state := memory.New()
+	// the state already holds a newer value of the key 3
+	if err := state.Set(context.Background(), fd, api.Keys{"id": "3"}, 32, now.Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	b := &Backfiller{Reader: reader, State: state, Logger: logr.Discard()}
+	if err := b.Backfill(context.Background(), []api.FeatureDescriptor{fd}); err != nil {
+		t.Fatalf("Backfill() error = %v", err)
+	}
+
+	for _, tt := range []struct {
+		id      string
+		version uint
+		want    any
+	}{
+		// the latest value and the kept previous version are restored, and the values are cast to the primitive
+		{"1", 0, 3},
+		{"1", 1, 2},
+		// stale values are not read nor restored
+		{"2", 0, nil},
+		// newer values are not overridden
+		{"3", 0, 32},
+		{"3", 1, nil},
+	} {
+		if got := getValue(t, state, fd, tt.id, tt.version); got != tt.want {
+			t.Errorf("keys %s version %d: expected %v, got %v", tt.id, tt.version, tt.want, got)
+		}
+	}
+
+	p := b.Progress(fd.FQN)
+	if !p.Completed || p.Entities != 2 || p.Processed != 2 || p.Restored != 1 {
+		t.Errorf("unexpected progress %+v", p)
+	}
+}
+
+func TestBackfillWindows(t *testing.T) {
+	fd := api.FeatureDescriptor{
+		FQN:        "default.clicks",
+		Primitive:  api.PrimitiveTypeFloat,
+		Aggr:       []api.AggrFn{api.AggrFnSum, api.AggrFnCount},
+		Freshness:  time.Minute,
+		Staleness:  10 * time.Minute,
+		Keys:       []string{"id"},
+		DataSource: "default.source",
+	}
+	now := time.Now()
+	bucketRecord := func(ts time.Time, active bool, values ...float64) api.HistoricalRecord {
+		r := bucket(t, fd, ts, active, values...)
+		r.Timestamp = api.BucketTime(r.Bucket, fd.Freshness)
+		return r
+	}
+	reader := fakeReader{records: map[string][]api.HistoricalRecord{fd.FQN: {
+		// the bucket is dead
+		bucketRecord(now.Add(-20*time.Minute), false, 100),
+		// the final record is preferred over the snapshot that followed it
+		bucketRecord(now.Add(-5*time.Minute), true, 1),
+		bucketRecord(now.Add(-5*time.Minute), false, 1, 2),
+		bucketRecord(now.Add(-5*time.Minute), true, 1000),
+		// the bucket already exists in the state
+		bucketRecord(now.Add(-3*time.Minute), false, 50),
+		bucketRecord(now, true, 4),
+	}}}
+
+	state := memory.New()
+	if err := state.WindowAdd(context.Background(), fd, api.Keys{"id": "1"}, 5.0, now.Add(-3*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	b := &Backfiller{Reader: reader, State: state, Logger: logr.Discard()}
+	if err := b.Backfill(context.Background(), []api.FeatureDescriptor{fd}); err != nil {
+		t.Fatalf("Backfill() error = %v", err)
+	}
+
+	v, err := state.Get(context.Background(), fd, api.Keys{"id": "1"}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := api.WindowResultMap{api.AggrFnSum: 1 + 2 + 5 + 4, api.AggrFnCount: 4}
+	if v == nil || v.Value.(api.WindowResultMap)[api.AggrFnSum] != want[api.AggrFnSum] ||
+		v.Value.(api.WindowResultMap)[api.AggrFnCount] != want[api.AggrFnCount] {
+		t.Errorf("expected the window %v, got %+v", want, v)
+	}
+	if p := b.Progress(fd.FQN); !p.Completed || p.Restored != 1 {
+		t.Errorf("unexpected progress %+v", p)
+	}
+}
+
+func TestBackfillCheckpoint(t *testing.T) {
+	fd := api.FeatureDescriptor{
+		FQN:        "default.score",
+		Primitive:  api.PrimitiveTypeInteger,
+		Freshness:  time.Minute,
+		Staleness:  time.Hour,
+		Keys:       []string{"id"},
+		DataSource: "default.source",
+	}
+	skipped := fd
+	skipped.FQN = "default.computed"
+	skipped.DataSource = ""
+
+	checkpoint := filepath.Join(t.TempDir(), "checkpoint.json")
+	reader := &countingReader{
+		fakeReader: fakeReader{records: map[string][]api.HistoricalRecord{fd.FQN: {
+			record(fd.FQN, "1", time.Now().Add(-time.Minute), 1),
+		}}},
+		reads: make(map[string]int),
+	}
+	fds := []api.FeatureDescriptor{fd, skipped}
+
+	b := &Backfiller{Reader: reader, State: memory.New(), Checkpoint: checkpoint, Logger: logr.Discard()}
+	if err := b.Backfill(context.Background(), fds); err != nil {
+		t.Fatalf("Backfill() error = %v", err)
+	}
+	if reader.reads[fd.FQN] != 1 || reader.reads[skipped.FQN] != 0 {
+		t.Errorf("unexpected reads %v", reader.reads)
+	}
+
+	// a new run skips the features that were completed
+	b = &Backfiller{Reader: reader, State: memory.New(), Checkpoint: checkpoint, Logger: logr.Discard()}
+	if err := b.Backfill(context.Background(), fds); err != nil {
+		t.Fatalf("Backfill() error = %v", err)
+	}
+	if reader.reads[fd.FQN] != 1 {
+		t.Errorf("expected the completed feature to be skipped, got %d reads", reader.reads[fd.FQN])
+	}
+	if p := b.Progress(fd.FQN); !p.Completed || p.Restored != 1 {
+		t.Errorf("expected the progress to be loaded from the checkpoint, got %+v", p)
+	}
+}
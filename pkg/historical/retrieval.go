@@ -0,0 +1,282 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package historical builds point-in-time correct datasets out of the records that were written by the historian,
+// and backfills the online state from them.
+package historical
+
+import (
+	"context"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"math"
+	"sort"
+	"time"
+)
+
+// Entity is the keys and the event time to retrieve the features for.
+type Entity struct {
+	Keys      api.Keys
+	Timestamp time.Time
+}
+
+// Row is the result of the retrieval for an Entity.
+type Row struct {
+	Entity
+	// Values by selector. A value that was not available at the time of the entity is nil.
+	Values map[string]any
+}
+
+type selector struct {
+	selector string
+	fd       api.FeatureDescriptor
+	field    string
+	aggrFn   api.AggrFn
+	version  uint
+}
+
+// Retrieve returns the values of the selectors as they were known at the time of each entity (an "as-of" join).
+//
+// Windowed features are calculated over a window that ends at the last bucket that was completed by the time
+// of the entity, so the currently open bucket is never leaked into the dataset.
+func Retrieve(ctx context.Context, reader api.HistoricalReader, getter api.FeatureDescriptorGetter, entities []Entity, selectors []string) ([]Row, error) {
+	if len(entities) == 0 {
+		return nil, nil
+	}
+
+	var sels []selector
+	for _, s := range selectors {
+		sel, err := parseSelector(ctx, getter, s)
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+
+	since, until := entities[0].Timestamp, entities[0].Timestamp
+	for _, e := range entities {
+		if e.Timestamp.Before(since) {
+			since = e.Timestamp
+		}
+		if e.Timestamp.After(until) {
+			until = e.Timestamp
+		}
+	}
+
+	// read the records of each feature once
+	lookback := make(map[string]time.Duration)
+	fds := make(map[string]api.FeatureDescriptor)
+	for _, sel := range sels {
+		fds[sel.fd.FQN] = sel.fd
+		lb := lookbackOf(sel)
+		if cur, ok := lookback[sel.fd.FQN]; !ok || lb > cur {
+			lookback[sel.fd.FQN] = lb
+		}
+	}
+	records := make(map[string]map[string][]api.HistoricalRecord)
+	for fqn, lb := range lookback {
+		from := time.Time{}
+		if lb != math.MaxInt64 {
+			from = since.Add(-lb)
+		}
+		recs, err := reader.Read(ctx, fqn, from, until)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read historical records of %s: %w", fqn, err)
+		}
+		byKeys := make(map[string][]api.HistoricalRecord)
+		for _, r := range recs {
+			byKeys[r.EncodedKeys] = append(byKeys[r.EncodedKeys], r)
+		}
+		records[fqn] = byKeys
+	}
+
+	rows := make([]Row, len(entities))
+	for i, e := range entities {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		rows[i] = Row{Entity: e, Values: make(map[string]any, len(sels))}
+		for _, sel := range sels {
+			keys := e.Keys
+			encodedKeys, err := keys.Encode(sel.fd)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode keys of entity %d for %s: %w", i, sel.selector, err)
+			}
+			recs := records[sel.fd.FQN][encodedKeys]
+			if sel.fd.ValidWindow() {
+				rows[i].Values[sel.selector] = windowAsOf(sel, recs, e.Timestamp)
+			} else {
+				rows[i].Values[sel.selector] = fieldOf(sel, valueAsOf(sel, recs, e.Timestamp))
+			}
+		}
+	}
+	return rows, nil
+}
+
+func parseSelector(ctx context.Context, getter api.FeatureDescriptorGetter, s string) (selector, error) {
+	parsed, err := api.ParseSelectorStruct(s)
+	if err != nil {
+		return selector{}, err
+	}
+	if parsed.Encoding != "" {
+		return selector{}, fmt.Errorf("encodings are not supported for historical retrieval: %s", s)
+	}
+	fqn, err := api.NormalizeFQN(s, "default")
+	if err != nil {
+		return selector{}, err
+	}
+	fd, err := getter(ctx, fqn)
+	if err != nil {
+		return selector{}, fmt.Errorf("failed to get FeatureDescriptor for %s: %w", fqn, err)
+	}
+
+	sel := selector{selector: s, fd: fd, field: parsed.Field, aggrFn: parsed.AggrFn, version: parsed.Version}
+	if fd.ValidWindow() {
+		if parsed.Version > 0 {
+			return sel, fmt.Errorf("version is not supported for windowed features: %s", s)
+		}
+		if parsed.AggrFn == api.AggrFnUnknown {
+			return sel, fmt.Errorf("an aggregation function must be selected for windowed features: %s", s)
+		}
+		found := false
+		for _, fn := range fd.Aggr {
+			if fn == parsed.AggrFn {
+				found = true
+			}
+		}
+		if !found {
+			return sel, fmt.Errorf("aggregation function %s is not enabled for %s", parsed.AggrFn, fqn)
+		}
+		if parsed.Lookback > 0 {
+			sel.fd, err = fd.WithLookback(parsed.Lookback)
+			if err != nil {
+				return sel, fmt.Errorf("invalid lookback for %s: %w", s, err)
+			}
+		}
+		return sel, nil
+	}
+	if parsed.AggrFn != api.AggrFnUnknown {
+		return sel, fmt.Errorf("aggregation functions are only supported for windowed features: %s", s)
+	}
+	if parsed.Version > 0 && (fd.KeepPrevious == nil || parsed.Version > fd.KeepPrevious.Versions) {
+		return sel, fmt.Errorf("version %d is not kept for %s", parsed.Version, fqn)
+	}
+	if parsed.Field != "" {
+		if fd.Primitive != api.PrimitiveTypeStruct {
+			return sel, fmt.Errorf("fields are only supported for struct features: %s", s)
+		}
+		if _, ok := fd.Field(parsed.Field); !ok {
+			return sel, fmt.Errorf("field `%s` is not defined for %s", parsed.Field, fqn)
+		}
+	}
+	return sel, nil
+}
+
+// fieldOf returns the selected field of a struct value, or the value itself if no field was selected.
+func fieldOf(sel selector, v any) any {
+	if sel.field == "" || v == nil {
+		return v
+	}
+	st, err := sel.fd.StructValue(v)
+	if err != nil {
+		return nil
+	}
+	return st[sel.field]
+}
+
+// lookbackOf returns how far before the entity's timestamp the records of the selector are relevant.
+func lookbackOf(sel selector) time.Duration {
+	fd := sel.fd
+	if fd.ValidWindow() {
+		return fd.Staleness + fd.Freshness
+	}
+	if sel.version > 0 {
+		if fd.KeepPrevious.Over <= 0 {
+			// versions are kept forever
+			return math.MaxInt64
+		}
+		return fd.Staleness + time.Duration(sel.version)*fd.KeepPrevious.Over
+	}
+	return fd.Staleness
+}
+
+// valueAsOf returns the value (or its previous version) that was the latest at the given time.
+// The records must be ordered by timestamp.
+func valueAsOf(sel selector, recs []api.HistoricalRecord, ts time.Time) any {
+	// index of the first record after ts
+	idx := sort.Search(len(recs), func(i int) bool {
+		return recs[i].Timestamp.After(ts)
+	}) - 1
+	if idx < 0 {
+		return nil
+	}
+
+	if sel.version == 0 {
+		if sel.fd.Staleness > 0 && ts.Sub(recs[idx].Timestamp) > sel.fd.Staleness {
+			return nil
+		}
+		return recs[idx].Value
+	}
+
+	j := idx - int(sel.version)
+	if j < 0 {
+		return nil
+	}
+	// the previous versions are kept for `Over` per version since the latest write, the same as in the state
+	over := sel.fd.KeepPrevious.Over
+	if over > 0 && ts.Sub(recs[idx].Timestamp) > time.Duration(sel.version)*over {
+		return nil
+	}
+	return recs[j].Value
+}
+
+// windowAsOf returns the aggregated value of the window at the given time.
+// Only the buckets that were completed by that time are taken into account.
+func windowAsOf(sel selector, recs []api.HistoricalRecord, ts time.Time) any {
+	fd := sel.fd
+	buckets := make(map[string]api.BucketData)
+	dead := make(map[string]bool)
+	for _, r := range recs {
+		data, ok := r.Value.(api.BucketData)
+		if !ok || dead[r.Bucket] {
+			continue
+		}
+		buckets[r.Bucket] = data
+		dead[r.Bucket] = !r.ActiveBucket
+	}
+
+	numberOfBuckets := int(math.Ceil(float64(fd.Staleness) / float64(fd.Freshness)))
+	var window []api.BucketData
+	// the bucket of `ts` itself is still open, so the window ends at the previous one
+	for i := 1; i <= numberOfBuckets; i++ {
+		if data, ok := buckets[api.BucketName(ts.Add(-fd.Freshness*time.Duration(i)), fd.Freshness)]; ok {
+			window = append(window, data)
+		}
+	}
+
+	ret := api.WindowResult([]api.AggrFn{sel.aggrFn}, window...)
+	v, ok := ret[sel.aggrFn]
+	if !ok {
+		return nil
+	}
+	switch sel.aggrFn {
+	case api.AggrFnCount, api.AggrFnCountDistinct:
+		return int(v)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package historical
+
+import (
+	"context"
+	"fmt"
+	"github.com/raptor-ml/raptor/api"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var base = time.Date(2022, 1, 2, 3, 0, 0, 0, time.UTC)
+
+func records(fqn string, values ...any) []api.HistoricalRecord {
+	var recs []api.HistoricalRecord
+	for i, v := range values {
+		recs = append(recs, api.HistoricalRecord{
+			FQN:         fqn,
+			EncodedKeys: "1",
+			Timestamp:   base.Add(time.Duration(i) * time.Hour),
+			Value:       v,
+		})
+	}
+	return recs
+}
+
+func TestValueAsOf(t *testing.T) {
+	fd := api.FeatureDescriptor{
+		FQN:          "default.score",
+		Primitive:    api.PrimitiveTypeInteger,
+		Freshness:    time.Minute,
+		Staleness:    90 * time.Minute,
+		KeepPrevious: &api.KeepPrevious{Versions: 2, Over: 30 * time.Minute},
+	}
+	// the values 1, 2 and 3 were written at base, base+1h and base+2h
+	recs := records(fd.FQN, 1, 2, 3)
+
+	tests := []struct {
+		name    string
+		version uint
+		ts      time.Time
+		want    any
+	}{
+		{name: "before the first record", ts: base.Add(-time.Second), want: nil},
+		{name: "at a record", ts: base.Add(time.Hour), want: 2},
+		{name: "between records", ts: base.Add(90 * time.Minute), want: 2},
+		{name: "the latest record", ts: base.Add(3 * time.Hour), want: 3},
+		{name: "stale", ts: base.Add(2*time.Hour + 91*time.Minute), want: nil},
+		{name: "previous version", version: 1, ts: base.Add(2*time.Hour + time.Minute), want: 2},
+		{name: "two versions back", version: 2, ts: base.Add(2*time.Hour + time.Minute), want: 1},
+		{name: "previous version of the first record", version: 1, ts: base.Add(time.Minute), want: nil},
+		{name: "previous version before it was replaced", version: 1, ts: base.Add(59 * time.Minute), want: nil},
+		{name: "expired previous version", version: 1, ts: base.Add(2*time.Hour + 31*time.Minute), want: nil},
+		{name: "expired version two back", version: 2, ts: base.Add(2*time.Hour + 61*time.Minute), want: nil},
+		{name: "version two back before it expired", version: 2, ts: base.Add(2*time.Hour + 59*time.Minute), want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := valueAsOf(selector{fd: fd, version: tt.version}, recs, tt.ts)
+			if got != tt.want {
+				t.Errorf("valueAsOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// bucket returns the record of a bucket with the given values
+func bucket(t *testing.T, fd api.FeatureDescriptor, start time.Time, active bool, values ...float64) api.HistoricalRecord {
+	t.Helper()
+	data := api.BucketData{}
+	for _, v := range values {
+		u, err := api.BucketUpdates(fd.Aggr, v, start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data.Apply(u)
+	}
+	return api.HistoricalRecord{
+		FQN:          fd.FQN,
+		EncodedKeys:  "1",
+		Timestamp:    start,
+		Value:        data,
+		Bucket:       api.BucketName(start, fd.Freshness),
+		ActiveBucket: active,
+	}
+}
+
+func TestWindowAsOf(t *testing.T) {
+	fd := api.FeatureDescriptor{
+		FQN:       "default.clicks",
+		Primitive: api.PrimitiveTypeFloat,
+		Aggr:      []api.AggrFn{api.AggrFnSum, api.AggrFnCount, api.AggrFnMax},
+		Freshness: time.Minute,
+		Staleness: 5 * time.Minute,
+	}
+	recs := []api.HistoricalRecord{
+		// outside the window of base+2m30s
+		bucket(t, fd, base.Add(-4*time.Minute), false, 100),
+		bucket(t, fd, base.Add(-3*time.Minute), false, 1),
+		// the bucket was updated while it was open, and the last record is the completed one
+		bucket(t, fd, base, true, 2),
+		bucket(t, fd, base, false, 2, 3),
+		// records of a bucket after it was completed are ignored
+		bucket(t, fd, base, true, 1000),
+		bucket(t, fd, base.Add(time.Minute), false, 4),
+		// the bucket of base+2m30s is still open
+		bucket(t, fd, base.Add(2*time.Minute), true, 50),
+	}
+
+	tests := []struct {
+		name   string
+		aggrFn api.AggrFn
+		ts     time.Time
+		want   any
+	}{
+		{name: "sum", aggrFn: api.AggrFnSum, ts: base.Add(150 * time.Second), want: 10.0},
+		{name: "count", aggrFn: api.AggrFnCount, ts: base.Add(150 * time.Second), want: 4},
+		{name: "max", aggrFn: api.AggrFnMax, ts: base.Add(150 * time.Second), want: 4.0},
+		{name: "completed buckets only", aggrFn: api.AggrFnSum, ts: base.Add(30 * time.Second), want: 101.0},
+		{name: "the bucket is included once it's completed", aggrFn: api.AggrFnSum, ts: base.Add(3*time.Minute + time.Second), want: 59.0},
+		{name: "before the first bucket", aggrFn: api.AggrFnSum, ts: base.Add(-5 * time.Minute), want: nil},
+		{name: "after the window", aggrFn: api.AggrFnSum, ts: base.Add(time.Hour), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := windowAsOf(selector{fd: fd, aggrFn: tt.aggrFn}, recs, tt.ts)
+			if got != tt.want {
+				t.Errorf("windowAsOf() = %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+type fakeReader struct {
+	records map[string][]api.HistoricalRecord
+}
+
+func (r fakeReader) Read(_ context.Context, fqn string, since, until time.Time) ([]api.HistoricalRecord, error) {
+	var ret []api.HistoricalRecord
+	for _, rec := range r.records[fqn] {
+		if !rec.Timestamp.Before(since) && !rec.Timestamp.After(until) {
+			ret = append(ret, rec)
+		}
+	}
+	return ret, nil
+}
+
+func (fakeReader) Close(context.Context) error {
+	return nil
+}
+
+func TestRetrieve(t *testing.T) {
+	score := api.FeatureDescriptor{
+		FQN:       "default.score",
+		Primitive: api.PrimitiveTypeInteger,
+		Freshness: time.Minute,
+		Staleness: 90 * time.Minute,
+		Keys:      []string{"id"},
+	}
+	clicks := api.FeatureDescriptor{
+		FQN:       "default.clicks",
+		Primitive: api.PrimitiveTypeFloat,
+		Aggr:      []api.AggrFn{api.AggrFnSum},
+		Freshness: time.Minute,
+		Staleness: 5 * time.Minute,
+		Keys:      []string{"id"},
+	}
+	getter := func(_ context.Context, fqn string) (api.FeatureDescriptor, error) {
+		switch fqn {
+		case score.FQN:
+			return score, nil
+		case clicks.FQN:
+			return clicks, nil
+		}
+		return api.FeatureDescriptor{}, fmt.Errorf("%w: %s", api.ErrFeatureNotFound, fqn)
+	}
+	reader := fakeReader{records: map[string][]api.HistoricalRecord{
+		score.FQN: records(score.FQN, 1, 2),
+		clicks.FQN: {
+			bucket(t, clicks, base, false, 1, 2),
+			bucket(t, clicks, base.Add(time.Minute), false, 3),
+		},
+	}}
+
+	entities := []Entity{
+		{Keys: api.Keys{"id": "1"}, Timestamp: base.Add(2 * time.Minute)},
+		{Keys: api.Keys{"id": "1"}, Timestamp: base.Add(61 * time.Minute)},
+		{Keys: api.Keys{"id": "2"}, Timestamp: base.Add(2 * time.Minute)},
+	}
+	rows, err := Retrieve(context.Background(), reader, getter, entities, []string{"default.score", "default.clicks+sum"})
+	if err != nil {
+		t.Fatalf("Retrieve() error = %v", err)
+	}
+	want := []map[string]any{
+		{"default.score": 1, "default.clicks+sum": 6.0},
+		{"default.score": 2, "default.clicks+sum": nil},
+		{"default.score": nil, "default.clicks+sum": nil},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %d", len(want), len(rows))
+	}
+	for i, row := range rows {
+		if !reflect.DeepEqual(row.Entity, entities[i]) {
+			t.Errorf("row %d: expected the entity %+v, got %+v", i, entities[i], row.Entity)
+		}
+		if !reflect.DeepEqual(row.Values, want[i]) {
+			t.Errorf("row %d: expected %v, got %v", i, want[i], row.Values)
+		}
+	}
+
+	for _, s := range []string{"default.clicks", "default.clicks+max", "default.score+sum", "default.score@-1", "default.missing"} {
+		if _, err := Retrieve(context.Background(), reader, getter, entities, []string{s}); err == nil {
+			t.Errorf("expected an error for the selector %s", s)
+		}
+	}
+}
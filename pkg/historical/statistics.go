@@ -0,0 +1,127 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package historical
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/raptor-ml/raptor/api"
+	"golang.org/x/sync/singleflight"
+)
+
+// statisticFns are the statistics that can be calculated by Statistics
+var statisticFns = []api.AggrFn{
+	api.AggrFnAvg, api.AggrFnMin, api.AggrFnMax, api.AggrFnLast, api.AggrFnP50, api.AggrFnP95, api.AggrFnP99,
+}
+
+// Statistics calculates statistics over the recent historical values of the features, across all of their keys.
+//
+// The statistics of a feature are calculated out of its records of the last Lookback, and are cached for TTL, so
+// the historical storage is read at most once per TTL for each feature. Concurrent requests of an expired feature
+// share a single calculation.
+type Statistics struct {
+	Reader   api.HistoricalReader
+	Lookback time.Duration
+	TTL      time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedStatistics
+	group singleflight.Group
+}
+
+type cachedStatistics struct {
+	result    api.WindowResultMap
+	expiresAt time.Time
+}
+
+// Statistic returns the statistic of the recent historical values of a numeric feature.
+func (s *Statistics) Statistic(ctx context.Context, fd api.FeatureDescriptor, fn api.AggrFn) (float64, error) {
+	if fd.ValidWindow() || (fd.Primitive != api.PrimitiveTypeInteger && fd.Primitive != api.PrimitiveTypeFloat) {
+		return 0, fmt.Errorf("statistics are only supported for numeric scalar features")
+	}
+	supported := false
+	for _, f := range statisticFns {
+		supported = supported || f == fn
+	}
+	if !supported {
+		return 0, fmt.Errorf("unsupported statistic %s", fn)
+	}
+
+	s.mu.Lock()
+	c, ok := s.cache[fd.FQN]
+	s.mu.Unlock()
+	if !ok || time.Now().After(c.expiresAt) {
+		// the calculation is shared, so it isn't canceled along with the request that started it
+		ch := s.group.DoChan(fd.FQN, func() (any, error) {
+			return s.refresh(context.WithoutCancel(ctx), fd)
+		})
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case r := <-ch:
+			if r.Err != nil {
+				return 0, r.Err
+			}
+			c = r.Val.(cachedStatistics)
+		}
+	}
+
+	v, ok := c.result[fn]
+	if !ok {
+		return 0, fmt.Errorf("no recent historical values for %s", fd.FQN)
+	}
+	return v, nil
+}
+
+// refresh calculates the statistics of the feature, and caches them
+func (s *Statistics) refresh(ctx context.Context, fd api.FeatureDescriptor) (cachedStatistics, error) {
+	res, err := s.calculate(ctx, fd)
+	if err != nil {
+		return cachedStatistics{}, err
+	}
+	c := cachedStatistics{result: res, expiresAt: time.Now().Add(s.TTL)}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cache == nil {
+		s.cache = make(map[string]cachedStatistics)
+	}
+	s.cache[fd.FQN] = c
+	return c, nil
+}
+
+func (s *Statistics) calculate(ctx context.Context, fd api.FeatureDescriptor) (api.WindowResultMap, error) {
+	until := time.Now()
+	recs, err := s.Reader.Read(ctx, fd.FQN, until.Add(-s.Lookback), until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read historical records of %s: %w", fd.FQN, err)
+	}
+
+	data := make(api.BucketData)
+	for _, r := range recs {
+		updates, err := api.BucketUpdates(statisticFns, r.Value, r.Timestamp)
+		if err != nil {
+			// i.e. records without a value
+			continue
+		}
+		data.Apply(updates)
+	}
+	return api.WindowResult(statisticFns, data), nil
+}
@@ -17,10 +17,13 @@ limitations under the License.
 package plugins
 
 import (
+	"errors"
 	"fmt"
 	"github.com/raptor-ml/raptor/api"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"io"
+	"strings"
 )
 
 // # Available plugins
@@ -32,7 +35,10 @@ var Configurers = make(registry[api.BindConfig])
 var StateFactories = make(registry[api.StateFactory])
 var CollectNotifierFactories = make(registry[api.CollectNotifierFactory])
 var WriteNotifierFactories = make(registry[api.WriteNotifierFactory])
+var WatchNotifierFactories = make(registry[api.WatchNotifierFactory])
 var HistoricalWriterFactories = make(registry[api.HistoricalWriterFactory])
+var HistoricalReaderFactories = make(registry[api.HistoricalReaderFactory])
+var Encoders = make(registry[api.EncoderFactory])
 
 // # Plugin Registry
 
@@ -77,6 +83,55 @@ func NewWriteNotifier(provider string, viper *viper.Viper) (api.Notifier[api.Wri
 	return n, fmt.Errorf("notifier provider `%s` is not registered", provider)
 }
 
+// NewWatchNotifier creates a new api.Notifier[api.WatchNotification] for a notifier provider.
+func NewWatchNotifier(provider string, viper *viper.Viper) (api.Notifier[api.WatchNotification], error) {
+	if p := WatchNotifierFactories.Get(provider); p != nil {
+		return p(viper)
+	}
+	var n api.Notifier[api.WatchNotification]
+	return n, fmt.Errorf("notifier provider `%s` is not registered", provider)
+}
+
+// InProcessProvider is the name of the in-process state and notifier provider.
+const InProcessProvider = "memory"
+
+// embeddedStateProviders are the state providers that run within the process, and don't require an external server.
+// Their notifier provider defaults to the in-process one, so they can run without any external server as well.
+var embeddedStateProviders = map[string]bool{InProcessProvider: true, "bolt": true}
+
+// ResolveNotifierProvider makes sure the notifier provider can be used with the state provider.
+// For the embedded state providers, the `notifier-provider` defaults to the in-process one when it's not set. The
+// in-process state can't be shared with other processes, so it's only usable with the in-process notifier.
+// The Redis notifier providers are rejected when no Redis server is configured.
+func ResolveNotifierProvider(viper *viper.Viper) error {
+	state := viper.GetString("state-provider")
+	if embeddedStateProviders[state] && !viper.IsSet("notifier-provider") {
+		viper.Set("notifier-provider", InProcessProvider)
+	}
+
+	provider := viper.GetString("notifier-provider")
+	if state == InProcessProvider && provider != InProcessProvider {
+		return fmt.Errorf("the `%s` state provider requires the `%s` notifier provider, got `%s`",
+			InProcessProvider, InProcessProvider, provider)
+	}
+	if strings.HasPrefix(provider, "redis") && len(viper.GetStringSlice("redis")) == 0 {
+		return fmt.Errorf("the `%s` notifier provider requires a Redis server, but none is configured (see `--redis`)",
+			provider)
+	}
+	return nil
+}
+
+// Close closes the plugins that hold resources (i.e. connections or background routines), if they implement io.Closer.
+func Close(plugins ...any) error {
+	var errs []error
+	for _, p := range plugins {
+		if c, ok := p.(io.Closer); ok {
+			errs = append(errs, c.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // BindConfig adds config flags for the plugin.
 func BindConfig(set *pflag.FlagSet) error {
 	for _, p := range Configurers {
@@ -95,6 +150,14 @@ func NewHistoricalWriter(provider string, viper *viper.Viper) (api.HistoricalWri
 	return nil, fmt.Errorf("historical writer provider `%s` is not registered", provider)
 }
 
+// NewHistoricalReader creates a new HistoricalReader for an historical reader provider.
+func NewHistoricalReader(provider string, viper *viper.Viper) (api.HistoricalReader, error) {
+	if p := HistoricalReaderFactories.Get(provider); p != nil {
+		return p(viper)
+	}
+	return nil, fmt.Errorf("historical reader provider `%s` is not registered", provider)
+}
+
 type modelServerRegistry map[string]api.ModelServer
 
 func (r modelServerRegistry) Register(name string, p api.ModelServer) {
@@ -0,0 +1,78 @@
+{{- /* gotype: github.com/raptor-ml/raptor/pkg/querybuilder.featureSetQuery */ -}}
+{{- /* @formatter:off */ -}}
+{{- /***
+  # Point in time join query
+  --------------------------
+  The ClickHouse flavor of the feature set query (see ../featureset.tmpl.sql).
+
+  1. Select all the data
+    1.1. base - base data is selecting since `since` minus the biggest window size
+    1.2. data - the base without the "extra time" - we'll use that for our joins
+    1.3. primitivesData - the data without the windowed features
+  2. Prepare the Windows data using the shared `window` template (see window.tmpl.sql)
+  3. Prepare the primitives' data - for each primitive create the `f_XX` CTE, casted to the feature's type
+  4. Build the final view by join the key feature with each feature CTE.
+       ClickHouse supports only equality conditions in JOIN ON, and the non-key CTEs hold a single row, so the
+       staleness of the joined value is checked in the SELECT.
+ ***/ -}}
+WITH
+    {{- /* 1. Get all the data relevant for this feature set */}}
+    base AS (
+        SELECT  fqn,
+                keys,
+                timestamp,
+                value AS val,
+                bucket,
+                bucket_active
+        FROM {{.FeaturesTable}}
+        {{- /* we should take a greater time before `since` to avoid a window that started exactly at 00:00 */}}
+        WHERE timestamp BETWEEN {{subtractDuration .BeforePadding .Since}}
+            AND {{.Until}}
+            AND fqn IN (
+            {{- range $i, $f := .Features -}}
+                {{- if ne $i 0}}, {{end -}}
+                '{{$f.FQN}}'
+            {{- end -}})
+    ),
+    data AS (SELECT * FROM base WHERE timestamp >= {{.Since}}),
+    primitivesData AS (SELECT *, val AS _val FROM data WHERE bucket IS NULL)
+{{- range $_, $f := .Features}}
+{{- if $f.ValidWindow}}
+    {{- /* 2. Calculate the windowed feature */ -}}
+    ,
+    {{- template "window" (window $f $.Since (ne $f.FQN $.KeyFeature))}}
+{{- else}}
+    {{- /* 3. Get the primitive's data */ -}}
+    ,
+    {{tmpName $f.FQN}} AS (
+        SELECT  fqn,
+                keys,
+                timestamp,
+                {{template "castValue" (castFeature $f)}} AS val
+        FROM primitivesData
+        WHERE fqn = '{{$f.FQN}}'
+            {{ if ne $f.FQN $.KeyFeature}}ORDER BY timestamp DESC LIMIT 1{{end}}
+    )
+{{- end}}
+{{- end}}
+{{- /* 4. Build the final results */}}
+SELECT  key_feature.timestamp AS timestamp,
+        key_feature.keys AS keys
+{{- range $_, $f := .Features}},
+    {{- if eq $f.FQN $.KeyFeature}}
+        key_feature.val AS {{escapeName $f.FQN}}
+    {{- else}}
+    {{- $n := tmpName $f.FQN}}
+        if({{$n}}.timestamp <= key_feature.timestamp
+            AND {{$n}}.timestamp >= {{subtractDuration $f.Staleness "key_feature.timestamp"}},
+            {{$n}}.val, NULL) AS {{escapeName $f.FQN}}
+    {{- end}}
+{{- end}}
+    FROM {{tmpName .KeyFeature}} AS key_feature
+{{- range $_, $f := .Features}}
+{{- if eq $f.FQN $.KeyFeature}}{{continue}}{{end}}
+{{- $n := tmpName $f.FQN}}
+    {{- /* 4.1. Join the KeyFeature with the feature's CTE */}}
+        LEFT JOIN {{$n}} ON {{$n}}.keys = key_feature.keys
+{{- end}}
+    ORDER BY timestamp
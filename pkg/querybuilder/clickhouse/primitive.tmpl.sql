@@ -0,0 +1,27 @@
+{{- /* gotype: github.com/raptor-ml/raptor/pkg/querybuilder.featureQuery */ -}}
+{{- /* @formatter:off */ -}}
+WITH results AS (
+    SELECT  fqn,
+            keys,
+            timestamp,
+            value AS _val,
+            {{- /* Add expiration of this value. lagInFrame returns the type's default when there's no row, hence the toNullable */}}
+            lagInFrame(toNullable(timestamp), 1) OVER (PARTITION BY fqn, keys ORDER BY timestamp DESC
+                ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS _next_timestamp,
+            {{subtractDuration .Staleness "timestamp"}} AS _expire
+    FROM {{.FeaturesTable}}
+    WHERE fqn = '{{.FQN}}'
+        AND timestamp BETWEEN {{.Since}} AND {{.Until}}
+        AND bucket IS NULL
+)
+SELECT
+    fqn,
+    keys,
+    timestamp,
+    {{template "castValue" (castFeature .FeatureDescriptor)}} AS value,
+    CASE
+        WHEN _next_timestamp < _expire THEN _next_timestamp
+        ELSE _expire
+    END AS valid_till
+FROM results
+ORDER BY fqn, timestamp, keys
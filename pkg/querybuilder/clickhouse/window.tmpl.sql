@@ -0,0 +1,54 @@
+{{- /* @formatter:off */ -}}
+{{- /***
+  The ClickHouse parts of the shared `window` template (see ../window.tmpl.sql).
+  The values are JSON strings, so the fields are extracted with the JSON functions, and the value object is built as a
+  JSON string as well.
+ ***/ -}}
+{{- define "windowTimestamp"}}timestamp{{end}}
+
+{{- define "windowField"}}JSONExtract(val, '{{.}}', 'Nullable(Float64)'){{end}}
+
+{{- /* gotype: github.com/raptor-ml/raptor/pkg/querybuilder.windowSketches */}}
+{{- define "windowSketches"}}
+        SELECT fqn, keys, win_end, kv.1 AS _key, kv.2 AS _value
+        FROM {{.From}}
+        ARRAY JOIN JSONExtractKeysAndValues(val, 'Float64') AS kv
+        WHERE {{range $i, $p := .Prefixes}}{{if $i}} OR {{end}}startsWith(kv.1, '{{$p}}'){{end}}
+{{- end}}
+
+{{- define "windowArgMin"}}if(countIf(JSONHas(val, '{{.}}')) > 0,
+                        argMinIf(JSONExtractFloat(val, '{{.}}'), JSONExtractFloat(val, '{{.}}_ts'), JSONHas(val, '{{.}}')),
+                        NULL)
+{{- end}}
+
+{{- define "windowArgMax"}}if(countIf(JSONHas(val, '{{.}}')) > 0,
+                        argMaxIf(JSONExtractFloat(val, '{{.}}'), JSONExtractFloat(val, '{{.}}_ts'), JSONHas(val, '{{.}}')),
+                        NULL)
+{{- end}}
+
+{{- define "windowBinIndex"}}toInt32(splitByChar(':', {{.}})[2]){{end}}
+
+{{- define "windowInteger"}}{{.}}{{end}}
+
+{{- /* NULL values are omitted by the mapFilter. The map's values must share a type, hence the toFloat64 */}}
+{{- /* gotype: github.com/raptor-ml/raptor/pkg/querybuilder.windowQuery */}}
+{{- define "windowObject"}}toJSONString(mapFilter((k, v) -> isNotNull(v), map(
+                {{- range $i, $fn := .Aggr}}
+                    {{- if ne $i 0}},{{end}}
+                    '{{$fn}}', toFloat64({{template "windowAggrFn" $fn.String}})
+                {{- end}}
+                )))
+{{- end}}
+
+{{- /***
+  castValue extracts the JSON `_val` column as the type of the feature (see castFeature).
+  ClickHouse resolves the aliases of the SELECT before its columns, so the casted value can't be read from a column
+  with the same name as its alias.
+ ***/}}
+{{- define "castValue"}}
+{{- if eq . "JSON"}}_val
+{{- else if eq . "String"}}JSONExtractString(_val)
+{{- else if eq . "DateTime64(6)"}}parseDateTime64BestEffortOrNull(JSONExtractString(_val), 6)
+{{- else}}JSONExtract(_val, '{{.}}')
+{{- end}}
+{{- end}}
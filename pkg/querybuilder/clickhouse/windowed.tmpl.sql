@@ -0,0 +1,49 @@
+{{- /* gotype: github.com/raptor-ml/raptor/pkg/querybuilder.featureQuery */ -}}
+{{- /* @formatter:off */ -}}
+{{- /***
+  # Windowed feature
+  --------------------------
+  The ClickHouse flavor of the windowed feature query (see ../windowed.tmpl.sql).
+
+  1. Select all the data
+    1.1. base - base data is selecting since `since` minus the window size
+            (to allow windows that started exactly in `since` to be included)
+    1.2. data - the base without the "extra time" - we'll use that for our joins
+  2. Prepare the Windows data using the shared `window` template (see window.tmpl.sql)
+  3. Add the expiration of the values and show the result ordered
+ ***/ -}}
+WITH
+    {{- /* 1. Get all the data relevant for this feature set */}}
+    base AS (
+        SELECT  fqn,
+                keys,
+                timestamp,
+                value AS val,
+                bucket,
+                bucket_active
+        FROM {{.FeaturesTable}}
+        WHERE timestamp BETWEEN {{subtractDuration .Staleness .Since}}
+          AND {{.Until}}
+          AND fqn = '{{.FQN}}'
+    ),
+    data AS (SELECT * FROM base WHERE timestamp >= {{.Since}}),
+    {{- /* 2. Calculate the windowed feature */}}
+    {{- template "window" (window .FeatureDescriptor .Since false)}},
+    {{- /* 3. Add expiration of this value */}}
+    results AS (
+        SELECT  *,
+                lagInFrame(toNullable(timestamp), 1) OVER (PARTITION BY fqn, keys ORDER BY timestamp DESC
+                    ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS _next_timestamp,
+                {{subtractDuration .Staleness "timestamp"}} AS _expire
+        FROM {{tmpName .FQN}}
+    )
+SELECT  fqn,
+        keys,
+        timestamp,
+        val AS value,
+        CASE
+            WHEN _next_timestamp < _expire THEN _next_timestamp
+            ELSE _expire
+        END AS valid_till
+FROM results
+ORDER BY fqn, keys, timestamp
@@ -27,8 +27,8 @@ func (qb *queryBuilder) Feature(ft api.FeatureDescriptor) (string, error) {
 	data := featureQuery{
 		baseQuery: baseQuery{
 			FeaturesTable: qb.featureTable,
-			Since:         "$SINCE",
-			Until:         "$UNTIL",
+			Since:         qb.since,
+			Until:         qb.until,
 		},
 		FeatureDescriptor: ft,
 	}
@@ -13,11 +13,12 @@
             (to allow windows that started exactly in $SINCE to be included)
     1.2. data - the base without the "extra time" - we'll use that for our joins
     1.3. primitivesData - the data without the windowed features
-  2. Prepare the Windows data - for each window create the following CTEs:
+  2. Prepare the Windows data using the shared `window` template (see window.tmpl.sql):
     2.1. winData_f_XX - the windowed data for the feature
     2.2. f_XX - the windowed feature
         2.2.1. Joining winDataXX with itself in the range of the window
-        2.2.2. Take only the latest value*
+        2.2.2. Merging the buckets and calculating the aggregations of the window
+        2.2.3. Take only the latest value*
                 ORDER BY feature.TIMESTAMP DESC LIMIT 1
   3. Prepare the primitives' data - for each primitive create the `f_XX` CTE
     3.1. WHERE fqn=<fqn>
@@ -52,43 +53,9 @@ WITH
     primitivesData AS (SELECT * FROM data WHERE BUCKET IS NULL)
 {{- range $_, $f := .Features}}
 {{- if $f.ValidWindow}}
-    {{- /* 2.1. Get the buckets data with start and end dates */ -}}
+    {{- /* 2. Calculate the windowed feature */ -}}
     ,
-    winData_{{tmpName $f.FQN}} AS (
-        SELECT *, {{subtractDuration $f.Staleness "timestamp"}} AS WIN_START, TIMESTAMP AS WIN_END
-        FROM base
-        WHERE BUCKET IS NOT NULL
-          AND BUCKET_ACTIVE = false
-          AND FQN = '{{$f.FQN}}'
-          AND TIMESTAMP >= {{subtractDuration $f.Staleness $.Since}}
-    ),
-    {{- /* 2.2. Get the windowed feature */}}
-    {{tmpName $f.FQN}} AS (
-        SELECT
-            b1.KEYS,
-            b1.WIN_START,
-            b1.WIN_END,
-            b1.WIN_END as TIMESTAMP,
-            sum (b1.VAL['count']) as _ COUNT,
-            sum (b1.VAL['sum']) as _ SUM,
-            min (b1.VAL['min']) as _ MIN,
-            max (b1.VAL['max']) as _ MAX,
-            (_ SUM / _ COUNT) as _ AVG,
-            OBJECT_CONSTRUCT( {{- /*- building a unified value object*/}}
-                'count',
-                _ COUNT :: int ::variant, 'sum',
-                _ SUM :: int ::variant,
-                'min', _ MIN :: int ::variant,
-                'max', _ MAX :: int ::variant,
-                'avg', _ AVG :: double ::variant
-            ) as VAL
-        FROM (
-            SELECT * FROM winData_{{tmpName $f.FQN}} WHERE WIN_END >= {{$.Since}}) b1
-                LEFT JOIN winData_{{tmpName $f.FQN}} AS b2 ON b1.KEYS = b2.KEYS
-                    AND b2.WIN_END >= b1.WIN_START AND b2.WIN_END < b1.WIN_END AND b2.BUCKET != b1.BUCKET
-            GROUP BY b1.KEYS, b1.WIN_START, b1.WIN_END
-            {{ if ne $f.FQN $.KeyFeature}}ORDER BY TIMESTAMP DESC LIMIT 1{{end}}
-    )
+    {{- template "window" (window $f $.Since (ne $f.FQN $.KeyFeature))}}
 {{- else}}
     {{- /* 3. Get the buckets data with start and end dates */ -}}
     ,
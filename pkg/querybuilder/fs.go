@@ -50,8 +50,8 @@ func (qb *queryBuilder) FeatureSet(ctx context.Context, fs manifests.ModelSpec,
 
 	data := featureSetQuery{
 		baseQuery: baseQuery{
-			Since:         "$SINCE",
-			Until:         "$UNTIL",
+			Since:         qb.since,
+			Until:         qb.until,
 			FeaturesTable: qb.featureTable,
 		},
 		KeyFeature: fs.KeyFeature,
@@ -0,0 +1,27 @@
+{{- /* gotype: github.com/raptor-ml/raptor/pkg/querybuilder.featureQuery */ -}}
+{{- /* @formatter:off */ -}}
+{{- /* PostgreSQL can't reference the aliases of the same SELECT, so the expiration is calculated in the CTE */ -}}
+WITH results AS (
+    SELECT  fqn,
+            keys,
+            "timestamp",
+            value AS val,
+            {{- /* Add expiration of this value */}}
+            LAG("timestamp", 1) OVER (PARTITION BY fqn, keys ORDER BY "timestamp" DESC) AS _next_timestamp,
+            {{subtractDuration .Staleness `"timestamp"`}} AS _expire
+    FROM {{.FeaturesTable}}
+    WHERE fqn = '{{.FQN}}'
+        AND "timestamp" BETWEEN {{.Since}} AND {{.Until}}
+        AND bucket IS NULL
+)
+SELECT
+    fqn,
+    keys,
+    "timestamp",
+    {{template "castValue" (castFeature .FeatureDescriptor)}} AS value,
+    CASE
+        WHEN _next_timestamp < _expire THEN _next_timestamp
+        ELSE _expire
+    END AS valid_till
+FROM results
+ORDER BY fqn, "timestamp", keys
@@ -0,0 +1,44 @@
+{{- /* @formatter:off */ -}}
+{{- /***
+  The PostgreSQL parts of the shared `window` template (see ../window.tmpl.sql).
+  The values are stored as jsonb.
+ ***/ -}}
+{{- define "windowTimestamp"}}"timestamp"{{end}}
+
+{{- define "windowField"}}(val->>'{{.}}')::double precision{{end}}
+
+{{- /* gotype: github.com/raptor-ml/raptor/pkg/querybuilder.windowSketches */}}
+{{- define "windowSketches"}}
+        SELECT p.fqn, p.keys, p.win_end, f.key AS _key, f.value::double precision AS _value
+        FROM {{.From}} p, LATERAL jsonb_each_text(p.val) AS f(key, value)
+        WHERE {{range $i, $p := .Prefixes}}{{if $i}} OR {{end}}f.key LIKE '{{$p}}%'{{end}}
+{{- end}}
+
+{{- define "windowArgMin"}}(ARRAY_AGG(val->'{{.}}' ORDER BY (val->>'{{.}}_ts')::double precision)
+                        FILTER (WHERE val->'{{.}}' IS NOT NULL))[1]
+{{- end}}
+
+{{- define "windowArgMax"}}(ARRAY_AGG(val->'{{.}}' ORDER BY (val->>'{{.}}_ts')::double precision DESC)
+                        FILTER (WHERE val->'{{.}}' IS NOT NULL))[1]
+{{- end}}
+
+{{- define "windowBinIndex"}}split_part({{.}}, ':', 2)::int{{end}}
+
+{{- define "windowInteger"}}{{.}}::bigint{{end}}
+
+{{- /* NULL values are omitted by jsonb_strip_nulls */}}
+{{- /* gotype: github.com/raptor-ml/raptor/pkg/querybuilder.windowQuery */}}
+{{- define "windowObject"}}jsonb_strip_nulls(jsonb_build_object(
+                {{- range $i, $fn := .Aggr}}
+                    {{- if ne $i 0}},{{end}}
+                    '{{$fn}}', {{template "windowAggrFn" $fn.String}}
+                {{- end}}
+                ))
+{{- end}}
+
+{{- /* castValue casts the jsonb `val` column to the type of the feature (see castFeature) */}}
+{{- define "castValue"}}
+{{- if eq . "jsonb"}}val
+{{- else}}(val #>> '{}')::{{.}}
+{{- end}}
+{{- end}}
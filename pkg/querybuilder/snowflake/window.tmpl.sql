@@ -0,0 +1,32 @@
+{{- /* @formatter:off */ -}}
+{{- /***
+  The Snowflake parts of the shared `window` template (see ../window.tmpl.sql).
+ ***/ -}}
+{{- define "windowTimestamp"}}TIMESTAMP{{end}}
+
+{{- define "windowField"}}val['{{.}}'] :: double{{end}}
+
+{{- /* gotype: github.com/raptor-ml/raptor/pkg/querybuilder.windowSketches */}}
+{{- define "windowSketches"}}
+        SELECT p.fqn, p.keys, p.win_end, f.key AS _key, f.value :: double AS _value
+        FROM {{.From}} p, LATERAL FLATTEN(input => p.val) f
+        WHERE {{range $i, $p := .Prefixes}}{{if $i}} OR {{end}}STARTSWITH(f.key, '{{$p}}'){{end}}
+{{- end}}
+
+{{- define "windowArgMin"}}MIN_BY(val['{{.}}'], val['{{.}}_ts'] :: double){{end}}
+
+{{- define "windowArgMax"}}MAX_BY(val['{{.}}'], val['{{.}}_ts'] :: double){{end}}
+
+{{- define "windowBinIndex"}}SPLIT_PART({{.}}, ':', 2) :: int{{end}}
+
+{{- define "windowInteger"}}{{.}} :: int{{end}}
+
+{{- /* NULL values are omitted by OBJECT_CONSTRUCT */}}
+{{- /* gotype: github.com/raptor-ml/raptor/pkg/querybuilder.windowQuery */}}
+{{- define "windowObject"}}OBJECT_CONSTRUCT(
+                {{- range $i, $fn := .Aggr}}
+                    {{- if ne $i 0}},{{end}}
+                    '{{$fn}}', {{template "windowAggrFn" $fn.String}} :: variant
+                {{- end}}
+                )
+{{- end}}
@@ -27,9 +27,34 @@ import (
 	"time"
 )
 
-//go:embed *.tmpl.sql
+//go:embed *.tmpl.sql snowflake/*.tmpl.sql postgres/*.tmpl.sql clickhouse/*.tmpl.sql
 var tplFiles embed.FS
 
+// Dialect is the SQL flavor of the generated queries. Each dialect has its own set of templates, besides the shared
+// `window` template that is completed by the dialect's parts (see window.tmpl.sql).
+type Dialect string
+
+const (
+	// DialectSnowflake is the default dialect. Its templates live at the root of the package, except for its parts of
+	// the `window` template.
+	DialectSnowflake Dialect = "snowflake"
+	// DialectPostgres generates queries for PostgreSQL. The value column is expected to be `jsonb`.
+	DialectPostgres Dialect = "postgres"
+	// DialectClickHouse generates queries for ClickHouse. The value column is expected to be a JSON `String`.
+	DialectClickHouse Dialect = "clickhouse"
+)
+
+func (d Dialect) patterns() []string {
+	switch d {
+	case DialectSnowflake:
+		return []string{"*.tmpl.sql", "snowflake/*.tmpl.sql"}
+	case DialectPostgres, DialectClickHouse:
+		return []string{"window.tmpl.sql", string(d) + "/*.tmpl.sql"}
+	default:
+		panic(fmt.Sprintf("unsupported dialect `%s`", d))
+	}
+}
+
 type baseQuery struct {
 	FeaturesTable string
 	Since         string
@@ -48,11 +73,20 @@ type QueryBuilder interface {
 type queryBuilder struct {
 	tpls         *template.Template
 	featureTable string
+	since        string
+	until        string
 }
 
 type Config struct {
 	FeaturesTable string
 
+	// Dialect selects the templates of the queries. Defaults to DialectSnowflake.
+	Dialect Dialect
+	// Since and Until are the expressions that bound the time range of the queries.
+	// Defaults to the `$SINCE` and `$UNTIL` session variables.
+	Since string
+	Until string
+
 	// EscapeName is used to escape the feature's FQN.
 	EscapeName func(s string) string
 	// SubtractDuration is used to subtract a duration from a field with your SQL flavor.
@@ -79,17 +113,29 @@ func New(config Config) QueryBuilder {
 	if config.EscapeName == nil {
 		config.EscapeName = EscapeName
 	}
+	if config.Dialect == "" {
+		config.Dialect = DialectSnowflake
+	}
+	if config.Since == "" {
+		config.Since = "$SINCE"
+	}
+	if config.Until == "" {
+		config.Until = "$UNTIL"
+	}
 	tpls := template.New("").Funcs(template.FuncMap{
 		"escapeName":       config.EscapeName,
 		"subtractDuration": config.SubtractDuration,
 		"castFeature":      config.CastFeature,
 		"tmpName":          config.TmpName,
+		"window":           newWindowQuery,
 	})
-	tpls = template.Must(tpls.ParseFS(tplFiles, "*.sql"))
+	tpls = template.Must(tpls.ParseFS(tplFiles, config.Dialect.patterns()...))
 
 	return &queryBuilder{
 		featureTable: config.FeaturesTable,
 		tpls:         tpls,
+		since:        config.Since,
+		until:        config.Until,
 	}
 }
 
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2022 RaptorML authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package querybuilder
+
+import (
+	"github.com/raptor-ml/raptor/api"
+)
+
+// windowQuery is the data of the shared `window` template, that calculates a windowed feature out of its buckets.
+type windowQuery struct {
+	api.FeatureDescriptor
+	Since string
+	// LatestOnly limits the results to the latest window
+	LatestOnly bool
+
+	HLLRegisters  int
+	DDSketchGamma float64
+}
+
+func newWindowQuery(fd api.FeatureDescriptor, since string, latestOnly bool) windowQuery {
+	return windowQuery{
+		FeatureDescriptor: fd,
+		Since:             since,
+		LatestOnly:        latestOnly,
+		HLLRegisters:      api.HLLRegisters,
+		DDSketchGamma:     api.DDSketchGamma,
+	}
+}
+
+// windowSketches is the data of the dialect's `windowSketches` template
+type windowSketches struct {
+	// From is the CTE of the buckets
+	From string
+	// Prefixes of the sketches' fields
+	Prefixes []string
+}
+
+// Sketches returns the data of the fields of the sketches, out of the buckets in the `from` CTE
+func (q windowQuery) Sketches(from string) windowSketches {
+	s := windowSketches{From: from}
+	if q.HasCountDistinct() {
+		s.Prefixes = append(s.Prefixes, "hll:")
+	}
+	if q.HasPercentiles() {
+		s.Prefixes = append(s.Prefixes, "dd")
+	}
+	return s
+}
+
+func (q windowQuery) has(fns ...api.AggrFn) bool {
+	for _, a := range q.Aggr {
+		for _, fn := range fns {
+			if a == fn {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasCountDistinct returns true if the HyperLogLog registers should be merged
+func (q windowQuery) HasCountDistinct() bool {
+	return q.has(api.AggrFnCountDistinct)
+}
+
+// HasPercentiles returns true if the DDSketch bins should be merged
+func (q windowQuery) HasPercentiles() bool {
+	return q.has(api.AggrFnP50, api.AggrFnP95, api.AggrFnP99)
+}
@@ -0,0 +1,183 @@
+{{- /* gotype: github.com/raptor-ml/raptor/pkg/querybuilder.windowQuery */ -}}
+{{- /* @formatter:off */ -}}
+{{- /***
+  # Window
+  --------------------------
+  A shared template that calculates a windowed feature out of its finished buckets, in the `base` CTE.
+  The last CTE is named after the feature (f_XX), and holds the window's value for each window end time.
+
+  The template is shared by all the dialects. The dialect specific parts are defined in the `window.tmpl.sql` of each
+  dialect's directory:
+    - windowTimestamp - the timestamp column
+    - windowField - extracts a numeric field of the bucket's value
+    - windowSketches - selects the sketches' fields of the buckets as rows of (`_key`, `_value`)
+    - windowArgMin, windowArgMax - the value of a field with the min/max `<field>_ts`
+    - windowBinIndex - the index of a DDSketch bin out of its key
+    - windowInteger - casts a numeric value to an integer
+    - windowObject - builds the value object out of the feature's aggregations (see windowAggrFn)
+  Some dialects (i.e. PostgreSQL) can't reference the aliases of the same SELECT, so the derived values are calculated
+  in sub-queries.
+
+  1. winData_f_XX - the buckets of the feature with the window's start and end timestamps
+  2. f_XX_pairs - join each bucket (b1) with all the buckets (b2) of its window.
+       Some dialects (i.e. ClickHouse) support only equality conditions in JOIN ON, so the window's range is filtered in
+       the WHERE clause.
+  3. f_XX_sketches - the fields of the HyperLogLog registers and the DDSketch bins of the buckets
+  4. f_XX_hll - merge the HyperLogLog registers (max per register) and estimate the distinct count.
+       Registers that were never set are zero, hence the `zeros`.
+  5. f_XX_dd, f_XX_pct - merge the DDSketch bins (sum per bin) and find the bin of each percentile
+  6. f_XX_aggr - merge the plain fields of the buckets
+  7. f_XX - build the value object of the window out of the feature's aggregations
+ ***/ -}}
+{{- define "window"}}
+{{- $n := tmpName .FQN}}
+    {{- /* 1. Get the buckets data with start and end dates */}}
+    winData_{{$n}} AS (
+        SELECT  *,
+                {{subtractDuration .Staleness "win_end"}} AS win_start
+        FROM (
+            SELECT  *,
+                    {{template "windowTimestamp"}} AS win_end
+            FROM base
+            WHERE bucket IS NOT NULL
+              AND bucket_active = false
+              AND fqn = '{{.FQN}}'
+              AND {{template "windowTimestamp"}} >= {{subtractDuration .Staleness .Since}}
+        ) AS buckets
+    ),
+    {{- /* 2. Join each bucket with the buckets of its window */}}
+    {{$n}}_pairs AS (
+        SELECT b1.fqn AS fqn, b1.keys AS keys, b1.win_start AS win_start, b1.win_end AS win_end, b2.val AS val
+        FROM (SELECT * FROM winData_{{$n}} WHERE win_end >= {{.Since}}) AS b1
+        INNER JOIN winData_{{$n}} AS b2
+        ON b1.keys = b2.keys
+        WHERE b2.win_end > b1.win_start AND b2.win_end <= b1.win_end
+    ),
+{{- if or .HasCountDistinct .HasPercentiles}}
+    {{- /* 3. Extract the fields of the sketches */}}
+    {{$n}}_sketches AS (
+        {{- template "windowSketches" (.Sketches (print $n "_pairs"))}}
+    ),
+{{- end}}
+{{- if .HasCountDistinct}}
+    {{- /* 4. Merge the HyperLogLog registers */}}
+    {{$n}}_hll AS (
+        SELECT  fqn, keys, win_end,
+                round(CASE
+                    WHEN _raw <= 2.5 * {{.HLLRegisters}} AND _zeros > 0 THEN {{.HLLRegisters}} * (ln({{.HLLRegisters}}) - ln(_zeros))
+                    ELSE _raw
+                END) AS _distinct
+        FROM (
+            SELECT  fqn, keys, win_end, _zeros,
+                    (0.7213 / (1 + 1.079 / {{.HLLRegisters}})) * pow({{.HLLRegisters}}, 2) / (_harmonic + _zeros) AS _raw
+            FROM (
+                SELECT  fqn, keys, win_end,
+                        {{.HLLRegisters}} - count(*) AS _zeros,
+                        sum(pow(2, -_rank)) AS _harmonic
+                FROM (
+                    SELECT fqn, keys, win_end, _key, max(_value) AS _rank
+                    FROM {{$n}}_sketches
+                    WHERE _key LIKE 'hll:%'
+                    GROUP BY fqn, keys, win_end, _key
+                ) AS registers
+                GROUP BY fqn, keys, win_end
+            ) AS harmonic
+        ) AS estimates
+    ),
+{{- end}}
+{{- if .HasPercentiles}}
+    {{- /* 5. Merge the DDSketch bins. The bin i holds the values within (gamma^(i-1), gamma^i] */}}
+    {{$n}}_dd AS (
+        SELECT  fqn, keys, win_end, _bin,
+                sum(sum(_count)) OVER (PARTITION BY fqn, keys, win_end ORDER BY _bin
+                    ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS _cum,
+                sum(sum(_count)) OVER (PARTITION BY fqn, keys, win_end) AS _total
+        FROM (
+            SELECT  fqn, keys, win_end,
+                    CASE
+                        WHEN _key = 'dd0' THEN 0
+                        WHEN _key LIKE 'dd-:%' THEN -2 * pow({{.DDSketchGamma}}, {{template "windowBinIndex" "_key"}}) / ({{.DDSketchGamma}} + 1)
+                        ELSE 2 * pow({{.DDSketchGamma}}, {{template "windowBinIndex" "_key"}}) / ({{.DDSketchGamma}} + 1)
+                    END AS _bin,
+                    _value AS _count
+            FROM {{$n}}_sketches
+            WHERE _key LIKE 'dd%'
+        ) AS bins
+        GROUP BY fqn, keys, win_end, _bin
+    ),
+    {{$n}}_pct AS (
+        SELECT  fqn, keys, win_end,
+                min(CASE WHEN _cum > 0.5 * (_total - 1) THEN _bin END) AS _p50,
+                min(CASE WHEN _cum > 0.95 * (_total - 1) THEN _bin END) AS _p95,
+                min(CASE WHEN _cum > 0.99 * (_total - 1) THEN _bin END) AS _p99
+        FROM {{$n}}_dd
+        GROUP BY fqn, keys, win_end
+    ),
+{{- end}}
+    {{- /***
+      6. Merge the plain fields. Missing fields are extracted as NULL, so they are ignored by the aggregations.
+         The sums of squared differences from the mean (m2) of the buckets are combined around the mean of the window
+         (see api.BucketOpMoments).
+     ***/}}
+    {{$n}}_aggr AS (
+        SELECT  *,
+                CASE WHEN _m2_count >= 2 THEN _m2 / (_m2_count - 1) END AS _variance
+        FROM (
+            SELECT  fqn, keys, win_start, win_end,
+                    sum({{template "windowField" "count"}}) AS _count,
+                    sum({{template "windowField" "sum"}}) AS _sum,
+                    min({{template "windowField" "min"}}) AS _min,
+                    max({{template "windowField" "max"}}) AS _max,
+                    sum({{template "windowField" "m2"}}
+                        + {{template "windowField" "m2_count"}} * pow({{template "windowField" "m2_mean"}} - _m2_mean, 2)) AS _m2,
+                    sum({{template "windowField" "m2_count"}}) AS _m2_count,
+                    {{template "windowArgMin" "first"}} AS _first,
+                    {{template "windowArgMax" "last"}} AS _last
+            FROM (
+                SELECT  *,
+                        sum({{template "windowField" "m2_count"}} * {{template "windowField" "m2_mean"}})
+                            OVER (PARTITION BY fqn, keys, win_start, win_end)
+                        / sum({{template "windowField" "m2_count"}}) OVER (PARTITION BY fqn, keys, win_start, win_end) AS _m2_mean
+                FROM {{$n}}_pairs
+            ) AS moments
+            GROUP BY fqn, keys, win_start, win_end
+        ) AS merged
+    ),
+    {{- /* 7. Build the value object */}}
+    {{$n}} AS (
+        SELECT  a.fqn AS fqn,
+                a.keys AS keys,
+                a.win_start AS win_start,
+                a.win_end AS win_end,
+                a.win_end AS {{template "windowTimestamp"}},
+                {{template "windowObject" .}} AS val
+        FROM {{$n}}_aggr AS a
+        {{- if .HasCountDistinct}}
+        LEFT JOIN {{$n}}_hll AS h ON h.keys = a.keys AND h.win_end = a.win_end
+        {{- end}}
+        {{- if .HasPercentiles}}
+        LEFT JOIN {{$n}}_pct AS p ON p.keys = a.keys AND p.win_end = a.win_end
+        {{- end}}
+        {{- if .LatestOnly}}
+        ORDER BY win_end DESC LIMIT 1
+        {{- end}}
+    )
+{{- end}}
+
+{{- define "windowAggrFn"}}
+{{- if eq . "sum"}}a._sum
+{{- else if eq . "count"}}{{template "windowInteger" "a._count"}}
+{{- else if eq . "min"}}a._min
+{{- else if eq . "max"}}a._max
+{{- else if eq . "avg"}}(a._sum / nullif(a._count, 0))
+{{- else if eq . "variance"}}a._variance
+{{- else if eq . "stddev"}}sqrt(a._variance)
+{{- else if eq . "first"}}a._first
+{{- else if eq . "last"}}a._last
+{{- else if eq . "count_distinct"}}{{template "windowInteger" "h._distinct"}}
+{{- else if eq . "p50"}}p._p50
+{{- else if eq . "p95"}}p._p95
+{{- else if eq . "p99"}}p._p99
+{{- else}}NULL
+{{- end}}
+{{- end}}
@@ -10,11 +10,11 @@
     1.1. base - base data is selecting since $SINCE minus the window size
             (to allow windows that started exactly in $MIN_DATE to be included)
     1.2. data - the base without the "extra time" - we'll use that for our joins
-  2. Prepare the Windows data - for each window create the following CTEs:
+  2. Prepare the Windows data using the shared `window` template (see window.tmpl.sql):
     2.1. winData_f_XX - the windowed data for the feature
     2.2. f_XX - the windowed feature
         2.2.1. Joining winDataXX with itself in the range of the window
-        2.2.2. Take only the latest value for each `keys`
+        2.2.2. Merging the buckets and calculating the aggregations of the window
   3. Show the result ordered
  ***/ -}}
 WITH
@@ -33,42 +33,8 @@ WITH
           AND FQN = '{{.FQN}}'
     ),
     data as (SELECT * FROM base WHERE TIMESTAMP >= {{.Since}}),
-    {{- /* 2.1. Get the buckets data with start and end dates */}}
-    winData_{{tmpName .FQN}} AS (
-        SELECT  *,
-                {{subtractDuration .Staleness "timestamp"}} AS WIN_START, TIMESTAMP AS WIN_END
-                FROM base
-                WHERE BUCKET IS NOT NULL
-                  AND BUCKET_ACTIVE = false
-                  AND FQN = '{{.FQN}}'
-                  AND TIMESTAMP >= {{subtractDuration .Staleness $.Since}}
-    ),
-    {{- /* 2.2. Get the windowed feature */}}
-    {{tmpName .FQN}} AS (
-        SELECT
-            b1.FQN,
-            b1.KEYS,
-            b1.WIN_START,
-            b1.WIN_END,
-            b1.WIN_END as TIMESTAMP,
-            sum (b1.VAL['count']) as _ COUNT,
-            sum (b1.VAL['sum']) as _ SUM,
-            min (b1.VAL['min']) as _ MIN,
-            max (b1.VAL['max']) as _ MAX,
-            (_ SUM / _ COUNT) as _ AVG,
-            OBJECT_CONSTRUCT({{- /*- building a unified value object*/}}
-                'count', _ COUNT :: int ::variant,
-                'sum', _ SUM :: int ::variant,
-                'min', _ MIN :: int ::variant,
-                'max', _ MAX :: int ::variant,
-                'avg', _ AVG :: double ::variant
-            ) as VAL
-        FROM (SELECT * FROM winData_{{tmpName .FQN}} WHERE WIN_END >= {{$.Since}}) b1
-        LEFT JOIN winData_{{tmpName .FQN}} as b2
-        ON b1.KEYS = b2.KEYS
-            AND b2.WIN_END >= b1.WIN_START and b2.WIN_END < b1.WIN_END AND b2.BUCKET != b1.BUCKET
-        GROUP BY b1.FQN, b1.KEYS, b1.WIN_START, b1.WIN_END
-    ),
+    {{- /* 2. Calculate the windowed feature */}}
+    {{- template "window" (window .FeatureDescriptor .Since false)}},
     {{- /* Add expiration of this value */}}
     results as (
         SELECT *,
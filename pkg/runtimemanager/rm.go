@@ -26,6 +26,10 @@ import (
 	coreApi "github.com/raptor-ml/raptor/api/proto/gen/go/core/v1alpha1"
 	runtimeApi "github.com/raptor-ml/raptor/api/proto/gen/go/py_runtime/v1alpha1"
 	"github.com/raptor-ml/raptor/pkg/sdk"
+	"github.com/raptor-ml/raptor/pkg/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/local"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -211,7 +215,17 @@ func (r *runtime) LoadProgram(env, fqn, program string, packages []string) (*api
 	return pp, nil
 }
 
-func (r *runtime) ExecuteProgram(ctx context.Context, env string, fqn string, keys api.Keys, row map[string]any, ts time.Time, dryRun bool) (api.Value, api.Keys, error) {
+func (r *runtime) ExecuteProgram(ctx context.Context, env string, fqn string, keys api.Keys, row map[string]any, ts time.Time, dryRun bool) (_ api.Value, _ api.Keys, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "runtime.ExecuteProgram", trace.WithAttributes(
+		tracing.AttrFQN.String(fqn),
+		attribute.String("raptor.runtime.env", env),
+		attribute.Bool("raptor.runtime.dry_run", dryRun),
+	))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	rt, err := r.getRuntime(env)
 	if err != nil {
 		return api.Value{}, keys, fmt.Errorf("failed to get runtime: %w", err)
@@ -270,6 +284,8 @@ func (r *runtime) getRuntime(name string) (runtimeApi.RuntimeServiceClient, erro
 
 	cc, err := grpc.Dial(
 		fmt.Sprintf("unix://%s", socket),
+		// propagates the trace context to the runtime
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
 		grpc.WithStreamInterceptor(grpcMiddleware.ChainStreamClient(
 			grpcRetry.StreamClientInterceptor(),
 		)),
@@ -18,6 +18,7 @@ package sdk
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/raptor-ml/raptor/api"
@@ -73,8 +74,50 @@ func (e *grpcEngine) Get(ctx context.Context, selector string, keys api.Keys) (a
 	ret.Value = FromValue(resp.Value.Value)
 	ret.Timestamp = resp.Value.Timestamp.AsTime()
 	ret.Fresh = resp.Value.Fresh
+	ret.Degraded = FromAPIDegradedInputs(resp.Value.Degraded)
+	ret.Fallback = FromAPIFallback(resp.Fallback)
 	return ret, FromAPIFeatureDescriptor(resp.FeatureDescriptor), nil
 }
+func (e *grpcEngine) BatchGet(ctx context.Context, items []api.BatchGetItem) ([]api.BatchGetResult, error) {
+	req := coreApi.BatchGetRequest{
+		Uuid:  uuid.NewString(),
+		Items: make([]*coreApi.BatchGetItem, len(items)),
+	}
+	for i, item := range items {
+		req.Items[i] = &coreApi.BatchGetItem{
+			Selector: item.Selector,
+			Keys:     item.Keys,
+		}
+	}
+	resp, err := e.client.BatchGet(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get features: %w", normalizeError(err))
+	}
+
+	if resp.Uuid != req.Uuid {
+		return nil, fmt.Errorf("got %s uuid but requested with %s", resp.Uuid, req.Uuid)
+	}
+	if len(resp.Results) != len(items) {
+		return nil, fmt.Errorf("got %d results but requested %d items", len(resp.Results), len(items))
+	}
+
+	ret := make([]api.BatchGetResult, len(resp.Results))
+	for i, r := range resp.Results {
+		if codes.Code(r.Code) != codes.OK {
+			ret[i].Err = fmt.Errorf("failed to get feature: %w", normalizeError(status.Error(codes.Code(r.Code), r.Error)))
+			continue
+		}
+		ret[i].Value = api.Value{
+			Value:     FromValue(r.Value.Value),
+			Timestamp: r.Value.Timestamp.AsTime(),
+			Fresh:     r.Value.Fresh,
+			Degraded:  FromAPIDegradedInputs(r.Value.Degraded),
+			Fallback:  FromAPIFallback(r.Fallback),
+		}
+		ret[i].FeatureDescriptor = FromAPIFeatureDescriptor(r.FeatureDescriptor)
+	}
+	return ret, nil
+}
 func (e *grpcEngine) Set(ctx context.Context, fqn string, keys api.Keys, val any, ts time.Time) error {
 	req := coreApi.SetRequest{
 		Uuid:      uuid.NewString(),
@@ -143,6 +186,101 @@ func (e *grpcEngine) Update(ctx context.Context, fqn string, keys api.Keys, val
 	}
 	return nil
 }
+func (e *grpcEngine) Ingest(ctx context.Context, records <-chan api.IngestRecord) (api.IngestSummary, error) {
+	stream, err := e.client.Ingest(ctx)
+	if err != nil {
+		for range records {
+		}
+		return api.IngestSummary{}, fmt.Errorf("failed to open ingestion stream: %w", normalizeError(err))
+	}
+
+	for rec := range records {
+		err := stream.Send(&coreApi.IngestRequest{
+			Method:    ToAPIWriteMethod(rec.Method),
+			Selector:  rec.Selector,
+			Keys:      rec.Keys,
+			Value:     ToAPIValue(rec.Value),
+			Timestamp: timestamppb.New(rec.Timestamp),
+		})
+		if err != nil {
+			// The actual error is returned by CloseAndRecv
+			for range records {
+			}
+			break
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return api.IngestSummary{}, fmt.Errorf("failed to ingest records: %w", normalizeError(err))
+	}
+
+	ret := api.IngestSummary{
+		Received:  resp.Received,
+		Succeeded: resp.Succeeded,
+		Failed:    resp.Failed,
+	}
+	for _, f := range resp.Failures {
+		ret.Failures = append(ret.Failures, api.IngestFailure{
+			Index: f.Index,
+			Err:   errors.New(f.Error),
+		})
+	}
+	return ret, nil
+}
+func (e *grpcEngine) Watch(ctx context.Context, selectors []string, keys api.Keys) (<-chan api.WatchEvent, error) {
+	// The WatchResponse doesn't carry the FeatureDescriptor, so it's fetched once for every selector.
+	// The watched values are read from the state as is, so they never carry a fallback.
+	fds := make(map[string]api.FeatureDescriptor, len(selectors))
+	for _, selector := range selectors {
+		fd, err := e.FeatureDescriptor(ctx, selector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to watch features: %w", err)
+		}
+		fds[selector] = fd
+	}
+
+	req := &coreApi.WatchRequest{
+		Uuid:      uuid.NewString(),
+		Selectors: selectors,
+		Keys:      keys,
+	}
+	stream, err := e.client.Watch(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch features: %w", normalizeError(err))
+	}
+
+	c := make(chan api.WatchEvent)
+	go func() {
+		defer close(c)
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			if resp.Uuid != req.Uuid {
+				continue
+			}
+			ev := api.WatchEvent{
+				Selector: resp.Selector,
+				Keys:     resp.Value.Keys,
+				Value: api.Value{
+					Value:     FromValue(resp.Value.Value),
+					Timestamp: resp.Value.Timestamp.AsTime(),
+					Fresh:     resp.Value.Fresh,
+					Degraded:  FromAPIDegradedInputs(resp.Value.Degraded),
+				},
+				FeatureDescriptor: fds[resp.Selector],
+			}
+			select {
+			case c <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c, nil
+}
 
 func normalizeError(err error) error {
 	if err == nil {
@@ -24,6 +24,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"io"
 	"strings"
 )
 
@@ -55,53 +56,142 @@ func (s *serviceServer) FeatureDescriptor(ctx context.Context, req *coreApi.Feat
 func (s *serviceServer) Get(ctx context.Context, req *coreApi.GetRequest) (*coreApi.GetResponse, error) {
 	resp, fd, err := s.engine.Get(ctx, req.GetSelector(), req.GetKeys())
 	if err != nil {
-		if errors.Is(err, api.ErrFeatureNotFound) {
-			return nil, status.Errorf(codes.NotFound, "feature not found")
+		return nil, getError(err)
+	}
+
+	fv, err := featureValue(req.GetSelector(), req.GetKeys(), resp, fd)
+	if err != nil {
+		return nil, err
+	}
+	ret := &coreApi.GetResponse{
+		Uuid:              req.GetUuid(),
+		Value:             fv,
+		FeatureDescriptor: ToAPIFeatureDescriptor(fd),
+		Fallback:          ToAPIFallback(resp.Fallback),
+	}
+
+	return ret, nil
+}
+
+func (s *serviceServer) BatchGet(ctx context.Context, req *coreApi.BatchGetRequest) (*coreApi.BatchGetResponse, error) {
+	items := make([]api.BatchGetItem, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		items[i] = api.BatchGetItem{
+			Selector: item.GetSelector(),
+			Keys:     item.GetKeys(),
 		}
-		return nil, status.Errorf(codes.Internal, "failed to get value: %s", err)
 	}
 
+	results, err := s.engine.BatchGet(ctx, items)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get values: %s", err)
+	}
+
+	ret := &coreApi.BatchGetResponse{
+		Uuid:    req.GetUuid(),
+		Results: make([]*coreApi.BatchGetResult, len(results)),
+	}
+	for i, res := range results {
+		r := &coreApi.BatchGetResult{}
+		err := res.Err
+		if err != nil {
+			err = getError(err)
+		} else {
+			r.FeatureDescriptor = ToAPIFeatureDescriptor(res.FeatureDescriptor)
+			r.Value, err = featureValue(items[i].Selector, items[i].Keys, res.Value, res.FeatureDescriptor)
+			r.Fallback = ToAPIFallback(res.Value.Fallback)
+		}
+		if err != nil {
+			st := status.Convert(err)
+			r.Error = st.Message()
+			r.Code = uint32(st.Code())
+		}
+		ret.Results[i] = r
+	}
+	return ret, nil
+}
+
+func (s *serviceServer) Watch(req *coreApi.WatchRequest, stream coreApi.EngineService_WatchServer) error {
+	c, err := s.engine.Watch(stream.Context(), req.GetSelectors(), req.GetKeys())
+	if err != nil {
+		return getError(err)
+	}
+
+	for ev := range c {
+		fv, err := featureValue(ev.Selector, ev.Keys, ev.Value, ev.FeatureDescriptor)
+		if err != nil {
+			return err
+		}
+		err = stream.Send(&coreApi.WatchResponse{
+			Uuid:     req.GetUuid(),
+			Selector: ev.Selector,
+			Value:    fv,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getError converts an error of the read pipeline to a gRPC status error
+func getError(err error) error {
+	if errors.Is(err, api.ErrFeatureNotFound) {
+		return status.Errorf(codes.NotFound, "feature not found")
+	}
+	return status.Errorf(codes.Internal, "failed to get value: %s", err)
+}
+
+// writeError converts an error of the write pipeline to a gRPC status error.
+// Rejected values are reported as invalid arguments, since retrying the write won't help.
+func writeError(err error, method string) error {
+	switch {
+	case errors.Is(err, api.ErrFeatureNotFound):
+		return status.Errorf(codes.NotFound, "feature not found")
+	case errors.Is(err, api.ErrConstraintViolation), errors.Is(err, api.ErrUnsupportedPrimitiveError):
+		return status.Errorf(codes.InvalidArgument, "failed to %s value: %s", method, err)
+	}
+	return status.Errorf(codes.Internal, "failed to %s value: %s", method, err)
+}
+
+// featureValue converts a value returned from the read pipeline to a coreApi.FeatureValue
+func featureValue(selector string, keys api.Keys, resp api.Value, fd api.FeatureDescriptor) (*coreApi.FeatureValue, error) {
 	val := resp.Value
 	if r, ok := resp.Value.(api.WindowResultMap); ok {
 		if len(fd.Aggr) < 1 {
 			return nil, status.Errorf(codes.InvalidArgument, "the feature is windowed, but requested window function not found."+
-				"please use s request with FullyQualifiedName with an aggregator i.e. `%s+%s`", req.GetSelector(), fd.Aggr[0])
+				"please use s request with FullyQualifiedName with an aggregator i.e. `%s+%s`", selector, fd.Aggr[0])
 		}
 		if len(fd.Aggr) != 1 {
 			return nil, status.Errorf(codes.InvalidArgument, "the feature is windowed, but requested window function not found."+
-				"please use s request with FullyQualifiedName with an aggregator i.e. `%s+%s`", req.GetSelector(), fd.Aggr[0])
+				"please use s request with FullyQualifiedName with an aggregator i.e. `%s+%s`", selector, fd.Aggr[0])
+		}
+		val = nil
+		if v, ok := r[fd.Aggr[0]]; ok {
+			val = v
 		}
-		val = r[fd.Aggr[0]]
 	}
 
-	fqn, err := api.NormalizeFQN(req.GetSelector(), "undefined-namespace")
+	fqn, err := api.NormalizeFQN(selector, "undefined-namespace")
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to normalize fqn: %s", err)
 	}
 	if strings.HasPrefix(fqn, "undefined-namespace") {
 		return nil, status.Errorf(codes.InvalidArgument, "When requesting a feature using gRPC, you must specify the namespace in the FullyQualifiedName.")
 	}
-	ret := &coreApi.GetResponse{
-		Uuid: req.GetUuid(),
-		Value: &coreApi.FeatureValue{
-			Fqn:       fqn,
-			Keys:      req.GetKeys(),
-			Value:     ToAPIValue(val),
-			Timestamp: timestamppb.New(resp.Timestamp),
-		},
-		FeatureDescriptor: ToAPIFeatureDescriptor(fd),
-	}
-
-	return ret, nil
+	return &coreApi.FeatureValue{
+		Fqn:       fqn,
+		Keys:      keys,
+		Value:     ToAPIValue(val),
+		Timestamp: timestamppb.New(resp.Timestamp),
+		Degraded:  ToAPIDegradedInputs(resp.Degraded),
+	}, nil
 }
 
 func (s *serviceServer) Set(ctx context.Context, req *coreApi.SetRequest) (*coreApi.SetResponse, error) {
 	err := s.engine.Set(ctx, req.GetSelector(), req.GetKeys(), FromValue(req.Value), req.Timestamp.AsTime())
 	if err != nil {
-		if errors.Is(err, api.ErrFeatureNotFound) {
-			return nil, status.Errorf(codes.NotFound, "feature not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to set value: %s", err)
+		return nil, writeError(err, "set")
 	}
 	return &coreApi.SetResponse{
 		Uuid:      req.GetUuid(),
@@ -111,10 +201,7 @@ func (s *serviceServer) Set(ctx context.Context, req *coreApi.SetRequest) (*core
 func (s *serviceServer) Append(ctx context.Context, req *coreApi.AppendRequest) (*coreApi.AppendResponse, error) {
 	err := s.engine.Append(ctx, req.GetFqn(), req.GetKeys(), fromScalar(req.Value), req.Timestamp.AsTime())
 	if err != nil {
-		if errors.Is(err, api.ErrFeatureNotFound) {
-			return nil, status.Errorf(codes.NotFound, "feature not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to append value: %s", err)
+		return nil, writeError(err, "append")
 	}
 	return &coreApi.AppendResponse{
 		Uuid:      req.GetUuid(),
@@ -124,10 +211,7 @@ func (s *serviceServer) Append(ctx context.Context, req *coreApi.AppendRequest)
 func (s *serviceServer) Incr(ctx context.Context, req *coreApi.IncrRequest) (*coreApi.IncrResponse, error) {
 	err := s.engine.Incr(ctx, req.GetFqn(), req.GetKeys(), fromScalar(req.Value), req.Timestamp.AsTime())
 	if err != nil {
-		if errors.Is(err, api.ErrFeatureNotFound) {
-			return nil, status.Errorf(codes.NotFound, "feature not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to incr value: %s", err)
+		return nil, writeError(err, "incr")
 	}
 	return &coreApi.IncrResponse{
 		Uuid:      req.GetUuid(),
@@ -137,13 +221,63 @@ func (s *serviceServer) Incr(ctx context.Context, req *coreApi.IncrRequest) (*co
 func (s *serviceServer) Update(ctx context.Context, req *coreApi.UpdateRequest) (*coreApi.UpdateResponse, error) {
 	err := s.engine.Update(ctx, req.GetSelector(), req.GetKeys(), FromValue(req.Value), req.Timestamp.AsTime())
 	if err != nil {
-		if errors.Is(err, api.ErrFeatureNotFound) {
-			return nil, status.Errorf(codes.NotFound, "feature not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to update value: %s", err)
+		return nil, writeError(err, "update")
 	}
 	return &coreApi.UpdateResponse{
 		Uuid:      req.GetUuid(),
 		Timestamp: timestamppb.Now(),
 	}, nil
 }
+
+func (s *serviceServer) Ingest(stream coreApi.EngineService_IngestServer) error {
+	records := make(chan api.IngestRecord)
+
+	var summary api.IngestSummary
+	var ingestErr error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		summary, ingestErr = s.engine.Ingest(stream.Context(), records)
+	}()
+
+	var recvErr error
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			recvErr = err
+			break
+		}
+		records <- api.IngestRecord{
+			Method:    FromAPIWriteMethod(req.GetMethod()),
+			Selector:  req.GetSelector(),
+			Keys:      req.GetKeys(),
+			Value:     FromValue(req.GetValue()),
+			Timestamp: req.GetTimestamp().AsTime(),
+		}
+	}
+	close(records)
+	<-done
+
+	if recvErr != nil {
+		return recvErr
+	}
+	if ingestErr != nil {
+		return status.Errorf(codes.Internal, "failed to ingest records: %s", ingestErr)
+	}
+
+	resp := &coreApi.IngestResponse{
+		Received:  summary.Received,
+		Succeeded: summary.Succeeded,
+		Failed:    summary.Failed,
+	}
+	for _, f := range summary.Failures {
+		resp.Failures = append(resp.Failures, &coreApi.IngestFailure{
+			Index: f.Index,
+			Error: writeError(f.Err, "ingest").Error(),
+		})
+	}
+	return stream.SendAndClose(resp)
+}
@@ -45,6 +45,10 @@ func FromAPIPrimitive(p coreApi.Primitive) api.PrimitiveType {
 		return api.PrimitiveTypeBooleanList
 	case coreApi.Primitive_PRIMITIVE_TIMESTAMP_LIST:
 		return api.PrimitiveTypeTimestampList
+	case coreApi.Primitive_PRIMITIVE_EMBEDDING:
+		return api.PrimitiveTypeEmbedding
+	case coreApi.Primitive_PRIMITIVE_STRUCT:
+		return api.PrimitiveTypeStruct
 	}
 }
 func FromAPIAggrFunc(f coreApi.AggrFn) api.AggrFn {
@@ -61,6 +65,22 @@ func FromAPIAggrFunc(f coreApi.AggrFn) api.AggrFn {
 		return api.AggrFnMax
 	case coreApi.AggrFn_AGGR_FN_COUNT:
 		return api.AggrFnCount
+	case coreApi.AggrFn_AGGR_FN_COUNT_DISTINCT:
+		return api.AggrFnCountDistinct
+	case coreApi.AggrFn_AGGR_FN_STDDEV:
+		return api.AggrFnStddev
+	case coreApi.AggrFn_AGGR_FN_VARIANCE:
+		return api.AggrFnVariance
+	case coreApi.AggrFn_AGGR_FN_FIRST:
+		return api.AggrFnFirst
+	case coreApi.AggrFn_AGGR_FN_LAST:
+		return api.AggrFnLast
+	case coreApi.AggrFn_AGGR_FN_P50:
+		return api.AggrFnP50
+	case coreApi.AggrFn_AGGR_FN_P95:
+		return api.AggrFnP95
+	case coreApi.AggrFn_AGGR_FN_P99:
+		return api.AggrFnP99
 	}
 }
 func FromAPIAggrFuncs(fs []coreApi.AggrFn) []api.AggrFn {
@@ -70,6 +90,60 @@ func FromAPIAggrFuncs(fs []coreApi.AggrFn) []api.AggrFn {
 	}
 	return afs
 }
+
+// FromAPIWriteMethod converts a coreApi.WriteMethod to api.StateMethod.
+// Unknown methods are converted to api.StateMethodGet, which is not a valid write method.
+func FromAPIWriteMethod(m coreApi.WriteMethod) api.StateMethod {
+	switch m {
+	default:
+		return api.StateMethodGet
+	case coreApi.WriteMethod_WRITE_METHOD_SET:
+		return api.StateMethodSet
+	case coreApi.WriteMethod_WRITE_METHOD_APPEND:
+		return api.StateMethodAppend
+	case coreApi.WriteMethod_WRITE_METHOD_INCR:
+		return api.StateMethodIncr
+	case coreApi.WriteMethod_WRITE_METHOD_UPDATE:
+		return api.StateMethodUpdate
+	}
+}
+
+// FromAPIErrorPolicy converts a coreApi.ErrorPolicy to api.ErrorPolicy. Unspecified policies fail the read.
+func FromAPIErrorPolicy(p coreApi.ErrorPolicy) api.ErrorPolicy {
+	switch p {
+	default:
+		return api.ErrorPolicyFail
+	case coreApi.ErrorPolicy_ERROR_POLICY_DEFAULT:
+		return api.ErrorPolicyDefault
+	case coreApi.ErrorPolicy_ERROR_POLICY_LAST_KNOWN:
+		return api.ErrorPolicyLastKnown
+	}
+}
+
+// FromAPIFallback converts a coreApi.Fallback to api.Fallback. The value is empty if the value wasn't served by a fallback.
+func FromAPIFallback(f coreApi.Fallback) api.Fallback {
+	switch f {
+	default:
+		return ""
+	case coreApi.Fallback_FALLBACK_DEFAULT:
+		return api.FallbackDefault
+	case coreApi.Fallback_FALLBACK_LAST_KNOWN:
+		return api.FallbackLastKnown
+	}
+}
+
+// FromAPIDegradedInputs converts coreApi.DegradedInput to the degraded inputs of an api.Value
+func FromAPIDegradedInputs(ds []*coreApi.DegradedInput) []api.DegradedInput {
+	var ret []api.DegradedInput
+	for _, d := range ds {
+		ret = append(ret, api.DegradedInput{
+			Selector: d.GetSelector(),
+			Policy:   d.GetPolicy(),
+			Error:    d.GetError(),
+		})
+	}
+	return ret
+}
 func FromAPIFeatureDescriptor(m *coreApi.FeatureDescriptor) api.FeatureDescriptor {
 	var kp *api.KeepPrevious
 	if m.KeepPrevious != nil {
@@ -78,6 +152,13 @@ func FromAPIFeatureDescriptor(m *coreApi.FeatureDescriptor) api.FeatureDescripto
 			Over:     m.KeepPrevious.Over.AsDuration(),
 		}
 	}
+	var fields []api.StructField
+	for _, f := range m.Fields {
+		fields = append(fields, api.StructField{
+			Name:      f.Name,
+			Primitive: FromAPIPrimitive(f.Primitive),
+		})
+	}
 	return api.FeatureDescriptor{
 		FQN:          m.Fqn,
 		Primitive:    FromAPIPrimitive(m.Primitive),
@@ -88,6 +169,10 @@ func FromAPIFeatureDescriptor(m *coreApi.FeatureDescriptor) api.FeatureDescripto
 		KeepPrevious: kp,
 		Builder:      m.Builder,
 		DataSource:   m.DataSource,
+		EmbeddingDim: int(m.EmbeddingDim),
+		Fields:       fields,
+		Default:      FromValue(m.DefaultValue),
+		OnError:      FromAPIErrorPolicy(m.OnError),
 	}
 }
 
@@ -127,6 +212,14 @@ func FromValue(val *coreApi.Value) any {
 			ret[i] = fromScalar(v)
 		}
 		return ret
+	case *coreApi.Value_EmbeddingValue:
+		return api.Embedding(v.EmbeddingValue.GetValues())
+	case *coreApi.Value_StructValue:
+		ret := make(api.Struct, len(v.StructValue.GetFields()))
+		for k, field := range v.StructValue.GetFields() {
+			ret[k] = FromValue(field)
+		}
+		return ret
 	}
 
 	panic("unknown value type")
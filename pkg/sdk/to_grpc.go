@@ -56,7 +56,19 @@ func ToAPIValue(val any) *coreApi.Value {
 		panic("unknown primitive type")
 	}
 
-	if primitive.Scalar() {
+	if primitive == api.PrimitiveTypeEmbedding {
+		ret.Value = &coreApi.Value_EmbeddingValue{EmbeddingValue: &coreApi.Embedding{Values: val.(api.Embedding)}}
+	} else if primitive == api.PrimitiveTypeStruct {
+		st := &coreApi.Struct{Fields: make(map[string]*coreApi.Value)}
+		ret.Value = &coreApi.Value_StructValue{StructValue: st}
+
+		v := reflect.ValueOf(val)
+		for _, k := range v.MapKeys() {
+			if field := ToAPIValue(v.MapIndex(k).Interface()); field != nil {
+				st.Fields[k.String()] = field
+			}
+		}
+	} else if primitive.Scalar() {
 		ret.Value = &coreApi.Value_ScalarValue{ScalarValue: ToAPIScalar(val)}
 	} else {
 		list := &coreApi.List{}
@@ -94,6 +106,10 @@ func ToAPIPrimitive(p api.PrimitiveType) coreApi.Primitive {
 		return coreApi.Primitive_PRIMITIVE_BOOL_LIST
 	case api.PrimitiveTypeTimestampList:
 		return coreApi.Primitive_PRIMITIVE_TIMESTAMP_LIST
+	case api.PrimitiveTypeEmbedding:
+		return coreApi.Primitive_PRIMITIVE_EMBEDDING
+	case api.PrimitiveTypeStruct:
+		return coreApi.Primitive_PRIMITIVE_STRUCT
 	}
 }
 func ToAPIAggrFn(f api.AggrFn) coreApi.AggrFn {
@@ -110,6 +126,22 @@ func ToAPIAggrFn(f api.AggrFn) coreApi.AggrFn {
 		return coreApi.AggrFn_AGGR_FN_MIN
 	case api.AggrFnMax:
 		return coreApi.AggrFn_AGGR_FN_MAX
+	case api.AggrFnCountDistinct:
+		return coreApi.AggrFn_AGGR_FN_COUNT_DISTINCT
+	case api.AggrFnStddev:
+		return coreApi.AggrFn_AGGR_FN_STDDEV
+	case api.AggrFnVariance:
+		return coreApi.AggrFn_AGGR_FN_VARIANCE
+	case api.AggrFnFirst:
+		return coreApi.AggrFn_AGGR_FN_FIRST
+	case api.AggrFnLast:
+		return coreApi.AggrFn_AGGR_FN_LAST
+	case api.AggrFnP50:
+		return coreApi.AggrFn_AGGR_FN_P50
+	case api.AggrFnP95:
+		return coreApi.AggrFn_AGGR_FN_P95
+	case api.AggrFnP99:
+		return coreApi.AggrFn_AGGR_FN_P99
 	}
 }
 func ToAPIAggrFns(fs []api.AggrFn) []coreApi.AggrFn {
@@ -119,6 +151,55 @@ func ToAPIAggrFns(fs []api.AggrFn) []coreApi.AggrFn {
 	}
 	return ret
 }
+func ToAPIWriteMethod(m api.StateMethod) coreApi.WriteMethod {
+	switch m {
+	default:
+		return coreApi.WriteMethod_WRITE_METHOD_UNSPECIFIED
+	case api.StateMethodSet:
+		return coreApi.WriteMethod_WRITE_METHOD_SET
+	case api.StateMethodAppend:
+		return coreApi.WriteMethod_WRITE_METHOD_APPEND
+	case api.StateMethodIncr:
+		return coreApi.WriteMethod_WRITE_METHOD_INCR
+	case api.StateMethodUpdate:
+		return coreApi.WriteMethod_WRITE_METHOD_UPDATE
+	}
+}
+func ToAPIErrorPolicy(p api.ErrorPolicy) coreApi.ErrorPolicy {
+	switch p {
+	default:
+		return coreApi.ErrorPolicy_ERROR_POLICY_UNSPECIFIED
+	case api.ErrorPolicyFail:
+		return coreApi.ErrorPolicy_ERROR_POLICY_FAIL
+	case api.ErrorPolicyDefault:
+		return coreApi.ErrorPolicy_ERROR_POLICY_DEFAULT
+	case api.ErrorPolicyLastKnown:
+		return coreApi.ErrorPolicy_ERROR_POLICY_LAST_KNOWN
+	}
+}
+func ToAPIFallback(f api.Fallback) coreApi.Fallback {
+	switch f {
+	default:
+		return coreApi.Fallback_FALLBACK_UNSPECIFIED
+	case api.FallbackDefault:
+		return coreApi.Fallback_FALLBACK_DEFAULT
+	case api.FallbackLastKnown:
+		return coreApi.Fallback_FALLBACK_LAST_KNOWN
+	}
+}
+
+// ToAPIDegradedInputs converts the degraded inputs of an api.Value to coreApi.DegradedInput
+func ToAPIDegradedInputs(ds []api.DegradedInput) []*coreApi.DegradedInput {
+	var ret []*coreApi.DegradedInput
+	for _, d := range ds {
+		ret = append(ret, &coreApi.DegradedInput{
+			Selector: d.Selector,
+			Policy:   d.Policy,
+			Error:    d.Error,
+		})
+	}
+	return ret
+}
 
 func ToAPIFeatureDescriptor(fd api.FeatureDescriptor) *coreApi.FeatureDescriptor {
 	var kp *coreApi.KeepPrevious
@@ -138,6 +219,15 @@ func ToAPIFeatureDescriptor(fd api.FeatureDescriptor) *coreApi.FeatureDescriptor
 		KeepPrevious: kp,
 		Builder:      fd.Builder,
 		DataSource:   fd.DataSource,
+		EmbeddingDim: uint32(fd.EmbeddingDim),
+		DefaultValue: ToAPIValue(fd.Default),
+		OnError:      ToAPIErrorPolicy(fd.OnError),
+	}
+	for _, f := range fd.Fields {
+		ret.Fields = append(ret.Fields, &coreApi.StructField{
+			Name:      f.Name,
+			Primitive: ToAPIPrimitive(f.Primitive),
+		})
 	}
 
 	return ret
@@ -0,0 +1,119 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"github.com/raptor-ml/raptor/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+// State wraps the state, so every call to the state provider is traced
+func State(s api.State, provider string) api.State {
+	return &state{State: s, provider: provider}
+}
+
+type state struct {
+	api.State
+	provider string
+}
+
+func (s *state) start(ctx context.Context, method string, fd api.FeatureDescriptor) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, "state."+method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		AttrFQN.String(fd.FQN),
+		attribute.String("raptor.state.provider", s.provider),
+	))
+}
+
+func (s *state) Get(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, version uint) (*api.Value, error) {
+	ctx, span := s.start(ctx, "Get", fd)
+	defer span.End()
+
+	v, err := s.State.Get(ctx, fd, keys, version)
+	RecordError(span, err)
+	span.SetAttributes(AttrCacheHit.Bool(v != nil))
+	if v != nil {
+		span.SetAttributes(AttrFresh.Bool(v.Fresh))
+	}
+	return v, err
+}
+func (s *state) Set(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val any, ts time.Time) error {
+	ctx, span := s.start(ctx, "Set", fd)
+	defer span.End()
+
+	err := s.State.Set(ctx, fd, keys, val, ts)
+	RecordError(span, err)
+	return err
+}
+func (s *state) Append(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val any, ts time.Time) error {
+	ctx, span := s.start(ctx, "Append", fd)
+	defer span.End()
+
+	err := s.State.Append(ctx, fd, keys, val, ts)
+	RecordError(span, err)
+	return err
+}
+func (s *state) Incr(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, by any, ts time.Time) error {
+	ctx, span := s.start(ctx, "Incr", fd)
+	defer span.End()
+
+	err := s.State.Incr(ctx, fd, keys, by, ts)
+	RecordError(span, err)
+	return err
+}
+func (s *state) Update(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val any, ts time.Time) error {
+	ctx, span := s.start(ctx, "Update", fd)
+	defer span.End()
+
+	err := s.State.Update(ctx, fd, keys, val, ts)
+	RecordError(span, err)
+	return err
+}
+func (s *state) WindowAdd(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val any, ts time.Time) error {
+	ctx, span := s.start(ctx, "WindowAdd", fd)
+	defer span.End()
+
+	err := s.State.WindowAdd(ctx, fd, keys, val, ts)
+	RecordError(span, err)
+	return err
+}
+func (s *state) WindowMerge(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, data api.BucketData, ts time.Time) error {
+	ctx, span := s.start(ctx, "WindowMerge", fd)
+	defer span.End()
+
+	err := s.State.WindowMerge(ctx, fd, keys, data, ts)
+	RecordError(span, err)
+	return err
+}
+func (s *state) WindowBuckets(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, buckets []string) (api.RawBuckets, error) {
+	ctx, span := s.start(ctx, "WindowBuckets", fd)
+	defer span.End()
+
+	ret, err := s.State.WindowBuckets(ctx, fd, keys, buckets)
+	RecordError(span, err)
+	return ret, err
+}
+func (s *state) DeadWindowBuckets(ctx context.Context, fd api.FeatureDescriptor, ignore api.RawBuckets) (api.RawBuckets, error) {
+	ctx, span := s.start(ctx, "DeadWindowBuckets", fd)
+	defer span.End()
+
+	ret, err := s.State.DeadWindowBuckets(ctx, fd, ignore)
+	RecordError(span, err)
+	return ret, err
+}
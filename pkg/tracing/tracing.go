@@ -0,0 +1,129 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing instruments the feature pipeline with OpenTelemetry.
+//
+// The tracer provider is global, so packages can start spans via Tracer() even if tracing wasn't set up. In that case,
+// the spans are no-ops.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+	"sync/atomic"
+)
+
+const instrumentationName = "github.com/raptor-ml/raptor"
+
+// Span attributes
+const (
+	AttrFQN      = attribute.Key("raptor.fqn")
+	AttrSelector = attribute.Key("raptor.selector")
+	AttrCacheHit = attribute.Key("raptor.cache_hit")
+	AttrFresh    = attribute.Key("raptor.fresh")
+	AttrFallback = attribute.Key("raptor.fallback")
+)
+
+// enabled indicates whether a tracer provider was set up
+var enabled atomic.Bool
+
+// Tracer returns the tracer of Raptor
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Enabled returns true if tracing was set up, so callers can skip instrumentation that is costly by itself.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// RecordError marks the span as failed
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// BindConfig adds config flags for tracing.
+func BindConfig(set *pflag.FlagSet) error {
+	set.String("tracing-exporter", "", "The exporter of the traces: `otlp` or `stdout`. Tracing is disabled when empty.")
+	set.String("tracing-otlp-endpoint", "", "The OTLP gRPC endpoint to export the traces to, i.e. `otel-collector:4317`. "+
+		"When empty, the standard OTEL_EXPORTER_OTLP_* environment variables are used.")
+	set.Bool("tracing-otlp-insecure", false, "Disable TLS for the OTLP endpoint.")
+	set.Float64("tracing-sample-ratio", 1, "The ratio of the traces to sample.")
+	return nil
+}
+
+// Setup sets up the global tracer provider according to the configuration.
+// The returned function flushes the pending spans and shuts the provider down.
+func Setup(ctx context.Context, viper *viper.Viper, service, version string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch viper.GetString("tracing-exporter") {
+	case "":
+		return func(context.Context) error { return nil }, nil
+	case "otlp":
+		var opts []otlptracegrpc.Option
+		if ep := viper.GetString("tracing-otlp-endpoint"); ep != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(ep))
+		}
+		if viper.GetBool("tracing-otlp-insecure") {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter: %s", viper.GetString("tracing-exporter"))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(service),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(viper.GetFloat64("tracing-sample-ratio")))),
+	)
+	otel.SetTracerProvider(tp)
+	enabled.Store(true)
+	return tp.Shutdown, nil
+}
@@ -0,0 +1,285 @@
+/*
+Copyright (c) 2022 RaptorML authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"github.com/raptor-ml/raptor/api"
+	"github.com/raptor-ml/raptor/internal/engine"
+	"github.com/raptor-ml/raptor/internal/plugins/providers/state/memory"
+	"github.com/raptor-ml/raptor/pkg/tracing"
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"testing"
+	"time"
+)
+
+var errTest = errors.New("test error")
+
+func attr(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func spanNames(spans []sdktrace.ReadOnlySpan) []string {
+	var names []string
+	for _, s := range spans {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+// the spans of the middlewares are named after the functions that create them
+
+func double() api.Middleware {
+	return func(next api.MiddlewareHandler) api.MiddlewareHandler {
+		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
+			val.Value = val.Value.(int) * 2
+			return next(ctx, fd, keys, val)
+		}
+	}
+}
+
+func increment() api.Middleware {
+	return func(next api.MiddlewareHandler) api.MiddlewareHandler {
+		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
+			val.Value = val.Value.(int) + 1
+			return next(ctx, fd, keys, val)
+		}
+	}
+}
+
+func fail() api.Middleware {
+	return func(next api.MiddlewareHandler) api.MiddlewareHandler {
+		return func(ctx context.Context, fd api.FeatureDescriptor, keys api.Keys, val api.Value) (api.Value, error) {
+			return val, errTest
+		}
+	}
+}
+
+func TestPipelineSpans(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeout   bool
+		mws       engine.Middlewares
+		wantNames []string
+		wantErr   bool
+	}{
+		{
+			name:      "middlewares",
+			mws:       engine.Middlewares{double(), increment()},
+			wantNames: []string{"middleware.increment", "middleware.double"},
+		},
+		{
+			name:      "middlewares with a timeout",
+			timeout:   true,
+			mws:       engine.Middlewares{double(), increment()},
+			wantNames: []string{"middleware.increment", "middleware.double"},
+		},
+		{
+			name:      "failing middleware",
+			mws:       engine.Middlewares{double(), fail(), increment()},
+			wantNames: []string{"middleware.fail", "middleware.double"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := tracing.NewRecorder(t)
+			ctx := context.Background()
+			if tt.timeout {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, time.Minute)
+				defer cancel()
+			}
+
+			p := engine.Pipeline{
+				Middlewares:       tt.mws,
+				FeatureDescriptor: api.FeatureDescriptor{FQN: "a.default"},
+			}
+			_, err := p.Apply(ctx, api.Keys{"id": "1"}, api.Value{Value: 1})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Apply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			// the spans are ended from the innermost middleware out
+			spans := sr.Ended()
+			names := spanNames(spans)
+			if len(names) != len(tt.wantNames) {
+				t.Fatalf("expected the spans %v, got %v", tt.wantNames, names)
+			}
+			for i, s := range spans {
+				if s.Name() != tt.wantNames[i] {
+					t.Errorf("expected the spans %v, got %v", tt.wantNames, names)
+				}
+				if v, ok := attr(s, tracing.AttrFQN); !ok || v.AsString() != "a.default" {
+					t.Errorf("%s: expected the FQN attribute, got %v", s.Name(), s.Attributes())
+				}
+				if i > 0 && s.SpanContext().SpanID() != spans[i-1].Parent().SpanID() {
+					t.Errorf("expected %s to be nested within %s", spans[i-1].Name(), s.Name())
+				}
+				// the error is recorded by the failing middleware, and by the ones it's nested within
+				wantCode := codes.Unset
+				if tt.wantErr {
+					wantCode = codes.Error
+				}
+				if s.Status().Code != wantCode {
+					t.Errorf("%s: expected the status %s, got %s", s.Name(), wantCode, s.Status().Code)
+				}
+			}
+		})
+	}
+}
+
+func TestPipelineDisabled(t *testing.T) {
+	if tracing.Enabled() {
+		t.Skip("tracing is enabled")
+	}
+	p := engine.Pipeline{
+		Middlewares:       engine.Middlewares{double()},
+		FeatureDescriptor: api.FeatureDescriptor{FQN: "a.default"},
+	}
+	// without a tracer provider the middlewares aren't wrapped, so the result is the same
+	v, err := p.Apply(context.Background(), api.Keys{"id": "1"}, api.Value{Value: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Value != 4 {
+		t.Errorf("expected 4, got %v", v.Value)
+	}
+}
+
+// failingState fails the writes
+type failingState struct {
+	api.State
+}
+
+func (failingState) Set(context.Context, api.FeatureDescriptor, api.Keys, any, time.Time) error {
+	return errTest
+}
+
+func TestState(t *testing.T) {
+	sr := tracing.NewRecorder(t)
+	ctx := context.Background()
+	fd := api.FeatureDescriptor{
+		FQN:       "a.default",
+		Primitive: api.PrimitiveTypeInteger,
+		Freshness: time.Hour,
+		Staleness: 2 * time.Hour,
+	}
+	keys := api.Keys{"id": "1"}
+	s := tracing.State(memory.New(), "memory")
+
+	if _, err := s.Get(ctx, fd, keys, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set(ctx, fd, keys, 1, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(ctx, fd, keys, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := tracing.State(failingState{}, "failing").Set(ctx, fd, keys, 1, time.Now()); !errors.Is(err, errTest) {
+		t.Fatalf("expected the state's error, got %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		provider  string
+		cacheHit  *bool
+		fresh     *bool
+		wantError bool
+	}{
+		{name: "state.Get", provider: "memory", cacheHit: ptr(false)},
+		{name: "state.Set", provider: "memory"},
+		{name: "state.Get", provider: "memory", cacheHit: ptr(true), fresh: ptr(true)},
+		{name: "state.Set", provider: "failing", wantError: true},
+	}
+	spans := sr.Ended()
+	if len(spans) != len(tests) {
+		t.Fatalf("expected a span per call, got %v", spanNames(spans))
+	}
+	for i, tt := range tests {
+		s := spans[i]
+		if s.Name() != tt.name {
+			t.Errorf("span %d: expected %s, got %s", i, tt.name, s.Name())
+		}
+		if v, ok := attr(s, tracing.AttrFQN); !ok || v.AsString() != fd.FQN {
+			t.Errorf("span %d: expected the FQN attribute, got %v", i, s.Attributes())
+		}
+		if v, ok := attr(s, "raptor.state.provider"); !ok || v.AsString() != tt.provider {
+			t.Errorf("span %d: expected the provider %s, got %v", i, tt.provider, s.Attributes())
+		}
+		for key, want := range map[attribute.Key]*bool{tracing.AttrCacheHit: tt.cacheHit, tracing.AttrFresh: tt.fresh} {
+			v, ok := attr(s, key)
+			if want == nil && ok {
+				t.Errorf("span %d: expected no %s attribute, got %v", i, key, v.AsBool())
+			} else if want != nil && (!ok || v.AsBool() != *want) {
+				t.Errorf("span %d: expected %s=%v, got %v", i, key, *want, s.Attributes())
+			}
+		}
+		if tt.wantError {
+			if s.Status().Code != codes.Error || len(s.Events()) == 0 || s.Events()[0].Name != "exception" {
+				t.Errorf("span %d: expected the error to be recorded, got %+v %v", i, s.Status(), s.Events())
+			}
+		} else if s.Status().Code == codes.Error {
+			t.Errorf("span %d: unexpected error status %+v", i, s.Status())
+		}
+	}
+}
+
+func ptr(b bool) *bool {
+	return &b
+}
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		name     string
+		exporter string
+		wantErr  bool
+	}{
+		{name: "disabled", exporter: ""},
+		{name: "unknown exporter", exporter: "jaeger", wantErr: true},
+		{name: "unknown exporter case", exporter: "OTLP", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := viper.New()
+			v.Set("tracing-exporter", tt.exporter)
+			shutdown, err := tracing.Setup(context.Background(), v, "test", "test")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Setup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if err := shutdown(context.Background()); err != nil {
+				t.Error(err)
+			}
+			if tracing.Enabled() {
+				t.Error("expected tracing to stay disabled")
+			}
+		})
+	}
+}
@@ -94,6 +94,9 @@ class RuntimeServicer(api_pb2_grpc.RuntimeServiceServicer):
 
         ts = request.timestamp.ToDatetime()
 
+        # propagate the trace context of the engine, so the calls back to the engine are part of the same trace
+        trace_metadata = [(k, v) for k, v in context.invocation_metadata() if k in ('traceparent', 'tracestate')]
+
         def feature_request(selector: str, keys: Dict[str, str], timestamp: datetime) -> core_pb2.GetResponse:
             if timestamp != ts:
                 warnings.warn('Timestamp mismatch')
@@ -101,7 +104,7 @@ class RuntimeServicer(api_pb2_grpc.RuntimeServiceServicer):
             selector = normalize_selector(selector, namespace)
             fg_keys = keys if keys is not None else request.keys
             req = core_pb2.GetRequest(uuid=str(uuid4()), selector=selector, keys=fg_keys)
-            resp: core_pb2.GetResponse = self.engine.Get(req)
+            resp: core_pb2.GetResponse = self.engine.Get(req, metadata=trace_metadata)
             if resp.uuid != req.uuid:
                 raise Exception('UUID mismatch')
 
@@ -149,7 +152,7 @@ class RuntimeServicer(api_pb2_grpc.RuntimeServiceServicer):
                     value=ret.result,
                 )
                 ur.timestamp.FromDatetime(ts)
-                uresp = self.engine.Update(ur)
+                uresp = self.engine.Update(ur, metadata=trace_metadata)
                 if uresp.uuid != ur.uuid:
                     raise Exception('UUID mismatch')
 
@@ -168,6 +171,10 @@ class RuntimeServicer(api_pb2_grpc.RuntimeServiceServicer):
 
     @staticmethod
     def proto_value_to_py(value: types_pb2.Value) -> Union[primitive, None]:
+        if value.WhichOneof('value') == 'embedding_value':
+            return list(value.embedding_value.values)
+        if value.WhichOneof('value') == 'struct_value':
+            return {k: RuntimeServicer.proto_value_to_py(v) for k, v in value.struct_value.fields.items()}
         if value.scalar_value is not None:
             return RuntimeServicer.proto_scalar_to_py(value.scalar_value)
         elif value.list_value is not None:
@@ -190,6 +197,9 @@ class RuntimeServicer(api_pb2_grpc.RuntimeServiceServicer):
 
     @staticmethod
     def py_to_proto_value(value: primitive) -> types_pb2.Value:
+        if isinstance(value, dict):
+            return types_pb2.Value(struct_value=types_pb2.Struct(
+                fields={k: RuntimeServicer.py_to_proto_value(v) for k, v in value.items() if v is not None}))
         if isinstance(value, list) or isinstance(value, List):
             return types_pb2.Value(
                 list_value=types_pb2.List(values=[RuntimeServicer.py_to_proto_scalar(v) for v in value]))
@@ -233,6 +243,8 @@ class RuntimeServicer(api_pb2_grpc.RuntimeServiceServicer):
             return types_pb2.PRIMITIVE_BOOL_LIST
         elif p == List[datetime]:
             return types_pb2.PRIMITIVE_TIMESTAMP_LIST
+        elif p == dict:
+            return types_pb2.PRIMITIVE_STRUCT
         return types_pb2.PRIMITIVE_UNSPECIFIED
 
     @staticmethod